@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	ap "go.opentelemetry.io/build-tools/actionpin/internal"
+	"go.opentelemetry.io/build-tools/internal/logging"
+	"go.opentelemetry.io/build-tools/internal/repo"
+)
+
+var errUnpinned = errors.New("unpinned actions found")
+
+type commandConfig struct {
+	config      ap.Config
+	quiet       bool
+	verbose     bool
+	rootCommand cobra.Command
+	pinCommand  cobra.Command
+}
+
+func newCommandConfig() *commandConfig {
+	c := &commandConfig{}
+
+	c.rootCommand = cobra.Command{
+		Use:   "actionpin",
+		Short: "Audit that GitHub Actions are pinned to commit SHAs",
+		Long: `Actionpin scans the GitHub Actions workflows and composite actions of a repository
+		and fails if an action is referenced by a mutable tag or branch instead of a commit SHA.
+		Actions matching an allowlist pattern, local actions and Docker images are not reported.`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			logging.Configure(c.quiet, c.verbose)
+			if c.config.RootPath == "" {
+				rp, err := repo.FindRoot()
+				if err != nil {
+					return fmt.Errorf("could not find a valid repository: %w", err)
+				}
+				c.config.RootPath = rp
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			problems, err := ap.Audit(c.config)
+			if err != nil {
+				return err
+			}
+			for _, p := range problems {
+				fmt.Fprintln(cmd.OutOrStdout(), p)
+			}
+			if len(problems) > 0 {
+				logging.Errorf("found %d unpinned actions, run actionpin pin to pin them", len(problems))
+				return errUnpinned
+			}
+			return nil
+		},
+	}
+
+	c.pinCommand = cobra.Command{
+		Use:   "pin",
+		Short: "Pin GitHub Actions to the commit SHA of their current ref",
+		Long: `Pin rewrites the references to actions that actionpin reports to the commit SHA
+		their tag or branch currently points to, followed by a comment with the tag or branch.
+		Refs are resolved with git ls-remote.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n, err := ap.Pin(cmd.Context(), c.config)
+			if err != nil {
+				return err
+			}
+			logging.Infof("pinned %d actions", n)
+			return nil
+		},
+	}
+	c.rootCommand.AddCommand(&c.pinCommand)
+	return c
+}
+
+var (
+	comCfg = newCommandConfig()
+)
+
+// Execute runs the actionpin command line, exiting with a non-zero status if
+// it fails or unpinned actions are found.
+func Execute() {
+	if err := comCfg.rootCommand.Execute(); err != nil {
+		if !errors.Is(err, errUnpinned) {
+			logging.Errorf("failed to execute: %v", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func init() {
+	flags := comCfg.rootCommand.PersistentFlags()
+	flags.StringVar(&comCfg.config.RootPath, "root", "", `path to the root directory of the repository. If --root flag is not provided actionpin will attempt to find a
+	git repository in the current or a parent directory.`)
+	flags.StringSliceVar(&comCfg.config.Allowed, "allow", []string{}, "list of comma separated patterns of actions, e.g. actions/*, that may be referenced by tags or branches. "+
+		"multiple calls of --allow can be made")
+	flags.StringVar(&comCfg.config.AllowlistFile, "allowlist", "", "path to a file listing allowed action patterns, one per line")
+	flags.BoolVarP(&comCfg.verbose, "verbose", "v", false, "verbose output")
+	flags.BoolVarP(&comCfg.quiet, "quiet", "q", false, "only log errors, takes precedence over --verbose")
+}
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actionpin
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+)
+
+// Config configures an audit of the actions used by a repository.
+type Config struct {
+	// RootPath is the root directory of the repository.
+	RootPath string
+	// Allowed are patterns, matched with path.Match against an action or its
+	// repository, of actions that may be referenced by mutable refs.
+	Allowed []string
+	// AllowlistFile lists additional allowed patterns, one per line.
+	AllowlistFile string
+}
+
+// Problem is an action referenced by a mutable ref.
+type Problem struct {
+	// File is the path of the workflow relative to the root.
+	File   string
+	Line   int
+	Action string
+	Ref    string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s:%d: %s@%s is not pinned to a commit SHA", p.File, p.Line, p.Action, p.Ref)
+}
+
+// Audit returns the references to actions that are not pinned to a commit
+// SHA and are not allowed by cfg, ordered by file and line.
+func Audit(cfg Config) ([]Problem, error) {
+	var problems []Problem
+	err := walkUnpinned(cfg, func(file string, _ []byte, refs []reference) error {
+		for _, r := range refs {
+			problems = append(problems, Problem{File: file, Line: r.Line, Action: r.Action, Ref: r.Ref})
+		}
+		return nil
+	})
+	return problems, err
+}
+
+// walkUnpinned calls fn with the content and the unpinned, disallowed
+// references of each workflow file that has some.
+func walkUnpinned(cfg Config, fn func(file string, content []byte, refs []reference) error) error {
+	allowed, err := allowlist(cfg)
+	if err != nil {
+		return err
+	}
+	files, err := workflowFiles(cfg.RootPath)
+	if err != nil {
+		return err
+	}
+	logging.Debugf("auditing %d workflow files", len(files))
+
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join(cfg.RootPath, filepath.FromSlash(file)))
+		if err != nil {
+			return err
+		}
+		var unpinned []reference
+		for _, r := range parseReferences(content) {
+			switch {
+			case r.pinned():
+			case isAllowed(allowed, r):
+				logging.Debugf("%s:%d: %s is allowed", file, r.Line, r)
+			default:
+				unpinned = append(unpinned, r)
+			}
+		}
+		if len(unpinned) == 0 {
+			continue
+		}
+		if err := fn(file, content, unpinned); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// allowlist returns the allowed patterns of cfg, including those read from
+// cfg.AllowlistFile. Blank lines and lines starting with "#" are ignored.
+func allowlist(cfg Config) ([]string, error) {
+	allowed := append([]string(nil), cfg.Allowed...)
+	if cfg.AllowlistFile != "" {
+		patterns, err := readAllowlist(cfg.AllowlistFile)
+		if err != nil {
+			return nil, err
+		}
+		allowed = append(allowed, patterns...)
+	}
+	for _, pattern := range allowed {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid allowlist pattern %q: %w", pattern, err)
+		}
+	}
+	return allowed, nil
+}
+
+func readAllowlist(file string) ([]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read allowlist %s: %w", file, err)
+	}
+	return patterns, nil
+}
+
+func isAllowed(allowed []string, r reference) bool {
+	for _, pattern := range allowed {
+		if ok, _ := path.Match(pattern, r.Action); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, r.repository()); ok {
+			return true
+		}
+	}
+	return false
+}
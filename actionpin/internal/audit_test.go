@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actionpin
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/testfiles"
+)
+
+func TestAudit(t *testing.T) {
+	root := t.TempDir()
+	testfiles.Write(t, root, map[string]string{
+		".github/workflows/ci.yml": `jobs:
+  build:
+    steps:
+      - uses: actions/checkout@v3
+      - uses: actions/setup-go@v3
+      - uses: github/codeql-action/init@v2
+      - uses: codecov/codecov-action@` + testSHA + ` # v3.1.1
+      - uses: securego/gosec@master
+`,
+		".github/actions/setup/action.yml": `runs:
+  using: composite
+  steps:
+    - uses: evantorrie/mott-the-tidier@v1-beta
+`,
+		"allowlist.txt": "# First-party actions\ngithub/codeql-action\n",
+	})
+
+	problems, err := Audit(Config{
+		RootPath:      root,
+		Allowed:       []string{"actions/*"},
+		AllowlistFile: filepath.Join(root, "allowlist.txt"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Problem{
+		{File: ".github/actions/setup/action.yml", Line: 4, Action: "evantorrie/mott-the-tidier", Ref: "v1-beta"},
+		{File: ".github/workflows/ci.yml", Line: 8, Action: "securego/gosec", Ref: "master"},
+	}, problems)
+}
+
+func TestAuditInvalidAllowPattern(t *testing.T) {
+	_, err := Audit(Config{RootPath: t.TempDir(), Allowed: []string{"actions/["}})
+	assert.ErrorContains(t, err, `invalid allowlist pattern "actions/["`)
+}
+
+func TestAuditMissingAllowlist(t *testing.T) {
+	root := t.TempDir()
+	_, err := Audit(Config{RootPath: root, AllowlistFile: filepath.Join(root, "missing.txt")})
+	assert.Error(t, err)
+}
+
+func TestProblemString(t *testing.T) {
+	p := Problem{File: ".github/workflows/ci.yml", Line: 4, Action: "actions/checkout", Ref: "v3"}
+	assert.Equal(t, ".github/workflows/ci.yml:4: actions/checkout@v3 is not pinned to a commit SHA", p.String())
+}
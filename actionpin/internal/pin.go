@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actionpin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+)
+
+// resolve returns the commit SHA that ref of the GitHub repository points to.
+// It is a variable so tests can avoid network access.
+var resolve = lsRemote
+
+// Pin rewrites the references to actions that Audit reports to the commit SHA
+// their ref currently points to, followed by a comment with the ref, e.g.
+//
+//	uses: actions/checkout@8e5e7e5ab8b370d6c329ec480221332ada57f0ab # v3
+//
+// It returns the number of rewritten references.
+func Pin(ctx context.Context, cfg Config) (int, error) {
+	resolved := make(map[string]string)
+	var pinned int
+	err := walkUnpinned(cfg, func(file string, content []byte, refs []reference) error {
+		lines := bytes.SplitAfter(content, []byte("\n"))
+		for _, r := range refs {
+			key := r.repository() + "@" + r.Ref
+			sha, ok := resolved[key]
+			if !ok {
+				var err error
+				sha, err = resolve(ctx, r.repository(), r.Ref)
+				if err != nil {
+					return fmt.Errorf("%s:%d: failed to resolve %s: %w", file, r.Line, r, err)
+				}
+				resolved[key] = sha
+			}
+			lines[r.Line-1] = pinLine(lines[r.Line-1], r, sha)
+			logging.Infof("%s:%d: pinned %s to %s", file, r.Line, r, sha)
+			pinned++
+		}
+
+		path := filepath.Join(cfg.RootPath, filepath.FromSlash(file))
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, bytes.Join(lines, nil), info.Mode().Perm())
+	})
+	return pinned, err
+}
+
+// pinLine returns line, which contains the uses key of r, with the ref of r
+// replaced by sha and the ref as a trailing comment. An existing trailing
+// comment is replaced.
+func pinLine(line []byte, r reference, sha string) []byte {
+	eol := line[len(bytes.TrimRight(line, "\r\n")):]
+	m := usesRegexp.FindSubmatch(bytes.TrimRight(line, "\r\n"))
+	if m == nil {
+		return line
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s%s%s@%s%s # %s", m[1], m[2], r.Action, sha, m[4], r.Ref)
+	b.Write(eol)
+	return b.Bytes()
+}
+
+// lsRemote resolves ref with git ls-remote. Tags are preferred over branches,
+// and annotated tags are resolved to the commit they point to.
+func lsRemote(ctx context.Context, repository, ref string) (string, error) {
+	url := "https://github.com/" + repository
+	tag := "refs/tags/" + ref
+	branch := "refs/heads/" + ref
+	cmd := exec.CommandContext(ctx, "git", "ls-remote", url, tag, tag+"^{}", branch)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git ls-remote %s: %w", url, err)
+	}
+
+	refs := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		sha, name, ok := strings.Cut(scanner.Text(), "\t")
+		if ok {
+			refs[name] = sha
+		}
+	}
+	for _, name := range []string{tag + "^{}", tag, branch} {
+		if sha, ok := refs[name]; ok {
+			return sha, nil
+		}
+	}
+	return "", fmt.Errorf("ref %q not found in %s", ref, url)
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actionpin
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/testfiles"
+)
+
+func TestPin(t *testing.T) {
+	var calls []string
+	t.Cleanup(func(f func(context.Context, string, string) (string, error)) func() {
+		return func() { resolve = f }
+	}(resolve))
+	resolve = func(_ context.Context, repository, ref string) (string, error) {
+		calls = append(calls, repository+"@"+ref)
+		return testSHA, nil
+	}
+
+	root := t.TempDir()
+	testfiles.Write(t, root, map[string]string{
+		".github/workflows/ci.yml": "jobs:\r\n" +
+			"  build:\r\n" +
+			"    steps:\r\n" +
+			"      - uses: actions/checkout@v3\r\n" +
+			"      - uses: 'github/codeql-action/init@v2' # init CodeQL\r\n" +
+			"      - uses: github/codeql-action/analyze@v2\r\n" +
+			"      - uses: actions/setup-go@v3\r\n",
+	})
+
+	n, err := Pin(context.Background(), Config{RootPath: root, Allowed: []string{"actions/setup-go"}})
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []string{"actions/checkout@v3", "github/codeql-action@v2"}, calls, "refs are resolved once per repository")
+
+	got, err := os.ReadFile(filepath.Join(root, ".github", "workflows", "ci.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, "jobs:\r\n"+
+		"  build:\r\n"+
+		"    steps:\r\n"+
+		"      - uses: actions/checkout@"+testSHA+" # v3\r\n"+
+		"      - uses: 'github/codeql-action/init@"+testSHA+"' # v2\r\n"+
+		"      - uses: github/codeql-action/analyze@"+testSHA+" # v2\r\n"+
+		"      - uses: actions/setup-go@v3\r\n", string(got))
+
+	problems, err := Audit(Config{RootPath: root})
+	require.NoError(t, err)
+	assert.Equal(t, []Problem{
+		{File: ".github/workflows/ci.yml", Line: 7, Action: "actions/setup-go", Ref: "v3"},
+	}, problems)
+}
+
+func TestPinResolveError(t *testing.T) {
+	t.Cleanup(func(f func(context.Context, string, string) (string, error)) func() {
+		return func() { resolve = f }
+	}(resolve))
+	resolve = func(context.Context, string, string) (string, error) {
+		return "", errors.New("not found")
+	}
+
+	root := t.TempDir()
+	workflow := "steps:\n  - uses: actions/checkout@v3\n"
+	testfiles.Write(t, root, map[string]string{".github/workflows/ci.yml": workflow})
+
+	_, err := Pin(context.Background(), Config{RootPath: root})
+	assert.ErrorContains(t, err, ".github/workflows/ci.yml:2: failed to resolve actions/checkout@v3: not found")
+
+	got, err := os.ReadFile(filepath.Join(root, ".github", "workflows", "ci.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, workflow, string(got), "files are not modified on failure")
+}
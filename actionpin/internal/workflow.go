@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actionpin
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	usesRegexp = regexp.MustCompile(`^(\s*(?:-\s+)?uses:\s*)(["']?)([^"'\s#]+)(["']?)(\s+#.*)?\s*$`)
+	shaRegexp  = regexp.MustCompile(`^[0-9a-f]{40}$`)
+)
+
+// reference is an action referenced by the uses key of a workflow step or
+// composite action.
+type reference struct {
+	// Line is the 1-based line of the uses key.
+	Line int
+	// Action is the action without its ref, e.g. github/codeql-action/init.
+	Action string
+	Ref    string
+}
+
+// repository returns the GitHub repository containing the action.
+func (r reference) repository() string {
+	parts := strings.SplitN(r.Action, "/", 3)
+	if len(parts) < 2 {
+		return r.Action
+	}
+	return parts[0] + "/" + parts[1]
+}
+
+// pinned returns whether the reference is a full commit SHA.
+func (r reference) pinned() bool {
+	return shaRegexp.MatchString(r.Ref)
+}
+
+func (r reference) String() string {
+	return r.Action + "@" + r.Ref
+}
+
+// parseReferences returns the actions referenced in content. Local actions
+// and Docker images are ignored as they are not fetched from a repository.
+func parseReferences(content []byte) []reference {
+	var refs []reference
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		m := usesRegexp.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		uses := m[3]
+		if strings.HasPrefix(uses, "./") || strings.HasPrefix(uses, "docker://") {
+			continue
+		}
+		action, ref, ok := strings.Cut(uses, "@")
+		if !ok {
+			continue
+		}
+		refs = append(refs, reference{Line: line, Action: action, Ref: ref})
+	}
+	return refs
+}
+
+// workflowFiles returns the paths, relative to root, of the workflow and
+// composite action definitions of the repository.
+func workflowFiles(root string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(filepath.Join(root, ".github"), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == filepath.Join(root, ".github") && errors.Is(err, fs.ErrNotExist) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		ext := path.Ext(rel)
+		if ext != ".yml" && ext != ".yaml" {
+			return nil
+		}
+		if path.Dir(rel) == ".github/workflows" || strings.TrimSuffix(path.Base(rel), ext) == "action" {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	return files, err
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actionpin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/testfiles"
+)
+
+const testSHA = "8e5e7e5ab8b370d6c329ec480221332ada57f0ab"
+
+func TestParseReferences(t *testing.T) {
+	content := `name: ci
+jobs:
+  build:
+    steps:
+      - uses: actions/checkout@v3
+      - name: Init CodeQL
+        uses: "github/codeql-action/init@v2"
+      - uses: codecov/codecov-action@` + testSHA + ` # v3.1.1
+      - uses: ./.github/actions/local
+      - uses: docker://avtodev/markdown-lint:v1
+      - run: echo "uses: not/an-action@v1"
+    uses: org/repo/.github/workflows/reusable.yml@main
+`
+	assert.Equal(t, []reference{
+		{Line: 5, Action: "actions/checkout", Ref: "v3"},
+		{Line: 7, Action: "github/codeql-action/init", Ref: "v2"},
+		{Line: 8, Action: "codecov/codecov-action", Ref: testSHA},
+		{Line: 12, Action: "org/repo/.github/workflows/reusable.yml", Ref: "main"},
+	}, parseReferences([]byte(content)))
+}
+
+func TestReference(t *testing.T) {
+	r := reference{Action: "github/codeql-action/init", Ref: "v2"}
+	assert.Equal(t, "github/codeql-action", r.repository())
+	assert.Equal(t, "github/codeql-action/init@v2", r.String())
+	assert.False(t, r.pinned())
+
+	r = reference{Action: "actions/checkout", Ref: testSHA}
+	assert.Equal(t, "actions/checkout", r.repository())
+	assert.True(t, r.pinned())
+
+	r.Ref = testSHA[:7]
+	assert.False(t, r.pinned(), "abbreviated SHAs are not pinned")
+}
+
+func TestWorkflowFiles(t *testing.T) {
+	root := t.TempDir()
+	testfiles.Write(t, root, map[string]string{
+		".github/workflows/ci.yml":           "",
+		".github/workflows/release.yaml":     "",
+		".github/workflows/README.md":        "",
+		".github/actions/setup/action.yml":   "",
+		".github/actions/other/action.yaml":  "",
+		".github/actions/other/config.yml":   "",
+		".github/dependabot.yml":             "",
+		"docs/.github/workflows/example.yml": "",
+	})
+
+	files, err := workflowFiles(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		".github/actions/other/action.yaml",
+		".github/actions/setup/action.yml",
+		".github/workflows/ci.yml",
+		".github/workflows/release.yaml",
+	}, files)
+}
+
+func TestWorkflowFilesNoGitHubDir(t *testing.T) {
+	files, err := workflowFiles(t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
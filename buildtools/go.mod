@@ -0,0 +1,86 @@
+module go.opentelemetry.io/build-tools/buildtools
+
+go 1.18
+
+require (
+	github.com/stretchr/testify v1.8.1
+	go.opentelemetry.io/build-tools/actionpin v0.2.0
+	go.opentelemetry.io/build-tools/checkdoc v0.2.0
+	go.opentelemetry.io/build-tools/checklicense v0.2.0
+	go.opentelemetry.io/build-tools/chloggen v0.2.0
+	go.opentelemetry.io/build-tools/crosslink v0.2.0
+	go.opentelemetry.io/build-tools/dbotconf v0.2.0
+	go.opentelemetry.io/build-tools/issuegenerator v0.2.0
+	go.opentelemetry.io/build-tools/mdlinkcheck v0.2.0
+	go.opentelemetry.io/build-tools/multimod v0.2.0
+	go.opentelemetry.io/build-tools/semconvgen v0.2.0
+	go.opentelemetry.io/build-tools/sumdrift v0.2.0
+)
+
+require (
+	github.com/ProtonMail/go-crypto v0.0.0-20210428141323-04723f9f07d7 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/emirpasic/gods v1.12.0 // indirect
+	github.com/fsnotify/fsnotify v1.5.4 // indirect
+	github.com/go-git/gcfg v1.5.0 // indirect
+	github.com/go-git/go-billy/v5 v5.3.1 // indirect
+	github.com/go-git/go-git/v5 v5.4.2 // indirect
+	github.com/google/go-github v17.0.0+incompatible // indirect
+	github.com/google/go-querystring v1.0.0 // indirect
+	github.com/hashicorp/hcl v1.0.0 // indirect
+	github.com/imdario/mergo v0.3.12 // indirect
+	github.com/jbenet/go-context v0.0.0-20150711004518-d14ea06fba99 // indirect
+	github.com/joshdk/go-junit v0.0.0-20210226021600-6145f504ca0d // indirect
+	github.com/kevinburke/ssh_config v0.0.0-20201106050909-4977a11b4351 // indirect
+	github.com/magiconair/properties v1.8.6 // indirect
+	github.com/mitchellh/go-homedir v1.1.0 // indirect
+	github.com/mitchellh/mapstructure v1.5.0 // indirect
+	github.com/pelletier/go-toml/v2 v2.0.5 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/sergi/go-diff v1.1.0 // indirect
+	github.com/spf13/afero v1.8.2 // indirect
+	github.com/spf13/cast v1.5.0 // indirect
+	github.com/spf13/cobra v1.6.1 // indirect
+	github.com/spf13/jwalterweatherman v1.1.0 // indirect
+	github.com/spf13/pflag v1.0.5 // indirect
+	github.com/spf13/viper v1.13.0 // indirect
+	github.com/subosito/gotenv v1.4.1 // indirect
+	github.com/xanzy/ssh-agent v0.3.0 // indirect
+	go.opentelemetry.io/build-tools v0.2.0 // indirect
+	go.uber.org/atomic v1.9.0 // indirect
+	go.uber.org/multierr v1.8.0 // indirect
+	go.uber.org/zap v1.23.0 // indirect
+	golang.org/x/crypto v0.1.0 // indirect
+	golang.org/x/mod v0.6.0 // indirect
+	golang.org/x/net v0.1.0 // indirect
+	golang.org/x/oauth2 v0.0.0-20220411215720-9780585627b5 // indirect
+	golang.org/x/sys v0.1.0 // indirect
+	golang.org/x/text v0.4.0 // indirect
+	gopkg.in/ini.v1 v1.67.0 // indirect
+	gopkg.in/warnings.v0 v0.1.2 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace go.opentelemetry.io/build-tools => ../
+
+replace go.opentelemetry.io/build-tools/actionpin => ../actionpin
+
+replace go.opentelemetry.io/build-tools/checkdoc => ../checkdoc
+
+replace go.opentelemetry.io/build-tools/checklicense => ../checklicense
+
+replace go.opentelemetry.io/build-tools/chloggen => ../chloggen
+
+replace go.opentelemetry.io/build-tools/crosslink => ../crosslink
+
+replace go.opentelemetry.io/build-tools/dbotconf => ../dbotconf
+
+replace go.opentelemetry.io/build-tools/issuegenerator => ../issuegenerator
+
+replace go.opentelemetry.io/build-tools/mdlinkcheck => ../mdlinkcheck
+
+replace go.opentelemetry.io/build-tools/multimod => ../multimod
+
+replace go.opentelemetry.io/build-tools/semconvgen => ../semconvgen
+
+replace go.opentelemetry.io/build-tools/sumdrift => ../sumdrift
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// executableFunc returns the path of the running binary. It is a variable so
+// it can be replaced in tests.
+var executableFunc = os.Executable
+
+// link creates a symbolic link named after each tool in dir pointing to the
+// running binary. Links that already point to it are left as they are; any
+// other existing file is an error.
+func link(dir string) error {
+	exe, err := executableFunc()
+	if err != nil {
+		return fmt.Errorf("unable to locate %s binary: %w", name, err)
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return fmt.Errorf("unable to locate %s binary: %w", name, err)
+	}
+
+	if err = os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+
+	for _, n := range toolNames() {
+		dst := filepath.Join(dir, n)
+		target, err := os.Readlink(dst)
+		switch {
+		case err == nil && target == exe:
+			continue
+		case err == nil:
+			return fmt.Errorf("%s already links to %s", dst, target)
+		case errors.Is(err, fs.ErrNotExist):
+			// Create below.
+		default:
+			return fmt.Errorf("%s already exists and is not a symbolic link", dst)
+		}
+
+		if err = os.Symlink(exe, dst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubExecutable(t *testing.T) string {
+	exe := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(exe, nil, 0o600))
+	exe, err := filepath.EvalSymlinks(exe)
+	require.NoError(t, err)
+
+	t.Cleanup(func(f func() (string, error)) func() {
+		return func() { executableFunc = f }
+	}(executableFunc))
+	executableFunc = func() (string, error) { return exe, nil }
+	return exe
+}
+
+func TestLink(t *testing.T) {
+	exe := stubExecutable(t)
+	dir := filepath.Join(t.TempDir(), "bin")
+
+	require.NoError(t, link(dir))
+	for _, n := range toolNames() {
+		target, err := os.Readlink(filepath.Join(dir, n))
+		require.NoError(t, err)
+		assert.Equal(t, exe, target)
+	}
+
+	// Existing links are left as they are.
+	assert.NoError(t, link(dir))
+}
+
+func TestLinkExisting(t *testing.T) {
+	stubExecutable(t)
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "multimod"), nil, 0o600))
+	assert.Error(t, link(dir))
+
+	dir = t.TempDir()
+	require.NoError(t, os.Symlink(filepath.Join(dir, "other"), filepath.Join(dir, "multimod")))
+	assert.Error(t, link(dir))
+}
@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command buildtools is a single binary bundling all the build tools.
+//
+// It behaves as the tool it is invoked as, typically through a symbolic link
+// named after the tool, e.g. a buildtools binary linked to as multimod runs
+// multimod. Tools can also be run as a subcommand of buildtools:
+//
+//	buildtools multimod verify
+//
+// Run "buildtools link <dir>" to create the symbolic links for all tools in
+// dir and "buildtools manifest" to print the version information embedded
+// in the binary.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	actionpin "go.opentelemetry.io/build-tools/actionpin/cmd"
+	checkdoc "go.opentelemetry.io/build-tools/checkdoc/cmd"
+	checklicense "go.opentelemetry.io/build-tools/checklicense/cmd"
+	chloggen "go.opentelemetry.io/build-tools/chloggen/cmd"
+	crosslink "go.opentelemetry.io/build-tools/crosslink/cmd"
+	dbotconf "go.opentelemetry.io/build-tools/dbotconf/cmd"
+	issuegenerator "go.opentelemetry.io/build-tools/issuegenerator/cmd"
+	mdlinkcheck "go.opentelemetry.io/build-tools/mdlinkcheck/cmd"
+	multimod "go.opentelemetry.io/build-tools/multimod/cmd"
+	semconvgen "go.opentelemetry.io/build-tools/semconvgen/cmd"
+	sumdrift "go.opentelemetry.io/build-tools/sumdrift/cmd"
+)
+
+const name = "buildtools"
+
+// tools maps the name of each bundled tool to its entry point.
+var tools = map[string]func(){
+	"actionpin":      actionpin.Execute,
+	"checkdoc":       checkdoc.Execute,
+	"checklicense":   checklicense.Execute,
+	"chloggen":       chloggen.Execute,
+	"crosslink":      crosslink.Execute,
+	"dbotconf":       dbotconf.Execute,
+	"issuegenerator": issuegenerator.Execute,
+	"mdlinkcheck":    mdlinkcheck.Execute,
+	"multimod":       multimod.Execute,
+	"semconvgen":     semconvgen.Execute,
+	"sumdrift":       sumdrift.Execute,
+}
+
+// toolNames returns the names of all bundled tools in lexical order.
+func toolNames() []string {
+	names := make([]string, 0, len(tools))
+	for n := range tools {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// invokedName returns the name the binary was invoked as, without directory
+// or Windows executable extension.
+func invokedName(arg0 string) string {
+	base := filepath.Base(arg0)
+	if strings.EqualFold(filepath.Ext(base), ".exe") {
+		base = base[:len(base)-len(".exe")]
+	}
+	return base
+}
+
+// resolve returns the name of the tool to run for the command line args and
+// the command line to run it with, whose first element is the tool name. An
+// empty name is returned if args do not name a tool.
+func resolve(args []string) (string, []string) {
+	if len(args) == 0 {
+		return "", nil
+	}
+	if n := invokedName(args[0]); tools[n] != nil {
+		return n, append([]string{n}, args[1:]...)
+	}
+	if len(args) > 1 && tools[args[1]] != nil {
+		return args[1], args[1:]
+	}
+	return "", nil
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintf(w, `Usage:
+  %[1]s <tool> [arguments]
+  %[1]s link <dir>
+  %[1]s manifest
+
+Or invoke %[1]s through a symbolic link named after the tool.
+
+Tools:
+`, name)
+	for _, n := range toolNames() {
+		fmt.Fprintf(w, "  %s\n", n)
+	}
+}
+
+func main() {
+	if tool, args := resolve(os.Args); tool != "" {
+		os.Args = args
+		tools[tool]()
+		return
+	}
+
+	if len(os.Args) < 2 {
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "link":
+		if len(os.Args) != 3 {
+			usage(os.Stderr)
+			os.Exit(2)
+		}
+		if err := link(os.Args[2]); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			os.Exit(1)
+		}
+	case "manifest":
+		if err := writeManifest(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			os.Exit(1)
+		}
+	case "help", "-h", "-help", "--help":
+		usage(os.Stdout)
+	default:
+		fmt.Fprintf(os.Stderr, "%s: unknown tool %q\n\n", name, os.Args[1])
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		wantTool string
+		wantArgs []string
+	}{
+		{
+			name:     "symlink",
+			args:     []string{"/usr/local/bin/multimod", "verify"},
+			wantTool: "multimod",
+			wantArgs: []string{"multimod", "verify"},
+		},
+		{
+			name:     "windows executable",
+			args:     []string{"tools/crosslink.EXE", "--root", "."},
+			wantTool: "crosslink",
+			wantArgs: []string{"crosslink", "--root", "."},
+		},
+		{
+			name:     "subcommand",
+			args:     []string{"./buildtools", "chloggen", "validate"},
+			wantTool: "chloggen",
+			wantArgs: []string{"chloggen", "validate"},
+		},
+		{
+			name:     "invoked name takes precedence",
+			args:     []string{"dbotconf", "multimod"},
+			wantTool: "dbotconf",
+			wantArgs: []string{"dbotconf", "multimod"},
+		},
+		{
+			name: "unknown tool",
+			args: []string{"buildtools", "unknown"},
+		},
+		{
+			name: "no tool",
+			args: []string{"buildtools"},
+		},
+		{
+			name: "empty",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tool, args := resolve(tc.args)
+			assert.Equal(t, tc.wantTool, tool)
+			assert.Equal(t, tc.wantArgs, args)
+		})
+	}
+}
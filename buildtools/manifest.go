@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"runtime/debug"
+)
+
+const modulePrefix = "go.opentelemetry.io/build-tools/"
+
+// version is the release of the binary, set at build time with
+// -ldflags "-X main.version=<version>".
+var version = "devel"
+
+// readBuildInfoFunc is a variable so it can be replaced in tests.
+var readBuildInfoFunc = debug.ReadBuildInfo
+
+// manifest describes the tools bundled in the binary.
+type manifest struct {
+	Version   string     `json:"version"`
+	GoVersion string     `json:"go_version"`
+	Tools     []toolInfo `json:"tools"`
+}
+
+// toolInfo describes the module a bundled tool was built from.
+type toolInfo struct {
+	Name    string `json:"name"`
+	Module  string `json:"module"`
+	Version string `json:"version"`
+	Sum     string `json:"sum,omitempty"`
+	// Replace is the module or directory the module was replaced with.
+	Replace string `json:"replace,omitempty"`
+}
+
+func buildManifest() (manifest, error) {
+	info, ok := readBuildInfoFunc()
+	if !ok {
+		return manifest{}, errors.New("binary built without module support")
+	}
+
+	deps := make(map[string]*debug.Module, len(info.Deps))
+	for _, d := range info.Deps {
+		deps[d.Path] = d
+	}
+
+	m := manifest{Version: version, GoVersion: info.GoVersion}
+	for _, n := range toolNames() {
+		t := toolInfo{Name: n, Module: modulePrefix + n}
+		if d, ok := deps[t.Module]; ok {
+			t.Version, t.Sum = d.Version, d.Sum
+			if r := d.Replace; r != nil {
+				t.Replace = r.Path
+				if r.Version != "" && r.Version != "(devel)" {
+					t.Replace += "@" + r.Version
+					t.Sum = r.Sum
+				}
+			}
+		}
+		m.Tools = append(m.Tools, t)
+	}
+	return m, nil
+}
+
+// writeManifest writes the manifest of the running binary to w as JSON.
+func writeManifest(w io.Writer) error {
+	m, err := buildManifest()
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(m)
+}
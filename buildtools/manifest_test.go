@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteManifest(t *testing.T) {
+	t.Cleanup(func(f func() (*debug.BuildInfo, bool)) func() {
+		return func() { readBuildInfoFunc = f }
+	}(readBuildInfoFunc))
+	readBuildInfoFunc = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			GoVersion: "go1.19",
+			Deps: []*debug.Module{
+				{Path: "go.opentelemetry.io/build-tools/multimod", Version: "v0.3.0", Sum: "h1:multimod"},
+				{
+					Path:    "go.opentelemetry.io/build-tools/crosslink",
+					Version: "v0.3.0",
+					Replace: &debug.Module{Path: "../crosslink", Version: "(devel)"},
+				},
+				{
+					Path:    "go.opentelemetry.io/build-tools/chloggen",
+					Version: "v0.3.0",
+					Sum:     "h1:chloggen",
+					Replace: &debug.Module{Path: "example.com/fork/chloggen", Version: "v0.3.1", Sum: "h1:fork"},
+				},
+				{Path: "github.com/spf13/cobra", Version: "v1.6.1"},
+			},
+		}, true
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeManifest(&buf))
+
+	var got manifest
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, version, got.Version)
+	assert.Equal(t, "go1.19", got.GoVersion)
+	require.Len(t, got.Tools, len(tools))
+
+	byName := make(map[string]toolInfo, len(got.Tools))
+	for _, ti := range got.Tools {
+		byName[ti.Name] = ti
+	}
+	assert.Equal(t, toolInfo{
+		Name:    "multimod",
+		Module:  "go.opentelemetry.io/build-tools/multimod",
+		Version: "v0.3.0",
+		Sum:     "h1:multimod",
+	}, byName["multimod"])
+	assert.Equal(t, toolInfo{
+		Name:    "crosslink",
+		Module:  "go.opentelemetry.io/build-tools/crosslink",
+		Version: "v0.3.0",
+		Replace: "../crosslink",
+	}, byName["crosslink"])
+	assert.Equal(t, toolInfo{
+		Name:    "chloggen",
+		Module:  "go.opentelemetry.io/build-tools/chloggen",
+		Version: "v0.3.0",
+		Sum:     "h1:fork",
+		Replace: "example.com/fork/chloggen@v0.3.1",
+	}, byName["chloggen"])
+	assert.Equal(t, toolInfo{
+		Name:   "semconvgen",
+		Module: "go.opentelemetry.io/build-tools/semconvgen",
+	}, byName["semconvgen"])
+}
+
+func TestWriteManifestNoBuildInfo(t *testing.T) {
+	t.Cleanup(func(f func() (*debug.BuildInfo, bool)) func() {
+		return func() { readBuildInfoFunc = f }
+	}(readBuildInfoFunc))
+	readBuildInfoFunc = func() (*debug.BuildInfo, bool) { return nil, false }
+
+	assert.Error(t, writeManifest(&bytes.Buffer{}))
+}
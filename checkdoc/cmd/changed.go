@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const goModFileName = "go.mod"
+
+// changedFiles returns the absolute paths of files changed in the git diff
+// range diffRange of the repository containing projectPath. If diffRange is
+// empty the files currently staged for commit are returned.
+func changedFiles(projectPath string, diffRange string) ([]string, error) {
+	projectPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"-C", projectPath, "diff", "--name-only", "--relative"}
+	if diffRange == "" {
+		args = append(args, "--cached")
+	} else {
+		args = append(args, diffRange)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed files: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var files []string
+	for _, line := range strings.Split(string(out), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		files = append(files, filepath.Join(projectPath, filepath.FromSlash(line)))
+	}
+	return files, nil
+}
+
+// owningModule returns the directory of the Go module that contains dir,
+// stopping at projectPath. If no go.mod file is found below projectPath,
+// projectPath itself is returned.
+func owningModule(projectPath string, dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, goModFileName)); err == nil {
+			return dir
+		}
+		if dir == projectPath {
+			return projectPath
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir || !strings.HasPrefix(parent, projectPath) {
+			return projectPath
+		}
+		dir = parent
+	}
+}
+
+// changedModules returns the set of directories of the Go modules owning one
+// of the changed files, projectPath being an absolute path.
+func changedModules(projectPath string, changed []string) map[string]struct{} {
+	modules := make(map[string]struct{})
+	for _, f := range changed {
+		modules[owningModule(projectPath, filepath.Dir(f))] = struct{}{}
+	}
+	return modules
+}
+
+// checkChangedDocs is like checkDocs but only checks the components owned by
+// a Go module containing one of the changed files. If the components file
+// itself changed every component is checked.
+func checkChangedDocs(projectPath string, relativeComponentsPath string, projectGoModule string, changed []string) error {
+	projectPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return err
+	}
+	componentsFilePath := filepath.Join(projectPath, relativeComponentsPath)
+
+	for _, f := range changed {
+		if f == componentsFilePath {
+			return checkDocs(projectPath, relativeComponentsPath, projectGoModule)
+		}
+	}
+	modules := changedModules(projectPath, changed)
+	if len(modules) == 0 {
+		return nil
+	}
+
+	return checkComponentDocs(projectPath, relativeComponentsPath, projectGoModule, func(componentPath string) bool {
+		_, ok := modules[owningModule(projectPath, componentPath)]
+		return ok
+	})
+}
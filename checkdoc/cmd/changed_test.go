@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testComponents = `package components
+
+import (
+	_ "example.com/project/receiver/documented"
+	_ "example.com/project/receiver/undocumented"
+)
+`
+
+// newTestProject creates a project with two component modules, only one of
+// which has a README.
+func newTestProject(t *testing.T) string {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":                             "module example.com/project\n",
+		"components.go":                      testComponents,
+		"receiver/documented/go.mod":         "module example.com/project/receiver/documented\n",
+		"receiver/documented/README.md":      "# Documented\n",
+		"receiver/documented/internal/a.go":  "package internal\n",
+		"receiver/undocumented/go.mod":       "module example.com/project/receiver/undocumented\n",
+		"receiver/undocumented/receiver.go":  "package undocumented\n",
+		"receiver/undocumented/testdata/a.y": "a: b\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	return root
+}
+
+func TestOwningModule(t *testing.T) {
+	root := newTestProject(t)
+	tests := []struct {
+		name string
+		dir  string
+		want string
+	}{
+		{
+			name: "Module root",
+			dir:  "receiver/documented",
+			want: "receiver/documented",
+		},
+		{
+			name: "Nested directory",
+			dir:  "receiver/documented/internal",
+			want: "receiver/documented",
+		},
+		{
+			name: "Deleted directory",
+			dir:  "receiver/undocumented/removed",
+			want: "receiver/undocumented",
+		},
+		{
+			name: "Project root",
+			dir:  "",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(root, filepath.FromSlash(tt.dir))
+			want := filepath.Join(root, filepath.FromSlash(tt.want))
+			if got := owningModule(root, dir); got != want {
+				t.Errorf("owningModule() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestChangedModules(t *testing.T) {
+	root := newTestProject(t)
+	changed := []string{
+		filepath.Join(root, "receiver", "documented", "config.go"),
+		filepath.Join(root, "receiver", "documented", "internal", "metadata.go"),
+		filepath.Join(root, "README.md"),
+	}
+	want := map[string]struct{}{
+		filepath.Join(root, "receiver", "documented"): {},
+		root: {},
+	}
+	require.Equal(t, want, changedModules(root, changed))
+	require.Empty(t, changedModules(root, nil))
+}
+
+func TestCheckChangedDocs(t *testing.T) {
+	root := newTestProject(t)
+	tests := []struct {
+		name    string
+		changed []string
+		wantErr bool
+	}{
+		{
+			name:    "No changes",
+			changed: nil,
+			wantErr: false,
+		},
+		{
+			name:    "Documented component changed",
+			changed: []string{"receiver/documented/internal/a.go"},
+			wantErr: false,
+		},
+		{
+			name:    "Undocumented component changed",
+			changed: []string{"receiver/undocumented/testdata/a.y"},
+			wantErr: true,
+		},
+		{
+			name:    "Components file changed",
+			changed: []string{"components.go"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed := make([]string, len(tt.changed))
+			for i, c := range tt.changed {
+				changed[i] = filepath.Join(root, filepath.FromSlash(c))
+			}
+			err := checkChangedDocs(root, "components.go", "example.com/project", changed)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkChangedDocs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
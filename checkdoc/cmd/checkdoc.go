@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"flag"
+	"os"
+)
+
+const (
+	// Absolute root path of the project
+	projectPath = "project-path"
+	// Relative path where imports all default components
+	relativeDefaultComponentsPath = "component-rel-path"
+	// The project Go Module name
+	projectGoModule = "module-name"
+	// Only check components owned by modules with changed files
+	changedOnly = "changed-only"
+	// The git diff range used to find changed files
+	diffRange = "diff-range"
+	// Check the Go code examples in module READMEs
+	examplesCheck = "check-examples"
+	// Check every package has tests
+	testsCheck = "check-tests"
+	// Packages not checked to have tests
+	testExceptions = "test-exceptions"
+	// Rules reported as warnings instead of errors
+	warnRules = "warn"
+	// Maximum number of warnings before failing
+	maxWarnings = "max-warnings"
+	// YAML config of the rules checked on file contents
+	contentRules = "content-rules"
+	// Format of the report
+	outputFormat = "output"
+)
+
+// Execute verifies if README.md and proper documentations for the enabled default components
+// are existed in OpenTelemetry core and contrib repository.
+// Usage in the core repo:
+//
+//	checkdoc --project-path path/to/project \
+//				--component-rel-path service/defaultcomponents/defaults.go \
+//				--module-name go.opentelemetry.io/collector
+//
+// Usage in the contrib repo:
+//
+//	checkdoc --project-path path/to/project \
+//				--component-rel-path cmd/otelcontrib/components.go \
+//				--module-name github.com/open-telemetry/opentelemetry-collector-contrib
+func Execute() {
+	projectPath := flag.String(projectPath, "", "specify the project path")
+	componentPath := flag.String(relativeDefaultComponentsPath, "", "specify the relative component path")
+	moduleName := flag.String(projectGoModule, "", "specify the project go module")
+	onlyChanged := flag.Bool(changedOnly, false, "only check modules containing changed files")
+	gitDiffRange := flag.String(diffRange, "", "git diff range used with --changed-only (default: staged files)")
+	examples := flag.Bool(examplesCheck, false, "check the Go code examples in module READMEs parse and compile")
+	tests := flag.Bool(testsCheck, false, "check every non-internal, non-generated package has at least one test file")
+	exceptions := flag.String(testExceptions, "", "comma separated list of package directory patterns not checked by --check-tests")
+	warn := flag.String(warnRules, "", "comma separated list of rules (docs, examples, tests, content) reported as warnings instead of errors")
+	maxWarns := flag.Int(maxWarnings, -1, "maximum number of warnings allowed, negative for no limit")
+	contentRulesPath := flag.String(contentRules, "", "YAML config of the rules checked on the content of files, e.g. component READMEs")
+	output := flag.String(outputFormat, outputText, "format of the report, text or sarif for GitHub code scanning")
+
+	flag.Parse()
+
+	if err := checkOutput(*output); err != nil {
+		panic(err)
+	}
+
+	rep, err := newReport(splitRules(*warn))
+	if err != nil {
+		panic(err)
+	}
+
+	var rules []contentRule
+	if *contentRulesPath != "" {
+		if rules, err = readContentRules(*contentRulesPath); err != nil {
+			panic(err)
+		}
+	}
+
+	if *onlyChanged {
+		var changed []string
+		changed, err = changedFiles(*projectPath, *gitDiffRange)
+		if err == nil {
+			err = rep.add(ruleDocs, checkChangedDocs(
+				*projectPath,
+				*componentPath,
+				*moduleName,
+				changed,
+			))
+		}
+		if err == nil && *examples {
+			err = rep.add(ruleExamples, checkChangedExamples(*projectPath, changed))
+		}
+		if err == nil && *tests {
+			err = rep.add(ruleTests, checkChangedTests(*projectPath, splitExceptions(*exceptions), changed))
+		}
+		if err == nil && rules != nil {
+			err = rep.add(ruleContent, checkChangedContent(*projectPath, rules, changed))
+		}
+	} else {
+		err = rep.add(ruleDocs, checkDocs(
+			*projectPath,
+			*componentPath,
+			*moduleName,
+		))
+		if err == nil && *examples {
+			err = rep.add(ruleExamples, checkExamples(*projectPath, func(string) bool { return true }))
+		}
+		if err == nil && *tests {
+			err = rep.add(ruleTests, checkTests(*projectPath, splitExceptions(*exceptions), func(string) bool { return true }))
+		}
+		if err == nil && rules != nil {
+			err = rep.add(ruleContent, checkContent(*projectPath, rules, func(string) bool { return true }))
+		}
+	}
+
+	if err == nil {
+		err = rep.writeOutput(os.Stdout, *output)
+	}
+	if err == nil {
+		err = rep.err(*maxWarns)
+	}
+	if err != nil {
+		panic(err)
+	}
+}
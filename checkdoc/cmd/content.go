@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// contentConfig is the YAML configuration of the content rule.
+type contentConfig struct {
+	Rules []contentRule `yaml:"rules"`
+}
+
+// contentRule lists the assertions every file matching one of its patterns
+// must satisfy.
+type contentRule struct {
+	// Files are slash separated glob patterns relative to the project path.
+	// A ** path element matches any number of directories.
+	Files []string `yaml:"files"`
+	// Headings are Markdown heading lines that must be in the file, e.g.
+	// "## Configuration".
+	Headings []string `yaml:"headings"`
+	// Patterns are regular expressions that must match the file content.
+	Patterns []string `yaml:"patterns"`
+	// MinLength is the minimum length of the file content in bytes, ignoring
+	// leading and trailing white space.
+	MinLength int `yaml:"min_length"`
+
+	patterns []*regexp.Regexp
+}
+
+// readContentRules reads the content rules of the YAML config file at
+// configPath.
+func readContentRules(configPath string) ([]contentRule, error) {
+	data, err := os.ReadFile(filepath.Clean(configPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read content rules: %w", err)
+	}
+
+	var cfg contentConfig
+	if err = yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid content rules %s: %w", configPath, err)
+	}
+	for i := range cfg.Rules {
+		rule := &cfg.Rules[i]
+		if len(rule.Files) == 0 {
+			return nil, fmt.Errorf("invalid content rules %s: rule %d has no files", configPath, i+1)
+		}
+		for _, f := range rule.Files {
+			if _, err = path.Match(f, ""); err != nil {
+				return nil, fmt.Errorf("invalid content rules %s: invalid files pattern %q: %w", configPath, f, err)
+			}
+		}
+		for _, p := range rule.Patterns {
+			re, err := regexp.Compile(p)
+			if err != nil {
+				return nil, fmt.Errorf("invalid content rules %s: %w", configPath, err)
+			}
+			rule.patterns = append(rule.patterns, re)
+		}
+	}
+	return cfg.Rules, nil
+}
+
+// matches returns whether the slash separated path rel matches one of the
+// files patterns of the rule.
+func (r contentRule) matches(rel string) bool {
+	for _, f := range r.Files {
+		if matchGlob(strings.Split(f, "/"), strings.Split(rel, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob returns whether the path elements name match the pattern
+// elements, where a ** element matches zero or more path elements.
+func matchGlob(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchGlob(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// violations returns the assertions of the rule content does not satisfy.
+func (r contentRule) violations(content []byte) []string {
+	var out []string
+	if r.MinLength > 0 {
+		if n := len(bytes.TrimSpace(content)); n < r.MinLength {
+			out = append(out, fmt.Sprintf("content is %d bytes long, less than %d", n, r.MinLength))
+		}
+	}
+	for _, h := range r.Headings {
+		if !hasHeading(content, h) {
+			out = append(out, fmt.Sprintf("missing heading %q", h))
+		}
+	}
+	for _, re := range r.patterns {
+		if !re.Match(content) {
+			out = append(out, fmt.Sprintf("does not match %q", re.String()))
+		}
+	}
+	return out
+}
+
+// hasHeading returns whether content has a line that is heading, ignoring
+// surrounding white space.
+func hasHeading(content []byte, heading string) bool {
+	heading = strings.TrimSpace(heading)
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.TrimSpace(line) == heading {
+			return true
+		}
+	}
+	return false
+}
+
+// checkContent checks that every file of projectPath selected by include,
+// which is passed the absolute directory of the file, satisfies the content
+// rules it matches. Every violation is reported, not only the first one.
+func checkContent(projectPath string, rules []contentRule, include func(string) bool) error {
+	var violations []finding
+	err := filepath.WalkDir(projectPath, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if p != projectPath && (strings.HasPrefix(name, ".") || name == "vendor") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(projectPath, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		var content []byte
+		for _, rule := range rules {
+			if !rule.matches(rel) || !include(filepath.Dir(p)) {
+				continue
+			}
+			if content == nil {
+				if content, err = os.ReadFile(filepath.Clean(p)); err != nil {
+					return err
+				}
+			}
+			for _, v := range rule.violations(content) {
+				violations = append(violations, finding{
+					Module:  relModule(projectPath, filepath.Dir(p)),
+					Path:    rel,
+					Message: rel + ": " + v,
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(violations) > 0 {
+		sort.SliceStable(violations, func(i, j int) bool { return violations[i].Message < violations[j].Message })
+		return &findingsError{summary: "files do not satisfy the content rules", findings: violations}
+	}
+	return nil
+}
+
+// checkChangedContent is like checkContent but only checks the files of Go
+// modules containing one of the changed files.
+func checkChangedContent(projectPath string, rules []contentRule, changed []string) error {
+	projectPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return err
+	}
+
+	modules := changedModules(projectPath, changed)
+	if len(modules) == 0 {
+		return nil
+	}
+
+	return checkContent(projectPath, rules, func(dir string) bool {
+		_, ok := modules[owningModule(projectPath, dir)]
+		return ok
+	})
+}
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/testfiles"
+)
+
+const testContentRules = `rules:
+  - files: ["**/README.md"]
+    headings: ["## Configuration"]
+    patterns: ["(?m)^\\| Stability"]
+    min_length: 40
+`
+
+// newContentProject creates a project with READMEs satisfying the content
+// rules or not.
+func newContentProject(t *testing.T) string {
+	root := t.TempDir()
+	testfiles.Write(t, root, map[string]string{
+		"go.mod":            "module example.com/project\n",
+		"rules.yaml":        testContentRules,
+		"good/README.md":    "# Good\n\n| Stability | beta |\n\n## Configuration\n\nNone.\n",
+		"bad/README.md":     "# Bad\n",
+		"module/go.mod":     "module example.com/project/module\n",
+		"module/README.md":  "# Module\n\n| Stability | beta |\n\n### Configuration\n\nNone at all.\n",
+		".github/README.md": "# Ignored\n",
+	})
+	return root
+}
+
+func TestReadContentRules(t *testing.T) {
+	root := newContentProject(t)
+
+	rules, err := readContentRules(filepath.Join(root, "rules.yaml"))
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, []string{"**/README.md"}, rules[0].Files)
+	assert.Equal(t, []string{"## Configuration"}, rules[0].Headings)
+	assert.Equal(t, 40, rules[0].MinLength)
+	require.Len(t, rules[0].patterns, 1)
+
+	for name, content := range map[string]string{
+		"no-files.yaml":    "rules:\n  - headings: [\"# A\"]\n",
+		"bad-glob.yaml":    "rules:\n  - files: [\"[\"]\n",
+		"bad-pattern.yaml": "rules:\n  - files: [\"*\"]\n    patterns: [\"(\"]\n",
+		"bad-yaml.yaml":    "rules: {",
+	} {
+		testfiles.Write(t, root, map[string]string{name: content})
+		_, err = readContentRules(filepath.Join(root, name))
+		assert.Error(t, err, name)
+	}
+
+	_, err = readContentRules(filepath.Join(root, "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "README.md", name: "README.md", want: true},
+		{pattern: "README.md", name: "a/README.md", want: false},
+		{pattern: "*/README.md", name: "a/README.md", want: true},
+		{pattern: "**/README.md", name: "README.md", want: true},
+		{pattern: "**/README.md", name: "a/b/README.md", want: true},
+		{pattern: "receiver/**/*.md", name: "receiver/a/doc.md", want: true},
+		{pattern: "receiver/**/*.md", name: "exporter/a/doc.md", want: false},
+		{pattern: "**", name: "a/b", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchGlob(strings.Split(tt.pattern, "/"), strings.Split(tt.name, "/")))
+		})
+	}
+}
+
+func TestCheckContent(t *testing.T) {
+	root := newContentProject(t)
+	rules, err := readContentRules(filepath.Join(root, "rules.yaml"))
+	require.NoError(t, err)
+
+	err = checkContent(root, rules, func(string) bool { return true })
+	require.Error(t, err)
+	assert.Equal(t, "files do not satisfy the content rules:\n"+
+		"bad/README.md: content is 5 bytes long, less than 40\n"+
+		"bad/README.md: does not match \"(?m)^\\\\| Stability\"\n"+
+		"bad/README.md: missing heading \"## Configuration\"\n"+
+		"module/README.md: missing heading \"## Configuration\"", err.Error())
+}
+
+func TestCheckChangedContent(t *testing.T) {
+	root := newContentProject(t)
+	rules, err := readContentRules(filepath.Join(root, "rules.yaml"))
+	require.NoError(t, err)
+
+	assert.NoError(t, checkChangedContent(root, rules, nil))
+
+	err = checkChangedContent(root, rules, []string{filepath.Join(root, "module", "README.md")})
+	require.Error(t, err)
+	assert.Equal(t, "files do not satisfy the content rules:\n"+
+		"module/README.md: missing heading \"## Configuration\"", err.Error())
+}
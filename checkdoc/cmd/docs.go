@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package main
+package cmd
 
 import (
 	"fmt"
@@ -27,14 +27,21 @@ const (
 	readMeFileName = "README.md"
 )
 
-// checkDocs returns an error if README.md for at least one
-// enabled component is missing. "projectPath" is the absolute path to the root
+// checkDocs returns an error listing every enabled component for which
+// README.md is missing. "projectPath" is the absolute path to the root
 // of the project to which the components belong. "defaultComponentsFilePath" is
 // the path to the file that contains imports to all required components,
 // "goModule" is the Go module to which the imports belong. This method is intended
 // to be used only to verify documentation in Opentelemetry core and contrib
 // repositories.
 func checkDocs(projectPath string, relativeComponentsPath string, projectGoModule string) error {
+	return checkComponentDocs(projectPath, relativeComponentsPath, projectGoModule, func(string) bool { return true })
+}
+
+// checkComponentDocs checks that a README exists for every component
+// imported by the components file for which include returns true. include
+// is passed the absolute path of the component directory.
+func checkComponentDocs(projectPath string, relativeComponentsPath string, projectGoModule string, include func(string) bool) error {
 	defaultComponentsFilePath := filepath.Join(projectPath, relativeComponentsPath)
 	_, err := os.Stat(defaultComponentsFilePath)
 	if err != nil {
@@ -49,18 +56,31 @@ func checkDocs(projectPath string, relativeComponentsPath string, projectGoModul
 
 	importPrefixesToCheck := getImportPrefixesToCheck(projectGoModule)
 
+	var missing []finding
 	for _, i := range f.Imports {
 		importPath := strings.Trim(i.Path.Value, `"`)
 
 		if isComponentImport(importPath, importPrefixesToCheck) {
 			relativeComponentPath := strings.Replace(importPath, projectGoModule, "", 1)
-			readmePath := filepath.Join(projectPath, relativeComponentPath, readMeFileName)
+			componentPath := filepath.Join(projectPath, relativeComponentPath)
+			if !include(componentPath) {
+				continue
+			}
+			readmePath := filepath.Join(componentPath, readMeFileName)
 			_, err := os.Stat(readmePath)
 			if err != nil {
-				return fmt.Errorf("README does not exist at %s, add one", readmePath)
+				missing = append(missing, finding{
+					Module:  relModule(projectPath, componentPath),
+					Path:    relPath(projectPath, componentPath),
+					Message: readmePath,
+				})
 			}
 		}
 	}
+
+	if len(missing) > 0 {
+		return &findingsError{summary: "README does not exist for components, add one", findings: missing}
+	}
 	return nil
 }
 
@@ -0,0 +1,216 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// exampleDirPattern is the pattern of the temporary package directories
+// examples are compiled in. The leading underscore makes the go command
+// ignore them when matching packages, e.g. with ./...
+const exampleDirPattern = "_checkdoc_example_"
+
+// goCmd is the go command used to compile examples.
+var goCmd = "go"
+
+// example is a fenced Go code block of a README.
+type example struct {
+	// line is the line of the opening fence.
+	line int
+	code string
+}
+
+// readmeExamples returns the fenced Go code blocks of the Markdown file at
+// path.
+func readmeExamples(path string) ([]example, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		examples []example
+		current  *example
+		fence    string
+		code     strings.Builder
+	)
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; s.Scan(); n++ {
+		line := s.Text()
+		trimmed := strings.TrimSpace(line)
+		if current == nil {
+			if !strings.HasPrefix(trimmed, "```") && !strings.HasPrefix(trimmed, "~~~") {
+				continue
+			}
+			fence = trimmed[:3]
+			info := strings.Fields(strings.TrimLeft(trimmed, fence[:1]))
+			if len(info) > 0 && info[0] == "go" {
+				current = &example{line: n}
+				code.Reset()
+			} else {
+				// Skip other code blocks, which may contain Go fences.
+				current = &example{line: -1}
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			if current.line > 0 {
+				current.code = code.String()
+				examples = append(examples, *current)
+			}
+			current = nil
+			continue
+		}
+		code.WriteString(line)
+		code.WriteString("\n")
+	}
+	return examples, s.Err()
+}
+
+// hasPackageClause returns whether code is a complete Go source file.
+func hasPackageClause(code string) bool {
+	f, err := parser.ParseFile(token.NewFileSet(), "", code, parser.PackageClauseOnly)
+	return err == nil && f.Name != nil
+}
+
+// parseFragment parses code that is not a complete Go source file, either as
+// top-level declarations or as statements.
+func parseFragment(code string) error {
+	fset := token.NewFileSet()
+	if _, err := parser.ParseFile(fset, "", "package example\n"+code, parser.AllErrors); err == nil {
+		return nil
+	}
+	_, err := parser.ParseFile(fset, "", "package example\nfunc _() {\n"+code+"\n}\n", parser.AllErrors)
+	return err
+}
+
+// compileExample compiles code, a complete Go source file, as a package in a
+// temporary directory of the module at moduleDir so it is built against the
+// current API of the module.
+func compileExample(moduleDir string, code string) error {
+	dir, err := os.MkdirTemp(moduleDir, exampleDirPattern)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.WriteFile(filepath.Join(dir, "example.go"), []byte(code), 0o600); err != nil {
+		return err
+	}
+
+	cmd := exec.Command(goCmd, "build", "-o", os.DevNull, "./"+filepath.Base(dir)) // #nosec G204
+	cmd.Dir = moduleDir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// moduleDirs returns the directories of all Go modules in projectPath.
+func moduleDirs(projectPath string) ([]string, error) {
+	var dirs []string
+	err := filepath.WalkDir(projectPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != projectPath && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "vendor" || name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() == goModFileName {
+			dirs = append(dirs, filepath.Dir(path))
+		}
+		return nil
+	})
+	return dirs, err
+}
+
+// checkExamples checks that the Go code blocks in the README of every module
+// in projectPath selected by include are valid. Complete Go source files are
+// compiled against the module, other code blocks are only parsed.
+func checkExamples(projectPath string, include func(string) bool) error {
+	dirs, err := moduleDirs(projectPath)
+	if err != nil {
+		return err
+	}
+
+	var failures []finding
+	for _, dir := range dirs {
+		if !include(dir) {
+			continue
+		}
+		readmePath := filepath.Join(dir, readMeFileName)
+		examples, err := readmeExamples(readmePath)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to read %s: %w", readmePath, err)
+		}
+
+		for _, e := range examples {
+			if hasPackageClause(e.code) {
+				err = compileExample(dir, e.code)
+			} else {
+				err = parseFragment(e.code)
+			}
+			if err != nil {
+				failures = append(failures, finding{
+					Module:  relModule(projectPath, dir),
+					Path:    relPath(projectPath, readmePath),
+					Line:    e.line,
+					Message: fmt.Sprintf("%s:%d: %v", readmePath, e.line, err),
+				})
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		return &findingsError{summary: "invalid README examples", findings: failures}
+	}
+	return nil
+}
+
+// checkChangedExamples is like checkExamples but only checks the READMEs of Go
+// modules containing one of the changed files.
+func checkChangedExamples(projectPath string, changed []string) error {
+	projectPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return err
+	}
+
+	modules := changedModules(projectPath, changed)
+	if len(modules) == 0 {
+		return nil
+	}
+
+	return checkExamples(projectPath, func(moduleDir string) bool {
+		_, ok := modules[moduleDir]
+		return ok
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/testfiles"
+)
+
+const testExamplesReadme = "# Example\n" +
+	"\n" +
+	"```go\n" +
+	"package main\n" +
+	"\n" +
+	"import \"example.com/project\"\n" +
+	"\n" +
+	"func main() { project.Hello() }\n" +
+	"```\n" +
+	"\n" +
+	"```go\n" +
+	"project.Hello()\n" +
+	"```\n" +
+	"\n" +
+	"````md\n" +
+	"```go\n" +
+	"not go\n" +
+	"```\n" +
+	"````\n" +
+	"\n" +
+	"```sh\n" +
+	"go run .\n" +
+	"```\n"
+
+func TestReadmeExamples(t *testing.T) {
+	root := t.TempDir()
+	testfiles.Write(t, root, map[string]string{"README.md": testExamplesReadme})
+
+	got, err := readmeExamples(filepath.Join(root, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, []example{
+		{line: 3, code: "package main\n\nimport \"example.com/project\"\n\nfunc main() { project.Hello() }\n"},
+		{line: 11, code: "project.Hello()\n"},
+	}, got)
+}
+
+func TestParseFragment(t *testing.T) {
+	assert.NoError(t, parseFragment("x := 1\n_ = x\n"))
+	assert.NoError(t, parseFragment("func f() {}\n"))
+	assert.Error(t, parseFragment("func f() {\n"))
+}
+
+func TestCheckExamples(t *testing.T) {
+	root := t.TempDir()
+	testfiles.Write(t, root, map[string]string{
+		"go.mod":             "module example.com/project\n\ngo 1.18\n",
+		"project.go":         "package project\n\nfunc Hello() {}\n",
+		"README.md":          testExamplesReadme,
+		"broken/go.mod":      "module example.com/project/broken\n\ngo 1.18\n",
+		"broken/broken.go":   "package broken\n",
+		"broken/README.md":   "```go\npackage main\n\nfunc main() { undefined() }\n```\n\n```go\nfunc {\n```\n",
+		"testdata/go.mod":    "module example.com/project/testdata\n",
+		"testdata/README.md": "```go\nfunc {\n```\n",
+	})
+
+	err := checkExamples(root, func(dir string) bool { return dir == root })
+	assert.NoError(t, err)
+
+	err = checkExamples(root, func(string) bool { return true })
+	require.Error(t, err)
+	msg := err.Error()
+	assert.Contains(t, msg, filepath.Join(root, "broken", readMeFileName)+":1:")
+	assert.Contains(t, msg, "undefined")
+	assert.Contains(t, msg, filepath.Join(root, "broken", readMeFileName)+":7:")
+	assert.NotContains(t, msg, "testdata")
+
+	// Temporary example packages are removed.
+	entries, err := os.ReadDir(filepath.Join(root, "broken"))
+	require.NoError(t, err)
+	for _, e := range entries {
+		assert.False(t, strings.HasPrefix(e.Name(), exampleDirPattern), e.Name())
+	}
+}
+
+func TestCheckChangedExamples(t *testing.T) {
+	root := t.TempDir()
+	testfiles.Write(t, root, map[string]string{
+		"go.mod":           "module example.com/project\n\ngo 1.18\n",
+		"README.md":        "```go\nx := 1\n_ = x\n```\n",
+		"broken/go.mod":    "module example.com/project/broken\n\ngo 1.18\n",
+		"broken/README.md": "```go\nfunc {\n```\n",
+	})
+
+	assert.NoError(t, checkChangedExamples(root, nil))
+	assert.NoError(t, checkChangedExamples(root, []string{filepath.Join(root, "README.md")}))
+	assert.Error(t, checkChangedExamples(root, []string{filepath.Join(root, "broken", "README.md")}))
+}
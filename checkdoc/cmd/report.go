@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Rules reported by checkdoc.
+const (
+	ruleDocs     = "docs"
+	ruleExamples = "examples"
+	ruleTests    = "tests"
+	ruleContent  = "content"
+)
+
+var rules = []string{ruleDocs, ruleExamples, ruleTests, ruleContent}
+
+// Severities of rule findings.
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+// finding is a single problem found by a check in a Go module of the project.
+type finding struct {
+	// Module is the module directory relative to the project path.
+	Module string
+	// Path is the file or directory the finding is about relative to the
+	// project path, and Line its line in the file if known.
+	Path    string
+	Line    int
+	Message string
+}
+
+// findingsError is returned by a check that ran successfully but found
+// problems in the project, as opposed to a check that failed to run.
+type findingsError struct {
+	summary  string
+	findings []finding
+}
+
+func (e *findingsError) Error() string {
+	msgs := make([]string, len(e.findings))
+	for i, f := range e.findings {
+		msgs[i] = f.Message
+	}
+	return fmt.Sprintf("%s:\n%s", e.summary, strings.Join(msgs, "\n"))
+}
+
+// relModule returns the directory of the Go module owning dir relative to
+// projectPath.
+func relModule(projectPath string, dir string) string {
+	rel, err := filepath.Rel(projectPath, owningModule(projectPath, dir))
+	if err != nil {
+		return dir
+	}
+	return filepath.ToSlash(rel)
+}
+
+// relPath returns path relative to projectPath, slash separated.
+func relPath(projectPath string, path string) string {
+	rel, err := filepath.Rel(projectPath, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// report aggregates the findings of every check run, grouped by rule and
+// module.
+type report struct {
+	warnings map[string]bool
+	ran      []string
+	findings map[string][]finding
+}
+
+// newReport returns a report in which findings of the warnings rules have
+// warning severity and all other findings have error severity.
+func newReport(warnings []string) (*report, error) {
+	r := &report{
+		warnings: make(map[string]bool),
+		findings: make(map[string][]finding),
+	}
+	for _, w := range warnings {
+		if !isRule(w) {
+			return nil, fmt.Errorf("unknown rule %q, must be one of: %s", w, strings.Join(rules, ", "))
+		}
+		r.warnings[w] = true
+	}
+	return r, nil
+}
+
+func isRule(name string) bool {
+	for _, r := range rules {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+// add records that rule was checked with the result err. The findings of a
+// findingsError are added to the report, any other error is returned.
+func (r *report) add(rule string, err error) error {
+	r.ran = append(r.ran, rule)
+	var fErr *findingsError
+	if errors.As(err, &fErr) {
+		r.findings[rule] = append(r.findings[rule], fErr.findings...)
+		return nil
+	}
+	return err
+}
+
+func (r *report) severity(rule string) string {
+	if r.warnings[rule] {
+		return severityWarning
+	}
+	return severityError
+}
+
+// counts returns the number of error and warning findings.
+func (r *report) counts() (errs int, warns int) {
+	for rule, findings := range r.findings {
+		if r.severity(rule) == severityWarning {
+			warns += len(findings)
+		} else {
+			errs += len(findings)
+		}
+	}
+	return errs, warns
+}
+
+// write writes the findings grouped by rule and module followed by a summary
+// table of every rule checked.
+func (r *report) write(w io.Writer) error {
+	for _, rule := range r.ran {
+		findings := r.findings[rule]
+		if len(findings) == 0 {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s: %s\n", r.severity(rule), rule); err != nil {
+			return err
+		}
+		byModule := groupByModule(findings)
+		for _, mod := range sortedKeys(byModule) {
+			if _, err := fmt.Fprintf(w, "  %s\n", mod); err != nil {
+				return err
+			}
+			for _, msg := range byModule[mod] {
+				if _, err := fmt.Fprintf(w, "    %s\n", msg); err != nil {
+					return err
+				}
+			}
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RULE\tSEVERITY\tMODULES\tFINDINGS")
+	for _, rule := range r.ran {
+		findings := r.findings[rule]
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\n", rule, r.severity(rule), len(groupByModule(findings)), len(findings))
+	}
+	return tw.Flush()
+}
+
+// err returns an error if there are error findings or more than maxWarnings
+// warning findings. A negative maxWarnings allows any number of warnings.
+func (r *report) err(maxWarnings int) error {
+	errs, warns := r.counts()
+	switch {
+	case errs > 0:
+		return fmt.Errorf("found %d error(s) and %d warning(s)", errs, warns)
+	case maxWarnings >= 0 && warns > maxWarnings:
+		return fmt.Errorf("found %d warning(s), more than the maximum of %d", warns, maxWarnings)
+	}
+	return nil
+}
+
+func groupByModule(findings []finding) map[string][]string {
+	byModule := make(map[string][]string)
+	for _, f := range findings {
+		byModule[f.Module] = append(byModule[f.Module], f.Message)
+	}
+	return byModule
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// splitRules splits the comma separated list of rule names.
+func splitRules(list string) []string {
+	var out []string
+	for _, r := range strings.Split(list, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			out = append(out, r)
+		}
+	}
+	return out
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/testfiles"
+)
+
+func TestNewReport(t *testing.T) {
+	_, err := newReport([]string{ruleTests, "unknown"})
+	assert.ErrorContains(t, err, `unknown rule "unknown"`)
+
+	r, err := newReport([]string{ruleTests})
+	require.NoError(t, err)
+	assert.Equal(t, severityWarning, r.severity(ruleTests))
+	assert.Equal(t, severityError, r.severity(ruleDocs))
+}
+
+func TestReportAdd(t *testing.T) {
+	r, err := newReport(nil)
+	require.NoError(t, err)
+
+	assert.NoError(t, r.add(ruleDocs, nil))
+	assert.NoError(t, r.add(ruleTests, &findingsError{findings: []finding{{Module: "a", Message: "a/b"}}}))
+	failure := errors.New("failure")
+	assert.Equal(t, failure, r.add(ruleExamples, failure))
+
+	assert.Equal(t, []string{ruleDocs, ruleTests, ruleExamples}, r.ran)
+	assert.Equal(t, []finding{{Module: "a", Message: "a/b"}}, r.findings[ruleTests])
+}
+
+func TestReport(t *testing.T) {
+	root := newTestProject(t)
+	testfiles.Write(t, root, map[string]string{
+		"receiver/documented/receiver.go": "package documented\n",
+		"untested/a.go":                   "package untested\n",
+	})
+
+	r, err := newReport([]string{ruleTests})
+	require.NoError(t, err)
+	require.NoError(t, r.add(ruleDocs, checkDocs(root, "components.go", "example.com/project")))
+	require.NoError(t, r.add(ruleTests, checkTests(root, nil, func(string) bool { return true })))
+
+	var buf bytes.Buffer
+	require.NoError(t, r.write(&buf))
+	want := "error: docs\n" +
+		"  receiver/undocumented\n" +
+		"    " + filepath.Join(root, "receiver", "undocumented", readMeFileName) + "\n" +
+		"\n" +
+		"warning: tests\n" +
+		"  .\n" +
+		"    .\n" +
+		"    untested\n" +
+		"  receiver/documented\n" +
+		"    " + filepath.Join("receiver", "documented") + "\n" +
+		"  receiver/undocumented\n" +
+		"    " + filepath.Join("receiver", "undocumented") + "\n" +
+		"\n" +
+		"RULE   SEVERITY  MODULES  FINDINGS\n" +
+		"docs   error     1        1\n" +
+		"tests  warning   3        4\n"
+	assert.Equal(t, want, buf.String())
+
+	errs, warns := r.counts()
+	assert.Equal(t, 1, errs)
+	assert.Equal(t, 4, warns)
+	assert.EqualError(t, r.err(-1), "found 1 error(s) and 4 warning(s)")
+}
+
+func TestReportErr(t *testing.T) {
+	r, err := newReport([]string{ruleTests})
+	require.NoError(t, err)
+	require.NoError(t, r.add(ruleDocs, nil))
+	require.NoError(t, r.add(ruleTests, &findingsError{findings: []finding{{Module: "."}, {Module: "."}}}))
+
+	assert.NoError(t, r.err(-1))
+	assert.NoError(t, r.err(2))
+	assert.EqualError(t, r.err(1), "found 2 warning(s), more than the maximum of 1")
+}
+
+func TestSplitRules(t *testing.T) {
+	assert.Nil(t, splitRules(""))
+	assert.Equal(t, []string{"docs", "tests"}, splitRules(" docs, ,tests "))
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Output formats of the report.
+const (
+	outputText  = "text"
+	outputSARIF = "sarif"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "checkdoc"
+	toolURI      = "https://github.com/open-telemetry/opentelemetry-go-build-tools/tree/main/checkdoc"
+)
+
+// ruleDescriptions are the short descriptions of the rules in SARIF reports.
+var ruleDescriptions = map[string]string{
+	ruleDocs:     "Component is missing its " + readMeFileName,
+	ruleExamples: "README code example does not compile",
+	ruleTests:    "Package has no test files",
+	ruleContent:  "File content does not match a content rule",
+}
+
+// The subset of the SARIF 2.1.0 format used by checkdoc, as documented for
+// GitHub code scanning.
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID               string       `json:"id"`
+		ShortDescription sarifMessage `json:"shortDescription"`
+	}
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           *sarifRegion          `json:"region,omitempty"`
+	}
+	sarifArtifactLocation struct {
+		URI       string `json:"uri"`
+		URIBaseID string `json:"uriBaseId"`
+	}
+	sarifRegion struct {
+		StartLine int `json:"startLine"`
+	}
+)
+
+// sarif returns the report in the SARIF format. Every rule checked is listed,
+// and every finding is a result located at its path relative to the project
+// root, with the severity of its rule as level.
+func (r *report) sarif() sarifLog {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           toolName,
+			InformationURI: toolURI,
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+	for _, rule := range r.ran {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:               rule,
+			ShortDescription: sarifMessage{Text: ruleDescriptions[rule]},
+		})
+		for _, f := range r.findings[rule] {
+			path := f.Path
+			if path == "" {
+				path = f.Module
+			}
+			loc := sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: path, URIBaseID: "%SRCROOT%"},
+			}
+			if f.Line > 0 {
+				loc.Region = &sarifRegion{StartLine: f.Line}
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    rule,
+				Level:     r.severity(rule),
+				Message:   sarifMessage{Text: fmt.Sprintf("%s: %s", ruleDescriptions[rule], f.Message)},
+				Locations: []sarifLocation{{PhysicalLocation: loc}},
+			})
+		}
+	}
+	return sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}}
+}
+
+// writeSARIF writes the report to w in the SARIF format.
+func (r *report) writeSARIF(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r.sarif())
+}
+
+// checkOutput returns an error if output is not a known output format.
+func checkOutput(output string) error {
+	if output != outputText && output != outputSARIF {
+		return fmt.Errorf("unknown output %q, must be one of: %s, %s", output, outputText, outputSARIF)
+	}
+	return nil
+}
+
+// writeOutput writes the report to w in the output format.
+func (r *report) writeOutput(w io.Writer, output string) error {
+	if output == outputSARIF {
+		return r.writeSARIF(w)
+	}
+	return r.write(w)
+}
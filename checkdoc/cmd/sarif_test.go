@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/testfiles"
+)
+
+func TestReportSARIF(t *testing.T) {
+	root := newTestProject(t)
+	testfiles.Write(t, root, map[string]string{
+		"untested/a.go": "package untested\n",
+	})
+
+	r, err := newReport([]string{ruleTests})
+	require.NoError(t, err)
+	require.NoError(t, r.add(ruleDocs, checkDocs(root, "components.go", "example.com/project")))
+	require.NoError(t, r.add(ruleTests, checkTests(root, nil, func(string) bool { return true })))
+	require.NoError(t, r.add(ruleExamples, &findingsError{findings: []finding{
+		{Module: ".", Path: "README.md", Line: 3, Message: "README.md:3: undefined: Hello"},
+	}}))
+
+	var buf bytes.Buffer
+	require.NoError(t, r.writeOutput(&buf, outputSARIF))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Equal(t, "checkdoc", run.Tool.Driver.Name)
+	assert.Equal(t, []sarifRule{
+		{ID: ruleDocs, ShortDescription: sarifMessage{Text: "Component is missing its README.md"}},
+		{ID: ruleTests, ShortDescription: sarifMessage{Text: "Package has no test files"}},
+		{ID: ruleExamples, ShortDescription: sarifMessage{Text: "README code example does not compile"}},
+	}, run.Tool.Driver.Rules)
+
+	require.Len(t, run.Results, 5)
+	docs := run.Results[0]
+	assert.Equal(t, ruleDocs, docs.RuleID)
+	assert.Equal(t, severityError, docs.Level)
+	assert.Equal(t, "receiver/undocumented", docs.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Nil(t, docs.Locations[0].PhysicalLocation.Region)
+
+	var testURIs []string
+	for _, res := range run.Results[1:4] {
+		assert.Equal(t, ruleTests, res.RuleID)
+		assert.Equal(t, severityWarning, res.Level)
+		testURIs = append(testURIs, res.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	}
+	assert.ElementsMatch(t, []string{".", "receiver/undocumented", "untested"}, testURIs)
+
+	example := run.Results[4]
+	assert.Equal(t, "README code example does not compile: README.md:3: undefined: Hello", example.Message.Text)
+	assert.Equal(t, &sarifRegion{StartLine: 3}, example.Locations[0].PhysicalLocation.Region)
+}
+
+func TestCheckOutput(t *testing.T) {
+	assert.NoError(t, checkOutput(outputText))
+	assert.NoError(t, checkOutput(outputSARIF))
+	assert.EqualError(t, checkOutput("json"), `unknown output "json", must be one of: text, sarif`)
+}
@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// generatedRegexp matches the comment marking generated Go source files, see
+// https://go.dev/s/generatedcode.
+var generatedRegexp = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// isGenerated returns whether the Go source file at name is generated.
+func isGenerated(name string) (bool, error) {
+	f, err := os.Open(filepath.Clean(name))
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if generatedRegexp.MatchString(line) {
+			return true, nil
+		}
+		if strings.HasPrefix(line, "package ") {
+			// The comment must appear before the package clause.
+			return false, nil
+		}
+	}
+	return false, s.Err()
+}
+
+// isInternal returns whether the package in the directory dir, relative to
+// the project root, is internal.
+func isInternal(dir string) bool {
+	for _, elem := range strings.Split(filepath.ToSlash(dir), "/") {
+		if elem == "internal" {
+			return true
+		}
+	}
+	return false
+}
+
+// isExcepted returns whether the package in the directory dir, relative to
+// the project root, matches one of the exceptions. Exceptions are path.Match
+// patterns, a pattern ending in "/..." also matches all subdirectories.
+func isExcepted(dir string, exceptions []string) bool {
+	dir = filepath.ToSlash(dir)
+	for _, e := range exceptions {
+		if prefix := strings.TrimSuffix(e, "/..."); prefix != e {
+			if ok, _ := path.Match(prefix, dir); ok {
+				return true
+			}
+			for d := path.Dir(dir); d != "." && d != "/"; d = path.Dir(d) {
+				if ok, _ := path.Match(prefix, d); ok {
+					return true
+				}
+			}
+			continue
+		}
+		if ok, _ := path.Match(e, dir); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// untestedPackage returns whether the package in dir has Go source files but
+// no test files. Packages with only generated source files are considered
+// tested.
+func untestedPackage(dir string) (bool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false, err
+	}
+
+	var sources []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") {
+			continue
+		}
+		if strings.HasSuffix(name, "_test.go") {
+			return false, nil
+		}
+		sources = append(sources, filepath.Join(dir, name))
+	}
+
+	for _, s := range sources {
+		generated, err := isGenerated(s)
+		if err != nil {
+			return false, err
+		}
+		if !generated {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkTests checks that every package in projectPath selected by include
+// has at least one test file. Internal packages, packages only containing
+// generated code, and packages matching one of the exceptions are not
+// checked.
+func checkTests(projectPath string, exceptions []string, include func(string) bool) error {
+	var untested []finding
+	err := filepath.WalkDir(projectPath, func(dir string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		name := d.Name()
+		if dir != projectPath && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "vendor" || name == "testdata") {
+			return filepath.SkipDir
+		}
+
+		rel, err := filepath.Rel(projectPath, dir)
+		if err != nil {
+			return err
+		}
+		if isInternal(rel) || isExcepted(rel, exceptions) || !include(dir) {
+			return nil
+		}
+
+		ok, err := untestedPackage(dir)
+		if err != nil {
+			return err
+		}
+		if ok {
+			untested = append(untested, finding{
+				Module:  relModule(projectPath, dir),
+				Path:    filepath.ToSlash(rel),
+				Message: rel,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(untested) > 0 {
+		sort.Slice(untested, func(i, j int) bool { return untested[i].Message < untested[j].Message })
+		return &findingsError{summary: "packages without tests, add at least one _test.go file", findings: untested}
+	}
+	return nil
+}
+
+// checkChangedTests is like checkTests but only checks the packages of Go
+// modules containing one of the changed files.
+func checkChangedTests(projectPath string, exceptions []string, changed []string) error {
+	projectPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return err
+	}
+
+	modules := changedModules(projectPath, changed)
+	if len(modules) == 0 {
+		return nil
+	}
+
+	return checkTests(projectPath, exceptions, func(dir string) bool {
+		_, ok := modules[owningModule(projectPath, dir)]
+		return ok
+	})
+}
+
+// splitExceptions splits the comma separated list of test exceptions.
+func splitExceptions(exceptions string) []string {
+	var out []string
+	for _, e := range strings.Split(exceptions, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			out = append(out, strings.TrimSuffix(e, "/"))
+		}
+	}
+	return out
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/testfiles"
+)
+
+// newTestsProject creates a project with packages with and without tests.
+func newTestsProject(t *testing.T) string {
+	root := t.TempDir()
+	testfiles.Write(t, root, map[string]string{
+		"go.mod":                      "module example.com/project\n",
+		"project.go":                  "package project\n",
+		"project_test.go":             "package project\n",
+		"tested/a.go":                 "package tested\n",
+		"tested/a_test.go":            "package tested\n",
+		"untested/a.go":               "package untested\n",
+		"internal/a.go":               "package internal\n",
+		"tested/internal/nested/a.go": "package nested\n",
+		"generated/a.go":              "// Code generated by mdatagen. DO NOT EDIT.\n\npackage generated\n",
+		"partly/a.go":                 "// Code generated by mdatagen. DO NOT EDIT.\n\npackage partly\n",
+		"partly/b.go":                 "package partly\n",
+		"docs/README.md":              "# Docs\n",
+		"cmd/tool/main.go":            "package main\n",
+		"testdata/a.go":               "package testdata\n",
+		"module/go.mod":               "module example.com/project/module\n",
+		"module/a.go":                 "package module\n",
+	})
+	return root
+}
+
+func TestCheckTests(t *testing.T) {
+	root := newTestsProject(t)
+
+	err := checkTests(root, nil, func(string) bool { return true })
+	require.Error(t, err)
+	assert.Equal(t, "packages without tests, add at least one _test.go file:\n"+
+		filepath.Join("cmd", "tool")+"\nmodule\npartly\nuntested", err.Error())
+
+	err = checkTests(root, []string{"cmd/...", "module", "partly", "untested"}, func(string) bool { return true })
+	assert.NoError(t, err)
+}
+
+func TestCheckChangedTests(t *testing.T) {
+	root := newTestsProject(t)
+
+	assert.NoError(t, checkChangedTests(root, nil, nil))
+
+	err := checkChangedTests(root, nil, []string{filepath.Join(root, "module", "a.go")})
+	require.Error(t, err)
+	assert.Equal(t, "packages without tests, add at least one _test.go file:\nmodule", err.Error())
+}
+
+func TestIsExcepted(t *testing.T) {
+	tests := []struct {
+		dir        string
+		exceptions []string
+		want       bool
+	}{
+		{dir: "a", exceptions: nil, want: false},
+		{dir: "a", exceptions: []string{"a"}, want: true},
+		{dir: "a/b", exceptions: []string{"a"}, want: false},
+		{dir: "a/b", exceptions: []string{"a/*"}, want: true},
+		{dir: "a", exceptions: []string{"a/..."}, want: true},
+		{dir: "a/b/c", exceptions: []string{"a/..."}, want: true},
+		{dir: "ab/c", exceptions: []string{"a/..."}, want: false},
+		{dir: "x/cmd/y", exceptions: []string{"*/cmd/..."}, want: true},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, isExcepted(test.dir, test.exceptions), "%s %v", test.dir, test.exceptions)
+	}
+}
+
+func TestSplitExceptions(t *testing.T) {
+	assert.Nil(t, splitExceptions(""))
+	assert.Equal(t, []string{"a/...", "b"}, splitExceptions(" a/..., b/ ,"))
+}
@@ -14,46 +14,8 @@
 
 package main
 
-import (
-	"flag"
-)
+import "go.opentelemetry.io/build-tools/checkdoc/cmd"
 
-const (
-	// Absolute root path of the project
-	projectPath = "project-path"
-	// Relative path where imports all default components
-	relativeDefaultComponentsPath = "component-rel-path"
-	// The project Go Module name
-	projectGoModule = "module-name"
-)
-
-// The main verifies if README.md and proper documentations for the enabled default components
-// are existed in OpenTelemetry core and contrib repository.
-// Usage in the core repo:
-//
-//	checkdoc --project-path path/to/project \
-//				--component-rel-path service/defaultcomponents/defaults.go \
-//				--module-name go.opentelemetry.io/collector
-//
-// Usage in the contrib repo:
-//
-//	checkdoc --project-path path/to/project \
-//				--component-rel-path cmd/otelcontrib/components.go \
-//				--module-name github.com/open-telemetry/opentelemetry-collector-contrib
 func main() {
-	projectPath := flag.String(projectPath, "", "specify the project path")
-	componentPath := flag.String(relativeDefaultComponentsPath, "", "specify the relative component path")
-	moduleName := flag.String(projectGoModule, "", "specify the project go module")
-
-	flag.Parse()
-
-	err := checkDocs(
-		*projectPath,
-		*componentPath,
-		*moduleName,
-	)
-
-	if err != nil {
-		panic(err)
-	}
+	cmd.Execute()
 }
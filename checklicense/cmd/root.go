@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	cl "go.opentelemetry.io/build-tools/checklicense/internal"
+	"go.opentelemetry.io/build-tools/internal/logging"
+	"go.opentelemetry.io/build-tools/internal/repo"
+)
+
+var errMissingHeaders = errors.New("files without license header found")
+
+type commandConfig struct {
+	config      cl.Config
+	headerFile  string
+	excludeFile string
+	quiet       bool
+	verbose     bool
+	rootCommand cobra.Command
+}
+
+func newCommandConfig() *commandConfig {
+	c := &commandConfig{}
+
+	c.rootCommand = cobra.Command{
+		Use:   "checklicense",
+		Short: "Check the Go files of a repository start with a license header",
+		Long: `Checklicense verifies that every Go file of a repository, across all of its modules,
+		starts with the required license header, by default the Apache 2.0 header of OpenTelemetry.
+		With --fix, the header is inserted in files without a license header. Files with another
+		license header are reported and left unchanged.`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			logging.Configure(c.quiet, c.verbose)
+			if c.config.RootPath == "" {
+				rp, err := repo.FindRoot()
+				if err != nil {
+					return fmt.Errorf("could not find a valid repository: %w", err)
+				}
+				c.config.RootPath = rp
+			}
+			if c.headerFile != "" {
+				header, err := cl.ReadHeader(c.headerFile)
+				if err != nil {
+					return err
+				}
+				c.config.Header = header
+			}
+			if c.excludeFile != "" {
+				patterns, err := cl.ReadExcludeFile(c.excludeFile)
+				if err != nil {
+					return err
+				}
+				c.config.ExcludedPaths = append(c.config.ExcludedPaths, patterns...)
+			}
+			if c.config.Year == 0 {
+				c.config.Year = time.Now().Year()
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			problems, err := cl.Check(c.config)
+			if err != nil {
+				return err
+			}
+			for _, p := range problems {
+				fmt.Fprintln(cmd.OutOrStdout(), p)
+			}
+			if len(problems) > 0 {
+				logging.Errorf("found %d files without the license header", len(problems))
+				return errMissingHeaders
+			}
+			return nil
+		},
+	}
+	return c
+}
+
+var (
+	comCfg = newCommandConfig()
+)
+
+// Execute runs the checklicense command line, exiting with a non-zero status
+// if it fails or files without the license header are found.
+func Execute() {
+	if err := comCfg.rootCommand.Execute(); err != nil {
+		if !errors.Is(err, errMissingHeaders) {
+			logging.Errorf("failed to execute: %v", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func init() {
+	flags := comCfg.rootCommand.Flags()
+	flags.StringVar(&comCfg.config.RootPath, "root", "", `path to the root directory of the repository. If --root flag is not provided checklicense will attempt to find a
+	git repository in the current or a parent directory.`)
+	flags.StringSliceVar(&comCfg.config.ExcludedPaths, "exclude", []string{}, "list of comma separated glob patterns, relative to the root, of files and directories exempt from the check. "+
+		"multiple calls of --exclude can be made")
+	flags.StringVar(&comCfg.excludeFile, "exclude-file", "", "path to a file listing glob patterns of exempt files and directories, one per line")
+	flags.StringVar(&comCfg.headerFile, "header", "", "path to a file with the text of the required license header, without comment markers. "+
+		"{{year}} matches any year and is replaced with the current year by --fix. Defaults to the Apache 2.0 header of OpenTelemetry")
+	flags.BoolVar(&comCfg.config.Fix, "fix", false, "insert the license header in files without one")
+	flags.IntVar(&comCfg.config.Year, "year", 0, "year inserted in place of {{year}} by --fix (default: the current year)")
+	flags.BoolVarP(&comCfg.verbose, "verbose", "v", false, "verbose output")
+	flags.BoolVarP(&comCfg.quiet, "quiet", "q", false, "only log errors, takes precedence over --verbose")
+}
@@ -0,0 +1,20 @@
+module go.opentelemetry.io/build-tools/checklicense
+
+go 1.18
+
+require (
+	github.com/spf13/cobra v1.6.1
+	github.com/stretchr/testify v1.8.1
+	go.opentelemetry.io/build-tools v0.2.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/inconshreveable/mousetrap v1.0.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/spf13/pflag v1.0.5 // indirect
+	golang.org/x/mod v0.6.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace go.opentelemetry.io/build-tools => ../
@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checklicense
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+)
+
+// skippedDirs are directories never searched for Go files.
+var skippedDirs = map[string]struct{}{
+	".git":         {},
+	"node_modules": {},
+	"vendor":       {},
+}
+
+// Reasons a file is reported for.
+const (
+	reasonMissing  = "missing license header"
+	reasonMismatch = "license header does not match"
+)
+
+// Config configures a license header check.
+type Config struct {
+	// RootPath is the directory searched for Go files.
+	RootPath string
+	// ExcludedPaths are glob patterns, relative to RootPath, of files and
+	// directories that are not checked.
+	ExcludedPaths []string
+	// Header is the text of the required license header, without comment
+	// markers. DefaultHeader is used if it is empty.
+	Header string
+	// Fix inserts the header in files that do not have one instead of
+	// reporting them. Files with a different header are still reported.
+	Fix bool
+	// Year replaces the {{year}} placeholder of the headers inserted by Fix.
+	Year int
+}
+
+// Problem is a Go file without the required license header.
+type Problem struct {
+	// File is the path of the Go file relative to the root.
+	File   string
+	Reason string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.File, p.Reason)
+}
+
+// Check verifies that the Go files found in cfg.RootPath start with the
+// license header, and returns the files that do not, in lexical order.
+func Check(cfg Config) ([]Problem, error) {
+	text := cfg.Header
+	if text == "" {
+		text = DefaultHeader
+	}
+	h, err := newHeader(text)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := goFiles(cfg)
+	if err != nil {
+		return nil, err
+	}
+	logging.Debugf("checking %d Go files", len(files))
+
+	var problems []Problem
+	for _, file := range files {
+		p := filepath.Join(cfg.RootPath, filepath.FromSlash(file))
+		src, err := os.ReadFile(filepath.Clean(p))
+		if err != nil {
+			return nil, err
+		}
+
+		comment := leadingComment(src)
+		switch {
+		case h.matches(comment):
+			continue
+		case isLicense(comment):
+			problems = append(problems, Problem{file, reasonMismatch})
+		case cfg.Fix:
+			if err = os.WriteFile(p, append([]byte(h.comment(cfg.Year)), src...), 0600); err != nil {
+				return nil, fmt.Errorf("failed to add license header to %s: %w", file, err)
+			}
+			logging.Infof("added license header to %s", file)
+		default:
+			problems = append(problems, Problem{file, reasonMissing})
+		}
+	}
+	return problems, nil
+}
+
+// goFiles returns the paths, relative to the root, of the Go files that are
+// not excluded.
+func goFiles(cfg Config) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(cfg.RootPath, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(cfg.RootPath, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if _, ok := skippedDirs[d.Name()]; ok || excluded(cfg.ExcludedPaths, rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(p) == ".go" && !excluded(cfg.ExcludedPaths, rel) {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	return files, err
+}
+
+func excluded(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadExcludeFile returns the glob patterns listed in the file name, one per
+// line. Blank lines and lines starting with # are ignored.
+func ReadExcludeFile(name string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Clean(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exclude file: %w", err)
+	}
+	var patterns []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if _, err = path.Match(line, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q in exclude file: %w", line, err)
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns, scanner.Err()
+}
+
+// leadingComment returns the lines, without comment markers, of the first
+// comment of the Go source src. Blank lines and build constraints before it
+// are skipped.
+func leadingComment(src []byte) []string {
+	lines := strings.Split(strings.ReplaceAll(string(src), "\r\n", "\n"), "\n")
+	i := 0
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line != "" && !strings.HasPrefix(line, "//go:build") && !strings.HasPrefix(line, "// +build") {
+			break
+		}
+	}
+
+	var comment []string
+	if i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "/*") {
+		for ; i < len(lines); i++ {
+			line := strings.TrimSpace(lines[i])
+			line = strings.TrimPrefix(line, "/*")
+			end := strings.Contains(line, "*/")
+			line = strings.TrimPrefix(strings.TrimSpace(strings.SplitN(line, "*/", 2)[0]), "*")
+			comment = append(comment, line)
+			if end {
+				break
+			}
+		}
+		return trimBlank(comment)
+	}
+
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(line, "//") || strings.HasPrefix(line, "//go:build") || strings.HasPrefix(line, "// +build") {
+			break
+		}
+		comment = append(comment, strings.TrimPrefix(line, "//"))
+	}
+	return trimBlank(comment)
+}
+
+// trimBlank returns lines without their leading blank lines.
+func trimBlank(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	return lines
+}
+
+// isLicense returns whether the comment lines look like a license header,
+// i.e. mention a copyright or license.
+func isLicense(comment []string) bool {
+	for _, line := range comment {
+		l := strings.ToLower(line)
+		if strings.Contains(l, "copyright") || strings.Contains(l, "license") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checklicense
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/testfiles"
+)
+
+const licensed = `// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package a
+`
+
+func TestCheck(t *testing.T) {
+	root := t.TempDir()
+	testfiles.Write(t, root, map[string]string{
+		"a/licensed.go":        licensed,
+		"a/constraint.go":      "//go:build linux\n\n" + licensed,
+		"a/missing.go":         "// Package a does things.\npackage a\n",
+		"a/empty.go":           "package a\n",
+		"a/other.go":           "// Copyright 2021 Someone Else\n// All rights reserved.\n\npackage a\n",
+		"a/README.md":          "# not Go\n",
+		"gen/generated.go":     "package gen\n",
+		"vendor/x/x.go":        "package x\n",
+		"internal/excluded.go": "package internal\n",
+	})
+
+	problems, err := Check(Config{RootPath: root, ExcludedPaths: []string{"gen", "internal/excluded.go"}})
+	require.NoError(t, err)
+	assert.Equal(t, []Problem{
+		{"a/empty.go", reasonMissing},
+		{"a/missing.go", reasonMissing},
+		{"a/other.go", reasonMismatch},
+	}, problems)
+}
+
+func TestCheckFix(t *testing.T) {
+	root := t.TempDir()
+	testfiles.Write(t, root, map[string]string{
+		"header.txt": "Copyright {{year}} Example Authors\nSPDX-License-Identifier: Apache-2.0\n",
+		"old.go":     "// Copyright 2019 Example Authors\n// SPDX-License-Identifier: Apache-2.0\n\npackage a\n",
+		"block.go":   "/*\n * Copyright 2020 Example Authors\n * SPDX-License-Identifier: Apache-2.0\n */\n\npackage a\n",
+		"missing.go": "//go:build linux\n\npackage a\n",
+		"other.go":   "// Copyright The OpenTelemetry Authors\n\npackage a\n",
+	})
+	header, err := ReadHeader(filepath.Join(root, "header.txt"))
+	require.NoError(t, err)
+
+	cfg := Config{RootPath: root, Header: header, Fix: true, Year: 2024}
+	problems, err := Check(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []Problem{{"other.go", reasonMismatch}}, problems)
+
+	data, err := os.ReadFile(filepath.Join(root, "missing.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "// Copyright 2024 Example Authors\n// SPDX-License-Identifier: Apache-2.0\n\n//go:build linux\n\npackage a\n", string(data))
+
+	// Fixed files pass the check.
+	cfg.Fix = false
+	problems, err = Check(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []Problem{{"other.go", reasonMismatch}}, problems)
+}
+
+func TestDefaultHeader(t *testing.T) {
+	root := t.TempDir()
+	testfiles.Write(t, root, map[string]string{"a.go": "package a\n"})
+
+	problems, err := Check(Config{RootPath: root, Fix: true})
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	data, err := os.ReadFile(filepath.Join(root, "a.go"))
+	require.NoError(t, err)
+	h, err := newHeader(DefaultHeader)
+	require.NoError(t, err)
+	assert.True(t, h.matches(leadingComment(data)))
+	assert.True(t, h.matches(leadingComment([]byte(licensed))), "gofmt reformatted header")
+}
+
+func TestReadExcludeFile(t *testing.T) {
+	root := t.TempDir()
+	testfiles.Write(t, root, map[string]string{
+		"exclude.txt": "# generated code\ngen\n\n  third_party/*  \n",
+		"invalid.txt": "[\n",
+	})
+
+	patterns, err := ReadExcludeFile(filepath.Join(root, "exclude.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gen", "third_party/*"}, patterns)
+
+	_, err = ReadExcludeFile(filepath.Join(root, "invalid.txt"))
+	assert.ErrorContains(t, err, "invalid pattern")
+}
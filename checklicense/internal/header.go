@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checklicense
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// yearPlaceholder is replaced with the current year in the headers inserted
+// by Fix, and matches any year when checking headers.
+const yearPlaceholder = "{{year}}"
+
+// DefaultHeader is the Apache 2.0 license header of OpenTelemetry Go files.
+const DefaultHeader = `Copyright The OpenTelemetry Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.`
+
+var yearPattern = regexp.MustCompile(`^[0-9]{4}$`)
+
+// header is a license header, without comment markers.
+type header struct {
+	lines []string
+}
+
+// newHeader returns the header with the text, without comment markers.
+func newHeader(text string) (header, error) {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+	if text == "" {
+		return header{}, errors.New("license header is empty")
+	}
+	return header{lines: strings.Split(text, "\n")}, nil
+}
+
+// ReadHeader returns the text of the header file at path. Lines may contain
+// the {{year}} placeholder.
+func ReadHeader(path string) (string, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", fmt.Errorf("failed to read license header: %w", err)
+	}
+	return string(data), nil
+}
+
+// matches returns whether the comment lines, without comment markers, are
+// the header. Whitespace differences are ignored, e.g. the indentation of
+// the license URL which gofmt rewrites, and the year placeholder matches any
+// year.
+func (h header) matches(comment []string) bool {
+	if len(comment) < len(h.lines) {
+		return false
+	}
+	for i, line := range h.lines {
+		want, got := strings.Fields(line), strings.Fields(comment[i])
+		if len(want) != len(got) {
+			return false
+		}
+		for j := range want {
+			if want[j] == yearPlaceholder && yearPattern.MatchString(got[j]) {
+				continue
+			}
+			if want[j] != got[j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// comment returns the header as Go line comments for year, followed by a
+// blank line.
+func (h header) comment(year int) string {
+	var sb strings.Builder
+	for _, line := range h.lines {
+		line = strings.ReplaceAll(line, yearPlaceholder, strconv.Itoa(year))
+		if strings.TrimSpace(line) == "" {
+			sb.WriteString("//\n")
+			continue
+		}
+		sb.WriteString("// " + strings.TrimRight(line, " \t") + "\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
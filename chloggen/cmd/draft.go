@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/build-tools/chloggen/internal/chlog"
+	"go.opentelemetry.io/build-tools/internal/logging"
+)
+
+var (
+	revRange string
+)
+
+var draftCmd = &cobra.Command{
+	Use:   "draft",
+	Short: "Drafts change files from conventional commit messages",
+	Long: `Drafts a change file for every commit in a range whose conventional commit message describes a user-facing change:
+feat and perf commits are enhancements, fix commits are bug fixes, deprecate commits are deprecations and commits marked with
+"!" or a BREAKING CHANGE footer are breaking changes. The scope is used as the component and the pull request and issues
+referenced by the commit as the issues. Commits that already add a change file are skipped.
+The drafts must be reviewed before they are merged.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return draft(chlogCtx, revRange)
+	},
+}
+
+func draft(ctx chlog.Context, revRange string) error {
+	drafts, err := chlog.DraftEntries(ctx, revRange)
+	if err != nil {
+		return err
+	}
+
+	var written int
+	for _, d := range drafts {
+		path := filepath.Join(ctx.UnreleasedDir, draftFileName(d.Commit))
+		if _, err := os.Stat(path); err == nil {
+			logging.Infof("Skipping commit %s, %s already exists", d.Commit.Hash, path)
+			continue
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, "# Drafted from commit %s, review before merging.\n", d.Commit.Hash)
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(d.Entry); err != nil {
+			return err
+		}
+		if err := enc.Close(); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, buf.Bytes(), os.FileMode(0600)); err != nil {
+			return err
+		}
+		logging.Debugf("Drafted %s from commit %s", path, d.Commit.Hash)
+		written++
+	}
+	logging.Infof("Drafted %d changelog entries in %s", written, ctx.UnreleasedDir)
+	return nil
+}
+
+// draftFileName returns the name of the change file drafted from c.
+func draftFileName(c chlog.Commit) string {
+	hash := c.Hash
+	if len(hash) > 12 {
+		hash = hash[:12]
+	}
+	return "draft-" + hash + ".yaml"
+}
+
+func init() {
+	draftCmd.Flags().StringVarP(&revRange, "range", "r", "", "range of commits to draft change files from, e.g. v0.2.0..HEAD")
+	if err := draftCmd.MarkFlagRequired("range"); err != nil {
+		logging.Fatalf("could not mark range flag as required: %v", err)
+	}
+}
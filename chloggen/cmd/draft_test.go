@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/build-tools/chloggen/internal/chlog"
+)
+
+func TestDraft(t *testing.T) {
+	ctx := setupTestDir(t, []*chlog.Entry{})
+	root := filepath.Dir(ctx.ChangelogMD)
+	git := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+		cmd.Dir = root
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	git("init", "-q")
+	git("commit", "-q", "--allow-empty", "-m", "initial commit")
+	git("commit", "-q", "--allow-empty", "-m", "feat(chloggen): draft entries (#10)")
+	hash := git("rev-parse", "HEAD")
+
+	require.NoError(t, draft(ctx, "HEAD~1..HEAD"))
+
+	path := filepath.Join(ctx.UnreleasedDir, "draft-"+hash[:12]+".yaml")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "# Drafted from commit "+hash+", review before merging.\n"))
+
+	var entry chlog.Entry
+	require.NoError(t, yaml.Unmarshal(content, &entry))
+	assert.Equal(t, chlog.Entry{ChangeType: chlog.Enhancement, Component: "chloggen", Note: "Draft entries", Issues: []int{10}}, entry)
+	require.NoError(t, validate(ctx))
+
+	// Existing drafts are not overwritten.
+	require.NoError(t, os.WriteFile(path, []byte("edited"), 0o600))
+	require.NoError(t, draft(ctx, "HEAD~1..HEAD"))
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "edited", string(content))
+}
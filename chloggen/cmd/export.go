@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/build-tools/chloggen/internal/chlog"
+)
+
+const formatGitHubRelease = "github-release"
+
+var (
+	exportFormat  string
+	exportVersion string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Renders changelog entries in another format, e.g. GitHub release notes",
+	Long: `Renders the pending changelog entries, or those released in the version given with
+--version, in another format and prints them to stdout. The github-release format groups the
+entries by change type and component, for the body of a GitHub release.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return export(chlogCtx, cmd.OutOrStdout(), exportFormat, exportVersion)
+	},
+}
+
+func export(ctx chlog.Context, w io.Writer, format string, version string) error {
+	if format != formatGitHubRelease {
+		return fmt.Errorf("unsupported format %q, supported formats: %s", format, formatGitHubRelease)
+	}
+
+	var (
+		entries []*chlog.Entry
+		err     error
+	)
+	if version == "" {
+		entries, err = chlog.ReadEntries(ctx)
+	} else {
+		entries, err = chlog.ReadChangelogEntries(ctx, version)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		return fmt.Errorf("no entries to export")
+	}
+
+	notes, err := chlog.GenerateGitHubRelease(entries)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, notes)
+	return err
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&exportFormat, "format", formatGitHubRelease, "output format, one of: "+formatGitHubRelease)
+	exportCmd.Flags().StringVarP(&exportVersion, "version", "v", "", "export the entries released in this version of the changelog instead of the pending ones")
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/chloggen/internal/chlog"
+	"go.opentelemetry.io/build-tools/golden"
+)
+
+func TestExportGitHubRelease(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows line breaks cause comparison failures w/ golden files.")
+	}
+	tests := []struct {
+		name      string
+		entries   []*chlog.Entry
+		changelog string
+		version   string
+	}{
+		{
+			name:    "export_pending",
+			entries: append(getSampleEntries(), bugFixEntry()),
+		},
+		{
+			name:    "export_version",
+			version: "v0.44.0",
+		},
+		{
+			name:      "export_version_subtext",
+			changelog: "subtext.md",
+			version:   "v0.45.0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := setupTestDir(t, tc.entries)
+			if tc.changelog != "" {
+				changelogBytes, err := os.ReadFile(filepath.Join("testdata", tc.changelog))
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(ctx.ChangelogMD, changelogBytes, os.FileMode(0600)))
+			}
+
+			var buf bytes.Buffer
+			require.NoError(t, export(ctx, &buf, formatGitHubRelease, tc.version))
+
+			golden.Assert(t, filepath.Join("testdata", tc.name+".md"), buf.Bytes())
+		})
+	}
+}
+
+func TestExportErrors(t *testing.T) {
+	ctx := setupTestDir(t, nil)
+
+	var buf bytes.Buffer
+	assert.ErrorContains(t, export(ctx, &buf, "html", ""), "unsupported format")
+	assert.ErrorContains(t, export(ctx, &buf, formatGitHubRelease, ""), "no entries to export")
+	assert.ErrorContains(t, export(ctx, &buf, formatGitHubRelease, "v9.9.9"), "version v9.9.9 not found")
+	assert.Empty(t, buf.String())
+}
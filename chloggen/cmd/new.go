@@ -15,41 +15,63 @@
 package cmd
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
-	"log"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"unicode"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 
 	"go.opentelemetry.io/build-tools/chloggen/internal/chlog"
+	"go.opentelemetry.io/build-tools/internal/logging"
 )
 
 var (
-	filename string
+	filename    string
+	interactive bool
 )
 
 var newCmd = &cobra.Command{
 	Use:   "new",
 	Short: "Creates new change file",
+	Long: `Creates a new change file from the template, to be filled in, or with --interactive from answers
+to prompts for each field, which are validated as they are entered.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if interactive {
+			return initializeInteractive(chlogCtx, filename, cmd.InOrStdin(), cmd.OutOrStdout())
+		}
+		if filename == "" {
+			return errors.New("required flag \"filename\" not set")
+		}
 		return initialize(chlogCtx, filename)
 	},
 }
 
-func initialize(ctx chlog.Context, filename string) error {
+// entryPath returns the path of the change file named filename in the
+// unreleased directory, with the .yaml extension.
+func entryPath(ctx chlog.Context, filename string) (string, error) {
 	path := filepath.Join(ctx.UnreleasedDir, cleanFileName(filename))
-	var pathWithExt string
 	switch ext := filepath.Ext(path); ext {
 	case ".yaml":
-		pathWithExt = path
+		return path, nil
 	case ".yml":
-		pathWithExt = strings.TrimSuffix(path, ".yml") + ".yaml"
+		return strings.TrimSuffix(path, ".yml") + ".yaml", nil
 	case "":
-		pathWithExt = path + ".yaml"
+		return path + ".yaml", nil
 	default:
-		return fmt.Errorf("non-yaml extension: %s", ext)
+		return "", fmt.Errorf("non-yaml extension: %s", ext)
+	}
+}
+
+func initialize(ctx chlog.Context, filename string) error {
+	pathWithExt, err := entryPath(ctx, filename)
+	if err != nil {
+		return err
 	}
 
 	templateBytes, err := os.ReadFile(filepath.Clean(ctx.TemplateYAML))
@@ -60,18 +82,70 @@ func initialize(ctx chlog.Context, filename string) error {
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Changelog entry template copied to: %s\n", pathWithExt)
+	logging.Infof("Changelog entry template copied to: %s", pathWithExt)
+	return nil
+}
+
+// initializeInteractive writes a change file from the answers read from in
+// to the prompts written to out. If filename is empty, the file is named
+// after the component and note of the entry. Existing files are not
+// overwritten.
+func initializeInteractive(ctx chlog.Context, filename string, in io.Reader, out io.Writer) error {
+	entry, err := newPrompter(in, out).promptEntry()
+	if err != nil {
+		return err
+	}
+	if filename == "" {
+		filename = entryFileName(entry)
+	}
+	path, err := entryPath(ctx, filename)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err = enc.Encode(entry); err != nil {
+		return err
+	}
+	if err = enc.Close(); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filepath.Clean(path), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(buf.Bytes()); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	logging.Infof("Changelog entry written to: %s", path)
 	return nil
 }
 
+// entryFileName returns a file name made of the component and the first
+// words of the note of entry.
+func entryFileName(entry *chlog.Entry) string {
+	words := strings.FieldsFunc(strings.ToLower(entry.Component+" "+entry.Note), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > 6 {
+		words = words[:6]
+	}
+	return strings.Join(words, "-")
+}
+
 func cleanFileName(filename string) string {
 	replace := strings.NewReplacer("/", "_", "\\", "_")
 	return replace.Replace(filename)
 }
 
 func init() {
-	newCmd.Flags().StringVarP(&filename, "filename", "f", "", "name of the file to add")
-	if err := newCmd.MarkFlagRequired("filename"); err != nil {
-		log.Fatalf("could not mark filename flag as required: %v", err)
-	}
+	newCmd.Flags().StringVarP(&filename, "filename", "f", "", "name of the file to add, required unless --interactive is set")
+	newCmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "prompt for the fields of the entry instead of copying the template")
 }
@@ -15,8 +15,13 @@
 package cmd
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/build-tools/chloggen/internal/chlog"
@@ -65,6 +70,63 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewInteractive(t *testing.T) {
+	ctx := setupTestDir(t, []*chlog.Entry{})
+
+	in := strings.Join([]string{
+		"7",        // out of range change type
+		"4",        // enhancement
+		"",         // missing component
+		"chloggen", // component
+		"Prompt for new entries.",
+		"#12, abc",       // invalid issue
+		"#12, 34",        // issues
+		"First line.",    // subtext
+		"  Second line.", // indented subtext
+		"",
+	}, "\n") + "\n"
+	var out bytes.Buffer
+	require.NoError(t, initializeInteractive(ctx, "", strings.NewReader(in), &out))
+
+	assert.Contains(t, out.String(), `Invalid choice "7"`)
+	assert.Contains(t, out.String(), "This field is required.")
+	assert.Contains(t, out.String(), `invalid issue number "abc"`)
+
+	data, err := os.ReadFile(filepath.Join(ctx.UnreleasedDir, "chloggen-prompt-for-new-entries.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, `change_type: enhancement
+component: chloggen
+note: Prompt for new entries.
+issues:
+  - 12
+  - 34
+subtext: |-
+  First line.
+    Second line.
+`, string(data))
+	require.NoError(t, validate(ctx))
+
+	// Existing files are not overwritten.
+	err = initializeInteractive(ctx, "chloggen-prompt-for-new-entries", strings.NewReader(in), &out)
+	require.ErrorIs(t, err, os.ErrExist)
+}
+
+func TestNewInteractiveUnexpectedEOF(t *testing.T) {
+	ctx := setupTestDir(t, []*chlog.Entry{})
+	var out bytes.Buffer
+	err := initializeInteractive(ctx, "", strings.NewReader("bug_fix\ncrosslink\n"), &out)
+	require.EqualError(t, err, "unexpected EOF")
+}
+
+func TestParseIssues(t *testing.T) {
+	issues, err := parseIssues("12 #34,56")
+	require.NoError(t, err)
+	assert.Equal(t, []int{12, 34, 56}, issues)
+
+	_, err = parseIssues("12 -3")
+	require.EqualError(t, err, `invalid issue number "-3"`)
+}
+
 func TestCleanFilename(t *testing.T) {
 	require.Equal(t, "fix_some_bug", cleanFileName("fix/some_bug"))
 	require.Equal(t, "fix_some_bug", cleanFileName("fix\\some_bug"))
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/build-tools/chloggen/internal/chlog"
+)
+
+var versioningFile string
+
+var nextVersionCmd = &cobra.Command{
+	Use:   "next-version",
+	Short: "Suggests the next version of each module set given the pending changelog entries",
+	Long: `Prints the next version of each module set of the multimod versioning file suggested by the
+pending changelog entries. Breaking changes increment the major version, or the minor version
+of v0 module sets, bug fixes the patch version and other changes the minor version.
+
+An entry applies to the module sets with a module whose path is its component, or ends with
+/<component>. Entries whose component matches no module apply to all module sets.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return nextVersion(chlogCtx, cmd.OutOrStdout(), versioningFile)
+	},
+}
+
+func nextVersion(ctx chlog.Context, w io.Writer, versioningFile string) error {
+	sets, err := chlog.ReadModuleSets(versioningFile)
+	if err != nil {
+		return err
+	}
+	entries, err := chlog.ReadEntries(ctx)
+	if err != nil {
+		return err
+	}
+
+	next, err := chlog.NextVersions(sets, entries)
+	if err != nil {
+		return err
+	}
+	for _, n := range next {
+		reason := n.ChangeType
+		if reason == "" {
+			reason = "no changes"
+		}
+		if _, err = fmt.Fprintf(w, "%s: %s -> %s (%s)\n", n.ModuleSet.Name, n.ModuleSet.Version, n.Next, reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	nextVersionCmd.Flags().StringVar(&versioningFile, "versioning-file", "versions.yaml", "multimod versioning file defining the module sets")
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/chloggen/internal/chlog"
+)
+
+func TestNextVersion(t *testing.T) {
+	ctx := setupTestDir(t, []*chlog.Entry{enhancementEntry(), bugFixEntry()})
+	versioningFile := filepath.Join(t.TempDir(), "versions.yaml")
+	require.NoError(t, os.WriteFile(versioningFile, []byte("module-sets:\n"+
+		"  receivers:\n    version: v1.2.0\n    modules:\n      - go.opentelemetry.io/collector/receiver/foo\n"+
+		"  testbed:\n    version: v0.4.1\n    modules:\n      - go.opentelemetry.io/collector/testbed\n"), 0600))
+
+	var out bytes.Buffer
+	require.NoError(t, nextVersion(ctx, &out, versioningFile))
+	assert.Equal(t, "receivers: v1.2.0 -> v1.3.0 (enhancement)\n"+
+		"testbed: v0.4.1 -> v0.4.2 (bug_fix)\n", out.String())
+}
+
+func TestNextVersionMissingVersioningFile(t *testing.T) {
+	ctx := setupTestDir(t, nil)
+	assert.Error(t, nextVersion(ctx, &bytes.Buffer{}, filepath.Join(t.TempDir(), "versions.yaml")))
+}
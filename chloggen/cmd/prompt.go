@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go.opentelemetry.io/build-tools/chloggen/internal/chlog"
+)
+
+// prompter asks for the fields of a changelog entry on out and reads the
+// answers from in, one per line.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	return &prompter{in: bufio.NewReader(in), out: out}
+}
+
+// promptEntry prompts for every field of an entry, asking again for a field
+// until its answer is valid.
+func (p *prompter) promptEntry() (*chlog.Entry, error) {
+	entry := &chlog.Entry{}
+	var err error
+
+	if entry.ChangeType, err = p.selectOne("Change type", chlog.ChangeTypes()); err != nil {
+		return nil, err
+	}
+	if entry.Component, err = p.required("Component, or a single word describing the area of concern (e.g. crosslink)"); err != nil {
+		return nil, err
+	}
+	if entry.Note, err = p.required("Note, a brief description of the change"); err != nil {
+		return nil, err
+	}
+	if entry.Issues, err = p.issues("Issues, one or more tracking issue numbers separated by spaces or commas"); err != nil {
+		return nil, err
+	}
+	if entry.SubText, err = p.lines("Subtext, optional lines of additional information, ended by an empty line"); err != nil {
+		return nil, err
+	}
+	return entry, entry.Validate()
+}
+
+// readLine reads the next answer, without trailing spaces. It returns
+// io.ErrUnexpectedEOF if the input ends before an answer.
+func (p *prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if errors.Is(err, io.EOF) && line != "" {
+		err = nil
+	}
+	if errors.Is(err, io.EOF) {
+		return "", io.ErrUnexpectedEOF
+	}
+	return strings.TrimRightFunc(line, unicode.IsSpace), err
+}
+
+// selectOne asks to choose one of options, by number or by name.
+func (p *prompter) selectOne(label string, options []string) (string, error) {
+	for {
+		fmt.Fprintf(p.out, "%s:\n", label)
+		for i, o := range options {
+			fmt.Fprintf(p.out, "  %d) %s\n", i+1, o)
+		}
+		fmt.Fprint(p.out, "> ")
+		answer, err := p.readLine()
+		if err != nil {
+			return "", err
+		}
+		answer = strings.TrimSpace(answer)
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(options) {
+			return options[n-1], nil
+		}
+		for _, o := range options {
+			if answer == o {
+				return o, nil
+			}
+		}
+		fmt.Fprintf(p.out, "Invalid choice %q, enter a number between 1 and %d.\n", answer, len(options))
+	}
+}
+
+// required asks for a non-empty answer.
+func (p *prompter) required(label string) (string, error) {
+	for {
+		fmt.Fprintf(p.out, "%s:\n> ", label)
+		answer, err := p.readLine()
+		answer = strings.TrimSpace(answer)
+		if err != nil || answer != "" {
+			return answer, err
+		}
+		fmt.Fprintln(p.out, "This field is required.")
+	}
+}
+
+// issues asks for one or more issue numbers, optionally prefixed with "#".
+func (p *prompter) issues(label string) ([]int, error) {
+	for {
+		answer, err := p.required(label)
+		if err != nil {
+			return nil, err
+		}
+		if issues, err := parseIssues(answer); err != nil {
+			fmt.Fprintf(p.out, "%v.\n", err)
+		} else {
+			return issues, nil
+		}
+	}
+}
+
+// lines asks for any number of lines, ended by an empty line or the end of
+// the input, and joins them.
+func (p *prompter) lines(label string) (string, error) {
+	fmt.Fprintf(p.out, "%s:\n", label)
+	var lines []string
+	for {
+		fmt.Fprint(p.out, "> ")
+		line, err := p.readLine()
+		if errors.Is(err, io.ErrUnexpectedEOF) || err == nil && strings.TrimSpace(line) == "" {
+			return strings.Join(lines, "\n"), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, line)
+	}
+}
+
+// parseIssues parses issue numbers separated by spaces or commas.
+func parseIssues(s string) ([]int, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	issues := make([]int, 0, len(fields))
+	for _, f := range fields {
+		n, err := strconv.Atoi(strings.TrimPrefix(f, "#"))
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid issue number %q", f)
+		}
+		issues = append(issues, n)
+	}
+	return issues, nil
+}
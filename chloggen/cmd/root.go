@@ -18,36 +18,48 @@ import (
 	"github.com/spf13/cobra"
 
 	"go.opentelemetry.io/build-tools/chloggen/internal/chlog"
+	"go.opentelemetry.io/build-tools/internal/logging"
 )
 
 var (
 	chloggenDir string
 	chlogCtx    chlog.Context
+	quiet       bool
+	verbose     bool
 )
 
 var rootCmd = &cobra.Command{
 	Use:   "chloggen",
 	Short: "Updates CHANGELOG.MD to include all new changes",
 	Long:  `chloggen is a tool used to automate the generation of CHANGELOG files using individual yaml files as the source.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		logging.Configure(quiet, verbose)
+		return initConfig()
+	},
 }
 
 func Execute() {
 	cobra.CheckErr(rootCmd.Execute())
 }
 
-func initConfig() {
+func initConfig() error {
 	if chloggenDir == "" {
 		chloggenDir = ".chloggen"
 	}
-	chlogCtx = chlog.New(chlog.RepoRoot(), chlog.WithUnreleasedDir(chloggenDir))
+	var err error
+	chlogCtx, err = chlog.LoadConfig(chlog.New(chlog.RepoRoot(), chlog.WithUnreleasedDir(chloggenDir)))
+	return err
 }
 
 func init() {
-	cobra.OnInitialize(initConfig)
-
 	rootCmd.PersistentFlags().StringVar(&chloggenDir, "chloggen-directory", "", "directory containing unreleased change log entries (default: .chloggen)")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, logging.QuietUsage)
+	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, logging.VerboseUsage)
 
+	rootCmd.AddCommand(draftCmd)
+	rootCmd.AddCommand(exportCmd)
 	rootCmd.AddCommand(newCmd)
+	rootCmd.AddCommand(nextVersionCmd)
 	rootCmd.AddCommand(updateCmd)
 	rootCmd.AddCommand(validateCmd)
 }
@@ -17,13 +17,16 @@ package cmd
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"go.opentelemetry.io/build-tools/chloggen/internal/chlog"
+	"go.opentelemetry.io/build-tools/internal/logging"
 )
 
 const (
@@ -39,11 +42,15 @@ var updateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Updates CHANGELOG.MD to include all new changes",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return update(chlogCtx, version, dry)
+		return update(chlogCtx, cmd.OutOrStdout(), version, dry)
 	},
 }
 
-func update(ctx chlog.Context, version string, dry bool) error {
+// update renders the entries into their changelogs and removes them. With
+// dry, the rendered sections are written to w instead, as markdown that can
+// be pasted e.g. into the description of a release pull request, and no file
+// is modified.
+func update(ctx chlog.Context, w io.Writer, version string, dry bool) error {
 	entries, err := chlog.ReadEntries(ctx)
 	if err != nil {
 		return err
@@ -53,24 +60,67 @@ func update(ctx chlog.Context, version string, dry bool) error {
 		return fmt.Errorf("no entries to add to the changelog")
 	}
 
-	chlogUpdate, err := chlog.GenerateSummary(version, entries)
-	if err != nil {
-		return err
+	// Group the entries by the changelogs they are rendered into.
+	byChangelog := make(map[string][]*chlog.Entry)
+	for _, entry := range entries {
+		paths, err := ctx.EntryChangeLogs(entry)
+		if err != nil {
+			return err
+		}
+		for _, path := range paths {
+			byChangelog[path] = append(byChangelog[path], entry)
+		}
+	}
+	paths := make([]string, 0, len(byChangelog))
+	for path := range byChangelog {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	changelogs := make(map[string][]byte, len(paths))
+	for _, path := range paths {
+		chlogUpdate, err := ctx.RenderSummary(version, byChangelog[path])
+		if err != nil {
+			return err
+		}
+
+		if dry {
+			if len(paths) > 1 {
+				// An HTML comment does not show in the rendered markdown.
+				fmt.Fprintf(w, "<!-- %s -->\n", filepath.Base(path))
+			}
+			fmt.Fprint(w, chlogUpdate)
+			continue
+		}
+
+		if changelogs[path], err = insertUpdate(path, chlogUpdate); err != nil {
+			return err
+		}
 	}
-
 	if dry {
-		fmt.Printf("Generated changelog updates:")
-		fmt.Println(chlogUpdate)
 		return nil
 	}
 
-	oldChlogBytes, err := os.ReadFile(filepath.Clean(ctx.ChangelogMD))
-	if err != nil {
+	if err = chlog.UpdateChangelogs(ctx, changelogs); err != nil {
 		return err
 	}
+
+	for _, path := range paths {
+		logging.Infof("Finished updating %s", path)
+	}
+	return nil
+}
+
+// insertUpdate returns the content of the changelog at path with chlogUpdate
+// inserted at the insert point.
+func insertUpdate(path string, chlogUpdate string) ([]byte, error) {
+	oldChlogBytes, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
 	chlogParts := bytes.Split(oldChlogBytes, []byte(insertPoint))
 	if len(chlogParts) != 2 {
-		return fmt.Errorf("expected one instance of %s", insertPoint)
+		return nil, fmt.Errorf("expected one instance of %s in %s", insertPoint, path)
 	}
 
 	chlogHeader, chlogHistory := string(chlogParts[0]), string(chlogParts[1])
@@ -80,22 +130,15 @@ func update(ctx chlog.Context, version string, dry bool) error {
 	chlogBuilder.WriteString(insertPoint)
 	chlogBuilder.WriteString(chlogUpdate)
 	chlogBuilder.WriteString(chlogHistory)
-
-	tmpMD := ctx.ChangelogMD + ".tmp"
-	if err = os.WriteFile(filepath.Clean(tmpMD), []byte(chlogBuilder.String()), 0600); err != nil {
-		return err
-	}
-
-	if err = os.Rename(tmpMD, ctx.ChangelogMD); err != nil {
-		return err
-	}
-
-	fmt.Printf("Finished updating %s\n", ctx.ChangelogMD)
-
-	return chlog.DeleteEntries(ctx)
+	return []byte(chlogBuilder.String()), nil
 }
 
 func init() {
 	updateCmd.Flags().StringVarP(&version, "version", "v", "vTODO", "will be rendered directly into the update text")
+	updateCmd.Flags().BoolVar(&dry, "dry-run", false, "print the markdown of the changelog sections that would be inserted, "+
+		"with the version header and grouped entries, to stdout without modifying the changelogs or removing the entries")
 	updateCmd.Flags().BoolVarP(&dry, "dry", "d", false, "will generate the update text and print to stdout")
+	if err := updateCmd.Flags().MarkDeprecated("dry", "use --dry-run instead"); err != nil {
+		logging.Fatalf("could not mark dry flag as deprecated: %v", err)
+	}
 }
@@ -15,6 +15,8 @@
 package cmd
 
 import (
+	"bytes"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -23,6 +25,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/build-tools/chloggen/internal/chlog"
+	"go.opentelemetry.io/build-tools/golden"
 )
 
 func TestUpdateE2E(t *testing.T) {
@@ -87,7 +90,7 @@ func TestUpdateE2E(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := setupTestDir(t, tc.entries)
 
-			require.NoError(t, update(ctx, tc.version, tc.dry))
+			require.NoError(t, update(ctx, io.Discard, tc.version, tc.dry))
 
 			actualBytes, err := os.ReadFile(ctx.ChangelogMD)
 			require.NoError(t, err)
@@ -109,3 +112,52 @@ func TestUpdateE2E(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateMultipleChangelogs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows line breaks cause comparison failures w/ golden files.")
+	}
+
+	apiEntry := enhancementEntry()
+	apiEntry.Component = "pdata"
+	apiEntry.ChangeLogs = []string{"api"}
+	bothEntry := breakingEntry()
+	bothEntry.ChangeLogs = []string{"user", "api"}
+
+	ctx := setupTestDir(t, []*chlog.Entry{bugFixEntry(), apiEntry, bothEntry})
+	require.NoError(t, os.WriteFile(ctx.ConfigYAML, []byte("change_logs:\n"+
+		"  user: CHANGELOG.md\n"+
+		"  api: CHANGELOG-API.md\n"+
+		"default_change_logs: [user]\n"), os.FileMode(0600)))
+	apiChangelogMD := filepath.Join(filepath.Dir(ctx.ChangelogMD), "CHANGELOG-API.md")
+	require.NoError(t, os.WriteFile(apiChangelogMD, []byte("# API Changelog\n\n<!-- next version -->\n"), os.FileMode(0600)))
+
+	ctx, err := chlog.LoadConfig(ctx)
+	require.NoError(t, err)
+	require.NoError(t, validate(ctx))
+	require.NoError(t, update(ctx, io.Discard, "v0.45.0", false))
+
+	golden.AssertFile(t, filepath.Join("testdata", "multiple_changelogs.md"), ctx.ChangelogMD)
+	golden.AssertFile(t, filepath.Join("testdata", "multiple_changelogs_api.md"), apiChangelogMD)
+
+	remainingYAMLs, err := filepath.Glob(filepath.Join(ctx.UnreleasedDir, "*.yaml"))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{ctx.TemplateYAML, ctx.ConfigYAML}, remainingYAMLs)
+}
+
+func TestUpdateDryRunOutput(t *testing.T) {
+	ctx := setupTestDir(t, []*chlog.Entry{bugFixEntry(), enhancementEntry()})
+	before, err := os.ReadFile(ctx.ChangelogMD)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, update(ctx, &out, "v0.45.0", true))
+
+	expected, err := chlog.GenerateSummary("v0.45.0", []*chlog.Entry{bugFixEntry(), enhancementEntry()})
+	require.NoError(t, err)
+	require.Equal(t, expected, out.String())
+
+	after, err := os.ReadFile(ctx.ChangelogMD)
+	require.NoError(t, err)
+	require.Equal(t, string(before), string(after))
+}
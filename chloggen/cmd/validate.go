@@ -21,16 +21,42 @@ import (
 	"github.com/spf13/cobra"
 
 	"go.opentelemetry.io/build-tools/chloggen/internal/chlog"
+	"go.opentelemetry.io/build-tools/internal/logging"
+)
+
+var (
+	checkRefs    bool
+	validateRepo string
 )
 
 var validateCmd = &cobra.Command{
 	Use:   "validate",
 	Short: "Validates the files in the changelog directory",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return validate(chlogCtx)
+		if err := validate(chlogCtx); err != nil {
+			return err
+		}
+		if !checkRefs {
+			return nil
+		}
+		repo := validateRepo
+		if repo == "" {
+			repo = os.Getenv("GITHUB_REPOSITORY")
+		}
+		checker, err := chlog.NewRefChecker(repo, os.Getenv("GITHUB_TOKEN"))
+		if err != nil {
+			return fmt.Errorf("specify the repository of the issues with -repo or GITHUB_REPOSITORY: %w", err)
+		}
+		return validateRefs(chlogCtx, checker)
 	},
 }
 
+func init() {
+	validateCmd.Flags().BoolVar(&checkRefs, "check-refs", false, "check with the GitHub API that the issues of every entry are issues or pull requests of the repository, "+
+		"authenticating with the GITHUB_TOKEN environment variable if it is set")
+	validateCmd.Flags().StringVar(&validateRepo, "repo", "", "repository of the issues checked with -check-refs, in the owner/name form (default: $GITHUB_REPOSITORY)")
+}
+
 func validate(ctx chlog.Context) error {
 	if _, err := os.Stat(ctx.UnreleasedDir); err != nil {
 		return err
@@ -44,7 +70,26 @@ func validate(ctx chlog.Context) error {
 		if err = entry.Validate(); err != nil {
 			return err
 		}
+		if _, err = ctx.EntryChangeLogs(entry); err != nil {
+			return err
+		}
+		if err = ctx.CheckComponent(entry); err != nil {
+			return err
+		}
+	}
+	logging.Infof("PASS: all files in %s/ are valid", ctx.UnreleasedDir)
+	return nil
+}
+
+// validateRefs checks with checker that the issues of the entries exist.
+func validateRefs(ctx chlog.Context, checker *chlog.RefChecker) error {
+	entries, err := chlog.ReadEntries(ctx)
+	if err != nil {
+		return err
+	}
+	if err = checker.CheckEntries(entries); err != nil {
+		return err
 	}
-	fmt.Printf("PASS: all files in %s/ are valid\n", ctx.UnreleasedDir)
+	logging.Infof("PASS: all issues referenced in %s/ exist", ctx.UnreleasedDir)
 	return nil
 }
@@ -15,6 +15,7 @@
 package cmd
 
 import (
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -24,9 +25,10 @@ import (
 
 func TestValidateE2E(t *testing.T) {
 	tests := []struct {
-		name    string
-		entries []*chlog.Entry
-		wantErr string
+		name       string
+		entries    []*chlog.Entry
+		components []string
+		wantErr    string
 	}{
 		{
 			name:    "all_valid",
@@ -80,6 +82,17 @@ func TestValidateE2E(t *testing.T) {
 			}(),
 			wantErr: "specify one or more issues #'s",
 		},
+		{
+			name:       "known_components",
+			entries:    getSampleEntries(),
+			components: sampleComponents(getSampleEntries()),
+		},
+		{
+			name:       "unknown_component",
+			entries:    getSampleEntries(),
+			components: []string{"receiver/foo"},
+			wantErr:    "is not a known 'component'",
+		},
 		{
 			name: "all_invalid",
 			entries: func() []*chlog.Entry {
@@ -95,6 +108,7 @@ func TestValidateE2E(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := setupTestDir(t, tc.entries)
+			ctx.Components = tc.components
 
 			err := validate(ctx)
 			if tc.wantErr != "" {
@@ -105,3 +119,13 @@ func TestValidateE2E(t *testing.T) {
 		})
 	}
 }
+
+// sampleComponents returns the sorted components of entries.
+func sampleComponents(entries []*chlog.Entry) []string {
+	var components []string
+	for _, e := range entries {
+		components = append(components, e.Component)
+	}
+	sort.Strings(components)
+	return components
+}
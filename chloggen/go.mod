@@ -3,8 +3,10 @@ module go.opentelemetry.io/build-tools/chloggen
 go 1.18
 
 require (
+	github.com/pelletier/go-toml/v2 v2.0.5
 	github.com/spf13/cobra v1.6.1
 	github.com/stretchr/testify v1.8.1
+	go.opentelemetry.io/build-tools v0.2.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -14,3 +16,5 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
 )
+
+replace go.opentelemetry.io/build-tools => ../
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chlog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// loadComponents returns the sorted component names listed in the YAML file
+// at path, and the directories of the files matching pattern, both relative
+// to rootDir.
+func loadComponents(rootDir, path, pattern string) ([]string, error) {
+	var components []string
+	if path != "" {
+		data, err := os.ReadFile(filepath.Clean(filepath.Join(rootDir, path)))
+		if err != nil {
+			return nil, fmt.Errorf("could not read components file: %w", err)
+		}
+		if err = yaml.Unmarshal(data, &components); err != nil {
+			return nil, fmt.Errorf("invalid components file %s: %w", path, err)
+		}
+	}
+
+	if pattern != "" {
+		matches, err := filepath.Glob(filepath.Join(rootDir, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'components_glob': %w", err)
+		}
+		for _, match := range matches {
+			dir, err := filepath.Rel(rootDir, filepath.Dir(match))
+			if err != nil {
+				return nil, err
+			}
+			components = append(components, filepath.ToSlash(dir))
+		}
+	}
+
+	if len(components) == 0 {
+		return nil, fmt.Errorf("no components found")
+	}
+
+	seen := make(map[string]bool, len(components))
+	unique := components[:0]
+	for _, c := range components {
+		if c != "" && !seen[c] {
+			seen[c] = true
+			unique = append(unique, c)
+		}
+	}
+	sort.Strings(unique)
+	return unique, nil
+}
+
+// CheckComponent returns an error if the component of entry is not one of the
+// configured components.
+func (ctx Context) CheckComponent(entry *Entry) error {
+	if len(ctx.Components) == 0 {
+		return nil
+	}
+	i := sort.SearchStrings(ctx.Components, entry.Component)
+	if i < len(ctx.Components) && ctx.Components[i] == entry.Component {
+		return nil
+	}
+	if suggestion := closestComponent(ctx.Components, entry.Component); suggestion != "" {
+		return fmt.Errorf("'%s' is not a known 'component', did you mean '%s'?", entry.Component, suggestion)
+	}
+	return fmt.Errorf("'%s' is not a known 'component', see the components configured in %s", entry.Component, ctx.ConfigYAML)
+}
+
+// closestComponent returns the component closest to name, if it is at most
+// a few edits away, to point out misspellings.
+func closestComponent(components []string, name string) string {
+	const maxDistance = 2
+	closest, best := "", maxDistance+1
+	for _, c := range components {
+		if d := editDistance(c, name); d < best {
+			closest, best = c, d
+		}
+	}
+	return closest
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chlog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadComponents(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "components.yaml"), []byte("- pdata\n- chloggen\n"), 0600))
+	for _, dir := range []string{"receiver/fooreceiver", "exporter/barexporter", "pdata"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0750))
+		require.NoError(t, os.WriteFile(filepath.Join(root, dir, "metadata.yaml"), nil, 0600))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(root, "invalid.yaml"), []byte("pdata: true\n"), 0600))
+
+	components, err := loadComponents(root, "components.yaml", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"chloggen", "pdata"}, components)
+
+	components, err = loadComponents(root, "", "*/*/metadata.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"exporter/barexporter", "receiver/fooreceiver"}, components)
+
+	components, err = loadComponents(root, "components.yaml", "*/metadata.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"chloggen", "pdata"}, components)
+
+	_, err = loadComponents(root, "missing.yaml", "")
+	assert.ErrorContains(t, err, "could not read components file")
+
+	_, err = loadComponents(root, "invalid.yaml", "")
+	assert.ErrorContains(t, err, "invalid components file invalid.yaml")
+
+	_, err = loadComponents(root, "", "*/*/missing.yaml")
+	assert.ErrorContains(t, err, "no components found")
+}
+
+func TestCheckComponent(t *testing.T) {
+	ctx := New("/repo")
+	assert.NoError(t, ctx.CheckComponent(&Entry{Component: "anything"}))
+
+	ctx.Components = []string{"exporter/barexporter", "pdata", "receiver/fooreceiver"}
+	assert.NoError(t, ctx.CheckComponent(&Entry{Component: "pdata"}))
+	assert.EqualError(t, ctx.CheckComponent(&Entry{Component: "receiver/fooreceivre"}),
+		"'receiver/fooreceivre' is not a known 'component', did you mean 'receiver/fooreceiver'?")
+	assert.ErrorContains(t, ctx.CheckComponent(&Entry{Component: "collector"}),
+		"'collector' is not a known 'component', see the components configured in "+ctx.ConfigYAML)
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("pdata", "pdata"))
+	assert.Equal(t, 1, editDistance("pdata", "pdat"))
+	assert.Equal(t, 2, editDistance("fooreceiver", "fooreceivre"))
+	assert.Equal(t, 5, editDistance("", "pdata"))
+}
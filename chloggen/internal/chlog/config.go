@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chlog
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// config is the content of the config file of the unreleased directory.
+type config struct {
+	// ChangeLogs maps the name of each changelog to its path, relative to
+	// the repository root.
+	ChangeLogs map[string]string `yaml:"change_logs"`
+	// DefaultChangeLogs are the names of the changelogs entries that do not
+	// list any are rendered into.
+	DefaultChangeLogs []string `yaml:"default_change_logs"`
+	// ComponentsFile is the path of a YAML file listing the valid component
+	// names, relative to the repository root.
+	ComponentsFile string `yaml:"components_file"`
+	// ComponentsGlob matches the files, relative to the repository root,
+	// whose directories are valid component names.
+	ComponentsGlob string `yaml:"components_glob"`
+	// SummaryTemplate is the path of the Go template rendering the changelog
+	// section of a version, relative to the repository root.
+	SummaryTemplate string `yaml:"summary_template"`
+}
+
+// LoadConfig returns ctx configured with the changelogs and components defined
+// in its config file. ctx is returned unchanged if there is no config file.
+func LoadConfig(ctx Context) (Context, error) {
+	data, err := os.ReadFile(filepath.Clean(ctx.ConfigYAML))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ctx, nil
+	}
+	if err != nil {
+		return ctx, err
+	}
+
+	var cfg config
+	if err = yaml.Unmarshal(data, &cfg); err != nil {
+		return ctx, fmt.Errorf("invalid config %s: %w", ctx.ConfigYAML, err)
+	}
+	hasComponents := cfg.ComponentsFile != "" || cfg.ComponentsGlob != ""
+	onlyChangeLogs := !hasComponents && cfg.SummaryTemplate == ""
+	if len(cfg.ChangeLogs) == 0 && (onlyChangeLogs || len(cfg.DefaultChangeLogs) > 0) {
+		return ctx, fmt.Errorf("invalid config %s: specify one or more 'change_logs'", ctx.ConfigYAML)
+	}
+	for _, name := range cfg.DefaultChangeLogs {
+		if _, ok := cfg.ChangeLogs[name]; !ok {
+			return ctx, fmt.Errorf("invalid config %s: default changelog '%s' is not defined", ctx.ConfigYAML, name)
+		}
+	}
+
+	if len(cfg.ChangeLogs) > 0 {
+		ctx.ChangeLogs = make(map[string]string, len(cfg.ChangeLogs))
+		for name, path := range cfg.ChangeLogs {
+			ctx.ChangeLogs[name] = filepath.Join(ctx.rootDir, path)
+		}
+		ctx.DefaultChangeLogs = cfg.DefaultChangeLogs
+	}
+
+	if hasComponents {
+		if ctx.Components, err = loadComponents(ctx.rootDir, cfg.ComponentsFile, cfg.ComponentsGlob); err != nil {
+			return ctx, fmt.Errorf("invalid config %s: %w", ctx.ConfigYAML, err)
+		}
+	}
+
+	if cfg.SummaryTemplate != "" {
+		ctx.SummaryTemplate = filepath.Join(ctx.rootDir, cfg.SummaryTemplate)
+		if _, err = parseSummaryTemplate(ctx.SummaryTemplate); err != nil {
+			return ctx, fmt.Errorf("invalid config %s: %w", ctx.ConfigYAML, err)
+		}
+	}
+	return ctx, nil
+}
+
+// EntryChangeLogs returns the paths of the changelogs entry is rendered into.
+func (ctx Context) EntryChangeLogs(entry *Entry) ([]string, error) {
+	if len(ctx.ChangeLogs) == 0 {
+		if len(entry.ChangeLogs) > 0 {
+			return nil, fmt.Errorf("'change_logs' specified but no changelogs are configured in %s", ctx.ConfigYAML)
+		}
+		return []string{ctx.ChangelogMD}, nil
+	}
+
+	names := entry.ChangeLogs
+	if len(names) == 0 {
+		names = ctx.DefaultChangeLogs
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("specify one or more 'change_logs', there is no default")
+	}
+
+	paths := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		path, ok := ctx.ChangeLogs[name]
+		if !ok {
+			return nil, fmt.Errorf("'%s' is not a valid changelog. Specify one of %v", name, ctx.changeLogNames())
+		}
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+// changeLogNames returns the names of the configured changelogs in order.
+func (ctx Context) changeLogNames() []string {
+	names := make([]string, 0, len(ctx.ChangeLogs))
+	for name := range ctx.ChangeLogs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
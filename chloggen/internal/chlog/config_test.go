@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chlog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		expected    map[string]string
+		defaults    []string
+		components  []string
+		template    string
+		expectedErr string
+	}{
+		{
+			name: "no config",
+		},
+		{
+			name: "valid",
+			config: "change_logs:\n  user: CHANGELOG.md\n  api: CHANGELOG-API.md\n" +
+				"default_change_logs: [user]\n",
+			expected: map[string]string{"user": "CHANGELOG.md", "api": "CHANGELOG-API.md"},
+			defaults: []string{"user"},
+		},
+		{
+			name:       "components only",
+			config:     "components_file: components.yaml\n",
+			components: []string{"chloggen", "pdata"},
+		},
+		{
+			name:        "components without changelogs",
+			config:      "components_file: components.yaml\ndefault_change_logs: [user]\n",
+			expectedErr: "specify one or more 'change_logs'",
+		},
+		{
+			name:        "missing components file",
+			config:      "change_logs:\n  user: CHANGELOG.md\ncomponents_file: missing.yaml\n",
+			expectedErr: "could not read components file",
+		},
+		{
+			name:     "summary template only",
+			config:   "summary_template: summary.tmpl\n",
+			template: "summary.tmpl",
+		},
+		{
+			name:        "invalid summary template",
+			config:      "summary_template: invalid.tmpl\n",
+			expectedErr: "invalid summary template",
+		},
+		{
+			name:        "missing summary template",
+			config:      "summary_template: missing.tmpl\n",
+			expectedErr: "invalid summary template",
+		},
+		{
+			name:        "no changelogs",
+			config:      "default_change_logs: [user]\n",
+			expectedErr: "specify one or more 'change_logs'",
+		},
+		{
+			name:        "unknown default",
+			config:      "change_logs:\n  user: CHANGELOG.md\ndefault_change_logs: [api]\n",
+			expectedErr: "default changelog 'api' is not defined",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			root := t.TempDir()
+			ctx := New(root)
+			require.NoError(t, os.WriteFile(filepath.Join(root, "components.yaml"), []byte("[pdata, chloggen]\n"), 0600))
+			require.NoError(t, os.WriteFile(filepath.Join(root, "summary.tmpl"), []byte("## {{ .Version }}\n"), 0600))
+			require.NoError(t, os.WriteFile(filepath.Join(root, "invalid.tmpl"), []byte("{{ .Version\n"), 0600))
+			if tc.config != "" {
+				require.NoError(t, os.Mkdir(ctx.UnreleasedDir, 0750))
+				require.NoError(t, os.WriteFile(ctx.ConfigYAML, []byte(tc.config), 0600))
+			}
+
+			ctx, err := LoadConfig(ctx)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expected == nil {
+				assert.Nil(t, ctx.ChangeLogs)
+			} else {
+				expected := make(map[string]string, len(tc.expected))
+				for name, path := range tc.expected {
+					expected[name] = filepath.Join(root, path)
+				}
+				assert.Equal(t, expected, ctx.ChangeLogs)
+			}
+			assert.Equal(t, tc.defaults, ctx.DefaultChangeLogs)
+			assert.Equal(t, tc.components, ctx.Components)
+			if tc.template == "" {
+				assert.Empty(t, ctx.SummaryTemplate)
+			} else {
+				assert.Equal(t, filepath.Join(root, tc.template), ctx.SummaryTemplate)
+			}
+		})
+	}
+}
+
+func TestEntryChangeLogs(t *testing.T) {
+	ctx := New("/repo")
+	paths, err := ctx.EntryChangeLogs(&Entry{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{ctx.ChangelogMD}, paths)
+
+	_, err = ctx.EntryChangeLogs(&Entry{ChangeLogs: []string{"api"}})
+	assert.ErrorContains(t, err, "no changelogs are configured")
+
+	ctx.ChangeLogs = map[string]string{"user": "/repo/CHANGELOG.md", "api": "/repo/CHANGELOG-API.md"}
+	_, err = ctx.EntryChangeLogs(&Entry{})
+	assert.ErrorContains(t, err, "there is no default")
+
+	ctx.DefaultChangeLogs = []string{"user"}
+	paths, err = ctx.EntryChangeLogs(&Entry{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/repo/CHANGELOG.md"}, paths)
+
+	paths, err = ctx.EntryChangeLogs(&Entry{ChangeLogs: []string{"api", "user", "api"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/repo/CHANGELOG-API.md", "/repo/CHANGELOG.md"}, paths)
+
+	_, err = ctx.EntryChangeLogs(&Entry{ChangeLogs: []string{"other"}})
+	assert.ErrorContains(t, err, "'other' is not a valid changelog. Specify one of [api user]")
+}
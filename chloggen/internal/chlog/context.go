@@ -15,16 +15,18 @@
 package chlog
 
 import (
-	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
 )
 
 const (
 	changelogMD   = "CHANGELOG.md"
 	unreleasedDir = ".chloggen"
 	templateYAML  = "TEMPLATE.yaml"
+	configYAML    = "config.yaml"
 )
 
 // Context enables tests by allowing them to work in an test directory
@@ -33,6 +35,20 @@ type Context struct {
 	ChangelogMD   string
 	UnreleasedDir string
 	TemplateYAML  string
+	ConfigYAML    string
+	// ChangeLogs maps the name of each changelog configured in ConfigYAML to
+	// its path. Entries are rendered into ChangelogMD if it is empty.
+	ChangeLogs map[string]string
+	// DefaultChangeLogs are the names of the changelogs entries that do not
+	// list any are rendered into.
+	DefaultChangeLogs []string
+	// Components are the sorted valid component names configured in
+	// ConfigYAML. Any component is valid if it is empty.
+	Components []string
+	// SummaryTemplate is the path of the template rendering the changelog
+	// section of a version configured in ConfigYAML. The default template is
+	// used if it is empty.
+	SummaryTemplate string
 }
 
 type Option func(*Context)
@@ -41,6 +57,7 @@ func WithUnreleasedDir(unreleasedDir string) Option {
 	return func(ctx *Context) {
 		ctx.UnreleasedDir = filepath.Join(ctx.rootDir, unreleasedDir)
 		ctx.TemplateYAML = filepath.Join(ctx.rootDir, unreleasedDir, templateYAML)
+		ctx.ConfigYAML = filepath.Join(ctx.rootDir, unreleasedDir, configYAML)
 	}
 }
 
@@ -50,6 +67,7 @@ func New(rootDir string, options ...Option) Context {
 		ChangelogMD:   filepath.Join(rootDir, changelogMD),
 		UnreleasedDir: filepath.Join(rootDir, unreleasedDir),
 		TemplateYAML:  filepath.Join(rootDir, unreleasedDir, templateYAML),
+		ConfigYAML:    filepath.Join(rootDir, unreleasedDir, configYAML),
 	}
 	for _, op := range options {
 		op(&ctx)
@@ -61,7 +79,7 @@ func RepoRoot() string {
 	dir, err := os.Getwd()
 	if err != nil {
 		// This is not expected, but just in case
-		fmt.Println("FAIL: Could not determine current working directory")
+		logging.Errorf("Could not determine current working directory: %v", err)
 	}
 	return dir
 }
@@ -70,7 +88,7 @@ func moduleDir() string {
 	_, filename, _, ok := runtime.Caller(0)
 	if !ok {
 		// This is not expected, but just in case
-		fmt.Println("FAIL: Could not determine module directory")
+		logging.Errorf("Could not determine module directory")
 	}
 	return filepath.Dir(filename)
 }
@@ -28,6 +28,7 @@ func TestNew(t *testing.T) {
 	require.Equal(t, filepath.Join(root, unreleasedDir), ctx.UnreleasedDir)
 	require.Equal(t, filepath.Join(root, changelogMD), ctx.ChangelogMD)
 	require.Equal(t, filepath.Join(root, unreleasedDir, templateYAML), ctx.TemplateYAML)
+	require.Equal(t, filepath.Join(root, unreleasedDir, configYAML), ctx.ConfigYAML)
 }
 
 func TestWithUnreleasedDir(t *testing.T) {
@@ -38,4 +39,5 @@ func TestWithUnreleasedDir(t *testing.T) {
 	require.Equal(t, filepath.Join(root, unreleased), ctx.UnreleasedDir)
 	require.Equal(t, filepath.Join(root, changelogMD), ctx.ChangelogMD)
 	require.Equal(t, filepath.Join(root, unreleased, templateYAML), ctx.TemplateYAML)
+	require.Equal(t, filepath.Join(root, unreleased, configYAML), ctx.ConfigYAML)
 }
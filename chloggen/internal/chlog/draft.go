@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chlog
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	conventionalRegexp = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+	mergePRRegexp      = regexp.MustCompile(`^Merge pull request #(\d+)`)
+	subjectPRRegexp    = regexp.MustCompile(`\s*\(#(\d+)\)$`)
+	closesRegexp       = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?)\s+#(\d+)`)
+	breakingRegexp     = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE:`)
+)
+
+// commitChangeTypes maps the conventional commit types that describe a
+// user-facing change to the change type of their entries.
+var commitChangeTypes = map[string]string{
+	"feat":        Enhancement,
+	"perf":        Enhancement,
+	"fix":         BugFix,
+	"deprecate":   Deprecation,
+	"deprecation": Deprecation,
+}
+
+// Commit is a git commit.
+type Commit struct {
+	Hash    string
+	Message string
+	// Files are the paths, relative to the repository root, of the files
+	// changed by the commit.
+	Files []string
+}
+
+// Draft is a changelog entry drafted from a commit.
+type Draft struct {
+	Commit Commit
+	Entry  *Entry
+}
+
+// DraftEntries drafts changelog entries from the conventional commit messages
+// of the commits in revRange, e.g. "v0.2.0..HEAD", oldest first. Commits that
+// already add an entry file, or whose type does not describe a user-facing
+// change, are skipped. Drafts are meant to be reviewed: their component is
+// empty if the commit has no scope, and their issues are empty if the commit
+// references no pull request or issue.
+func DraftEntries(ctx Context, revRange string) ([]Draft, error) {
+	commits, err := readCommits(ctx.rootDir, revRange)
+	if err != nil {
+		return nil, err
+	}
+
+	var drafts []Draft
+	for _, c := range commits {
+		if addsEntry(ctx, c) {
+			continue
+		}
+		if entry, ok := draftEntry(c.Message); ok {
+			drafts = append(drafts, Draft{Commit: c, Entry: entry})
+		}
+	}
+	return drafts, nil
+}
+
+// readCommits returns the commits in revRange of the repository in dir,
+// following only the first parent of merge commits.
+func readCommits(dir, revRange string) ([]Commit, error) {
+	cmd := exec.Command("git", "log", "--reverse", "--first-parent", "--name-only", "--format=%x1e%H%x1f%B%x1f", revRange, "--")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("git log %s failed: %s", revRange, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("git log %s failed: %w", revRange, err)
+	}
+
+	var commits []Commit
+	for _, record := range strings.Split(string(out), "\x1e") {
+		fields := strings.SplitN(record, "\x1f", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		commits = append(commits, Commit{
+			Hash:    fields[0],
+			Message: strings.TrimSpace(fields[1]),
+			Files:   strings.Fields(fields[2]),
+		})
+	}
+	return commits, nil
+}
+
+// addsEntry returns whether c changes a file in the unreleased directory.
+func addsEntry(ctx Context, c Commit) bool {
+	rel, err := filepath.Rel(ctx.rootDir, ctx.UnreleasedDir)
+	if err != nil {
+		return false
+	}
+	prefix := filepath.ToSlash(rel) + "/"
+	for _, file := range c.Files {
+		if strings.HasPrefix(file, prefix) && isEntryFile(file) {
+			return true
+		}
+	}
+	return false
+}
+
+// draftEntry returns the entry drafted from a conventional commit message,
+// or false if the message does not describe a user-facing change.
+func draftEntry(message string) (*Entry, bool) {
+	subject, body, _ := strings.Cut(message, "\n")
+	var issues []int
+	if m := mergePRRegexp.FindStringSubmatch(subject); m != nil {
+		// The title of the pull request is the first line of the body.
+		issues = appendIssue(issues, m[1])
+		body = strings.TrimSpace(body)
+		subject, body, _ = strings.Cut(body, "\n")
+	}
+
+	m := conventionalRegexp.FindStringSubmatch(strings.TrimSpace(subject))
+	if m == nil {
+		return nil, false
+	}
+	commitType, scope, bang, description := strings.ToLower(m[1]), m[2], m[3], m[4]
+
+	changeType, ok := commitChangeTypes[commitType]
+	if bang != "" || breakingRegexp.MatchString(body) {
+		changeType, ok = Breaking, true
+	}
+	if !ok {
+		return nil, false
+	}
+
+	if pr := subjectPRRegexp.FindStringSubmatch(description); pr != nil {
+		issues = appendIssue(issues, pr[1])
+		description = strings.TrimSuffix(description, pr[0])
+	}
+	for _, ref := range closesRegexp.FindAllStringSubmatch(body, -1) {
+		issues = appendIssue(issues, ref[1])
+	}
+
+	return &Entry{
+		ChangeType: changeType,
+		Component:  strings.TrimSpace(scope),
+		Note:       capitalize(strings.TrimSpace(description)),
+		Issues:     issues,
+	}, true
+}
+
+// appendIssue appends the issue number n to issues if it is not in it yet.
+func appendIssue(issues []int, n string) []int {
+	issue, err := strconv.Atoi(n)
+	if err != nil {
+		return issues
+	}
+	for _, i := range issues {
+		if i == issue {
+			return issues
+		}
+	}
+	return append(issues, issue)
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chlog
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDraftEntry(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    *Entry
+	}{
+		{
+			name:    "feat_with_scope_and_pr",
+			message: "feat(crosslink): add skew report (#123)",
+			want:    &Entry{ChangeType: Enhancement, Component: "crosslink", Note: "Add skew report", Issues: []int{123}},
+		},
+		{
+			name:    "fix_closing_issues",
+			message: "fix(multimod): handle CRLF line endings\n\nFixes #45, closes #46.\nResolves #45",
+			want:    &Entry{ChangeType: BugFix, Component: "multimod", Note: "Handle CRLF line endings", Issues: []int{45, 46}},
+		},
+		{
+			name:    "breaking_bang",
+			message: "feat(chloggen)!: rename the update command",
+			want:    &Entry{ChangeType: Breaking, Component: "chloggen", Note: "Rename the update command"},
+		},
+		{
+			name:    "breaking_footer",
+			message: "refactor: drop the legacy flags\n\nBREAKING CHANGE: the --old flag is removed.",
+			want:    &Entry{ChangeType: Breaking, Note: "Drop the legacy flags"},
+		},
+		{
+			name:    "deprecation",
+			message: "deprecate(dbotconf): deprecate the verify command (#7)",
+			want:    &Entry{ChangeType: Deprecation, Component: "dbotconf", Note: "Deprecate the verify command", Issues: []int{7}},
+		},
+		{
+			name:    "merge_commit",
+			message: "Merge pull request #99 from user/branch\n\nperf(semconvgen): cache parsed specs",
+			want:    &Entry{ChangeType: Enhancement, Component: "semconvgen", Note: "Cache parsed specs", Issues: []int{99}},
+		},
+		{
+			name:    "chore",
+			message: "chore(deps): bump cobra (#12)",
+		},
+		{
+			name:    "not_conventional",
+			message: "Update README",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := draftEntry(tc.message)
+			if tc.want == nil {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+// git runs a git command in dir.
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func TestDraftEntries(t *testing.T) {
+	root := t.TempDir()
+	ctx := New(root)
+	git(t, root, "init", "-q")
+	git(t, root, "commit", "-q", "--allow-empty", "-m", "initial commit")
+	git(t, root, "tag", "v0.1.0")
+
+	git(t, root, "commit", "-q", "--allow-empty", "-m", "feat(chloggen): draft entries (#10)")
+	git(t, root, "commit", "-q", "--allow-empty", "-m", "docs: fix typo")
+
+	require.NoError(t, os.MkdirAll(ctx.UnreleasedDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(ctx.UnreleasedDir, "entry.yaml"), nil, 0o600))
+	git(t, root, "add", ".")
+	git(t, root, "commit", "-q", "-m", "fix(multimod): already has an entry (#11)")
+
+	git(t, root, "commit", "-q", "--allow-empty", "-m", "fix: handle empty files (#12)")
+
+	drafts, err := DraftEntries(ctx, "v0.1.0..HEAD")
+	require.NoError(t, err)
+	require.Len(t, drafts, 2)
+	assert.Equal(t, &Entry{ChangeType: Enhancement, Component: "chloggen", Note: "Draft entries", Issues: []int{10}}, drafts[0].Entry)
+	assert.Equal(t, &Entry{ChangeType: BugFix, Note: "Handle empty files", Issues: []int{12}}, drafts[1].Entry)
+	assert.Len(t, drafts[0].Commit.Hash, 40)
+
+	_, err = DraftEntries(ctx, "v9.9.9..HEAD")
+	assert.ErrorContains(t, err, "git log v9.9.9..HEAD failed")
+}
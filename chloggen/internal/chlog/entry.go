@@ -15,11 +15,15 @@
 package chlog
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/pelletier/go-toml/v2"
 	"gopkg.in/yaml.v3"
 )
 
@@ -31,12 +35,31 @@ const (
 	BugFix       = "bug_fix"
 )
 
+// Entry is a change file. Entries are written in YAML, JSON or TOML, depending
+// on the extension of the file, with the same fields.
 type Entry struct {
-	ChangeType string `yaml:"change_type"`
-	Component  string `yaml:"component"`
-	Note       string `yaml:"note"`
-	Issues     []int  `yaml:"issues"`
-	SubText    string `yaml:"subtext"`
+	ChangeType string `yaml:"change_type" json:"change_type" toml:"change_type"`
+	Component  string `yaml:"component" json:"component" toml:"component"`
+	Note       string `yaml:"note" json:"note" toml:"note"`
+	Issues     []int  `yaml:"issues" json:"issues" toml:"issues"`
+	SubText    string `yaml:"subtext" json:"subtext" toml:"subtext"`
+	// ChangeLogs are the names of the changelogs the entry is rendered into,
+	// as configured in the config file of the unreleased directory.
+	ChangeLogs []string `yaml:"change_logs,omitempty" json:"change_logs,omitempty" toml:"change_logs,omitempty"`
+}
+
+// entryExtensions are the extensions of entry files.
+var entryExtensions = []string{".yaml", ".json", ".toml"}
+
+// isEntryFile returns whether name has the extension of an entry file.
+func isEntryFile(name string) bool {
+	ext := filepath.Ext(name)
+	for _, e := range entryExtensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
 }
 
 var changeTypes = []string{
@@ -47,6 +70,11 @@ var changeTypes = []string{
 	BugFix,
 }
 
+// ChangeTypes returns the valid change types of entries.
+func ChangeTypes() []string {
+	return append([]string(nil), changeTypes...)
+}
+
 func (e Entry) Validate() error {
 	var validType bool
 	for _, ct := range changeTypes {
@@ -75,41 +103,39 @@ func (e Entry) Validate() error {
 }
 
 func (e Entry) String() string {
-	issueStrs := make([]string, 0, len(e.Issues))
-	for _, issue := range e.Issues {
-		issueStrs = append(issueStrs, fmt.Sprintf("#%d", issue))
-	}
-	issueStr := strings.Join(issueStrs, ", ")
+	return fmt.Sprintf("- `%s`: %s", e.Component, e.change())
+}
 
+// change returns the note, issues and subtext of the entry, without its
+// component.
+func (e Entry) change() string {
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("- `%s`: %s (%s)", e.Component, e.Note, issueStr))
+	sb.WriteString(e.Note)
+	if len(e.Issues) > 0 {
+		sb.WriteString(fmt.Sprintf(" (%s)", formatIssues(e.Issues)))
+	}
 	if e.SubText != "" {
-		sb.WriteString("\n  ")
-		lines := strings.Split(strings.ReplaceAll(e.SubText, "\r\n", "\n"), "\n")
-		sb.WriteString(strings.Join(lines, "\n  "))
+		sb.WriteString("\n")
+		sb.WriteString(indent(2, e.SubText))
 	}
 	return sb.String()
 }
 
 func ReadEntries(ctx Context) ([]*Entry, error) {
-	entryYAMLs, err := filepath.Glob(filepath.Join(ctx.UnreleasedDir, "*.yaml"))
+	entryFilenames, err := entryFiles(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	entries := make([]*Entry, 0, len(entryYAMLs))
-	for _, entryYAML := range entryYAMLs {
-		if filepath.Base(entryYAML) == filepath.Base(ctx.TemplateYAML) {
-			continue
-		}
-
-		fileBytes, err := os.ReadFile(filepath.Clean(entryYAML))
+	entries := make([]*Entry, 0, len(entryFilenames))
+	for _, entryFilename := range entryFilenames {
+		fileBytes, err := os.ReadFile(filepath.Clean(entryFilename))
 		if err != nil {
 			return nil, err
 		}
 
 		entry := &Entry{}
-		if err = yaml.Unmarshal(fileBytes, entry); err != nil {
+		if err = unmarshalEntry(entryFilename, fileBytes, entry); err != nil {
 			return nil, err
 		}
 		entries = append(entries, entry)
@@ -117,20 +143,46 @@ func ReadEntries(ctx Context) ([]*Entry, error) {
 	return entries, nil
 }
 
-func DeleteEntries(ctx Context) error {
-	entryYAMLs, err := filepath.Glob(filepath.Join(ctx.UnreleasedDir, "*.yaml"))
+// unmarshalEntry decodes the entry file name, in the format given by its
+// extension, into entry.
+func unmarshalEntry(name string, data []byte, entry *Entry) error {
+	var err error
+	switch filepath.Ext(name) {
+	case ".json":
+		// Empty files decode to an empty entry, as they do in YAML.
+		if len(bytes.TrimSpace(data)) > 0 {
+			err = json.Unmarshal(data, entry)
+		}
+	case ".toml":
+		err = toml.Unmarshal(data, entry)
+	default:
+		err = yaml.Unmarshal(data, entry)
+	}
 	if err != nil {
-		return err
+		return fmt.Errorf("invalid entry %s: %w", filepath.Base(name), err)
 	}
+	return nil
+}
 
-	for _, entryYAML := range entryYAMLs {
-		if filepath.Base(entryYAML) == filepath.Base(ctx.TemplateYAML) {
-			continue
+// entryFiles returns the paths of all entry files in the unreleased
+// directory, excluding the template and config file, in lexical order.
+func entryFiles(ctx Context) ([]string, error) {
+	var entryFilenames []string
+	for _, ext := range entryExtensions {
+		matches, err := filepath.Glob(filepath.Join(ctx.UnreleasedDir, "*"+ext))
+		if err != nil {
+			return nil, err
 		}
+		entryFilenames = append(entryFilenames, matches...)
+	}
+	sort.Strings(entryFilenames)
 
-		if err := os.Remove(entryYAML); err != nil {
-			fmt.Printf("Failed to delete: %s\n", entryYAML)
+	files := make([]string, 0, len(entryFilenames))
+	for _, entryFilename := range entryFilenames {
+		if base := filepath.Base(entryFilename); base == filepath.Base(ctx.TemplateYAML) || base == filepath.Base(ctx.ConfigYAML) {
+			continue
 		}
+		files = append(files, entryFilename)
 	}
-	return nil
+	return files, nil
 }
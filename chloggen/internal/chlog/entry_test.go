@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chlog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadEntries(t *testing.T) {
+	ctx := New(t.TempDir())
+	require.NoError(t, os.Mkdir(ctx.UnreleasedDir, 0o755))
+
+	files := map[string]string{
+		templateYAML: "change_type:\n",
+		"a.yaml":     "change_type: enhancement\ncomponent: a\nnote: Note A.\nissues: [1]\n",
+		"b.json":     `{"change_type": "bug_fix", "component": "b", "note": "Note B.", "issues": [2, 3], "subtext": "Sub B."}`,
+		"c.toml":     "change_type = \"breaking\"\ncomponent = \"c\"\nnote = \"Note C.\"\nissues = [4]\n",
+		"d.txt":      "ignored",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(ctx.UnreleasedDir, name), []byte(content), 0o600))
+	}
+
+	entries, err := ReadEntries(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*Entry{
+		{ChangeType: Enhancement, Component: "a", Note: "Note A.", Issues: []int{1}},
+		{ChangeType: BugFix, Component: "b", Note: "Note B.", Issues: []int{2, 3}, SubText: "Sub B."},
+		{ChangeType: Breaking, Component: "c", Note: "Note C.", Issues: []int{4}},
+	}, entries)
+}
+
+func TestReadEntriesInvalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"entry.yaml": "issues: one\n",
+		"entry.json": `{"issues": "one"}`,
+		"entry.toml": "issues = \"one\"\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := New(t.TempDir())
+			require.NoError(t, os.Mkdir(ctx.UnreleasedDir, 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(ctx.UnreleasedDir, name), []byte(content), 0o600))
+
+			_, err := ReadEntries(ctx)
+			assert.ErrorContains(t, err, "invalid entry "+name)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chlog
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// changeTypeTitles are the titles of the sections of each change type, in
+// the order they are rendered.
+var changeTypeTitles = []struct {
+	changeType string
+	title      string
+}{
+	{Breaking, "🛑 Breaking changes 🛑"},
+	{Deprecation, "🚩 Deprecations 🚩"},
+	{NewComponent, "🚀 New components 🚀"},
+	{Enhancement, "💡 Enhancements 💡"},
+	{BugFix, "🧰 Bug fixes 🧰"},
+}
+
+// changelogEntryRegexp matches an entry rendered in the changelog, capturing
+// its component, note and issues.
+var changelogEntryRegexp = regexp.MustCompile("^- `([^`]+)`: (.*?)(?: \\(((?:#\\d+(?:, )?)+)\\))?$")
+
+type releaseNotes struct {
+	Sections []releaseSection
+}
+
+type releaseSection struct {
+	Title      string
+	Components []componentChanges
+}
+
+type componentChanges struct {
+	Component string
+	Changes   []string
+}
+
+// GenerateGitHubRelease renders entries as the body of a GitHub release, with
+// the changes grouped by change type, then by component.
+func GenerateGitHubRelease(entries []*Entry) (string, error) {
+	var notes releaseNotes
+	for _, ct := range changeTypeTitles {
+		byComponent := make(map[string][]string)
+		for _, entry := range entries {
+			if entry.ChangeType == ct.changeType {
+				byComponent[entry.Component] = append(byComponent[entry.Component], entry.change())
+			}
+		}
+		if len(byComponent) == 0 {
+			continue
+		}
+
+		section := releaseSection{Title: ct.title}
+		for component, changes := range byComponent {
+			sort.Strings(changes)
+			section.Components = append(section.Components, componentChanges{
+				Component: component,
+				Changes:   changes,
+			})
+		}
+		sort.Slice(section.Components, func(i, j int) bool {
+			return section.Components[i].Component < section.Components[j].Component
+		})
+		notes.Sections = append(notes.Sections, section)
+	}
+
+	releaseTmpl := filepath.Join(moduleDir(), "github_release.tmpl")
+
+	tmpl := template.Must(
+		template.
+			New("github_release.tmpl").
+			Option("missingkey=error").
+			ParseFiles(releaseTmpl))
+
+	buf := bytes.Buffer{}
+	if err := tmpl.Execute(&buf, notes); err != nil {
+		return "", fmt.Errorf("failed executing template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// ReadChangelogEntries returns the entries released in version, parsed from
+// the section of the changelog for that version.
+func ReadChangelogEntries(ctx Context, version string) ([]*Entry, error) {
+	content, err := os.ReadFile(filepath.Clean(ctx.ChangelogMD))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		entries    []*Entry
+		found      bool
+		changeType string
+		last       *Entry
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "## "):
+			if found {
+				return entries, nil
+			}
+			found = strings.TrimSpace(strings.TrimPrefix(line, "## ")) == version
+		case !found:
+		case strings.HasPrefix(line, "### "):
+			changeType = sectionChangeType(strings.TrimPrefix(line, "### "))
+			last = nil
+		case strings.HasPrefix(line, "- "):
+			last = nil
+			if changeType == "" {
+				continue
+			}
+			last = parseChangelogEntry(changeType, line)
+			if last != nil {
+				entries = append(entries, last)
+			}
+		case strings.HasPrefix(line, "  ") && last != nil:
+			if last.SubText != "" {
+				last.SubText += "\n"
+			}
+			last.SubText += strings.TrimPrefix(line, "  ")
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("version %s not found in %s", version, ctx.ChangelogMD)
+	}
+	return entries, nil
+}
+
+// sectionChangeType returns the change type of the changelog section with
+// title, or an empty string if it is not one.
+func sectionChangeType(title string) string {
+	for _, ct := range changeTypeTitles {
+		if strings.TrimSpace(title) == ct.title {
+			return ct.changeType
+		}
+	}
+	return ""
+}
+
+// parseChangelogEntry parses an entry rendered in the changelog. It returns
+// nil if line is not an entry.
+func parseChangelogEntry(changeType, line string) *Entry {
+	m := changelogEntryRegexp.FindStringSubmatch(line)
+	if m == nil {
+		return nil
+	}
+
+	entry := &Entry{
+		ChangeType: changeType,
+		Component:  m[1],
+		Note:       m[2],
+	}
+	for _, issue := range strings.Split(m[3], ", ") {
+		if n, err := strconv.Atoi(strings.TrimPrefix(issue, "#")); err == nil {
+			entry.Issues = append(entry.Issues, n)
+		}
+	}
+	return entry
+}
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chlog
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/build-tools/internal/modset"
+)
+
+// bumpRanks ranks the change types by the version increment they require,
+// before the major version is taken into account.
+var bumpRanks = map[string]int{
+	BugFix:       1,
+	Enhancement:  2,
+	NewComponent: 2,
+	Deprecation:  2,
+	Breaking:     3,
+}
+
+// ModuleSet is a module set of a multimod versioning file.
+type ModuleSet struct {
+	Name    string
+	Version string
+	Modules []string
+}
+
+// ReadModuleSets returns the module sets of the multimod versioning file at
+// path and the versioning files it includes, sorted by name.
+func ReadModuleSets(path string) ([]ModuleSet, error) {
+	modSets, err := modset.Read(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid versioning file %s: %w", path, err)
+	}
+
+	sets := make([]ModuleSet, 0, len(modSets))
+	for name, s := range modSets {
+		sets = append(sets, ModuleSet{Name: name, Version: s.Version, Modules: s.Modules})
+	}
+	sort.Slice(sets, func(i, j int) bool { return sets[i].Name < sets[j].Name })
+	return sets, nil
+}
+
+// includes returns whether the changes of component are changes of a module
+// of s: component is the path of one of its modules, or a suffix of it after
+// a slash, e.g. multimod for go.opentelemetry.io/build-tools/multimod.
+func (s ModuleSet) includes(component string) bool {
+	for _, m := range s.Modules {
+		if m == component || strings.HasSuffix(m, "/"+component) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextVersion is the suggested next version of a module set.
+type NextVersion struct {
+	ModuleSet ModuleSet
+	Next      string
+	// ChangeType is the change type of the pending entries requiring the
+	// largest increment, or empty if no entry applies to the module set.
+	ChangeType string
+}
+
+// NextVersions returns the suggested next version of each of sets given the
+// pending entries. An entry applies to the module sets including its
+// component, or to all sets if none includes it.
+func NextVersions(sets []ModuleSet, entries []*Entry) ([]NextVersion, error) {
+	changeTypes := make([]string, len(sets))
+	for _, e := range entries {
+		var matched []int
+		for i, s := range sets {
+			if s.includes(e.Component) {
+				matched = append(matched, i)
+			}
+		}
+		if len(matched) == 0 {
+			for i := range sets {
+				matched = append(matched, i)
+			}
+		}
+		for _, i := range matched {
+			if bumpRanks[e.ChangeType] > bumpRanks[changeTypes[i]] {
+				changeTypes[i] = e.ChangeType
+			}
+		}
+	}
+
+	next := make([]NextVersion, 0, len(sets))
+	for i, s := range sets {
+		v, err := nextVersion(s.Version, changeTypes[i])
+		if err != nil {
+			return nil, fmt.Errorf("module set %s: %w", s.Name, err)
+		}
+		next = append(next, NextVersion{ModuleSet: s, Next: v, ChangeType: changeTypes[i]})
+	}
+	return next, nil
+}
+
+// nextVersion returns the version following version for changes of
+// changeType. Breaking changes increment the minor version of v0 versions and
+// the major version otherwise, bug fixes the patch version and other changes
+// the minor version. A prerelease version is followed by its release.
+func nextVersion(version, changeType string) (string, error) {
+	core, pre, _ := strings.Cut(strings.SplitN(version, "+", 2)[0], "-")
+	parts := strings.Split(strings.TrimPrefix(core, "v"), ".")
+	if !strings.HasPrefix(core, "v") || len(parts) != 3 {
+		return "", fmt.Errorf("invalid version %q", version)
+	}
+	var nums [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return "", fmt.Errorf("invalid version %q", version)
+		}
+		nums[i] = n
+	}
+	major, minor, patch := nums[0], nums[1], nums[2]
+
+	switch {
+	case changeType == "":
+		return version, nil
+	case pre != "":
+		return core, nil
+	case changeType == Breaking && major > 0:
+		return fmt.Sprintf("v%d.0.0", major+1), nil
+	case changeType == BugFix:
+		return fmt.Sprintf("v%d.%d.%d", major, minor, patch+1), nil
+	default:
+		return fmt.Sprintf("v%d.%d.0", major, minor+1), nil
+	}
+}
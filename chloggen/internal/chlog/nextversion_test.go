@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chlog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextVersion(t *testing.T) {
+	tests := []struct {
+		version    string
+		changeType string
+		expected   string
+	}{
+		{version: "v1.2.3", changeType: "", expected: "v1.2.3"},
+		{version: "v1.2.3", changeType: BugFix, expected: "v1.2.4"},
+		{version: "v1.2.3", changeType: Enhancement, expected: "v1.3.0"},
+		{version: "v1.2.3", changeType: Deprecation, expected: "v1.3.0"},
+		{version: "v1.2.3", changeType: NewComponent, expected: "v1.3.0"},
+		{version: "v1.2.3", changeType: Breaking, expected: "v2.0.0"},
+		{version: "v0.2.3", changeType: BugFix, expected: "v0.2.4"},
+		{version: "v0.2.3", changeType: Enhancement, expected: "v0.3.0"},
+		{version: "v0.2.3", changeType: Breaking, expected: "v0.3.0"},
+		{version: "v1.0.0-rc.1", changeType: Breaking, expected: "v1.0.0"},
+		{version: "v1.0.0+build", changeType: BugFix, expected: "v1.0.1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.version+"_"+tc.changeType, func(t *testing.T) {
+			actual, err := nextVersion(tc.version, tc.changeType)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	for _, invalid := range []string{"1.2.3", "v1.2", "v1.x.3"} {
+		_, err := nextVersion(invalid, BugFix)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestNextVersions(t *testing.T) {
+	sets := []ModuleSet{
+		{Name: "stable", Version: "v1.2.3", Modules: []string{"go.opentelemetry.io/foo", "go.opentelemetry.io/foo/bar"}},
+		{Name: "unstable", Version: "v0.4.0", Modules: []string{"go.opentelemetry.io/foo/baz"}},
+	}
+
+	tests := []struct {
+		name     string
+		entries  []*Entry
+		expected []string
+	}{
+		{
+			name:     "no entries",
+			expected: []string{"v1.2.3", "v0.4.0"},
+		},
+		{
+			name: "component of one set",
+			entries: []*Entry{
+				{ChangeType: BugFix, Component: "bar"},
+				{ChangeType: Breaking, Component: "go.opentelemetry.io/foo/baz"},
+			},
+			expected: []string{"v1.2.4", "v0.5.0"},
+		},
+		{
+			name: "largest increment",
+			entries: []*Entry{
+				{ChangeType: BugFix, Component: "bar"},
+				{ChangeType: Enhancement, Component: "foo"},
+			},
+			expected: []string{"v1.3.0", "v0.4.0"},
+		},
+		{
+			name:     "unknown component applies to all sets",
+			entries:  []*Entry{{ChangeType: Breaking, Component: "docs"}},
+			expected: []string{"v2.0.0", "v0.5.0"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			next, err := NextVersions(sets, tc.entries)
+			require.NoError(t, err)
+			actual := make([]string, 0, len(next))
+			for _, n := range next {
+				actual = append(actual, n.Next)
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestReadModuleSets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "versions.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("module-sets:\n"+
+		"  tools:\n    version: v0.2.0\n    modules:\n      - go.opentelemetry.io/build-tools\n"+
+		"  stable:\n    version: v1.0.0\n    modules:\n      - go.opentelemetry.io/stable\n"+
+		"excluded-modules:\n  - go.opentelemetry.io/excluded\n"+
+		"include:\n  - contrib.yaml\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(path), "contrib.yaml"), []byte("module-sets:\n"+
+		"  contrib:\n    version: v0.3.0\n    modules:\n      - go.opentelemetry.io/contrib\n"), 0600))
+
+	sets, err := ReadModuleSets(path)
+	require.NoError(t, err)
+	assert.Equal(t, []ModuleSet{
+		{Name: "contrib", Version: "v0.3.0", Modules: []string{"go.opentelemetry.io/contrib"}},
+		{Name: "stable", Version: "v1.0.0", Modules: []string{"go.opentelemetry.io/stable"}},
+		{Name: "tools", Version: "v0.2.0", Modules: []string{"go.opentelemetry.io/build-tools"}},
+	}, sets)
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chlog
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const gitHubAPIURL = "https://api.github.com"
+
+// RefChecker checks that the issue numbers of entries refer to issues or pull
+// requests of a GitHub repository.
+type RefChecker struct {
+	repository string
+	token      string
+	apiURL     string
+	client     *http.Client
+}
+
+// NewRefChecker returns a RefChecker for repository, in the owner/name form,
+// that authenticates to the GitHub API with token if it is not empty.
+func NewRefChecker(repository, token string) (*RefChecker, error) {
+	owner, name, ok := strings.Cut(repository, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("invalid repository '%s', expected owner/name", repository)
+	}
+	return &RefChecker{
+		repository: repository,
+		token:      token,
+		apiURL:     gitHubAPIURL,
+		client:     http.DefaultClient,
+	}, nil
+}
+
+// CheckEntries returns an error listing the issue numbers referenced by
+// entries that are neither an issue nor a pull request of the repository.
+func (c *RefChecker) CheckEntries(entries []*Entry) error {
+	referencedBy := make(map[int]*Entry)
+	for _, entry := range entries {
+		for _, issue := range entry.Issues {
+			if _, ok := referencedBy[issue]; !ok {
+				referencedBy[issue] = entry
+			}
+		}
+	}
+	issues := make([]int, 0, len(referencedBy))
+	for issue := range referencedBy {
+		issues = append(issues, issue)
+	}
+	sort.Ints(issues)
+
+	var missing []string
+	for _, issue := range issues {
+		exists, err := c.exists(issue)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			entry := referencedBy[issue]
+			missing = append(missing, fmt.Sprintf("#%d (%s: %s)", issue, entry.Component, entry.Note))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("issues not found in %s: %s", c.repository, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// exists returns whether issue is the number of an issue or pull request of
+// the repository. The issues endpoint of the GitHub API serves both.
+func (c *RefChecker) exists(issue int) (bool, error) {
+	url := fmt.Sprintf("%s/repos/%s/issues/%d", c.apiURL, c.repository, issue)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up #%d: %w", issue, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound, http.StatusGone:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to look up #%d: GitHub API returned %s", issue, resp.Status)
+	}
+}
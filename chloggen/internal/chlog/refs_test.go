@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chlog
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRefChecker(t *testing.T) {
+	for _, repository := range []string{"", "owner", "owner/", "/name", "owner/name/extra"} {
+		_, err := NewRefChecker(repository, "")
+		assert.ErrorContains(t, err, "expected owner/name", repository)
+	}
+	_, err := NewRefChecker("owner/name", "")
+	assert.NoError(t, err)
+}
+
+func TestRefCheckerCheckEntries(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/repos/owner/name/issues/12", "/repos/owner/name/issues/34":
+			w.WriteHeader(http.StatusOK)
+		case "/repos/owner/name/issues/500":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewRefChecker("owner/name", "secret")
+	require.NoError(t, err)
+	c.apiURL = server.URL
+
+	valid := []*Entry{
+		{ChangeType: Enhancement, Component: "foo", Note: "Add foo", Issues: []int{34, 12}},
+		{ChangeType: BugFix, Component: "bar", Note: "Fix bar", Issues: []int{12}},
+	}
+	assert.NoError(t, c.CheckEntries(valid))
+	assert.Equal(t, []string{"/repos/owner/name/issues/12", "/repos/owner/name/issues/34"}, requested)
+
+	missing := append(valid,
+		&Entry{ChangeType: BugFix, Component: "baz", Note: "Fix baz", Issues: []int{21, 43}},
+	)
+	assert.EqualError(t, c.CheckEntries(missing), "issues not found in owner/name: #21 (baz: Fix baz), #43 (baz: Fix baz)")
+
+	failing := append(valid,
+		&Entry{ChangeType: BugFix, Component: "baz", Note: "Fix baz", Issues: []int{500}},
+	)
+	assert.ErrorContains(t, c.CheckEntries(failing), "failed to look up #500: GitHub API returned 500")
+}
@@ -18,20 +18,62 @@ import (
 	"bytes"
 	"fmt"
 	"path/filepath"
-	"sort"
+	"strings"
 	"text/template"
 )
 
+// summary is the data of summary templates. Entries are printed in the
+// default format by {{ . }}, while custom templates can lay out their fields.
 type summary struct {
 	Version         string
-	BreakingChanges []string
-	Deprecations    []string
-	NewComponents   []string
-	Enhancements    []string
-	BugFixes        []string
+	BreakingChanges []*Entry
+	Deprecations    []*Entry
+	NewComponents   []*Entry
+	Enhancements    []*Entry
+	BugFixes        []*Entry
 }
 
+// summaryFuncs are the functions available to summary templates.
+var summaryFuncs = template.FuncMap{
+	"issues": formatIssues,
+	"indent": indent,
+}
+
+// formatIssues returns issues as a comma separated list of references, e.g.
+// "#1, #2".
+func formatIssues(issues []int) string {
+	issueStrs := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		issueStrs = append(issueStrs, fmt.Sprintf("#%d", issue))
+	}
+	return strings.Join(issueStrs, ", ")
+}
+
+// indent prefixes every line of text with the given number of spaces.
+func indent(spaces int, text string) string {
+	prefix := strings.Repeat(" ", spaces)
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	return prefix + strings.Join(lines, "\n"+prefix)
+}
+
+// GenerateSummary renders the changelog section of version with the default
+// template.
 func GenerateSummary(version string, entries []*Entry) (string, error) {
+	return generateSummary("", version, entries)
+}
+
+// RenderSummary renders the changelog section of version with the summary
+// template configured in ctx, or the default template.
+func (ctx Context) RenderSummary(version string, entries []*Entry) (string, error) {
+	return generateSummary(ctx.SummaryTemplate, version, entries)
+}
+
+func generateSummary(templatePath string, version string, entries []*Entry) (string, error) {
+	tmpl, err := parseSummaryTemplate(templatePath)
+	if err != nil {
+		return "", err
+	}
+
 	s := summary{
 		Version: version,
 	}
@@ -39,36 +81,18 @@ func GenerateSummary(version string, entries []*Entry) (string, error) {
 	for _, entry := range entries {
 		switch entry.ChangeType {
 		case Breaking:
-			s.BreakingChanges = append(s.BreakingChanges, entry.String())
+			s.BreakingChanges = append(s.BreakingChanges, entry)
 		case Deprecation:
-			s.Deprecations = append(s.Deprecations, entry.String())
+			s.Deprecations = append(s.Deprecations, entry)
 		case NewComponent:
-			s.NewComponents = append(s.NewComponents, entry.String())
+			s.NewComponents = append(s.NewComponents, entry)
 		case Enhancement:
-			s.Enhancements = append(s.Enhancements, entry.String())
+			s.Enhancements = append(s.Enhancements, entry)
 		case BugFix:
-			s.BugFixes = append(s.BugFixes, entry.String())
+			s.BugFixes = append(s.BugFixes, entry)
 		}
 	}
 
-	s.BreakingChanges = sort.StringSlice(s.BreakingChanges)
-	s.Deprecations = sort.StringSlice(s.Deprecations)
-	s.NewComponents = sort.StringSlice(s.NewComponents)
-	s.Enhancements = sort.StringSlice(s.Enhancements)
-	s.BugFixes = sort.StringSlice(s.BugFixes)
-
-	return s.String()
-}
-
-func (s summary) String() (string, error) {
-	summaryTmpl := filepath.Join(moduleDir(), "summary.tmpl")
-
-	tmpl := template.Must(
-		template.
-			New("summary.tmpl").
-			Option("missingkey=error").
-			ParseFiles(summaryTmpl))
-
 	buf := bytes.Buffer{}
 	if err := tmpl.Execute(&buf, s); err != nil {
 		return "", fmt.Errorf("failed executing template: %w", err)
@@ -76,3 +100,20 @@ func (s summary) String() (string, error) {
 
 	return buf.String(), nil
 }
+
+// parseSummaryTemplate parses the summary template at path, or the default
+// template if path is empty.
+func parseSummaryTemplate(path string) (*template.Template, error) {
+	if path == "" {
+		path = filepath.Join(moduleDir(), "summary.tmpl")
+	}
+	tmpl, err := template.
+		New(filepath.Base(path)).
+		Funcs(summaryFuncs).
+		Option("missingkey=error").
+		ParseFiles(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid summary template: %w", err)
+	}
+	return tmpl, nil
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chlog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderSummary(t *testing.T) {
+	entries := []*Entry{
+		{
+			ChangeType: Enhancement,
+			Component:  "receiver/foo",
+			Note:       "Add some bar",
+			Issues:     []int{12345, 12350},
+			SubText:    "More details\nabout it",
+		},
+		{
+			ChangeType: BugFix,
+			Component:  "exporter/bar",
+			Note:       "Fix some bar",
+			Issues:     []int{12346},
+		},
+	}
+
+	t.Run("default", func(t *testing.T) {
+		expected, err := GenerateSummary("v0.45.0", entries)
+		require.NoError(t, err)
+
+		actual, err := New(t.TempDir()).RenderSummary("v0.45.0", entries)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("custom template", func(t *testing.T) {
+		tmpl := `# {{ .Version }}
+{{- range .Enhancements }}
+* [{{ .Component }}] {{ .Note }} ({{ issues .Issues }})
+{{- if .SubText }}
+{{ indent 4 .SubText }}
+{{- end }}
+{{- end }}
+{{- range .BugFixes }}
+* [{{ .Component }}] {{ .Note }} ({{ issues .Issues }})
+{{- end }}
+`
+		ctx := New(t.TempDir())
+		ctx.SummaryTemplate = filepath.Join(t.TempDir(), "summary.tmpl")
+		require.NoError(t, os.WriteFile(ctx.SummaryTemplate, []byte(tmpl), 0600))
+
+		actual, err := ctx.RenderSummary("v0.45.0", entries)
+		require.NoError(t, err)
+		assert.Equal(t, `# v0.45.0
+* [receiver/foo] Add some bar (#12345, #12350)
+    More details
+    about it
+* [exporter/bar] Fix some bar (#12346)
+`, actual)
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+		ctx := New(t.TempDir())
+		ctx.SummaryTemplate = filepath.Join(t.TempDir(), "summary.tmpl")
+		require.NoError(t, os.WriteFile(ctx.SummaryTemplate, []byte("{{ .Date }}\n"), 0600))
+
+		_, err := ctx.RenderSummary("v0.45.0", entries)
+		assert.ErrorContains(t, err, "failed executing template")
+	})
+}
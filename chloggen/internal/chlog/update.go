@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chlog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+)
+
+// renameFunc is used to move files so tests can simulate failures.
+var renameFunc = os.Rename
+
+// UpdateChangelog replaces the contents of the changelog with changelog and
+// removes all entry files. See UpdateChangelogs.
+func UpdateChangelog(ctx Context, changelog []byte) error {
+	return UpdateChangelogs(ctx, map[string][]byte{ctx.ChangelogMD: changelog})
+}
+
+// UpdateChangelogs replaces the contents of each changelog file in
+// changelogs, keyed by path, and removes all entry files. The update is
+// applied atomically: the new changelogs are staged in temporary files and the
+// entries are moved aside before the changelogs are replaced, so that on any
+// failure the original changelogs and entries are restored.
+func UpdateChangelogs(ctx Context, changelogs map[string][]byte) (err error) {
+	entryFilenames, err := entryFiles(ctx)
+	if err != nil {
+		return err
+	}
+
+	paths := make([]string, 0, len(changelogs))
+	for path := range changelogs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	tmpMDs := make(map[string]string, len(paths))
+	defer func() {
+		if err == nil {
+			return
+		}
+		for _, tmpMD := range tmpMDs {
+			if rmErr := os.Remove(tmpMD); rmErr != nil && !os.IsNotExist(rmErr) {
+				logging.Warnf("Failed to remove %s: %v", tmpMD, rmErr)
+			}
+		}
+	}()
+	originals := make(map[string][]byte, len(paths))
+	perms := make(map[string]os.FileMode, len(paths))
+	for _, path := range paths {
+		if originals[path], err = os.ReadFile(filepath.Clean(path)); err != nil {
+			return err
+		}
+		var info os.FileInfo
+		if info, err = os.Stat(path); err != nil {
+			return err
+		}
+		perms[path] = info.Mode().Perm()
+		if tmpMDs[path], err = stageChangelog(path, changelogs[path], perms[path]); err != nil {
+			return err
+		}
+	}
+
+	// Move the entries aside so they can be restored if the update fails. The
+	// staging directory is inside the unreleased directory so that renames stay
+	// on one filesystem.
+	stagingDir, err := os.MkdirTemp(ctx.UnreleasedDir, ".update-")
+	if err != nil {
+		return err
+	}
+	moved := make(map[string]string, len(entryFilenames))
+	defer func() {
+		if err != nil {
+			if restoreErr := restoreEntries(moved); restoreErr != nil {
+				// Keep the staging directory, it holds the entries that
+				// could not be restored.
+				err = fmt.Errorf("%w (%v, remaining entries are in %s)", err, restoreErr, stagingDir)
+				return
+			}
+		}
+		if rmErr := os.RemoveAll(stagingDir); rmErr != nil {
+			logging.Warnf("Failed to remove %s: %v", stagingDir, rmErr)
+		}
+	}()
+
+	for _, entryFilename := range entryFilenames {
+		staged := filepath.Join(stagingDir, filepath.Base(entryFilename))
+		if err = renameFunc(entryFilename, staged); err != nil {
+			return fmt.Errorf("failed to remove entry %s: %w", entryFilename, err)
+		}
+		moved[entryFilename] = staged
+	}
+
+	var replaced []string
+	defer func() {
+		if err == nil {
+			return
+		}
+		for _, path := range replaced {
+			if restoreErr := os.WriteFile(path, originals[path], perms[path]); restoreErr != nil {
+				logging.Errorf("Failed to restore %s: %v", path, restoreErr)
+			}
+		}
+	}()
+	for _, path := range paths {
+		if err = renameFunc(tmpMDs[path], path); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		replaced = append(replaced, path)
+	}
+	return nil
+}
+
+// stageChangelog writes changelog to a temporary file next to path, with the
+// permissions perm, and returns its name.
+func stageChangelog(path string, changelog []byte, perm os.FileMode) (string, error) {
+	tmpMD, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return "", err
+	}
+	if _, err = tmpMD.Write(changelog); err != nil {
+		_ = tmpMD.Close()
+		_ = os.Remove(tmpMD.Name())
+		return "", err
+	}
+	if err = tmpMD.Close(); err != nil {
+		_ = os.Remove(tmpMD.Name())
+		return "", err
+	}
+	if err = os.Chmod(tmpMD.Name(), perm); err != nil {
+		_ = os.Remove(tmpMD.Name())
+		return "", err
+	}
+	return tmpMD.Name(), nil
+}
+
+// restoreEntries moves staged entry files back to their original location.
+func restoreEntries(moved map[string]string) error {
+	var failed int
+	for entryFilename, staged := range moved {
+		if err := renameFunc(staged, entryFilename); err != nil {
+			logging.Errorf("Failed to restore entry %s from %s: %v", entryFilename, staged, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to restore %d entries", failed)
+	}
+	return nil
+}
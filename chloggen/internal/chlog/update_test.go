@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chlog
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const originalChangelog = "# Changelog\n\n<!-- next version -->\n"
+
+// setupUpdateDir creates a changelog, a template, and two entries in a
+// temporary directory.
+func setupUpdateDir(t *testing.T) Context {
+	ctx := New(t.TempDir())
+	require.NoError(t, os.Mkdir(ctx.UnreleasedDir, 0750))
+	require.NoError(t, os.WriteFile(ctx.ChangelogMD, []byte(originalChangelog), 0644))
+	for _, name := range []string{templateYAML, "a.yaml", "b.yaml"} {
+		require.NoError(t, os.WriteFile(filepath.Join(ctx.UnreleasedDir, name), []byte(name), 0600))
+	}
+	return ctx
+}
+
+func unreleasedFiles(t *testing.T, ctx Context) []string {
+	dirEntries, err := os.ReadDir(ctx.UnreleasedDir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(dirEntries))
+	for _, e := range dirEntries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func TestUpdateChangelog(t *testing.T) {
+	ctx := setupUpdateDir(t)
+
+	require.NoError(t, UpdateChangelog(ctx, []byte("updated")))
+
+	content, err := os.ReadFile(ctx.ChangelogMD)
+	require.NoError(t, err)
+	assert.Equal(t, "updated", string(content))
+
+	info, err := os.Stat(ctx.ChangelogMD)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm(), "changelog permissions should be kept")
+
+	assert.Equal(t, []string{templateYAML}, unreleasedFiles(t, ctx))
+
+	tmpFiles, err := filepath.Glob(ctx.ChangelogMD + ".*.tmp")
+	require.NoError(t, err)
+	assert.Empty(t, tmpFiles)
+}
+
+func TestUpdateChangelogRollback(t *testing.T) {
+	errRename := errors.New("rename failed")
+
+	tests := []struct {
+		name string
+		// failOn returns true for the rename that should fail.
+		failOn func(oldpath, newpath string) bool
+	}{
+		{
+			name: "moving entry",
+			failOn: func(oldpath, _ string) bool {
+				return filepath.Base(oldpath) == "b.yaml"
+			},
+		},
+		{
+			name: "writing changelog",
+			failOn: func(_, newpath string) bool {
+				return filepath.Base(newpath) == changelogMD
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Cleanup(func(f func(string, string) error) func() {
+				return func() { renameFunc = f }
+			}(renameFunc))
+			renameFunc = func(oldpath, newpath string) error {
+				if tc.failOn(oldpath, newpath) {
+					return errRename
+				}
+				return os.Rename(oldpath, newpath)
+			}
+
+			ctx := setupUpdateDir(t)
+
+			err := UpdateChangelog(ctx, []byte("updated"))
+			assert.ErrorIs(t, err, errRename)
+
+			content, err := os.ReadFile(ctx.ChangelogMD)
+			require.NoError(t, err)
+			assert.Equal(t, originalChangelog, string(content))
+
+			assert.ElementsMatch(t, []string{templateYAML, "a.yaml", "b.yaml"}, unreleasedFiles(t, ctx))
+			for _, name := range []string{"a.yaml", "b.yaml"} {
+				content, err = os.ReadFile(filepath.Join(ctx.UnreleasedDir, name))
+				require.NoError(t, err)
+				assert.Equal(t, name, string(content))
+			}
+
+			tmpFiles, err := filepath.Glob(ctx.ChangelogMD + ".*.tmp")
+			require.NoError(t, err)
+			assert.Empty(t, tmpFiles)
+		})
+	}
+}
+
+func TestUpdateChangelogsRollback(t *testing.T) {
+	errRename := errors.New("rename failed")
+	t.Cleanup(func(f func(string, string) error) func() {
+		return func() { renameFunc = f }
+	}(renameFunc))
+
+	ctx := setupUpdateDir(t)
+	apiMD := filepath.Join(filepath.Dir(ctx.ChangelogMD), "CHANGELOG-API.md")
+	require.NoError(t, os.WriteFile(apiMD, []byte(originalChangelog), 0600))
+
+	// CHANGELOG.md is replaced first, then replacing CHANGELOG-API.md fails.
+	renameFunc = func(oldpath, newpath string) error {
+		if newpath == apiMD {
+			return errRename
+		}
+		return os.Rename(oldpath, newpath)
+	}
+
+	err := UpdateChangelogs(ctx, map[string][]byte{
+		ctx.ChangelogMD: []byte("updated"),
+		apiMD:           []byte("updated api"),
+	})
+	assert.ErrorIs(t, err, errRename)
+
+	for _, path := range []string{ctx.ChangelogMD, apiMD} {
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, originalChangelog, string(content))
+	}
+	assert.ElementsMatch(t, []string{templateYAML, "a.yaml", "b.yaml"}, unreleasedFiles(t, ctx))
+
+	tmpFiles, err := filepath.Glob(filepath.Join(filepath.Dir(ctx.ChangelogMD), "*.tmp"))
+	require.NoError(t, err)
+	assert.Empty(t, tmpFiles)
+}
@@ -16,23 +16,32 @@ package cmd
 
 import (
 	"fmt"
-	"log"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"go.uber.org/zap"
 
 	cl "go.opentelemetry.io/build-tools/crosslink/internal"
+	"go.opentelemetry.io/build-tools/internal/logging"
 	"go.opentelemetry.io/build-tools/internal/repo"
 	"go.opentelemetry.io/build-tools/internal/syncerror"
 )
 
 type commandConfig struct {
-	runConfig    cl.RunConfig
-	excludeFlags []string
-	rootCommand  cobra.Command
-	pruneCommand cobra.Command
+	runConfig         cl.RunConfig
+	excludeFlags      []string
+	quiet             bool
+	onlyCurrentModule bool
+	check             bool
+	report            string
+	configFile        string
+	rootCommand       cobra.Command
+	pruneCommand      cobra.Command
+	reconcileCommand  cobra.Command
+	skewCommand       cobra.Command
+	workCommand       cobra.Command
 }
 
 func newCommandConfig() *commandConfig {
@@ -43,6 +52,10 @@ func newCommandConfig() *commandConfig {
 	preRunSetup := func(cmd *cobra.Command, args []string) error {
 		c.runConfig.ExcludedPaths = transformExclude(c.excludeFlags)
 
+		if cmd.Flags().Changed("jobs") && c.runConfig.Jobs < 1 {
+			return fmt.Errorf("--jobs must be at least 1, got %d", c.runConfig.Jobs)
+		}
+
 		if c.runConfig.RootPath == "" {
 			rp, err := repo.FindRoot()
 			if err != nil {
@@ -51,6 +64,27 @@ func newCommandConfig() *commandConfig {
 			c.runConfig.RootPath = rp
 		}
 
+		configFile, optional := c.configFile, false
+		if configFile == "" {
+			configFile, optional = filepath.Join(c.runConfig.RootPath, cl.ConfigFileName), true
+		}
+		externalReplaces, err := cl.ReadExternalReplaces(configFile, optional)
+		if err != nil {
+			return fmt.Errorf("could not read config file: %w", err)
+		}
+		c.runConfig.ExternalReplaces = externalReplaces
+
+		if c.onlyCurrentModule {
+			wd, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("could not get working directory: %w", err)
+			}
+			c.runConfig.OnlyModule, err = cl.CurrentModule(wd, c.runConfig.RootPath)
+			if err != nil {
+				return fmt.Errorf("could not find current module: %w", err)
+			}
+		}
+
 		// enable verbosity on overwrite if user has not supplied another value
 		vExists := false
 		cmd.Flags().Visit(func(input *pflag.Flag) {
@@ -58,15 +92,25 @@ func newCommandConfig() *commandConfig {
 				vExists = true
 			}
 		})
-		if c.runConfig.Overwrite && !vExists {
+		if c.runConfig.Overwrite && !vExists && !c.quiet {
 			c.runConfig.Verbose = true
 		}
-		var err error
-		if c.runConfig.Verbose {
+		// quiet takes precedence over verbose.
+		if c.quiet {
+			c.runConfig.Verbose = false
+		}
+		logging.Configure(c.quiet, c.runConfig.Verbose)
+
+		switch {
+		case c.quiet:
+			zapCfg := zap.NewProductionConfig()
+			zapCfg.Level = zap.NewAtomicLevelAt(zap.ErrorLevel)
+			c.runConfig.Logger, err = zapCfg.Build()
+		case c.runConfig.Verbose:
 			c.runConfig.Logger, err = zap.NewDevelopment()
-			if err != nil {
-				return fmt.Errorf("could not create zap logger: %w", err)
-			}
+		}
+		if err != nil {
+			return fmt.Errorf("could not create zap logger: %w", err)
 		}
 		return nil
 
@@ -89,6 +133,16 @@ func newCommandConfig() *commandConfig {
 		PersistentPreRunE:  preRunSetup,
 		PersistentPostRunE: postRunSetup,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			switch c.report {
+			case "":
+			case cl.ReportJSON:
+				return cl.Report(c.runConfig, cmd.OutOrStdout(), c.check)
+			default:
+				return fmt.Errorf("invalid --report %q, must be %v", c.report, cl.ReportJSON)
+			}
+			if c.check {
+				return cl.Check(c.runConfig, cmd.OutOrStdout())
+			}
 			return cl.Crosslink(c.runConfig)
 		},
 	}
@@ -104,7 +158,45 @@ func newCommandConfig() *commandConfig {
 			return cl.Prune(c.runConfig)
 		},
 	}
+
+	c.reconcileCommand = cobra.Command{
+		Use:   "reconcile",
+		Short: "Reconcile replace statements that pin intra-repository modules to a version",
+		Long: `Reconcile finds replace statements in intra-repository go.mod files that pin intra-repository
+		modules to a version, as is common after merge mistakes, and converts them back to local path
+		replace statements. If a versioning file is provided, pins of modules listed in it are updated to
+		match the listed version instead. Each decision is logged.
+		This is a destructive action and will overwrite existing go.mod files.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cl.Reconcile(c.runConfig)
+		},
+	}
+	c.skewCommand = cobra.Command{
+		Use:   "skew",
+		Short: "Report intra-repository modules that require outdated versions of each other",
+		Long: `Skew compares the version each intra-repository module requires of other intra-repository
+		modules against their latest released version and reports the requirements that are older.
+		Latest versions are read from the versioning file if one is provided, or from the git tags of
+		the repository otherwise. No go.mod files are modified.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cl.Skew(c.runConfig, cmd.OutOrStdout())
+		},
+	}
+	c.workCommand = cobra.Command{
+		Use:   "work",
+		Short: "Generate or update a go.work file using all intra-repository modules",
+		Long: `Work writes a go.work file at the root of the repository with a use directive for each
+		intra-repository module that is not excluded. If a go.work file already exists, missing use directives
+		are added and those of excluded modules or of directories that no longer contain a module are removed.
+		Use directives of directories outside of the repository are left untouched.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cl.Work(c.runConfig)
+		},
+	}
 	c.rootCommand.AddCommand(&c.pruneCommand)
+	c.rootCommand.AddCommand(&c.reconcileCommand)
+	c.rootCommand.AddCommand(&c.skewCommand)
+	c.rootCommand.AddCommand(&c.workCommand)
 	return c
 }
 
@@ -117,8 +209,7 @@ var (
 func Execute() {
 	err := comCfg.rootCommand.Execute()
 	if err != nil {
-		log.Printf("failed execute: %v", err)
-		os.Exit(1)
+		logging.Fatalf("failed execute: %v", err)
 	}
 }
 
@@ -128,9 +219,30 @@ func init() {
 	git repository in the current or a parent directory.`)
 	comCfg.rootCommand.PersistentFlags().StringSliceVar(&comCfg.excludeFlags, "exclude", []string{}, "list of comma separated go modules that crosslink will ignore in operations."+
 		"multiple calls of --exclude can be made")
+	comCfg.rootCommand.PersistentFlags().StringToStringVar(&comCfg.runConfig.ModuleAliases, "module-alias", map[string]string{}, "list of comma separated upstream=fork module path prefixes, "+
+		"e.g. go.opentelemetry.io/collector=github.com/myorg/collector. Requirements on upstream modules are replaced with the intra-repository fork module. "+
+		"multiple calls of --module-alias can be made")
+	comCfg.rootCommand.PersistentFlags().StringVar(&comCfg.configFile, "config", "", "path to a crosslink config file mapping module path prefixes of other repositories "+
+		"to local checkouts, whose modules are then replaced too. Defaults to "+cl.ConfigFileName+" at the root of the repository if it exists")
+	comCfg.rootCommand.PersistentFlags().IntVarP(&comCfg.runConfig.Jobs, "jobs", "j", comCfg.runConfig.Jobs, "number of modules processed concurrently. "+
+		"Output is the same regardless of its value")
 	comCfg.rootCommand.PersistentFlags().BoolVarP(&comCfg.runConfig.Verbose, "verbose", "v", false, "verbose output")
+	comCfg.rootCommand.PersistentFlags().BoolVarP(&comCfg.quiet, "quiet", "q", false, "only log errors, takes precedence over --verbose")
 	comCfg.rootCommand.Flags().BoolVar(&comCfg.runConfig.Overwrite, "overwrite", false, "overwrite flag allows crosslink to make destructive (replacing or updating) actions to existing go.mod files")
+	comCfg.rootCommand.Flags().BoolVar(&comCfg.onlyCurrentModule, "only-current-module", false, "only update the go.mod file of the module containing the working directory, "+
+		"e.g. when invoked by a //go:generate directive. Replace statements are still based on the dependency graph of the whole repository")
+	comCfg.rootCommand.Flags().BoolVar(&comCfg.check, "check", false, "report the changes crosslink would make to go.mod files as a diff without modifying them, "+
+		"and exit with a non-zero status if there are any, e.g. to verify in CI that go.mod files are up to date")
+	comCfg.rootCommand.Flags().BoolVar(&comCfg.runConfig.Tidy, "tidy", false, "run go mod tidy in the modules whose go.mod file was changed, "+
+		"using up to --jobs concurrent runs and the GOFLAGS of the environment. Every module is tidied even if some fail")
+	comCfg.rootCommand.MarkFlagsMutuallyExclusive("check", "tidy")
+	comCfg.rootCommand.Flags().StringVar(&comCfg.report, "report", "", "write the replace statements added, updated and removed in each go.mod file to stdout in the given format, json. "+
+		"With --check, the changes crosslink would make are reported instead of a diff")
 	comCfg.rootCommand.Flags().BoolVarP(&comCfg.runConfig.Prune, "prune", "p", false, "enables pruning operations on all go.mod files inside root repository")
+	comCfg.reconcileCommand.Flags().StringVar(&comCfg.runConfig.VersioningFile, "versioning-file", "", "path to a multimod versioning file. "+
+		"Version-pinned replace statements of modules listed in it are updated to the listed version instead of being converted to local path replace statements")
+	comCfg.skewCommand.Flags().StringVar(&comCfg.runConfig.VersioningFile, "versioning-file", "", "path to a multimod versioning file. "+
+		"The versions listed in it are used as the latest versions instead of the git tags of the repository")
 }
 
 // transform array slice into map
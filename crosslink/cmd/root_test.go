@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -66,6 +67,7 @@ func TestTransform(t *testing.T) {
 
 var configReset = func() {
 	comCfg.runConfig = cl.DefaultRunConfig()
+	comCfg.onlyCurrentModule = false
 	comCfg.rootCommand.SetArgs([]string{})
 }
 
@@ -92,6 +94,7 @@ func TestPreRun(t *testing.T) {
 			expectedConfig: cl.RunConfig{
 				Overwrite:     false,
 				RootPath:      validRootPath,
+				Jobs:          runtime.NumCPU(),
 				Logger:        validProdLogger,
 				ExcludedPaths: make(map[string]struct{}),
 			},
@@ -165,15 +168,51 @@ func TestPreRun(t *testing.T) {
 			},
 			args: []string{"-v"},
 		},
+		{
+			testName:   "with module alias",
+			mockConfig: cl.DefaultRunConfig(),
+			expectedConfig: cl.RunConfig{
+				RootPath: validRootPath,
+				Jobs:     runtime.NumCPU(),
+				ModuleAliases: map[string]string{
+					"go.opentelemetry.io/collector":   "github.com/myorg/collector",
+					"go.opentelemetry.io/build-tools": "github.com/myorg/build-tools",
+				},
+			},
+			args: []string{
+				"--module-alias=go.opentelemetry.io/collector=github.com/myorg/collector",
+				"--module-alias=go.opentelemetry.io/build-tools=github.com/myorg/build-tools",
+			},
+		},
+		{
+			testName:   "with only current module",
+			mockConfig: cl.DefaultRunConfig(),
+			expectedConfig: cl.RunConfig{
+				RootPath:   validRootPath,
+				Jobs:       runtime.NumCPU(),
+				OnlyModule: "go.opentelemetry.io/build-tools/crosslink",
+			},
+			args: []string{"--only-current-module"},
+		},
 		{
 			testName:   "with good root path",
 			mockConfig: cl.DefaultRunConfig(),
 			expectedConfig: cl.RunConfig{
 				RootPath: validRootPath,
+				Jobs:     runtime.NumCPU(),
 				Logger:   validProdLogger,
 			},
 			args: []string{fmt.Sprintf("--root=%s", validRootPath)},
 		},
+		{
+			testName:   "with jobs",
+			mockConfig: cl.DefaultRunConfig(),
+			expectedConfig: cl.RunConfig{
+				RootPath: validRootPath,
+				Jobs:     4,
+			},
+			args: []string{"--jobs=4"},
+		},
 	}
 
 	for _, test := range tests {
@@ -197,6 +236,19 @@ func TestPreRun(t *testing.T) {
 	}
 }
 
+func TestPreRunInvalidJobs(t *testing.T) {
+	t.Cleanup(configReset)
+	comCfg.runConfig = cl.DefaultRunConfig()
+	t.Cleanup(func() { comCfg.rootCommand.Flags().Lookup("jobs").Changed = false })
+
+	err := comCfg.rootCommand.ParseFlags([]string{"--jobs=0"})
+	assert.NoError(t, err, "failed to parse flags")
+
+	testPreRun := comCfg.rootCommand.PersistentPreRunE
+	err = testPreRun(&comCfg.rootCommand, nil)
+	assert.ErrorContains(t, err, "--jobs must be at least 1")
+}
+
 // isolated test because the working directory needs to changed
 // and it will keep the happy path test above clean
 func TestBadRootPath(t *testing.T) {
@@ -11,6 +11,7 @@ require (
 	go.opentelemetry.io/build-tools v0.2.0
 	go.uber.org/zap v1.23.0
 	golang.org/x/mod v0.6.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -21,7 +22,6 @@ require (
 	go.uber.org/atomic v1.9.0 // indirect
 	go.uber.org/multierr v1.7.0 // indirect
 	gopkg.in/check.v1 v1.0.0-20190902080502-41f04d3bba15 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
 replace go.opentelemetry.io/build-tools => ../
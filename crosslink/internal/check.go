@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosslink
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/mod/modfile"
+)
+
+// ErrOutOfDate is returned by Check when go.mod files are missing replace
+// statements crosslink would insert, or hold ones it would update or prune.
+var ErrOutOfDate = errors.New("go.mod files are not up to date, run crosslink to update them")
+
+// moduleDiff holds the lines crosslink would remove from and add to a go.mod
+// file.
+type moduleDiff struct {
+	path    string
+	removed []string
+	added   []string
+}
+
+// Check computes the changes crosslink would make to the go.mod files of the
+// repository, with the same configuration, without modifying them. The
+// changes are reported to w as a diff and ErrOutOfDate is returned if there
+// are any.
+func Check(rc RunConfig, w io.Writer) error {
+	var (
+		mu    sync.Mutex
+		diffs []moduleDiff
+	)
+	err := crosslink(rc, func(module *moduleInfo) error {
+		d, err := diffModule(module)
+		if err != nil {
+			return err
+		}
+		if len(d.removed) > 0 || len(d.added) > 0 {
+			mu.Lock()
+			diffs = append(diffs, d)
+			mu.Unlock()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].path < diffs[j].path })
+	for _, d := range diffs {
+		path := d.path
+		if rel, err := filepath.Rel(rc.RootPath, path); err == nil {
+			path = rel
+		}
+		fmt.Fprintf(w, "--- %s\n+++ %s\n", path, path)
+		for _, line := range d.removed {
+			fmt.Fprintf(w, "-%s\n", line)
+		}
+		for _, line := range d.added {
+			fmt.Fprintf(w, "+%s\n", line)
+		}
+	}
+	return fmt.Errorf("%w (%d files)", ErrOutOfDate, len(diffs))
+}
+
+// diffModule compares the go.mod file of module on disk with its updated
+// contents. Both are formatted first so only the changes made by crosslink
+// are reported.
+func diffModule(module *moduleInfo) (moduleDiff, error) {
+	path := module.moduleContents.Syntax.Name
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return moduleDiff{}, fmt.Errorf("failed to read go.mod file: %w", err)
+	}
+	current, err := modfile.Parse(path, data, nil)
+	if err != nil {
+		return moduleDiff{}, fmt.Errorf("failed to parse go.mod file: %w", err)
+	}
+	before, err := current.Format()
+	if err != nil {
+		return moduleDiff{}, fmt.Errorf("failed to format go.mod file: %w", err)
+	}
+	after, err := module.moduleContents.Format()
+	if err != nil {
+		return moduleDiff{}, fmt.Errorf("failed to format go.mod file: %w", err)
+	}
+
+	removed, added := diffLines(string(before), string(after))
+	return moduleDiff{path: path, removed: removed, added: added}, nil
+}
+
+// diffLines returns the non-blank lines of before missing from after, and
+// those of after missing from before.
+func diffLines(before, after string) ([]string, []string) {
+	count := make(map[string]int)
+	for _, line := range strings.Split(before, "\n") {
+		count[line]++
+	}
+	var added []string
+	for _, line := range strings.Split(after, "\n") {
+		if count[line] > 0 {
+			count[line]--
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			added = append(added, line)
+		}
+	}
+
+	var removed []string
+	for _, line := range strings.Split(before, "\n") {
+		if count[line] > 0 {
+			count[line]--
+			if strings.TrimSpace(line) != "" {
+				removed = append(removed, line)
+			}
+		}
+	}
+	return removed, added
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosslink
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCheck(t *testing.T) {
+	lg, _ := zap.NewDevelopment()
+
+	tmpRootDir, err := createTempTestDir("testSimple")
+	require.NoError(t, err, "creating temp dir")
+	t.Cleanup(func() { os.RemoveAll(tmpRootDir) })
+	require.NoError(t, renameGoMod(tmpRootDir), "renaming gomod files")
+
+	rc := RunConfig{
+		RootPath:      tmpRootDir,
+		ExcludedPaths: map[string]struct{}{},
+		Logger:        lg,
+	}
+
+	rootModFile := filepath.Join(tmpRootDir, "go.mod")
+	before, err := os.ReadFile(rootModFile)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	err = Check(rc, &buf)
+	assert.ErrorIs(t, err, ErrOutOfDate)
+
+	report := buf.String()
+	assert.Contains(t, report, "--- go.mod\n+++ go.mod\n")
+	assert.Contains(t, report, "+replace go.opentelemetry.io/build-tools/crosslink/testroot/testA => ./testA\n")
+	assert.Contains(t, report, "+replace go.opentelemetry.io/build-tools/crosslink/testroot/testB => ./testB\n")
+	assert.Contains(t, report, "--- "+filepath.Join("testA", "go.mod")+"\n")
+	assert.Contains(t, report, "+replace go.opentelemetry.io/build-tools/crosslink/testroot/testB => ../testB\n")
+	assert.NotContains(t, report, filepath.Join("testB", "go.mod"))
+
+	after, err := os.ReadFile(rootModFile)
+	require.NoError(t, err)
+	assert.Equal(t, before, after, "check must not modify go.mod files")
+
+	// Pruning is only reported when requested.
+	buf.Reset()
+	rc.Prune = true
+	assert.ErrorIs(t, Check(rc, &buf), ErrOutOfDate)
+	assert.Contains(t, buf.String(), "-replace go.opentelemetry.io/build-tools/crosslink/testroot/testZ => ./testZ\n")
+
+	require.NoError(t, Crosslink(rc))
+
+	buf.Reset()
+	assert.NoError(t, Check(rc, &buf))
+	assert.Empty(t, buf.String())
+}
+
+func TestDiffLines(t *testing.T) {
+	removed, added := diffLines("a\n\nb\nb\nc\n", "a\nb\nc\n\nd\n")
+	assert.Equal(t, []string{"b"}, removed)
+	assert.Equal(t, []string{"d"}, added)
+}
@@ -38,6 +38,30 @@ func identifyRootModule(rootPath string) (string, error) {
 	return modfile.ModulePath(rootModFile), nil
 }
 
+// CurrentModule returns the path of the Go module containing dir, found by
+// looking for a go.mod file in dir and its parents up to rootPath.
+func CurrentModule(dir string, rootPath string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	rootPath, err = filepath.Abs(rootPath)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if modPath, err := identifyRootModule(dir); err == nil {
+			return modPath, nil
+		}
+		parent := filepath.Dir(dir)
+		if dir == rootPath || parent == dir {
+			return "", fmt.Errorf("no go.mod file found in %s or its parents within %s", dir, rootPath)
+		}
+		dir = parent
+	}
+}
+
 func writeModule(module *moduleInfo) error {
 	modContents := module.moduleContents
 	//  now overwrite the existing gomod file
@@ -16,6 +16,8 @@ package crosslink
 
 import (
 	"log"
+	"runtime"
+	"strings"
 
 	"go.uber.org/zap"
 	"golang.org/x/mod/modfile"
@@ -24,22 +26,46 @@ import (
 type moduleInfo struct {
 	moduleContents            modfile.File
 	requiredReplaceStatements map[string]struct{}
+	// requiredExternalReplaces maps the required modules of other
+	// repositories to the directory of their local checkout, empty if the
+	// module has no local copy.
+	requiredExternalReplaces map[string]string
 }
 
 func newModuleInfo(moduleContents modfile.File) *moduleInfo {
 	return &moduleInfo{
 		requiredReplaceStatements: make(map[string]struct{}),
+		requiredExternalReplaces:  make(map[string]string),
 		moduleContents:            moduleContents,
 	}
 }
 
 type RunConfig struct {
-	RootPath      string
-	Verbose       bool
-	ExcludedPaths map[string]struct{}
-	Overwrite     bool
-	Prune         bool
-	Logger        *zap.Logger
+	RootPath       string
+	Verbose        bool
+	ExcludedPaths  map[string]struct{}
+	Overwrite      bool
+	Prune          bool
+	VersioningFile string
+	// ModuleAliases maps upstream module path prefixes to the prefixes of the
+	// intra-repository modules of a fork, so that requirements on upstream
+	// modules are replaced with their local fork.
+	ModuleAliases map[string]string
+	// OnlyModule is the path of the only intra-repository module whose
+	// go.mod file is updated. All modules are updated if it is empty.
+	OnlyModule string
+	// ExternalReplaces maps module path prefixes of other repositories to
+	// the directories of their local checkouts, so that requirements on
+	// their modules are replaced with the local copy.
+	ExternalReplaces map[string]string
+	// Jobs is the number of modules whose dependency graph is resolved and
+	// whose go.mod file is updated concurrently. Modules are processed one at
+	// a time if it is lower than 2.
+	Jobs int
+	// Tidy runs go mod tidy in the modules whose go.mod file was changed by
+	// Crosslink.
+	Tidy   bool
+	Logger *zap.Logger
 }
 
 func DefaultRunConfig() RunConfig {
@@ -51,6 +77,27 @@ func DefaultRunConfig() RunConfig {
 	rc := RunConfig{
 		Logger:        lg,
 		ExcludedPaths: ep,
+		Jobs:          runtime.NumCPU(),
 	}
 	return rc
 }
+
+// aliasedPath returns the path of the intra-repository module that modPath
+// refers to. If modPath falls under an upstream prefix of rc.ModuleAliases, the
+// longest such prefix is replaced with its fork prefix, otherwise modPath is
+// returned unchanged.
+func (rc RunConfig) aliasedPath(modPath string) string {
+	upstream := ""
+	for prefix := range rc.ModuleAliases {
+		if len(prefix) <= len(upstream) {
+			continue
+		}
+		if modPath == prefix || strings.HasPrefix(modPath, prefix+"/") {
+			upstream = prefix
+		}
+	}
+	if upstream == "" {
+		return modPath
+	}
+	return rc.ModuleAliases[upstream] + strings.TrimPrefix(modPath, upstream)
+}
@@ -24,6 +24,16 @@ import (
 )
 
 func Crosslink(rc RunConfig) error {
+	if rc.Tidy {
+		return crosslinkAndTidy(rc, writeModule, goModTidy)
+	}
+	return crosslink(rc, writeModule)
+}
+
+// crosslink inserts the replace statements of the intra-repository modules
+// and passes each updated module to write. Modules are processed concurrently
+// so write must be safe for concurrent use.
+func crosslink(rc RunConfig, write func(*moduleInfo) error) error {
 	var err error
 
 	rc.Logger.Debug("Crosslink run config", zap.Any("run_config", rc))
@@ -38,25 +48,39 @@ func Crosslink(rc RunConfig) error {
 		return fmt.Errorf("failed to build dependency graph: %w", err)
 	}
 
-	for moduleName, moduleInfo := range graph {
-		err = insertReplace(moduleInfo, rc)
+	if rc.OnlyModule != "" {
+		if _, exists := graph[rc.OnlyModule]; !exists {
+			return fmt.Errorf("module %s is not an intra-repository module of %s", rc.OnlyModule, rootModulePath)
+		}
+	}
+
+	forEachModule(rc, sortedModules(graph), func(rc RunConfig, moduleName string) {
+		if rc.OnlyModule != "" && moduleName != rc.OnlyModule {
+			return
+		}
+		moduleInfo := graph[moduleName]
+
+		err := insertReplace(moduleInfo, rc)
+		if err == nil {
+			err = insertExternalReplace(moduleInfo, rc)
+		}
 		logger := rc.Logger.With(zap.String("module", moduleName))
 		if err != nil {
 			logger.Error("Failed to insert replace statements",
 				zap.Error(err))
-			continue
+			return
 		}
 
 		if rc.Prune {
 			pruneReplace(rootModulePath, moduleInfo, rc)
 		}
 
-		err = writeModule(moduleInfo)
+		err = write(moduleInfo)
 		if err != nil {
 			logger.Error("Failed to write module",
 				zap.Error(err))
 		}
-	}
+	})
 	return nil
 }
 
@@ -72,14 +96,9 @@ func insertReplace(module *moduleInfo, rc RunConfig) error {
 			continue
 		}
 
-		localPath, err := filepath.Rel(modContents.Module.Mod.Path, reqModule)
+		localPath, err := localReplacePath(modContents.Module.Mod.Path, rc.aliasedPath(reqModule))
 		if err != nil {
-			return fmt.Errorf("failed to retrieve relative path: %w", err)
-		}
-		if localPath == "." || localPath == ".." {
-			localPath += "/"
-		} else if !strings.HasPrefix(localPath, "..") {
-			localPath = "./" + localPath
+			return err
 		}
 
 		if oldReplace, exists := containsReplace(modContents.Replace, reqModule); exists {
@@ -120,6 +139,21 @@ func insertReplace(module *moduleInfo, rc RunConfig) error {
 	return nil
 }
 
+// localReplacePath returns the relative path used to replace reqModule with
+// its local copy from within the module modPath.
+func localReplacePath(modPath, reqModule string) (string, error) {
+	localPath, err := filepath.Rel(modPath, reqModule)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve relative path: %w", err)
+	}
+	if localPath == "." || localPath == ".." {
+		localPath += "/"
+	} else if !strings.HasPrefix(localPath, "..") {
+		localPath = "./" + localPath
+	}
+	return localPath, nil
+}
+
 // Identifies if a replace statement already exists for a given module name
 func containsReplace(replaceStatments []*modfile.Replace, modName string) (*modfile.Replace, bool) {
 	for _, repStatement := range replaceStatments {
@@ -87,6 +87,88 @@ func TestCrosslink(t *testing.T) {
 					"replace go.opentelemetry.io/build-tools/crosslink/testroot => ../\n\n"),
 			},
 		},
+		{
+			testName: "testSimpleParallel",
+			mockDir:  "testSimple",
+			config: RunConfig{
+				Jobs:          4,
+				ExcludedPaths: map[string]struct{}{},
+				Logger:        lg,
+			},
+			expected: map[string][]byte{
+				"go.mod": []byte("module go.opentelemetry.io/build-tools/crosslink/testroot\n\n" +
+					"go 1.18\n\n" +
+					"require (\n\t" +
+					"go.opentelemetry.io/build-tools/crosslink/testroot/testA v1.0.0\n" +
+					")\n" +
+					"replace go.opentelemetry.io/build-tools/crosslink/testroot/testA => ./testA\n\n" +
+					"replace go.opentelemetry.io/build-tools/crosslink/testroot/testY => ./testY\n\n" +
+					"replace go.opentelemetry.io/build-tools/crosslink/testroot/testZ => ./testZ\n\n" +
+					"replace go.opentelemetry.io/build-tools/crosslink/testroot/testB => ./testB"),
+				filepath.Join("testA", "go.mod"): []byte("module go.opentelemetry.io/build-tools/crosslink/testroot/testA\n\n" +
+					"go 1.18\n\n" +
+					"require (\n\t" +
+					"go.opentelemetry.io/build-tools/crosslink/testroot/testB v1.0.0\n" +
+					")\n" +
+					"replace go.opentelemetry.io/build-tools/crosslink/testroot/testB => ../testB"),
+				filepath.Join("testB", "go.mod"): []byte("module go.opentelemetry.io/build-tools/crosslink/testroot/testB\n\n" +
+					"go 1.18\n\n"),
+			},
+		},
+		{
+			testName: "testCyclic",
+			mockDir:  "testCyclic",
+			config: RunConfig{
+				Jobs:          4,
+				ExcludedPaths: map[string]struct{}{},
+				Logger:        lg,
+			},
+			expected: map[string][]byte{
+				"go.mod": []byte("module go.opentelemetry.io/build-tools/crosslink/testroot\n\n" +
+					"go 1.18\n\n" +
+					"require (\n\t" +
+					"go.opentelemetry.io/build-tools/crosslink/testroot/testA v1.0.0\n" +
+					")\n" +
+					"replace go.opentelemetry.io/build-tools/crosslink/testroot/testA => ./testA\n\n" +
+					"replace go.opentelemetry.io/build-tools/crosslink/testroot/testB => ./testB"),
+				filepath.Join("testA", "go.mod"): []byte("module go.opentelemetry.io/build-tools/crosslink/testroot/testA\n\n" +
+					"go 1.18\n\n" +
+					"require (\n\t" +
+					"go.opentelemetry.io/build-tools/crosslink/testroot/testB v1.0.0\n" +
+					")\n" +
+					"replace go.opentelemetry.io/build-tools/crosslink/testroot/testB => ../testB\n\n" +
+					"replace go.opentelemetry.io/build-tools/crosslink/testroot => ../"),
+				// b has req on root but not necessary to write out with current comparison logic
+				filepath.Join("testB", "go.mod"): []byte("module go.opentelemetry.io/build-tools/crosslink/testroot/testB\n\n" +
+					"go 1.18\n\n" +
+					"replace go.opentelemetry.io/build-tools/crosslink/testroot/testA => ../testA\n\n" +
+					"replace go.opentelemetry.io/build-tools/crosslink/testroot => ../\n\n"),
+			},
+		},
+		{
+			testName: "testSimpleOnlyModule",
+			mockDir:  "testSimple",
+			config: RunConfig{
+				OnlyModule:    "go.opentelemetry.io/build-tools/crosslink/testroot/testA",
+				ExcludedPaths: map[string]struct{}{},
+				Logger:        lg,
+			},
+			expected: map[string][]byte{
+				"go.mod": []byte("module go.opentelemetry.io/build-tools/crosslink/testroot\n\n" +
+					"go 1.18\n\n" +
+					"require (\n\t" +
+					"go.opentelemetry.io/build-tools/crosslink/testroot/testA v1.0.0\n" +
+					")\n" +
+					"replace go.opentelemetry.io/build-tools/crosslink/testroot/testY => ./testY\n\n" +
+					"replace go.opentelemetry.io/build-tools/crosslink/testroot/testZ => ./testZ"),
+				filepath.Join("testA", "go.mod"): []byte("module go.opentelemetry.io/build-tools/crosslink/testroot/testA\n\n" +
+					"go 1.18\n\n" +
+					"require (\n\t" +
+					"go.opentelemetry.io/build-tools/crosslink/testroot/testB v1.0.0\n" +
+					")\n" +
+					"replace go.opentelemetry.io/build-tools/crosslink/testroot/testB => ../testB"),
+			},
+		},
 		{
 			testName: "testSimpleWithPrune",
 			mockDir:  "testSimple",
@@ -112,6 +194,28 @@ func TestCrosslink(t *testing.T) {
 					"go 1.18\n\n"),
 			},
 		},
+		{
+			testName: "testAliasWithPrune",
+			mockDir:  "testAlias",
+			config: RunConfig{
+				Prune:  true,
+				Logger: lg,
+				ModuleAliases: map[string]string{
+					"go.opentelemetry.io/build-tools/crosslink/testroot": "github.com/myorg/testroot",
+				},
+			},
+			expected: map[string][]byte{
+				"go.mod": []byte("module github.com/myorg/testroot\n\n" +
+					"go 1.18\n\n" +
+					"replace go.opentelemetry.io/build-tools/crosslink/testroot/testA => ./testA\n\n" +
+					"replace go.opentelemetry.io/build-tools/crosslink/testroot/testB => ./testB"),
+				filepath.Join("testA", "go.mod"): []byte("module github.com/myorg/testroot/testA\n\n" +
+					"go 1.18\n\n" +
+					"replace go.opentelemetry.io/build-tools/crosslink/testroot/testB => ../testB"),
+				filepath.Join("testB", "go.mod"): []byte("module github.com/myorg/testroot/testB\n\n" +
+					"go 1.18\n\n"),
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -441,3 +545,65 @@ func TestBadRootPath(t *testing.T) {
 	}
 
 }
+
+func TestAliasedPath(t *testing.T) {
+	rc := RunConfig{ModuleAliases: map[string]string{
+		"go.opentelemetry.io/collector":          "github.com/myorg/collector",
+		"go.opentelemetry.io/collector/pdata":    "github.com/myorg/pdata",
+		"go.opentelemetry.io/collector/exporter": "github.com/myorg/collector/exporter",
+	}}
+
+	for modPath, expected := range map[string]string{
+		"go.opentelemetry.io/collector":                   "github.com/myorg/collector",
+		"go.opentelemetry.io/collector/component":         "github.com/myorg/collector/component",
+		"go.opentelemetry.io/collector/pdata":             "github.com/myorg/pdata",
+		"go.opentelemetry.io/collector/pdata/v2":          "github.com/myorg/pdata/v2",
+		"go.opentelemetry.io/collector-contrib":           "go.opentelemetry.io/collector-contrib",
+		"go.opentelemetry.io/collector/exporter/otlp":     "github.com/myorg/collector/exporter/otlp",
+		"github.com/myorg/collector/receiver":             "github.com/myorg/collector/receiver",
+		"go.opentelemetry.io/build-tools/crosslink/other": "go.opentelemetry.io/build-tools/crosslink/other",
+	} {
+		assert.Equal(t, expected, rc.aliasedPath(modPath), modPath)
+	}
+}
+
+func TestCrosslinkOnlyUnknownModule(t *testing.T) {
+	tmpRootDir, err := createTempTestDir("testSimple")
+	if err != nil {
+		t.Fatal("creating temp dir:", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(tmpRootDir) })
+	if err = renameGoMod(tmpRootDir); err != nil {
+		t.Fatalf("error renaming gomod files: %v", err)
+	}
+
+	config := DefaultRunConfig()
+	config.RootPath = tmpRootDir
+	config.OnlyModule = "go.opentelemetry.io/build-tools/crosslink/testroot/testC"
+	assert.ErrorContains(t, Crosslink(config), "is not an intra-repository module")
+}
+
+func TestCurrentModule(t *testing.T) {
+	tmpRootDir, err := createTempTestDir("testSimple")
+	if err != nil {
+		t.Fatal("creating temp dir:", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(tmpRootDir) })
+	if err = renameGoMod(tmpRootDir); err != nil {
+		t.Fatalf("error renaming gomod files: %v", err)
+	}
+	if err = os.MkdirAll(filepath.Join(tmpRootDir, "testA", "internal", "gen"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	modPath, err := CurrentModule(filepath.Join(tmpRootDir, "testA", "internal", "gen"), tmpRootDir)
+	assert.NoError(t, err)
+	assert.Equal(t, "go.opentelemetry.io/build-tools/crosslink/testroot/testA", modPath)
+
+	modPath, err = CurrentModule(tmpRootDir, tmpRootDir)
+	assert.NoError(t, err)
+	assert.Equal(t, "go.opentelemetry.io/build-tools/crosslink/testroot", modPath)
+
+	_, err = CurrentModule(t.TempDir(), tmpRootDir)
+	assert.Error(t, err)
+}
@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosslink
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/mod/modfile"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileName is the name of the crosslink config file looked up at the
+// root of the repository.
+const ConfigFileName = ".crosslink.yaml"
+
+// configFile is the content of a crosslink config file.
+type configFile struct {
+	// ExternalReplaces maps module path prefixes of other repositories to the
+	// directories of their local checkouts.
+	ExternalReplaces map[string]string `yaml:"external_replaces"`
+}
+
+// ReadExternalReplaces returns the external module path prefixes of the
+// crosslink config file at path mapped to the absolute directories of their
+// local checkouts. Relative directories are resolved against the directory of
+// the config file. If path does not exist and optional is true, no prefixes
+// and no error are returned.
+func ReadExternalReplaces(path string, optional bool) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		if optional && errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var cfg configFile
+	if err = yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	base, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+	replaces := make(map[string]string, len(cfg.ExternalReplaces))
+	for prefix, dir := range cfg.ExternalReplaces {
+		if prefix == "" || dir == "" {
+			return nil, fmt.Errorf("invalid external replace %q: %q in %s", prefix, dir, path)
+		}
+		dir = filepath.FromSlash(dir)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(base, dir)
+		}
+		replaces[strings.TrimSuffix(prefix, "/")] = filepath.Clean(dir)
+	}
+	return replaces, nil
+}
+
+// externalDir returns the local directory of the module modPath if it falls
+// under one of the prefixes of rc.ExternalReplaces, using the longest such
+// prefix. The returned bool reports whether a prefix matched.
+func (rc RunConfig) externalDir(modPath string) (string, bool) {
+	external := ""
+	for prefix := range rc.ExternalReplaces {
+		if len(prefix) <= len(external) {
+			continue
+		}
+		if modPath == prefix || strings.HasPrefix(modPath, prefix+"/") {
+			external = prefix
+		}
+	}
+	if external == "" {
+		return "", false
+	}
+	rel := strings.TrimPrefix(modPath, external)
+	return filepath.Join(rc.ExternalReplaces[external], filepath.FromSlash(rel)), true
+}
+
+// addExternalRequirements records the requirements of module on modules of
+// other repositories that have a local checkout in rc.ExternalReplaces.
+// Requirements on intra-repository modules, listed in moduleMap, are left to
+// the intra-repository replace statements.
+func addExternalRequirements(rc RunConfig, module *moduleInfo, moduleMap map[string]*moduleInfo) {
+	for _, req := range module.moduleContents.Require {
+		if _, intra := moduleMap[rc.aliasedPath(req.Mod.Path)]; intra {
+			continue
+		}
+		dir, ok := rc.externalDir(req.Mod.Path)
+		if !ok {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err != nil {
+			rc.Logger.Debug("No local copy of external module, ignoring replace",
+				zap.String("module", module.moduleContents.Module.Mod.Path),
+				zap.String("required_module", req.Mod.Path),
+				zap.String("dir", dir))
+			dir = ""
+		}
+		module.requiredExternalReplaces[req.Mod.Path] = dir
+	}
+}
+
+// insertExternalReplace inserts the replace statements of the modules of
+// other repositories required by module that have a local checkout.
+func insertExternalReplace(module *moduleInfo, rc RunConfig) error {
+	modContents := module.moduleContents
+
+	modDir, err := filepath.Abs(filepath.Dir(modContents.Syntax.Name))
+	if err != nil {
+		return err
+	}
+
+	for reqModule, dir := range module.requiredExternalReplaces {
+		if dir == "" {
+			continue
+		}
+		if _, exists := rc.ExcludedPaths[reqModule]; exists {
+			rc.Logger.Debug("Excluded Module, ignoring replace",
+				zap.Any("required_module", reqModule))
+			continue
+		}
+
+		localPath, err := filepath.Rel(modDir, dir)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve relative path: %w", err)
+		}
+		localPath = filepath.ToSlash(localPath)
+		if !strings.HasPrefix(localPath, "..") {
+			localPath = "./" + localPath
+		}
+
+		if oldReplace, exists := containsReplace(modContents.Replace, reqModule); exists {
+			if oldReplace.New.Path == localPath && oldReplace.New.Version == "" {
+				continue
+			}
+			if !rc.Overwrite {
+				rc.Logger.Debug("Replace statement already exists -run with overwrite to update if desired",
+					zap.String("module", modContents.Module.Mod.Path),
+					zap.String("current_replace", reqModule+" => "+oldReplace.New.Path))
+				continue
+			}
+			rc.Logger.Debug("Overwriting Module",
+				zap.String("module", modContents.Module.Mod.Path),
+				zap.String("old_replace", reqModule+" => "+oldReplace.New.Path),
+				zap.String("new_replace", reqModule+" => "+localPath))
+		} else {
+			rc.Logger.Debug("Inserting Replace Statement",
+				zap.String("module", modContents.Module.Mod.Path),
+				zap.String("statement", reqModule+" => "+localPath))
+		}
+
+		if err = modContents.AddReplace(reqModule, "", localPath, ""); err != nil {
+			rc.Logger.Error("Failed to add replace statement", zap.Error(err),
+				zap.String("module", modContents.Module.Mod.Path),
+				zap.String("statement", reqModule+" => "+localPath))
+		}
+	}
+	module.moduleContents = modContents
+
+	return nil
+}
+
+// isExternalReplace returns whether rep replaces a module of another
+// repository that falls under one of the prefixes of rc.ExternalReplaces.
+func (rc RunConfig) isExternalReplace(rep *modfile.Replace) bool {
+	_, ok := rc.externalDir(rep.Old.Path)
+	return ok
+}
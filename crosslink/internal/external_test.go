@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosslink
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/mod/modfile"
+)
+
+func TestReadExternalReplaces(t *testing.T) {
+	dir := t.TempDir()
+	abs := filepath.Join(t.TempDir(), "contrib")
+	configFile := filepath.Join(dir, ConfigFileName)
+	require.NoError(t, os.WriteFile(configFile, []byte("external_replaces:\n"+
+		"  go.opentelemetry.io/collector/: ../opentelemetry-collector\n"+
+		"  github.com/open-telemetry/opentelemetry-collector-contrib: "+filepath.ToSlash(abs)+"\n"), 0600))
+
+	replaces, err := ReadExternalReplaces(configFile, false)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"go.opentelemetry.io/collector":                             filepath.Join(filepath.Dir(dir), "opentelemetry-collector"),
+		"github.com/open-telemetry/opentelemetry-collector-contrib": abs,
+	}, replaces)
+
+	replaces, err = ReadExternalReplaces(filepath.Join(dir, "missing.yaml"), true)
+	assert.NoError(t, err)
+	assert.Empty(t, replaces)
+
+	_, err = ReadExternalReplaces(filepath.Join(dir, "missing.yaml"), false)
+	assert.Error(t, err)
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte("external_replaces:\n  example.com/a: \"\"\n"), 0600))
+	_, err = ReadExternalReplaces(invalid, false)
+	assert.Error(t, err)
+}
+
+func TestExternalDir(t *testing.T) {
+	rc := RunConfig{ExternalReplaces: map[string]string{
+		"go.opentelemetry.io/collector":       filepath.FromSlash("/src/collector"),
+		"go.opentelemetry.io/collector/pdata": filepath.FromSlash("/src/pdata"),
+	}}
+
+	for modPath, expected := range map[string]string{
+		"go.opentelemetry.io/collector":           "/src/collector",
+		"go.opentelemetry.io/collector/component": "/src/collector/component",
+		"go.opentelemetry.io/collector/pdata":     "/src/pdata",
+		"go.opentelemetry.io/collector/pdata/v2":  "/src/pdata/v2",
+		"go.opentelemetry.io/collector-contrib":   "",
+		"example.com/other":                       "",
+	} {
+		dir, ok := rc.externalDir(modPath)
+		assert.Equal(t, expected != "", ok, modPath)
+		if ok {
+			assert.Equal(t, filepath.FromSlash(expected), dir, modPath)
+		}
+	}
+}
+
+func TestCrosslinkExternal(t *testing.T) {
+	lg, _ := zap.NewDevelopment()
+
+	// The local checkout of the other repository lives outside of the
+	// repository, as is the case for side by side checkouts.
+	externalDir := t.TempDir()
+	for dir, modPath := range map[string]string{
+		"":     "example.com/external",
+		"modA": "example.com/external/modA",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(externalDir, dir), 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(externalDir, dir, "go.mod"), []byte("module "+modPath+"\n\ngo 1.18\n"), 0600))
+	}
+
+	tests := []struct {
+		testName string
+		config   RunConfig
+		// expected maps go.mod files to their replace statements, with the
+		// directories of external modules relative to externalDir.
+		expected map[string]map[string]string
+	}{
+		{
+			testName: "insert",
+			config:   RunConfig{Logger: lg},
+			expected: map[string]map[string]string{
+				"go.mod": {
+					"example.com/external":                                     "$EXTERNAL",
+					"example.com/external/old":                                 "../external/old",
+					"go.opentelemetry.io/build-tools/crosslink/testroot/testA": "./testA",
+					"go.opentelemetry.io/build-tools/crosslink/testroot/testB": "./testB",
+				},
+				filepath.Join("testA", "go.mod"): {
+					"example.com/external/modA":                                "../../old/modA",
+					"go.opentelemetry.io/build-tools/crosslink/testroot/testB": "../testB",
+				},
+			},
+		},
+		{
+			testName: "overwrite and prune",
+			config:   RunConfig{Logger: lg, Overwrite: true, Prune: true},
+			expected: map[string]map[string]string{
+				"go.mod": {
+					"example.com/external": "$EXTERNAL",
+					"go.opentelemetry.io/build-tools/crosslink/testroot/testA": "./testA",
+					"go.opentelemetry.io/build-tools/crosslink/testroot/testB": "./testB",
+				},
+				filepath.Join("testA", "go.mod"): {
+					"example.com/external/modA":                                "$EXTERNAL/modA",
+					"go.opentelemetry.io/build-tools/crosslink/testroot/testB": "../testB",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			tmpRootDir, err := createTempTestDir("testExternal")
+			require.NoError(t, err)
+			t.Cleanup(func() { os.RemoveAll(tmpRootDir) })
+			require.NoError(t, renameGoMod(tmpRootDir))
+
+			test.config.RootPath = tmpRootDir
+			test.config.ExternalReplaces = map[string]string{"example.com/external": externalDir}
+			require.NoError(t, Crosslink(test.config))
+
+			for modFilePath, expected := range test.expected {
+				modDir := filepath.Dir(filepath.Join(tmpRootDir, modFilePath))
+				rel, err := filepath.Rel(modDir, externalDir)
+				require.NoError(t, err)
+
+				data, err := os.ReadFile(filepath.Join(tmpRootDir, modFilePath))
+				require.NoError(t, err)
+				modFile, err := modfile.Parse(modFilePath, data, nil)
+				require.NoError(t, err)
+
+				actual := make(map[string]string)
+				for _, rep := range modFile.Replace {
+					actual[rep.Old.Path] = rep.New.Path
+				}
+				for old, path := range expected {
+					if strings.HasPrefix(path, "$EXTERNAL") {
+						expected[old] = filepath.ToSlash(rel) + strings.TrimPrefix(path, "$EXTERNAL")
+					}
+				}
+				assert.Equal(t, expected, actual, modFilePath)
+			}
+		})
+	}
+}
@@ -63,7 +63,10 @@ func buildDepedencyGraph(rc RunConfig, rootModulePath string) (map[string]*modul
 		return nil, fmt.Errorf("failed during file walk: %w", err)
 	}
 
-	for _, modInfo := range moduleMap {
+	// the requirements of each module are resolved concurrently, moduleMap is
+	// only read from here on.
+	forEachModule(rc, sortedModules(moduleMap), func(rc RunConfig, moduleName string) {
+		modInfo := moduleMap[moduleName]
 		// reqStack contains a list of module paths that are required to have local replace statements
 		// reqStack should only contain intra-repository modules
 		reqStack := make([]string, 0)
@@ -79,14 +82,18 @@ func buildDepedencyGraph(rc RunConfig, rootModulePath string) (map[string]*modul
 		//		- Crosslink will not make an assumption that a module exists even though it falls under the module path.
 		// 2. They fall under the module path of the root module
 		// 3. They are not the same module that we are currently working with.
+		// Requirements on upstream modules aliased to a fork are checked against the fork module path.
 		for _, req := range modContents.Require {
-			if _, existsInPath := moduleMap[req.Mod.Path]; strings.Contains(req.Mod.Path, rootModulePath) &&
-				req.Mod.Path != modContents.Module.Mod.Path && existsInPath {
+			modPath := rc.aliasedPath(req.Mod.Path)
+			if _, existsInPath := moduleMap[modPath]; strings.Contains(modPath, rootModulePath) &&
+				modPath != modContents.Module.Mod.Path && existsInPath {
 				reqStack = append(reqStack, req.Mod.Path)
 				alreadyInsertedRepSet[req.Mod.Path] = struct{}{}
 			}
 		}
 
+		addExternalRequirements(rc, modInfo, moduleMap)
+
 		// iterate through stack adding replace directives and transitive requirements as needed
 		// if the replace directive already exists for the module path then ensure that it is pointing to the correct location
 		for len(reqStack) > 0 {
@@ -96,13 +103,14 @@ func buildDepedencyGraph(rc RunConfig, rootModulePath string) (map[string]*modul
 
 			// now find all transitive dependencies for the current required module. Only add to stack if they
 			// have not already been added and they are not the current module we are working in.
-			if value, ok := moduleMap[reqModule]; ok {
+			if value, ok := moduleMap[rc.aliasedPath(reqModule)]; ok {
 				m := value.moduleContents
 				for _, transReq := range m.Require {
-					_, existsInPath := moduleMap[transReq.Mod.Path]
+					transModPath := rc.aliasedPath(transReq.Mod.Path)
+					_, existsInPath := moduleMap[transModPath]
 					_, alreadyInserted := alreadyInsertedRepSet[transReq.Mod.Path]
-					if transReq.Mod.Path != modContents.Module.Mod.Path &&
-						strings.Contains(transReq.Mod.Path, rootModulePath) &&
+					if transModPath != modContents.Module.Mod.Path &&
+						strings.Contains(transModPath, rootModulePath) &&
 						!alreadyInserted && existsInPath {
 						reqStack = append(reqStack, transReq.Mod.Path)
 						alreadyInsertedRepSet[transReq.Mod.Path] = struct{}{}
@@ -111,6 +119,6 @@ func buildDepedencyGraph(rc RunConfig, rootModulePath string) (map[string]*modul
 			}
 
 		}
-	}
+	})
 	return moduleMap, nil
 }
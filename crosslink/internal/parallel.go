@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosslink
+
+import (
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// sortedModules returns the module paths of graph in lexical order.
+func sortedModules(graph map[string]*moduleInfo) []string {
+	names := make([]string, 0, len(graph))
+	for name := range graph {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// forEachModule calls fn for each of names using up to rc.Jobs concurrent
+// workers. The run config passed to fn logs to a buffer, and the buffered
+// entries are written to rc.Logger in the order of names once all calls
+// returned, so the output does not depend on scheduling. fn must only modify
+// the module it is called for.
+func forEachModule(rc RunConfig, names []string, fn func(rc RunConfig, name string)) {
+	if rc.Jobs < 2 || len(names) < 2 {
+		for _, name := range names {
+			fn(rc, name)
+		}
+		return
+	}
+
+	logs := make([]*bufferCore, len(names))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < rc.Jobs && w < len(names); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				buffer := newBufferCore(rc.Logger.Core())
+				workerRC := rc
+				workerRC.Logger = zap.New(buffer, zap.AddCaller())
+				fn(workerRC, names[i])
+				logs[i] = buffer
+			}
+		}()
+	}
+	for i := range names {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	core := rc.Logger.Core()
+	for _, buffer := range logs {
+		buffer.replay(core)
+	}
+}
+
+// bufferedEntry is a log entry held by a bufferCore, with all its fields.
+type bufferedEntry struct {
+	entry  zapcore.Entry
+	fields []zapcore.Field
+}
+
+// bufferCore is a zapcore.Core holding the entries enabled by its
+// LevelEnabler in memory, to be replayed to another core later. The cores
+// returned by With share the buffer.
+type bufferCore struct {
+	zapcore.LevelEnabler
+	context []zapcore.Field
+
+	mu      *sync.Mutex
+	entries *[]bufferedEntry
+}
+
+func newBufferCore(enab zapcore.LevelEnabler) *bufferCore {
+	return &bufferCore{
+		LevelEnabler: enab,
+		mu:           &sync.Mutex{},
+		entries:      &[]bufferedEntry{},
+	}
+}
+
+func (c *bufferCore) With(fields []zapcore.Field) zapcore.Core {
+	context := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	context = append(context, c.context...)
+	return &bufferCore{
+		LevelEnabler: c.LevelEnabler,
+		context:      append(context, fields...),
+		mu:           c.mu,
+		entries:      c.entries,
+	}
+}
+
+func (c *bufferCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(e.Level) {
+		return ce.AddCore(e, c)
+	}
+	return ce
+}
+
+func (c *bufferCore) Write(e zapcore.Entry, fields []zapcore.Field) error {
+	all := make([]zapcore.Field, 0, len(c.context)+len(fields))
+	all = append(all, c.context...)
+	all = append(all, fields...)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	*c.entries = append(*c.entries, bufferedEntry{entry: e, fields: all})
+	return nil
+}
+
+func (c *bufferCore) Sync() error {
+	return nil
+}
+
+// replay writes the buffered entries to core, in the order they were logged.
+func (c *bufferCore) replay(core zapcore.Core) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, e := range *c.entries {
+		if ce := core.Check(e.entry, nil); ce != nil {
+			ce.Write(e.fields...)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosslink
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestForEachModule(t *testing.T) {
+	names := make([]string, 20)
+	for i := range names {
+		names[i] = fmt.Sprintf("example.com/mod%02d", i)
+	}
+
+	for _, jobs := range []int{0, 1, 4, 50} {
+		t.Run(fmt.Sprintf("jobs=%d", jobs), func(t *testing.T) {
+			core, observed := observer.New(zap.DebugLevel)
+			rc := RunConfig{Jobs: jobs, Logger: zap.New(core)}
+
+			var calls int32
+			forEachModule(rc, names, func(rc RunConfig, name string) {
+				atomic.AddInt32(&calls, 1)
+				rc.Logger.Debug("first", zap.String("module", name))
+				rc.Logger.With(zap.String("module", name)).Info("second")
+			})
+
+			assert.Equal(t, int32(len(names)), calls)
+			var logged []string
+			for _, e := range observed.All() {
+				logged = append(logged, e.ContextMap()["module"].(string)+" "+e.Message)
+			}
+			var expected []string
+			for _, name := range names {
+				expected = append(expected, name+" first", name+" second")
+			}
+			assert.Equal(t, expected, logged)
+		})
+	}
+}
+
+func TestBufferCore(t *testing.T) {
+	core, observed := observer.New(zap.InfoLevel)
+	buffer := newBufferCore(core)
+	logger := zap.New(buffer).With(zap.String("module", "example.com/mod"))
+
+	logger.Debug("not enabled")
+	logger.Info("first", zap.Int("n", 1))
+	logger.Warn("second")
+	assert.Zero(t, observed.Len(), "entries are only written when replayed")
+
+	buffer.replay(core)
+	entries := observed.AllUntimed()
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, "first", entries[0].Message)
+		assert.Equal(t, map[string]interface{}{"module": "example.com/mod", "n": int64(1)}, entries[0].ContextMap())
+		assert.Equal(t, "second", entries[1].Message)
+	}
+}
@@ -37,16 +37,17 @@ func Prune(rc RunConfig) error {
 		return fmt.Errorf("failed to build dependency graph: %w", err)
 	}
 
-	for moduleName, moduleInfo := range graph {
+	forEachModule(rc, sortedModules(graph), func(rc RunConfig, moduleName string) {
+		moduleInfo := graph[moduleName]
 		pruneReplace(rootModulePath, moduleInfo, rc)
 		logger := rc.Logger.With(zap.String("module", moduleName))
 
-		err = writeModule(moduleInfo)
+		err := writeModule(moduleInfo)
 		if err != nil {
 			logger.Error("Failed to write module",
 				zap.Error(err))
 		}
-	}
+	})
 	return nil
 }
 
@@ -64,7 +65,19 @@ func pruneReplace(rootModulePath string, module *moduleInfo, rc RunConfig) {
 			continue
 		}
 
-		if _, ok := module.requiredReplaceStatements[rep.Old.Path]; strings.Contains(rep.Old.Path, rootModulePath) && !ok {
+		// replace statements of modules of other repositories with a local
+		// checkout are pruned like intra-repository ones.
+		var required bool
+		switch {
+		case rc.isExternalReplace(rep):
+			_, required = module.requiredExternalReplaces[rep.Old.Path]
+		case strings.Contains(rc.aliasedPath(rep.Old.Path), rootModulePath):
+			_, required = module.requiredReplaceStatements[rep.Old.Path]
+		default:
+			required = true
+		}
+
+		if !required {
 			if rc.Verbose {
 				rc.Logger.Debug("Pruning replace statement",
 					zap.String("module", modContents.Module.Mod.Path),
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosslink
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/build-tools/internal/modset"
+)
+
+// Actions taken by reconcile for a version-pinned replace statement.
+const (
+	reconcileConverted = "converted"
+	reconcileUpdated   = "updated"
+	reconcileUnchanged = "unchanged"
+)
+
+// reconcileDecision records how a replace statement pinning an
+// intra-repository module to a version was reconciled.
+type reconcileDecision struct {
+	Module  string
+	Replace string
+	From    string
+	To      string
+	Action  string
+}
+
+// Reconcile is the main entry point for the reconcile subcommand. It finds
+// replace statements that pin intra-repository modules to a version and either
+// converts them back to local path replace statements or, when a versioning
+// file is configured, updates the pinned versions to match it.
+func Reconcile(rc RunConfig) error {
+	rc.Logger.Debug("Crosslink run config", zap.Any("run_config", rc))
+
+	rootModulePath, err := identifyRootModule(rc.RootPath)
+	if err != nil {
+		return fmt.Errorf("failed to identify root module: %w", err)
+	}
+
+	graph, err := buildDepedencyGraph(rc, rootModulePath)
+	if err != nil {
+		return fmt.Errorf("failed to build dependency graph: %w", err)
+	}
+
+	var versions map[string]string
+	if rc.VersioningFile != "" {
+		versions, err = readModuleVersions(rc.VersioningFile)
+		if err != nil {
+			return fmt.Errorf("failed to read versioning file: %w", err)
+		}
+	}
+
+	for moduleName, moduleInfo := range graph {
+		logger := rc.Logger.With(zap.String("module", moduleName))
+
+		decisions, err := reconcileReplace(rootModulePath, graph, moduleInfo, versions, rc)
+		if err != nil {
+			logger.Error("Failed to reconcile replace statements",
+				zap.Error(err))
+			continue
+		}
+
+		changed := false
+		for _, d := range decisions {
+			logger.Info("Reconciled version-pinned replace statement",
+				zap.String("replace", d.Replace),
+				zap.String("from", d.From),
+				zap.String("to", d.To),
+				zap.String("action", d.Action))
+			changed = changed || d.Action != reconcileUnchanged
+		}
+		if !changed {
+			continue
+		}
+
+		err = writeModule(moduleInfo)
+		if err != nil {
+			logger.Error("Failed to write module",
+				zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// reconcileReplace reconciles the replace statements of module that pin an
+// intra-repository module to a version. Modules with a version in versions
+// have their pin updated, all others are converted to a local path replace.
+func reconcileReplace(rootModulePath string, graph map[string]*moduleInfo, module *moduleInfo, versions map[string]string, rc RunConfig) ([]reconcileDecision, error) {
+	modContents := module.moduleContents
+
+	var decisions []reconcileDecision
+	for _, rep := range modContents.Replace {
+		if rep.New.Version == "" {
+			// Already a local path replace statement.
+			continue
+		}
+		if _, exists := graph[rep.Old.Path]; !exists || !strings.Contains(rep.Old.Path, rootModulePath) {
+			continue
+		}
+		if _, exists := rc.ExcludedPaths[rep.Old.Path]; exists {
+			rc.Logger.Debug("Excluded Module, ignoring reconcile", zap.String("excluded_mod", rep.Old.Path))
+			continue
+		}
+
+		d := reconcileDecision{
+			Module:  modContents.Module.Mod.Path,
+			Replace: rep.Old.Path,
+			From:    rep.New.Path + " " + rep.New.Version,
+		}
+
+		newPath, newVersion := rep.New.Path, versions[rep.New.Path]
+		switch {
+		case newVersion == rep.New.Version:
+			d.Action = reconcileUnchanged
+		case newVersion != "":
+			d.Action = reconcileUpdated
+		default:
+			localPath, err := localReplacePath(modContents.Module.Mod.Path, rep.Old.Path)
+			if err != nil {
+				return nil, err
+			}
+			newPath = localPath
+			d.Action = reconcileConverted
+		}
+		d.To = strings.TrimSpace(newPath + " " + newVersion)
+		decisions = append(decisions, d)
+
+		if d.Action == reconcileUnchanged {
+			continue
+		}
+		if err := modContents.AddReplace(rep.Old.Path, rep.Old.Version, newPath, newVersion); err != nil {
+			return nil, fmt.Errorf("failed to update replace statement for %s: %w", rep.Old.Path, err)
+		}
+	}
+	module.moduleContents = modContents
+
+	sort.Slice(decisions, func(i, j int) bool { return decisions[i].Replace < decisions[j].Replace })
+	return decisions, nil
+}
+
+// readModuleVersions returns the version of every module listed in a
+// multimod versioning file.
+func readModuleVersions(versioningFile string) (map[string]string, error) {
+	return modset.ModuleVersions(versioningFile)
+}
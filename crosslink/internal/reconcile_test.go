@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosslink
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+const testRoot = "go.opentelemetry.io/build-tools/crosslink/testroot"
+
+func TestReconcile(t *testing.T) {
+	lg, _ := zap.NewDevelopment()
+
+	tests := []struct {
+		testName       string
+		versioningFile string
+		expected       map[string]map[string]module.Version
+	}{
+		{
+			testName: "convert to local path",
+			expected: map[string]map[string]module.Version{
+				"go.mod": {
+					testRoot + "/testA":         {Path: "./testA"},
+					testRoot + "/testB":         {Path: "./testB"},
+					"go.opentelemetry.io/other": {Path: "go.opentelemetry.io/other", Version: "v1.0.0"},
+				},
+				filepath.Join("testA", "go.mod"): {
+					testRoot + "/testB": {Path: "../testB"},
+				},
+				filepath.Join("testB", "go.mod"): {
+					testRoot + "/testA": {Path: "../testA"},
+				},
+			},
+		},
+		{
+			testName:       "update pins from versioning file",
+			versioningFile: "versions.yaml",
+			expected: map[string]map[string]module.Version{
+				"go.mod": {
+					testRoot + "/testA":         {Path: testRoot + "/testA", Version: "v1.2.0"},
+					testRoot + "/testB":         {Path: "./testB"},
+					"go.opentelemetry.io/other": {Path: "go.opentelemetry.io/other", Version: "v1.0.0"},
+				},
+				filepath.Join("testA", "go.mod"): {
+					testRoot + "/testB": {Path: "../testB"},
+				},
+				filepath.Join("testB", "go.mod"): {
+					testRoot + "/testA": {Path: testRoot + "/testA", Version: "v1.2.0"},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			tmpRootDir, err := createTempTestDir("testReconcile")
+			require.NoError(t, err, "creating temp dir")
+			t.Cleanup(func() { os.RemoveAll(tmpRootDir) })
+			require.NoError(t, renameGoMod(tmpRootDir))
+
+			rc := RunConfig{RootPath: tmpRootDir, Logger: lg}
+			if test.versioningFile != "" {
+				rc.VersioningFile = filepath.Join(tmpRootDir, test.versioningFile)
+			}
+			require.NoError(t, Reconcile(rc))
+
+			for modFilePath, expected := range test.expected {
+				modContents, err := os.ReadFile(filepath.Clean(filepath.Join(tmpRootDir, modFilePath)))
+				require.NoError(t, err)
+				modFile, err := modfile.Parse(modFilePath, modContents, nil)
+				require.NoError(t, err)
+
+				actual := make(map[string]module.Version)
+				for _, rep := range modFile.Replace {
+					actual[rep.Old.Path] = rep.New
+				}
+				assert.Equal(t, expected, actual, modFilePath)
+			}
+		})
+	}
+}
+
+func TestReconcileReplaceDecisions(t *testing.T) {
+	lg, _ := zap.NewDevelopment()
+	modContents := []byte("module " + testRoot + "\n\n" +
+		"go 1.18\n\n" +
+		"replace " + testRoot + "/testA => " + testRoot + "/testA v1.2.0\n\n" +
+		"replace " + testRoot + "/testB => " + testRoot + "/testB v0.9.0\n\n" +
+		"replace " + testRoot + "/testC => " + testRoot + "/testC v1.0.0\n\n" +
+		"replace " + testRoot + "/testD => " + testRoot + "/testD v1.0.0\n\n" +
+		"replace " + testRoot + "/testE => ./testE\n")
+	modFile, err := modfile.Parse("go.mod", modContents, nil)
+	require.NoError(t, err)
+
+	graph := map[string]*moduleInfo{
+		testRoot:            newModuleInfo(*modFile),
+		testRoot + "/testA": nil,
+		testRoot + "/testB": nil,
+		testRoot + "/testC": nil,
+		testRoot + "/testD": nil,
+		testRoot + "/testE": nil,
+	}
+	versions := map[string]string{
+		testRoot + "/testA": "v1.2.0",
+		testRoot + "/testB": "v1.2.0",
+	}
+	rc := RunConfig{
+		Logger:        lg,
+		ExcludedPaths: map[string]struct{}{testRoot + "/testD": {}},
+	}
+
+	decisions, err := reconcileReplace(testRoot, graph, graph[testRoot], versions, rc)
+	require.NoError(t, err)
+	assert.Equal(t, []reconcileDecision{
+		{Module: testRoot, Replace: testRoot + "/testA", From: testRoot + "/testA v1.2.0", To: testRoot + "/testA v1.2.0", Action: reconcileUnchanged},
+		{Module: testRoot, Replace: testRoot + "/testB", From: testRoot + "/testB v0.9.0", To: testRoot + "/testB v1.2.0", Action: reconcileUpdated},
+		{Module: testRoot, Replace: testRoot + "/testC", From: testRoot + "/testC v1.0.0", To: "./testC", Action: reconcileConverted},
+	}, decisions)
+}
+
+func TestReadModuleVersions(t *testing.T) {
+	versions, err := readModuleVersions(filepath.Join(mockDataDir, "testReconcile", "versions.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{testRoot + "/testA": "v1.2.0"}, versions)
+
+	_, err = readModuleVersions(filepath.Join(mockDataDir, "testReconcile", "missing.yaml"))
+	assert.Error(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "versions.yaml"), []byte("module-sets:\n"+
+		"  stable:\n"+
+		"    version: v1.2.0\n"+
+		"    modules:\n"+
+		"      - "+testRoot+"/testA\n"+
+		"      - "+testRoot+"/testB\n"+
+		"    version-overrides:\n"+
+		"      - module: "+testRoot+"/testB\n"+
+		"        version: v1.1.0\n"+
+		"include:\n"+
+		"  - contrib.yaml\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "contrib.yaml"), []byte("module-sets:\n"+
+		"  contrib:\n"+
+		"    version: v0.3.0\n"+
+		"    modules:\n"+
+		"      - "+testRoot+"/testC\n"), 0600))
+
+	versions, err = readModuleVersions(filepath.Join(dir, "versions.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		testRoot + "/testA": "v1.2.0",
+		testRoot + "/testB": "v1.1.0",
+		testRoot + "/testC": "v0.3.0",
+	}, versions)
+}
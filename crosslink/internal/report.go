@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosslink
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// Report formats.
+const (
+	ReportJSON = "json"
+)
+
+// moduleReport holds the replace statements crosslink added to, updated in
+// and removed from a go.mod file.
+type moduleReport struct {
+	Path    string          `json:"path"`
+	Module  string          `json:"module"`
+	Added   []replaceChange `json:"added,omitempty"`
+	Updated []replaceChange `json:"updated,omitempty"`
+	Removed []replaceChange `json:"removed,omitempty"`
+}
+
+// replaceChange is a replace statement of Module, at Version if it is not
+// empty, whose replacement changed from Old to New. Old is empty for added
+// statements and New for removed ones.
+type replaceChange struct {
+	Module  string `json:"module"`
+	Version string `json:"version,omitempty"`
+	Old     string `json:"old,omitempty"`
+	New     string `json:"new,omitempty"`
+}
+
+func (r moduleReport) changed() bool {
+	return len(r.Added) > 0 || len(r.Updated) > 0 || len(r.Removed) > 0
+}
+
+// Report updates the go.mod files of the repository like Crosslink and writes
+// to w a JSON array with the replace statements added, updated and removed
+// in each changed go.mod file. If check is set, the go.mod files are not
+// modified and ErrOutOfDate is returned if any would change, like Check.
+func Report(rc RunConfig, w io.Writer, check bool) error {
+	var (
+		mu      sync.Mutex
+		reports = []moduleReport{}
+	)
+	write := func(module *moduleInfo) error {
+		r, err := reportModule(rc.RootPath, module)
+		if err != nil {
+			return err
+		}
+		if !check {
+			if err = writeModule(module); err != nil {
+				return err
+			}
+		}
+		if r.changed() {
+			mu.Lock()
+			reports = append(reports, r)
+			mu.Unlock()
+		}
+		return nil
+	}
+
+	var err error
+	switch {
+	case rc.Tidy && !check:
+		err = crosslinkAndTidy(rc, write, goModTidy)
+	default:
+		err = crosslink(rc, write)
+	}
+
+	// The report is written even if tidying failed, as the go.mod files
+	// were changed.
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Path < reports[j].Path })
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if encErr := enc.Encode(reports); encErr != nil {
+		return fmt.Errorf("failed to write report: %w", encErr)
+	}
+	if err != nil {
+		return err
+	}
+	if check && len(reports) > 0 {
+		return fmt.Errorf("%w (%d files)", ErrOutOfDate, len(reports))
+	}
+	return nil
+}
+
+// reportModule compares the replace statements of the go.mod file of module
+// on disk with those of its updated contents. The path of the go.mod file is
+// reported relative to root.
+func reportModule(root string, module *moduleInfo) (moduleReport, error) {
+	path := module.moduleContents.Syntax.Name
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return moduleReport{}, fmt.Errorf("failed to read go.mod file: %w", err)
+	}
+	current, err := modfile.Parse(path, data, nil)
+	if err != nil {
+		return moduleReport{}, fmt.Errorf("failed to parse go.mod file: %w", err)
+	}
+
+	if rel, err := filepath.Rel(root, path); err == nil {
+		path = rel
+	}
+	r := moduleReport{
+		Path:   filepath.ToSlash(path),
+		Module: module.moduleContents.Module.Mod.Path,
+	}
+
+	before := replacements(current.Replace)
+	after := replacements(module.moduleContents.Replace)
+	for _, rep := range module.moduleContents.Replace {
+		key := rep.Old
+		old, exists := before[key]
+		switch {
+		case !exists:
+			r.Added = append(r.Added, replaceChange{Module: key.Path, Version: key.Version, New: after[key]})
+		case old != after[key]:
+			r.Updated = append(r.Updated, replaceChange{Module: key.Path, Version: key.Version, Old: old, New: after[key]})
+		}
+	}
+	for _, rep := range current.Replace {
+		key := rep.Old
+		if _, exists := after[key]; !exists {
+			r.Removed = append(r.Removed, replaceChange{Module: key.Path, Version: key.Version, Old: before[key]})
+		}
+	}
+
+	for _, changes := range [][]replaceChange{r.Added, r.Updated, r.Removed} {
+		sort.Slice(changes, func(i, j int) bool {
+			if changes[i].Module != changes[j].Module {
+				return changes[i].Module < changes[j].Module
+			}
+			return changes[i].Version < changes[j].Version
+		})
+	}
+	return r, nil
+}
+
+// replacements maps the replaced module versions of replaces to their
+// replacement, e.g. "../foo" or "example.com/foo v1.2.3".
+func replacements(replaces []*modfile.Replace) map[module.Version]string {
+	m := make(map[module.Version]string, len(replaces))
+	for _, rep := range replaces {
+		replacement := rep.New.Path
+		if rep.New.Version != "" {
+			replacement += " " + rep.New.Version
+		}
+		m[rep.Old] = replacement
+	}
+	return m
+}
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosslink
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestReport(t *testing.T) {
+	lg, _ := zap.NewDevelopment()
+
+	tmpRootDir, err := createTempTestDir("testOverwrite")
+	require.NoError(t, err, "creating temp dir")
+	t.Cleanup(func() { os.RemoveAll(tmpRootDir) })
+	require.NoError(t, renameGoMod(tmpRootDir), "renaming gomod files")
+
+	rc := RunConfig{
+		RootPath:      tmpRootDir,
+		ExcludedPaths: map[string]struct{}{},
+		Overwrite:     true,
+		Logger:        lg,
+	}
+
+	expected := []moduleReport{
+		{
+			Path:   "go.mod",
+			Module: "go.opentelemetry.io/build-tools/crosslink/testroot",
+			Added: []replaceChange{
+				{Module: "go.opentelemetry.io/build-tools/crosslink/testroot/testB", New: "./testB"},
+			},
+			Updated: []replaceChange{
+				{Module: "go.opentelemetry.io/build-tools/crosslink/testroot/testA", Old: "../testA", New: "./testA"},
+			},
+		},
+		{
+			Path:   "testA/go.mod",
+			Module: "go.opentelemetry.io/build-tools/crosslink/testroot/testA",
+			Added: []replaceChange{
+				{Module: "go.opentelemetry.io/build-tools/crosslink/testroot/testB", New: "../testB"},
+			},
+		},
+	}
+
+	rootModFile := filepath.Join(tmpRootDir, "go.mod")
+	before, err := os.ReadFile(rootModFile)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.ErrorIs(t, Report(rc, &buf, true), ErrOutOfDate)
+	var actual []moduleReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &actual))
+	assert.Equal(t, expected, actual)
+
+	after, err := os.ReadFile(rootModFile)
+	require.NoError(t, err)
+	assert.Equal(t, before, after, "check must not modify go.mod files")
+
+	buf.Reset()
+	require.NoError(t, Report(rc, &buf, false))
+	actual = nil
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &actual))
+	assert.Equal(t, expected, actual)
+
+	after, err = os.ReadFile(rootModFile)
+	require.NoError(t, err)
+	assert.NotEqual(t, before, after, "go.mod files are updated")
+
+	buf.Reset()
+	require.NoError(t, Report(rc, &buf, true))
+	assert.Equal(t, "[]\n", buf.String())
+}
+
+func TestReportPrune(t *testing.T) {
+	lg, _ := zap.NewDevelopment()
+
+	tmpRootDir, err := createTempTestDir("testSimple")
+	require.NoError(t, err, "creating temp dir")
+	t.Cleanup(func() { os.RemoveAll(tmpRootDir) })
+	require.NoError(t, renameGoMod(tmpRootDir), "renaming gomod files")
+
+	rc := RunConfig{
+		RootPath:      tmpRootDir,
+		ExcludedPaths: map[string]struct{}{},
+		Prune:         true,
+		Logger:        lg,
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Report(rc, &buf, false))
+	var actual []moduleReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &actual))
+	require.Len(t, actual, 2)
+	assert.Equal(t, "go.mod", actual[0].Path)
+	assert.Equal(t, []replaceChange{
+		{Module: "go.opentelemetry.io/build-tools/crosslink/testroot/testY", Old: "./testY"},
+		{Module: "go.opentelemetry.io/build-tools/crosslink/testroot/testZ", Old: "./testZ"},
+	}, actual[0].Removed)
+}
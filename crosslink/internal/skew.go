@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosslink
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"go.uber.org/zap"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// versionSkew is a requirement of an intra-repository module on a version of
+// another intra-repository module older than its latest released version.
+type versionSkew struct {
+	Module     string
+	Dependency string
+	Required   string
+	Latest     string
+}
+
+// listTags returns the git tags of the repository at rootPath. It is a
+// variable so tests can avoid creating a git repository.
+var listTags = func(rootPath string) ([]string, error) {
+	cmd := exec.Command("git", "tag", "--list")
+	cmd.Dir = rootPath
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list git tags: %w", err)
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// Skew is the main entry point for the skew subcommand. It writes a report to
+// w of the intra-repository modules that require a version of another
+// intra-repository module older than its latest released version. Latest
+// versions are read from the versioning file if one is configured, or from the
+// git tags of the repository otherwise.
+func Skew(rc RunConfig, w io.Writer) error {
+	rc.Logger.Debug("Crosslink run config", zap.Any("run_config", rc))
+
+	rootModulePath, err := identifyRootModule(rc.RootPath)
+	if err != nil {
+		return fmt.Errorf("failed to identify root module: %w", err)
+	}
+
+	graph, err := buildDepedencyGraph(rc, rootModulePath)
+	if err != nil {
+		return fmt.Errorf("failed to build dependency graph: %w", err)
+	}
+
+	var latest map[string]string
+	if rc.VersioningFile != "" {
+		latest, err = readModuleVersions(rc.VersioningFile)
+		if err != nil {
+			return fmt.Errorf("failed to read versioning file: %w", err)
+		}
+	} else {
+		tags, err := listTags(rc.RootPath)
+		if err != nil {
+			return err
+		}
+		latest, err = latestTaggedVersions(rc.RootPath, graph, tags)
+		if err != nil {
+			return err
+		}
+	}
+
+	skews := findSkew(rootModulePath, graph, latest, rc)
+	if len(skews) == 0 {
+		rc.Logger.Info("No intra-repository version skew found")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "MODULE\tDEPENDENCY\tREQUIRED\tLATEST")
+	for _, s := range skews {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", s.Module, s.Dependency, s.Required, s.Latest)
+	}
+	return tw.Flush()
+}
+
+// findSkew returns the requirements of the modules in graph on other modules
+// of graph whose version is older than the latest one, ordered by module and
+// dependency.
+func findSkew(rootModulePath string, graph map[string]*moduleInfo, latest map[string]string, rc RunConfig) []versionSkew {
+	var skews []versionSkew
+	for modName, modInfo := range graph {
+		for _, req := range modInfo.moduleContents.Require {
+			depName := req.Mod.Path
+			if _, exists := graph[depName]; !exists || !strings.Contains(depName, rootModulePath) || depName == modName {
+				continue
+			}
+			if _, exists := rc.ExcludedPaths[depName]; exists {
+				rc.Logger.Debug("Excluded Module, ignoring skew", zap.String("excluded_mod", depName))
+				continue
+			}
+			latestVersion, ok := latest[depName]
+			if !ok {
+				rc.Logger.Debug("No released version found", zap.String("module", depName))
+				continue
+			}
+			if semver.Compare(req.Mod.Version, latestVersion) < 0 {
+				skews = append(skews, versionSkew{
+					Module:     modName,
+					Dependency: depName,
+					Required:   req.Mod.Version,
+					Latest:     latestVersion,
+				})
+			}
+		}
+	}
+
+	sort.Slice(skews, func(i, j int) bool {
+		if skews[i].Module != skews[j].Module {
+			return skews[i].Module < skews[j].Module
+		}
+		return skews[i].Dependency < skews[j].Dependency
+	})
+	return skews
+}
+
+// latestTaggedVersions returns the latest version of every module in graph
+// that has a tag. The tags of a module are prefixed with its directory
+// relative to rootPath, e.g. "sdk/metric/v0.1.0", and must match the major
+// version suffix of its path. Releases are preferred over pre-releases.
+func latestTaggedVersions(rootPath string, graph map[string]*moduleInfo, tags []string) (map[string]string, error) {
+	prefixes := make(map[string]string, len(graph))
+	for modName, modInfo := range graph {
+		rel, err := filepath.Rel(rootPath, filepath.Dir(modInfo.moduleContents.Syntax.Name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get directory of %s: %w", modName, err)
+		}
+		prefix := ""
+		if rel != "." {
+			prefix = filepath.ToSlash(rel) + "/"
+		}
+		prefixes[modName] = prefix
+	}
+
+	latest := make(map[string]string)
+	for modName, prefix := range prefixes {
+		_, pathMajor, _ := module.SplitPathVersion(modName)
+		var release, prerelease string
+		for _, tag := range tags {
+			version := strings.TrimPrefix(tag, prefix)
+			if version == tag && prefix != "" {
+				continue
+			}
+			if !semver.IsValid(version) || semver.Canonical(version) != version || module.CheckPathMajor(version, pathMajor) != nil {
+				continue
+			}
+			if semver.Prerelease(version) == "" {
+				if release == "" || semver.Compare(version, release) > 0 {
+					release = version
+				}
+			} else if prerelease == "" || semver.Compare(version, prerelease) > 0 {
+				prerelease = version
+			}
+		}
+		switch {
+		case release != "":
+			latest[modName] = release
+		case prerelease != "":
+			latest[modName] = prerelease
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosslink
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/mod/modfile"
+
+	"go.opentelemetry.io/build-tools/golden"
+)
+
+// writeSkewTestRepo writes the go.mod files of a repository where testA
+// requires testB v1.0.0 and testC/v2 v2.0.0, and testB requires testA v1.1.0.
+func writeSkewTestRepo(t *testing.T) string {
+	root := t.TempDir()
+	modFiles := map[string]string{
+		"go.mod": "module " + testRoot + "\n\ngo 1.18\n",
+		filepath.Join("testA", "go.mod"): "module " + testRoot + "/testA\n\ngo 1.18\n\n" +
+			"require (\n" +
+			"\t" + testRoot + "/testB v1.0.0\n" +
+			"\t" + testRoot + "/testC/v2 v2.0.0\n" +
+			"\tgo.opentelemetry.io/other v1.0.0\n" +
+			")\n",
+		filepath.Join("testB", "go.mod"): "module " + testRoot + "/testB\n\ngo 1.18\n\n" +
+			"require " + testRoot + "/testA v1.1.0\n",
+		filepath.Join("testC", "go.mod"): "module " + testRoot + "/testC/v2\n\ngo 1.18\n",
+	}
+	for name, content := range modFiles {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	return root
+}
+
+func TestSkewFromTags(t *testing.T) {
+	lg, _ := zap.NewDevelopment()
+	root := writeSkewTestRepo(t)
+
+	t.Cleanup(func(f func(string) ([]string, error)) func() {
+		return func() { listTags = f }
+	}(listTags))
+	listTags = func(string) ([]string, error) {
+		return []string{
+			"v0.1.0",
+			"testA/v1.1.0",
+			"testB/v1.0.0",
+			"testB/v1.2.0",
+			"testB/v1.3.0-rc.1",
+			"testC/v1.5.0",
+			"testC/v2.0.0",
+		}, nil
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, Skew(RunConfig{RootPath: root, Logger: lg}, &out))
+	golden.Assert(t, filepath.Join(testDataDir, "skew", "from_tags.golden"), out.Bytes())
+}
+
+func TestSkewFromVersioningFile(t *testing.T) {
+	lg, _ := zap.NewDevelopment()
+	root := writeSkewTestRepo(t)
+	versioningFile := filepath.Join(root, "versions.yaml")
+	require.NoError(t, os.WriteFile(versioningFile, []byte("module-sets:\n"+
+		"  one:\n"+
+		"    version: v1.2.0\n"+
+		"    modules:\n"+
+		"      - "+testRoot+"/testA\n"+
+		"      - "+testRoot+"/testB\n"+
+		"  two:\n"+
+		"    version: v2.1.0\n"+
+		"    modules:\n"+
+		"      - "+testRoot+"/testC/v2\n"), 0o600))
+
+	rc := RunConfig{
+		RootPath:       root,
+		VersioningFile: versioningFile,
+		ExcludedPaths:  map[string]struct{}{testRoot + "/testB": {}},
+		Logger:         lg,
+	}
+	var out bytes.Buffer
+	require.NoError(t, Skew(rc, &out))
+	golden.Assert(t, filepath.Join(testDataDir, "skew", "from_versioning_file.golden"), out.Bytes())
+}
+
+func TestLatestTaggedVersions(t *testing.T) {
+	graph := make(map[string]*moduleInfo)
+	for _, name := range []string{"go.mod", "sdk/go.mod", "sdk/metric/go.mod", "exporters/otlp/go.mod"} {
+		path := filepath.Join("/repo", filepath.FromSlash(name))
+		modPath := filepath.ToSlash(filepath.Join(testRoot, filepath.Dir(name)))
+		if name == "exporters/otlp/go.mod" {
+			modPath += "/v2"
+		}
+		modFile, err := modfile.Parse(path, []byte("module "+modPath+"\n"), nil)
+		require.NoError(t, err)
+		graph[modPath] = newModuleInfo(*modFile)
+	}
+
+	latest, err := latestTaggedVersions("/repo", graph, []string{
+		"v1.0.0",
+		"v1.1.0",
+		"v1",
+		"sdk/v0.9.0",
+		"sdk/metric/v0.2.0-beta",
+		"sdk/metric/v0.3.0-alpha",
+		"exporters/otlp/v1.4.0",
+		"exporters/otlp/v2.0.0",
+		"not-a-version",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		testRoot:                        "v1.1.0",
+		testRoot + "/sdk":               "v0.9.0",
+		testRoot + "/sdk/metric":        "v0.3.0-alpha",
+		testRoot + "/exporters/otlp/v2": "v2.0.0",
+	}, latest)
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosslink
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// goModTidy runs go mod tidy in the module directory dir. The go command
+// inherits the environment of crosslink, so GOFLAGS is respected.
+func goModTidy(dir string) error {
+	cmd := exec.Command("go", "mod", "tidy")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// crosslinkAndTidy inserts the replace statements like crosslink, passing each
+// updated module to write, and then passes the directory of every module
+// whose go.mod file changed to tidy.
+func crosslinkAndTidy(rc RunConfig, write func(*moduleInfo) error, tidy func(dir string) error) error {
+	var (
+		mu   sync.Mutex
+		dirs []string
+	)
+	err := crosslink(rc, func(module *moduleInfo) error {
+		d, err := diffModule(module)
+		if err != nil {
+			return err
+		}
+		if err = write(module); err != nil {
+			return err
+		}
+		if len(d.removed) > 0 || len(d.added) > 0 {
+			mu.Lock()
+			dirs = append(dirs, filepath.Dir(d.path))
+			mu.Unlock()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return tidyModules(rc, dirs, tidy)
+}
+
+// tidyModules calls tidy for each of the module directories dirs using up to
+// rc.Jobs concurrent workers. Every failure is logged with its module, and an
+// error listing the failed modules is returned if there are any.
+func tidyModules(rc RunConfig, dirs []string, tidy func(dir string) error) error {
+	sort.Strings(dirs)
+	var (
+		mu     sync.Mutex
+		failed []string
+	)
+	forEachModule(rc, dirs, func(rc RunConfig, dir string) {
+		rel := dir
+		if r, err := filepath.Rel(rc.RootPath, dir); err == nil {
+			rel = r
+		}
+		rc.Logger.Debug("Running go mod tidy", zap.String("module_dir", rel))
+		if err := tidy(dir); err != nil {
+			rc.Logger.Error("Failed to run go mod tidy",
+				zap.String("module_dir", rel),
+				zap.Error(err))
+			mu.Lock()
+			failed = append(failed, rel)
+			mu.Unlock()
+		}
+	})
+	if len(failed) == 0 {
+		return nil
+	}
+	sort.Strings(failed)
+	return fmt.Errorf("go mod tidy failed in %d modules: %s", len(failed), strings.Join(failed, ", "))
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosslink
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCrosslinkAndTidy(t *testing.T) {
+	lg, _ := zap.NewDevelopment()
+
+	tmpRootDir, err := createTempTestDir("testSimple")
+	require.NoError(t, err, "creating temp dir")
+	t.Cleanup(func() { os.RemoveAll(tmpRootDir) })
+	require.NoError(t, renameGoMod(tmpRootDir), "renaming gomod files")
+
+	rc := RunConfig{
+		RootPath:      tmpRootDir,
+		ExcludedPaths: map[string]struct{}{},
+		Jobs:          2,
+		Logger:        lg,
+	}
+
+	var (
+		mu     sync.Mutex
+		tidied []string
+	)
+	tidy := func(dir string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		tidied = append(tidied, dir)
+		return nil
+	}
+
+	require.NoError(t, crosslinkAndTidy(rc, writeModule, tidy))
+	sort.Strings(tidied)
+	assert.Equal(t, []string{tmpRootDir, filepath.Join(tmpRootDir, "testA")}, tidied,
+		"only the modules whose go.mod file changed are tidied")
+
+	tidied = nil
+	require.NoError(t, crosslinkAndTidy(rc, writeModule, tidy))
+	assert.Empty(t, tidied, "up to date modules are not tidied")
+}
+
+func TestTidyModulesFailures(t *testing.T) {
+	lg, _ := zap.NewDevelopment()
+	root := t.TempDir()
+	rc := RunConfig{RootPath: root, Jobs: 4, Logger: lg}
+
+	dirs := []string{
+		filepath.Join(root, "c"),
+		filepath.Join(root, "a"),
+		filepath.Join(root, "b"),
+	}
+	var (
+		mu     sync.Mutex
+		tidied []string
+	)
+	err := tidyModules(rc, dirs, func(dir string) error {
+		mu.Lock()
+		tidied = append(tidied, dir)
+		mu.Unlock()
+		if filepath.Base(dir) == "b" {
+			return nil
+		}
+		return errors.New("missing go.sum entry")
+	})
+	assert.EqualError(t, err, "go mod tidy failed in 2 modules: a, c")
+	assert.Len(t, tidied, 3, "all modules are tidied even if some fail")
+}
@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosslink
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/mod/modfile"
+)
+
+// Work is the main entry point for the work subcommand. It writes a go.work
+// file at the root of the repository with a use directive for each
+// intra-repository module that is not excluded. An existing go.work file is
+// updated: missing use directives are added and those of excluded modules or
+// of directories of the repository without a module anymore are removed. Use
+// directives of directories outside of the repository are kept.
+func Work(rc RunConfig) error {
+	rc.Logger.Debug("Crosslink run config", zap.Any("run_config", rc))
+
+	rootModulePath, err := identifyRootModule(rc.RootPath)
+	if err != nil {
+		return fmt.Errorf("failed to identify root module: %w", err)
+	}
+
+	graph, err := buildDepedencyGraph(rc, rootModulePath)
+	if err != nil {
+		return fmt.Errorf("failed to build dependency graph: %w", err)
+	}
+
+	workPath := filepath.Join(rc.RootPath, "go.work")
+	work, err := readWorkFile(workPath, graph[rootModulePath])
+	if err != nil {
+		return err
+	}
+
+	// uses maps the directory of each module to use, relative to the root,
+	// to its module path.
+	uses := make(map[string]string)
+	for modPath, modInfo := range graph {
+		if _, excluded := rc.ExcludedPaths[modPath]; excluded {
+			rc.Logger.Debug("Excluded Module, ignoring use", zap.String("module", modPath))
+			continue
+		}
+		dir, err := workUsePath(rc.RootPath, filepath.Dir(modInfo.moduleContents.Syntax.Name))
+		if err != nil {
+			return err
+		}
+		uses[dir] = modPath
+	}
+
+	for _, use := range append([]*modfile.Use(nil), work.Use...) {
+		dir := cleanUsePath(use.Path)
+		outside := dir == ".." || strings.HasPrefix(dir, "../") || path.IsAbs(dir) || filepath.IsAbs(use.Path)
+		if _, ok := uses[dir]; ok || outside {
+			continue
+		}
+		rc.Logger.Debug("Removing use directive", zap.String("use", use.Path))
+		if err = work.DropUse(use.Path); err != nil {
+			return fmt.Errorf("failed to drop use directive %s: %w", use.Path, err)
+		}
+	}
+
+	// Clean up the dropped use directives first, so new ones are not added
+	// next to them.
+	work.Cleanup()
+
+	dirs := make([]string, 0, len(uses))
+	for dir := range uses {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		if containsUse(work.Use, dir) {
+			continue
+		}
+		rc.Logger.Debug("Inserting use directive",
+			zap.String("module", uses[dir]),
+			zap.String("use", dir))
+		if err = work.AddUse(dir, uses[dir]); err != nil {
+			return fmt.Errorf("failed to add use directive %s: %w", dir, err)
+		}
+	}
+
+	work.SortBlocks()
+	work.Cleanup()
+	err = os.WriteFile(workPath, modfile.Format(work.Syntax), 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write go.work file: %w", err)
+	}
+	return nil
+}
+
+// readWorkFile parses the go.work file at workPath. If it does not exist, an
+// empty one using the go version of the root module is returned.
+func readWorkFile(workPath string, root *moduleInfo) (*modfile.WorkFile, error) {
+	data, err := os.ReadFile(filepath.Clean(workPath))
+	if errors.Is(err, fs.ErrNotExist) {
+		work := &modfile.WorkFile{Syntax: &modfile.FileSyntax{Name: workPath}}
+		if root != nil && root.moduleContents.Go != nil {
+			if err = work.AddGoStmt(root.moduleContents.Go.Version); err != nil {
+				return nil, fmt.Errorf("failed to add go directive: %w", err)
+			}
+		}
+		return work, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go.work file: %w", err)
+	}
+
+	work, err := modfile.ParseWork(workPath, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go.work file: %w", err)
+	}
+	return work, nil
+}
+
+// workUsePath returns the path of dir relative to rootPath in the form used by
+// use directives, e.g. "." or "./exporter/otlp".
+func workUsePath(rootPath, dir string) (string, error) {
+	rel, err := filepath.Rel(rootPath, dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve relative path: %w", err)
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == "." {
+		return rel, nil
+	}
+	return "./" + rel, nil
+}
+
+// cleanUsePath returns the canonical form of the path of a use directive, so
+// "exporter/otlp/" and "./exporter/otlp" are considered the same.
+func cleanUsePath(p string) string {
+	p = path.Clean(filepath.ToSlash(p))
+	if p == "." || p == ".." || path.IsAbs(p) || strings.HasPrefix(p, "./") || strings.HasPrefix(p, "../") {
+		return p
+	}
+	return "./" + p
+}
+
+// containsUse reports whether uses has a use directive for dir.
+func containsUse(uses []*modfile.Use, dir string) bool {
+	for _, use := range uses {
+		if cleanUsePath(use.Path) == dir {
+			return true
+		}
+	}
+	return false
+}
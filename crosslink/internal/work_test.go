@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crosslink
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/mod/modfile"
+)
+
+func TestWork(t *testing.T) {
+	lg, _ := zap.NewDevelopment()
+
+	tests := []struct {
+		testName     string
+		excluded     map[string]struct{}
+		existingWork string
+		expectedGo   string
+		expectedUses []string
+	}{
+		{
+			testName:     "new",
+			excluded:     map[string]struct{}{},
+			expectedGo:   "1.18",
+			expectedUses: []string{".", "./testA", "./testB"},
+		},
+		{
+			testName: "existing",
+			excluded: map[string]struct{}{
+				"go.opentelemetry.io/build-tools/crosslink/testroot/testB": {},
+			},
+			existingWork: "go 1.19\n\n" +
+				"use (\n\t" +
+				"testA\n\t" +
+				"./testB\n\t" +
+				"./removed\n\t" +
+				"../other\n" +
+				")\n",
+			expectedGo:   "1.19",
+			expectedUses: []string{"testA", "../other", "."},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			tmpRootDir, err := createTempTestDir("testSimple")
+			require.NoError(t, err, "creating temp dir")
+			t.Cleanup(func() { os.RemoveAll(tmpRootDir) })
+			require.NoError(t, renameGoMod(tmpRootDir), "renaming gomod files")
+
+			workPath := filepath.Join(tmpRootDir, "go.work")
+			if test.existingWork != "" {
+				require.NoError(t, os.WriteFile(workPath, []byte(test.existingWork), 0600))
+			}
+
+			rc := RunConfig{
+				RootPath:      tmpRootDir,
+				ExcludedPaths: test.excluded,
+				Logger:        lg,
+			}
+			require.NoError(t, Work(rc))
+
+			data, err := os.ReadFile(workPath)
+			require.NoError(t, err)
+			work, err := modfile.ParseWork(workPath, data, nil)
+			require.NoError(t, err)
+
+			require.NotNil(t, work.Go)
+			assert.Equal(t, test.expectedGo, work.Go.Version)
+
+			var uses []string
+			for _, use := range work.Use {
+				uses = append(uses, use.Path)
+			}
+			assert.ElementsMatch(t, test.expectedUses, uses)
+
+			// Running again is a no-op.
+			require.NoError(t, Work(rc))
+			again, err := os.ReadFile(workPath)
+			require.NoError(t, err)
+			assert.Equal(t, string(data), string(again))
+		})
+	}
+}
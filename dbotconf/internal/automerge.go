@@ -0,0 +1,242 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+)
+
+// autoMergeFile is the path of the configuration of the auto-merge policy.
+var autoMergeFile string
+
+var errInvalidAutoMerge = errors.New("invalid auto-merge configuration")
+
+const (
+	fetchMetadataAction = "dependabot/fetch-metadata@v1"
+	defaultMergeMethod  = "squash"
+)
+
+// updateTypes maps the update types of an auto-merge policy to those reported
+// by the fetch-metadata action.
+var updateTypes = map[string]string{
+	"patch": "version-update:semver-patch",
+	"minor": "version-update:semver-minor",
+	"major": "version-update:semver-major",
+}
+
+// metadataEcosystems maps package ecosystems to those reported by the
+// fetch-metadata action.
+var metadataEcosystems = map[string]string{
+	ghPkgEco:        "github_actions",
+	dockerPkgEco:    "docker",
+	gomodPkgEco:     "go_modules",
+	submodulePkgEco: "submodules",
+}
+
+// autoMergeConfig is the configuration of the auto-merge policy read from
+// autoMergeFile. It can be kept in the same file as the registries.
+type autoMergeConfig struct {
+	AutoMerge *autoMergePolicy `yaml:"auto-merge"`
+}
+
+// autoMergePolicy are the criteria Dependabot pull requests must all match to
+// be merged automatically. An empty list matches all pull requests.
+type autoMergePolicy struct {
+	UpdateTypes       []string `yaml:"update-types"`
+	PackageEcosystems []string `yaml:"package-ecosystems"`
+	DependencyGroups  []string `yaml:"dependency-groups"`
+	Approve           bool
+	MergeMethod       string `yaml:"merge-method"`
+}
+
+// loadAutoMergePolicy returns the auto-merge policy configured in path.
+func loadAutoMergePolicy(path string) (autoMergePolicy, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return autoMergePolicy{}, fmt.Errorf("failed to read auto-merge configuration file: %w", err)
+	}
+
+	var conf autoMergeConfig
+	if err := yaml.Unmarshal(data, &conf); err != nil {
+		return autoMergePolicy{}, fmt.Errorf("%w: %v", errInvalidAutoMerge, err)
+	}
+	if conf.AutoMerge == nil {
+		return autoMergePolicy{}, fmt.Errorf("%w: missing auto-merge section", errInvalidAutoMerge)
+	}
+
+	p := *conf.AutoMerge
+	if p.MergeMethod == "" {
+		p.MergeMethod = defaultMergeMethod
+	}
+	if err := validateAutoMergePolicy(p); err != nil {
+		return autoMergePolicy{}, fmt.Errorf("%w: %v", errInvalidAutoMerge, err)
+	}
+	return p, nil
+}
+
+func validateAutoMergePolicy(p autoMergePolicy) error {
+	for _, t := range p.UpdateTypes {
+		if _, ok := updateTypes[t]; !ok {
+			return fmt.Errorf("unsupported update type %q, must be patch, minor, or major", t)
+		}
+	}
+	for _, eco := range p.PackageEcosystems {
+		if _, ok := metadataEcosystems[eco]; !ok {
+			return fmt.Errorf("unsupported package ecosystem %q", eco)
+		}
+	}
+	for _, g := range p.DependencyGroups {
+		if g == "" || strings.ContainsAny(g, `'"\`) {
+			return fmt.Errorf("invalid dependency group %q", g)
+		}
+	}
+	switch p.MergeMethod {
+	case "merge", "squash", "rebase":
+	default:
+		return fmt.Errorf("unsupported merge method %q, must be merge, squash, or rebase", p.MergeMethod)
+	}
+	return nil
+}
+
+// condition returns the expression of the workflow steps acting on pull
+// requests that match the policy, or an empty one if all of them match.
+func (p autoMergePolicy) condition() string {
+	var conds []string
+	addCond := func(values []string, mapping map[string]string, output string) {
+		if len(values) == 0 {
+			return
+		}
+		mapped := make([]string, 0, len(values))
+		for _, v := range values {
+			if m, ok := mapping[v]; ok {
+				v = m
+			}
+			mapped = append(mapped, v)
+		}
+		// Values are validated not to contain quotes, so the JSON array is
+		// a valid expression string.
+		list, _ := json.Marshal(mapped)
+		conds = append(conds, fmt.Sprintf("contains(fromJSON('%s'), steps.metadata.outputs.%s)", list, output))
+	}
+	addCond(p.UpdateTypes, updateTypes, "update-type")
+	addCond(p.PackageEcosystems, metadataEcosystems, "package-ecosystem")
+	addCond(p.DependencyGroups, nil, "dependency-group")
+	return strings.Join(conds, " && ")
+}
+
+type workflow struct {
+	Name        string
+	On          string
+	Permissions map[string]string
+	Jobs        map[string]workflowJob
+}
+
+type workflowJob struct {
+	RunsOn string `yaml:"runs-on"`
+	If     string
+	Steps  []workflowStep
+}
+
+type workflowStep struct {
+	Name string
+	ID   string            `yaml:"id,omitempty"`
+	If   string            `yaml:"if,omitempty"`
+	Uses string            `yaml:"uses,omitempty"`
+	With map[string]string `yaml:"with,omitempty"`
+	Run  string            `yaml:"run,omitempty"`
+	Env  map[string]string `yaml:"env,omitempty"`
+}
+
+// buildAutoMergeWorkflow returns the GitHub workflow approving, if
+// configured, and enabling auto-merge for the Dependabot pull requests that
+// match p.
+func buildAutoMergeWorkflow(p autoMergePolicy) workflow {
+	env := map[string]string{
+		"PR_URL":   "${{ github.event.pull_request.html_url }}",
+		"GH_TOKEN": "${{ secrets.GITHUB_TOKEN }}",
+	}
+	cond := p.condition()
+
+	steps := []workflowStep{{
+		Name: "Fetch Dependabot metadata",
+		ID:   "metadata",
+		Uses: fetchMetadataAction,
+		With: map[string]string{"github-token": "${{ secrets.GITHUB_TOKEN }}"},
+	}}
+	if p.Approve {
+		steps = append(steps, workflowStep{
+			Name: "Approve",
+			If:   cond,
+			Run:  `gh pr review --approve "$PR_URL"`,
+			Env:  env,
+		})
+	}
+	steps = append(steps, workflowStep{
+		Name: "Enable auto-merge",
+		If:   cond,
+		Run:  fmt.Sprintf(`gh pr merge --auto --%s "$PR_URL"`, p.MergeMethod),
+		Env:  env,
+	})
+
+	return workflow{
+		Name: "Dependabot auto-merge",
+		On:   "pull_request",
+		Permissions: map[string]string{
+			"contents":      "write",
+			"pull-requests": "write",
+		},
+		Jobs: map[string]workflowJob{
+			"auto-merge": {
+				RunsOn: "ubuntu-latest",
+				If:     "github.actor == 'dependabot[bot]'",
+				Steps:  steps,
+			},
+		},
+	}
+}
+
+// autoMerge outputs the auto-merge workflow of the policy configured in
+// autoMergeFile.
+func autoMerge() error {
+	if autoMergeFile == "" {
+		return fmt.Errorf("%w: no configuration file given with --config", errInvalidAutoMerge)
+	}
+
+	p, err := loadAutoMergePolicy(autoMergeFile)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(output, header)
+	encoder := yaml.NewEncoder(output)
+	encoder.SetIndent(2)
+	return encoder.Encode(buildAutoMergeWorkflow(p))
+}
+
+func runAutoMerge(c *cobra.Command, _ []string) {
+	if err := autoMerge(); err != nil {
+		logging.Fatalf("%s: %v", c.CommandPath(), err)
+	}
+}
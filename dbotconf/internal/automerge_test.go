@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func setAutoMergeFile(t *testing.T, path string) {
+	t.Helper()
+	t.Cleanup(func(f string) func() { return func() { autoMergeFile = f } }(autoMergeFile))
+	autoMergeFile = path
+}
+
+func TestLoadAutoMergePolicy(t *testing.T) {
+	p, err := loadAutoMergePolicy(filepath.Join("testdata", "dbotconf.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, autoMergePolicy{
+		UpdateTypes:       []string{"patch", "minor"},
+		PackageEcosystems: []string{gomodPkgEco, ghPkgEco},
+		DependencyGroups:  []string{"otel"},
+		Approve:           true,
+		MergeMethod:       defaultMergeMethod,
+	}, p)
+
+	// The registries are read from the same file.
+	regs, err := loadRegistries(filepath.Join("testdata", "dbotconf.yml"))
+	require.NoError(t, err)
+	assert.Len(t, regs.defs, 2)
+}
+
+func TestLoadAutoMergePolicyErrors(t *testing.T) {
+	_, err := loadAutoMergePolicy(filepath.Join("testdata", "missing.yml"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	tests := []struct {
+		name string
+		conf string
+	}{
+		{name: "invalid yaml", conf: "auto-merge: ["},
+		{name: "missing section", conf: "registries: {}"},
+		{name: "update type", conf: "auto-merge:\n  update-types: [semver-patch]"},
+		{name: "package ecosystem", conf: "auto-merge:\n  package-ecosystems: [npm]"},
+		{name: "dependency group", conf: "auto-merge:\n  dependency-groups: [\"o'tel\"]"},
+		{name: "merge method", conf: "auto-merge:\n  merge-method: fast-forward"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "dbotconf.yml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.conf), 0o600))
+			_, err := loadAutoMergePolicy(path)
+			assert.ErrorIs(t, err, errInvalidAutoMerge)
+		})
+	}
+}
+
+func TestAutoMergeCondition(t *testing.T) {
+	assert.Equal(t, "", autoMergePolicy{}.condition())
+	assert.Equal(t,
+		`contains(fromJSON('["version-update:semver-patch"]'), steps.metadata.outputs.update-type) && `+
+			`contains(fromJSON('["go_modules","github_actions"]'), steps.metadata.outputs.package-ecosystem)`,
+		autoMergePolicy{
+			UpdateTypes:       []string{"patch"},
+			PackageEcosystems: []string{gomodPkgEco, ghPkgEco},
+		}.condition(),
+	)
+}
+
+func TestBuildAutoMergeWorkflow(t *testing.T) {
+	w := buildAutoMergeWorkflow(autoMergePolicy{UpdateTypes: []string{"patch"}, MergeMethod: "rebase"})
+	job := w.Jobs["auto-merge"]
+	require.Len(t, job.Steps, 2, "no approval step")
+	assert.Equal(t, fetchMetadataAction, job.Steps[0].Uses)
+	assert.Equal(t, `gh pr merge --auto --rebase "$PR_URL"`, job.Steps[1].Run)
+	assert.Contains(t, job.Steps[1].If, "version-update:semver-patch")
+
+	w = buildAutoMergeWorkflow(autoMergePolicy{Approve: true, MergeMethod: "squash"})
+	job = w.Jobs["auto-merge"]
+	require.Len(t, job.Steps, 3)
+	assert.Equal(t, `gh pr review --approve "$PR_URL"`, job.Steps[1].Run)
+	assert.Empty(t, job.Steps[2].If, "all pull requests match")
+}
+
+func TestRunAutoMerge(t *testing.T) {
+	var b bytes.Buffer
+	t.Cleanup(func(w io.Writer) func() { return func() { output = w } }(output))
+	output = &b
+
+	setAutoMergeFile(t, "")
+	assert.ErrorIs(t, autoMerge(), errInvalidAutoMerge)
+
+	setAutoMergeFile(t, filepath.Join("testdata", "dbotconf.yml"))
+	require.NoError(t, autoMerge())
+	assert.True(t, strings.HasPrefix(b.String(), header), "missing header")
+
+	var w map[string]interface{}
+	require.NoError(t, yaml.NewDecoder(&b).Decode(&w))
+	assert.Equal(t, "pull_request", w["on"])
+}
@@ -16,9 +16,14 @@ package internal
 
 import (
 	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
 )
 
 var (
+	quiet   bool
+	verbose bool
+
 	rootCmd = &cobra.Command{
 		Use:   "dbotconf",
 		Short: "Dependabot configuration utility",
@@ -26,26 +31,116 @@ var (
 		Example: `
   dbotconf generate > .github/dependabot.yml
 
-  dbotconf verify .github/dependabot.yml`,
+  dbotconf generate --format renovate > renovate.json
+
+  dbotconf verify .github/dependabot.yml
+
+  dbotconf verify --fix .github/dependabot.yml
+
+  dbotconf fix .github/dependabot.yml
+
+  dbotconf auto-merge --config .github/dbotconf.yml > .github/workflows/dependabot-auto-merge.yml`,
+		PersistentPreRun: func(*cobra.Command, []string) {
+			logging.Configure(quiet, verbose)
+		},
 	}
 
 	generateCmd = &cobra.Command{
 		Use:   "generate",
 		Short: "Generate Dependabot configuration",
-		Run:   runGenerate,
+		Long: `Generate Dependabot configuration with update checks for all modules in the repository.
+
+GitHub Actions updates are checked if the repository has workflows in
+.github/workflows, and Docker updates in every directory containing a
+Dockerfile, e.g. Dockerfile, Dockerfile.dev or build.Dockerfile, outside of
+testdata directories.
+
+Updates of the tool dependencies of a module, declared with tool directives or
+imported by tools.go files built with the tools build tag, are grouped in a
+single "tools" pull request. All the updates of a module only containing tool
+dependencies are grouped.
+
+Private registries are read from the YAML file given with --registries:
+
+  registries:
+    goproxy:
+      type: goproxy-server
+      url: https://goproxy.example.com
+      username: octocat
+      password: ${{secrets.GOPROXY_PASSWORD}}
+      package-ecosystems: [gomod]
+
+Registries are emitted in the registries section and used by the update checks
+of the listed package ecosystems. Credentials must reference Dependabot secrets.
+
+With --format renovate, a Renovate configuration is generated instead, with a
+package rule per module and per dependency group. Private registries are not
+supported in this format.`,
+		Run: runGenerate,
 	}
 
 	verifyCmd = &cobra.Command{
 		Use:   "verify [flags] path",
 		Short: "Verify Dependabot configuration is complete",
-		Long:  "Ensure Dependabot configuration contains update checks for all modules in the repository.",
-		Run:   runVerify,
+		Long: `Ensure Dependabot configuration contains update checks for all modules in the repository.
+
+With --fix, the configuration is fixed in place as the fix command does instead:
+missing update checks are added and those of modules that no longer exist are
+removed, keeping the other sections, their order and comments.`,
+		Run: runVerify,
+	}
+
+	fixCmd = &cobra.Command{
+		Use:   "fix [flags] path",
+		Short: "Fix Dependabot configuration in place",
+		Long:  "Add missing module update checks and remove those for modules that no longer exist, leaving the rest of the configuration untouched.",
+		Run:   runFix,
+	}
+
+	autoMergeCmd = &cobra.Command{
+		Use:   "auto-merge",
+		Short: "Generate a workflow merging Dependabot pull requests automatically",
+		Long: `Generate a GitHub workflow enabling auto-merge for the Dependabot pull requests that
+match the policy read from the YAML file given with --config:
+
+  auto-merge:
+    update-types: [patch, minor]
+    package-ecosystems: [gomod]
+    dependency-groups: [otel]
+    approve: true
+    merge-method: squash
+
+Pull requests must match all the criteria, an empty list matches any value.
+The policy can be kept in the same file as the registries given to generate.`,
+		Args: cobra.NoArgs,
+		Run:  runAutoMerge,
 	}
 )
 
 func BuildAndExecute() error {
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, logging.QuietUsage)
+	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, logging.VerboseUsage)
+
+	generateCmd.Flags().StringVar(&outputFormat, "format", outputFormat,
+		"Format of the generated configuration (dependabot or renovate).")
+	generateCmd.Flags().StringVar(&submoduleInterval, "submodule-interval", submoduleInterval,
+		"Update schedule interval (daily, weekly, or monthly) of git submodules, if the repository has any.")
+
+	verifyCmd.Flags().BoolVar(&verifyFix, "fix", false,
+		"Fix the configuration in place instead of reporting missing update checks.")
+
+	for _, c := range []*cobra.Command{generateCmd, verifyCmd, fixCmd} {
+		c.Flags().StringVar(&registriesFile, "registries", "",
+			"Path of the private registries configuration added to the Dependabot configuration.")
+	}
+
+	autoMergeCmd.Flags().StringVar(&autoMergeFile, "config", "",
+		"Path of the auto-merge policy configuration.")
+
 	rootCmd.AddCommand(generateCmd)
 	rootCmd.AddCommand(verifyCmd)
+	rootCmd.AddCommand(fixCmd)
+	rootCmd.AddCommand(autoMergeCmd)
 
 	return rootCmd.Execute()
 }
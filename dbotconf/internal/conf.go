@@ -15,10 +15,11 @@
 package internal
 
 const (
-	version2     = 2
-	ghPkgEco     = "github-actions"
-	dockerPkgEco = "docker"
-	gomodPkgEco  = "gomod"
+	version2        = 2
+	ghPkgEco        = "github-actions"
+	dockerPkgEco    = "docker"
+	gomodPkgEco     = "gomod"
+	submodulePkgEco = "gitsubmodule"
 )
 
 var (
@@ -26,20 +27,38 @@ var (
 	actionLabels   = []string{"dependencies", "actions", "Skip Changelog"}
 	dockerLabels   = []string{"dependencies", "docker", "Skip Changelog"}
 	goLabels       = []string{"dependencies", "go", "Skip Changelog"}
+	submodLabels   = []string{"dependencies", "submodules", "Skip Changelog"}
 )
 
 type dependabotConfig struct {
-	Version int
-	Updates []update
+	Version    int
+	Registries map[string]registry `yaml:",omitempty"`
+	Updates    []update
 }
 
 type update struct {
 	PackageEcosystem string `yaml:"package-ecosystem"`
 	Directory        string
-	Labels           []string `yaml:",omitempty"`
+	Labels           []string         `yaml:",omitempty"`
+	Registries       []string         `yaml:",omitempty"`
+	Groups           map[string]group `yaml:",omitempty"`
 	Schedule         schedule
 }
 
+type group struct {
+	Patterns []string
+}
+
+type registry struct {
+	Type         string
+	URL          string
+	Username     string `yaml:",omitempty"`
+	Password     string `yaml:",omitempty"`
+	Key          string `yaml:",omitempty"`
+	Token        string `yaml:",omitempty"`
+	ReplacesBase bool   `yaml:"replaces-base,omitempty"`
+}
+
 type schedule struct {
 	Interval string
 	Day      string `yaml:",omitempty"`
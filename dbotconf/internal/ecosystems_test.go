@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeRepoFiles writes empty files at the slash-separated paths relative to
+// root.
+func writeRepoFiles(t *testing.T, root string, paths ...string) {
+	t.Helper()
+	for _, path := range paths {
+		path = filepath.Join(root, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, nil, 0o600))
+	}
+}
+
+func TestHasWorkflows(t *testing.T) {
+	root := t.TempDir()
+	got, err := hasWorkflows(root)
+	require.NoError(t, err)
+	assert.False(t, got)
+
+	writeRepoFiles(t, root, ".github/workflows/README.md")
+	got, err = hasWorkflows(root)
+	require.NoError(t, err)
+	assert.False(t, got)
+
+	writeRepoFiles(t, root, ".github/workflows/ci.yaml")
+	got, err = hasWorkflows(root)
+	require.NoError(t, err)
+	assert.True(t, got)
+}
+
+func TestDockerDirs(t *testing.T) {
+	root := t.TempDir()
+	writeRepoFiles(t, root,
+		"Dockerfile",
+		"Dockerfile.dev",
+		"build/ci.Dockerfile",
+		"cmd/tool/Dockerfile",
+		"docs/Dockerfiles.md",
+		"testdata/Dockerfile",
+		".git/Dockerfile",
+	)
+
+	dirs, err := dockerDirs(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/", "/build", "/cmd/tool"}, dirs)
+
+	dirs, err = dockerDirs(filepath.Join(root, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, dirs)
+}
+
+func TestIsDockerfile(t *testing.T) {
+	for name, want := range map[string]bool{
+		"Dockerfile":         true,
+		"Dockerfile.release": true,
+		"build.Dockerfile":   true,
+		"Dockerfiles":        false,
+		"dockerfile":         false,
+	} {
+		assert.Equal(t, want, isDockerfile(name), name)
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+)
+
+var errNoUpdates = errors.New("no updates section found")
+
+// mappingValue returns the value node associated with key in the mapping
+// node n, or nil if n is not a mapping or does not contain key.
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// scalarValue returns the scalar value associated with key in the mapping
+// node n, or an empty string if none exists.
+func scalarValue(n *yaml.Node, key string) string {
+	v := mappingValue(n, key)
+	if v == nil || v.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return v.Value
+}
+
+// setMappingValue sets the value of key in the mapping n. A missing key is
+// added after the key after, or at the end if after is not found.
+func setMappingValue(n *yaml.Node, key, after string, value *yaml.Node) {
+	if v := mappingValue(n, key); v != nil {
+		*v = *value
+		return
+	}
+
+	idx := len(n.Content)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == after {
+			idx = i + 2
+			break
+		}
+	}
+	k := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+	content := make([]*yaml.Node, 0, len(n.Content)+2)
+	content = append(content, n.Content[:idx]...)
+	content = append(content, k, value)
+	n.Content = append(content, n.Content[idx:]...)
+}
+
+// fixRegistries sets the registries section of the configuration document
+// doc, and the registries of the update entries, to those configured in regs.
+func fixRegistries(doc, updates *yaml.Node, regs registries) error {
+	if len(regs.defs) == 0 {
+		return nil
+	}
+
+	var defs yaml.Node
+	if err := defs.Encode(regs.defs); err != nil {
+		return err
+	}
+	setMappingValue(doc, "registries", "version", &defs)
+
+	for _, n := range updates.Content {
+		names, ok := regs.byEco[scalarValue(n, "package-ecosystem")]
+		if !ok {
+			continue
+		}
+		var v yaml.Node
+		if err := v.Encode(names); err != nil {
+			return err
+		}
+		setMappingValue(n, "registries", "labels", &v)
+	}
+	return nil
+}
+
+// fixUpdates edits the updates sequence node in place so that it contains
+// exactly one gomod update for each of dirs. Updates for other package
+// ecosystems, and gomod updates that are still valid, are left untouched.
+func fixUpdates(updates *yaml.Node, dirs []string) error {
+	want := make(map[string]struct{}, len(dirs))
+	for _, d := range dirs {
+		want[d] = struct{}{}
+	}
+
+	have := make(map[string]struct{})
+	kept := updates.Content[:0]
+	for _, n := range updates.Content {
+		if scalarValue(n, "package-ecosystem") == gomodPkgEco {
+			dir := scalarValue(n, "directory")
+			if _, ok := want[dir]; !ok {
+				// Module no longer exists.
+				logging.Infof("Removing update check of %s, no module found", dir)
+				continue
+			}
+			if _, ok := have[dir]; ok {
+				// Duplicate entry.
+				continue
+			}
+			have[dir] = struct{}{}
+		}
+		kept = append(kept, n)
+	}
+
+	for _, d := range dirs {
+		if _, ok := have[d]; ok {
+			continue
+		}
+		logging.Infof("Adding update check of module %s", d)
+		var n yaml.Node
+		err := n.Encode(update{
+			PackageEcosystem: gomodPkgEco,
+			Directory:        d,
+			Labels:           goLabels,
+			Schedule:         weeklySchedule,
+		})
+		if err != nil {
+			return err
+		}
+		kept = append(kept, &n)
+	}
+	updates.Content = kept
+	return nil
+}
+
+func fix(args []string) error {
+	switch len(args) {
+	case 0:
+		return errNotEnoughArg
+	case 1:
+		// Valid case.
+	default:
+		return fmt.Errorf("%w, received %v", errTooManyArg, args)
+	}
+	path := filepath.Clean(args[0])
+
+	root, mods, err := allModsFunc()
+	if err != nil {
+		return err
+	}
+
+	dirs := make([]string, 0, len(mods))
+	for _, m := range mods {
+		local, err := localPath(root, m)
+		if err != nil {
+			return err
+		}
+		dirs = append(dirs, local)
+	}
+
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("dependabot configuration file does not exist: %s", path)
+	} else if err != nil {
+		return fmt.Errorf("failed to read dependabot configuration file: %s", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read dependabot configuration file: %s", path)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("%w: %v", errInvalid, err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return fmt.Errorf("%w: %s", errInvalid, errNoUpdates)
+	}
+	updates := mappingValue(doc.Content[0], "updates")
+	if updates == nil || updates.Kind != yaml.SequenceNode {
+		return fmt.Errorf("%w: %s", errInvalid, errNoUpdates)
+	}
+
+	if err := fixUpdates(updates, dirs); err != nil {
+		return err
+	}
+	regs, err := loadRegistries(registriesFile)
+	if err != nil {
+		return err
+	}
+	if err := fixRegistries(doc.Content[0], updates, regs); err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b.Bytes(), info.Mode().Perm())
+}
+
+func runFix(c *cobra.Command, args []string) {
+	if err := fix(args); err != nil {
+		logging.Fatalf("%s: %v", c.CommandPath(), err)
+	}
+}
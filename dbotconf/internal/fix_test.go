@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/modfile"
+	"gopkg.in/yaml.v3"
+)
+
+func copyTestdata(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestRunFixErrors(t *testing.T) {
+	assert.ErrorIs(t, fix(nil), errNotEnoughArg)
+	assert.ErrorIs(t, fix([]string{"", ""}), errTooManyArg)
+}
+
+func TestRunFix(t *testing.T) {
+	t.Cleanup(func(f func() (string, []*modfile.File, error)) func() {
+		return func() { allModsFunc = f }
+	}(allModsFunc))
+	allModsFunc = func() (string, []*modfile.File, error) {
+		return "/home/user/repo", []*modfile.File{
+			{Syntax: &modfile.FileSyntax{Name: "/home/user/repo/go.mod"}},
+			{Syntax: &modfile.FileSyntax{Name: "/home/user/repo/a/go.mod"}},
+			{Syntax: &modfile.FileSyntax{Name: "/home/user/repo/c/go.mod"}},
+		}, nil
+	}
+
+	path := copyTestdata(t, "dependabot.yml")
+	require.NoError(t, fix([]string{path}))
+
+	updates, err := configuredUpdates(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]struct{}{
+		"/":  {},
+		"/a": {},
+		"/c": {},
+	}, updates)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), header), "header comment not preserved")
+
+	var c dependabotConfig
+	require.NoError(t, yaml.Unmarshal(data, &c))
+	require.Len(t, c.Updates, 4)
+	// Existing entries are left as they were, including their labels.
+	assert.Equal(t, newUpdate(ghPkgEco, "/", actionLabels), c.Updates[0])
+	assert.Equal(t, newUpdate(gomodPkgEco, "/", actionLabels), c.Updates[1])
+	assert.Equal(t, newUpdate(gomodPkgEco, "/a", actionLabels), c.Updates[2])
+	assert.Equal(t, newUpdate(gomodPkgEco, "/c", goLabels), c.Updates[3])
+}
+
+func TestRunFixIdempotent(t *testing.T) {
+	t.Cleanup(func(f func() (string, []*modfile.File, error)) func() {
+		return func() { allModsFunc = f }
+	}(allModsFunc))
+	allModsFunc = func() (string, []*modfile.File, error) {
+		return "/home/user/repo", []*modfile.File{
+			{Syntax: &modfile.FileSyntax{Name: "/home/user/repo/go.mod"}},
+			{Syntax: &modfile.FileSyntax{Name: "/home/user/repo/a/go.mod"}},
+			{Syntax: &modfile.FileSyntax{Name: "/home/user/repo/a/b/go.mod"}},
+		}, nil
+	}
+
+	path := copyTestdata(t, "dependabot.yml")
+	require.NoError(t, fix([]string{path}))
+	first, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	require.NoError(t, fix([]string{path}))
+	second, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(first), string(second))
+}
+
+func TestRunFixInvalidYAML(t *testing.T) {
+	t.Cleanup(func(f func() (string, []*modfile.File, error)) func() {
+		return func() { allModsFunc = f }
+	}(allModsFunc))
+	allModsFunc = func() (string, []*modfile.File, error) {
+		return "", []*modfile.File{}, nil
+	}
+
+	path := copyTestdata(t, "invalid.yml")
+	assert.ErrorIs(t, fix([]string{path}), errInvalid)
+}
+
+func TestRunFixReturnAllModsError(t *testing.T) {
+	t.Cleanup(func(f func() (string, []*modfile.File, error)) func() {
+		return func() { allModsFunc = f }
+	}(allModsFunc))
+	allModsFunc = func() (string, []*modfile.File, error) {
+		return "", []*modfile.File{}, assert.AnError
+	}
+	assert.ErrorIs(t, fix([]string{""}), assert.AnError)
+}
@@ -15,13 +15,17 @@
 package internal
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/mod/modfile"
 	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
 )
 
 const header = "# File generated by dbotconf; DO NOT EDIT."
@@ -29,45 +33,129 @@ const header = "# File generated by dbotconf; DO NOT EDIT."
 // Allow testing override.
 var buildConfigFunc = buildConfig
 
-// buildConfig constructs a dependabotConfig for all modules in the repo.
+// submoduleInterval is the schedule interval of the git submodule update
+// check.
+var submoduleInterval = weeklySchedule.Interval
+
+var errInvalidInterval = errors.New("invalid schedule interval")
+
+// submoduleSchedule returns the update schedule for git submodules checked at
+// interval.
+func submoduleSchedule(interval string) (schedule, error) {
+	switch interval {
+	case weeklySchedule.Interval:
+		return weeklySchedule, nil
+	case "daily", "monthly":
+		return schedule{Interval: interval}, nil
+	default:
+		return schedule{}, fmt.Errorf("%w: %q, must be daily, weekly, or monthly", errInvalidInterval, interval)
+	}
+}
+
+// hasSubmodules returns if the repo with root contains git submodules.
+func hasSubmodules(root string) (bool, error) {
+	_, err := os.Stat(filepath.Join(root, ".gitmodules"))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// buildConfig constructs a dependabotConfig for all modules in the repo, its
+// GitHub Actions workflows and Dockerfiles, and its git submodules if it has
+// any.
 func buildConfig(root string, mods []*modfile.File) (*dependabotConfig, error) {
-	c := &dependabotConfig{
-		Version: version2,
-		Updates: []update{
-			{
-				PackageEcosystem: ghPkgEco,
-				Directory:        "/",
-				Labels:           actionLabels,
-				Schedule:         weeklySchedule,
-			},
-			{
-				PackageEcosystem: dockerPkgEco,
-				Directory:        "/",
-				Labels:           dockerLabels,
-				Schedule:         weeklySchedule,
-			},
-		},
+	c := &dependabotConfig{Version: version2}
+
+	workflows, err := hasWorkflows(root)
+	if err != nil {
+		return nil, err
+	}
+	if workflows {
+		c.Updates = append(c.Updates, update{
+			PackageEcosystem: ghPkgEco,
+			Directory:        "/",
+			Labels:           actionLabels,
+			Schedule:         weeklySchedule,
+		})
+	}
+
+	dirs, err := dockerDirs(root)
+	if err != nil {
+		return nil, err
 	}
+	for _, dir := range dirs {
+		c.Updates = append(c.Updates, update{
+			PackageEcosystem: dockerPkgEco,
+			Directory:        dir,
+			Labels:           dockerLabels,
+			Schedule:         weeklySchedule,
+		})
+	}
+
+	submodules, err := hasSubmodules(root)
+	if err != nil {
+		return nil, err
+	}
+	if submodules {
+		sched, err := submoduleSchedule(submoduleInterval)
+		if err != nil {
+			return nil, err
+		}
+		c.Updates = append(c.Updates, update{
+			PackageEcosystem: submodulePkgEco,
+			Directory:        "/",
+			Labels:           submodLabels,
+			Schedule:         sched,
+		})
+	}
+
 	for _, m := range mods {
 		local, err := localPath(root, m)
 		if err != nil {
 			return nil, err
 		}
 
-		c.Updates = append(c.Updates, update{
+		u := update{
 			PackageEcosystem: gomodPkgEco,
 			Directory:        local,
 			Labels:           goLabels,
 			Schedule:         weeklySchedule,
-		})
+		}
+		groups, err := toolGroups(m)
+		if err != nil {
+			return nil, err
+		}
+		u.Groups = groups
+		c.Updates = append(c.Updates, u)
+	}
+
+	regs, err := loadRegistries(registriesFile)
+	if err != nil {
+		return nil, err
 	}
+	regs.apply(c)
 	return c, nil
 }
 
+// toolGroups returns the dependency groups of the update of mod consolidating
+// the updates of its tool dependencies, if it has any. All updates of a module
+// only containing tool dependencies are grouped.
+func toolGroups(mod *modfile.File) (map[string]group, error) {
+	deps, onlyTools, err := toolDependencies(mod)
+	if err != nil || len(deps) == 0 {
+		return nil, err
+	}
+	if onlyTools {
+		deps = []string{"*"}
+	}
+	return map[string]group{toolsGroup: {Patterns: deps}}, nil
+}
+
 var output io.Writer = os.Stdout
 
-// generate outputs a generated dependabot configuration for all Go modules
-// contained in the repository.
+// generate outputs a generated dependabot, or Renovate, configuration for all
+// Go modules contained in the repository.
 func generate() error {
 	root, mods, err := allModsFunc()
 	if err != nil {
@@ -79,6 +167,14 @@ func generate() error {
 		return err
 	}
 
+	switch outputFormat {
+	case formatDependabot:
+	case formatRenovate:
+		return encodeRenovate(c)
+	default:
+		return fmt.Errorf("%w: %q, must be %s or %s", errInvalidFormat, outputFormat, formatDependabot, formatRenovate)
+	}
+
 	fmt.Fprintln(output, header)
 	encoder := yaml.NewEncoder(output)
 	encoder.SetIndent(2)
@@ -87,7 +183,6 @@ func generate() error {
 
 func runGenerate(c *cobra.Command, _ []string) {
 	if err := generate(); err != nil {
-		fmt.Printf("%s: %v", c.CommandPath(), err)
-		os.Exit(1)
+		logging.Fatalf("%s: %v", c.CommandPath(), err)
 	}
 }
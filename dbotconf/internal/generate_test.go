@@ -17,6 +17,8 @@ package internal
 import (
 	"bytes"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -65,6 +67,27 @@ func TestBuildConfig(t *testing.T) {
 		{Syntax: &modfile.FileSyntax{Name: "/home/user/repo/b/go.mod"}},
 	}
 
+	got, err := buildConfig(root, mods)
+	require.NoError(t, err)
+	assert.Equal(t, &dependabotConfig{
+		Version: version2,
+		Updates: []update{
+			newUpdate(gomodPkgEco, "/", goLabels),
+			newUpdate(gomodPkgEco, "/a", goLabels),
+			newUpdate(gomodPkgEco, "/b", goLabels),
+		},
+	}, got)
+}
+
+func TestBuildConfigEcosystems(t *testing.T) {
+	root := t.TempDir()
+	writeRepoFiles(t, root, ".github/workflows/ci.yml", "Dockerfile", "a/build.Dockerfile", "a/testdata/Dockerfile")
+	mods := []*modfile.File{
+		{Syntax: &modfile.FileSyntax{Name: filepath.Join(root, "go.mod")}},
+		{Syntax: &modfile.FileSyntax{Name: filepath.Join(root, "a", "go.mod")}},
+		{Syntax: &modfile.FileSyntax{Name: filepath.Join(root, "b", "go.mod")}},
+	}
+
 	got, err := buildConfig(root, mods)
 	require.NoError(t, err)
 	assert.Equal(t, &dependabotConfig{
@@ -72,6 +95,7 @@ func TestBuildConfig(t *testing.T) {
 		Updates: []update{
 			newUpdate(ghPkgEco, "/", actionLabels),
 			newUpdate(dockerPkgEco, "/", dockerLabels),
+			newUpdate(dockerPkgEco, "/a", dockerLabels),
 			newUpdate(gomodPkgEco, "/", goLabels),
 			newUpdate(gomodPkgEco, "/a", goLabels),
 			newUpdate(gomodPkgEco, "/b", goLabels),
@@ -79,6 +103,53 @@ func TestBuildConfig(t *testing.T) {
 	}, got)
 }
 
+func TestBuildConfigSubmodules(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".gitmodules"), nil, 0o600))
+	mods := []*modfile.File{
+		{Syntax: &modfile.FileSyntax{Name: filepath.Join(root, "go.mod")}},
+	}
+
+	t.Cleanup(func(i string) func() { return func() { submoduleInterval = i } }(submoduleInterval))
+	for _, interval := range []string{"daily", "weekly", "monthly"} {
+		submoduleInterval = interval
+		sched, err := submoduleSchedule(interval)
+		require.NoError(t, err)
+
+		got, err := buildConfig(root, mods)
+		require.NoError(t, err)
+		assert.Equal(t, &dependabotConfig{
+			Version: version2,
+			Updates: []update{
+				{
+					PackageEcosystem: submodulePkgEco,
+					Directory:        "/",
+					Labels:           submodLabels,
+					Schedule:         sched,
+				},
+				newUpdate(gomodPkgEco, "/", goLabels),
+			},
+		}, got, interval)
+	}
+
+	submoduleInterval = "hourly"
+	_, err := buildConfig(root, mods)
+	assert.ErrorIs(t, err, errInvalidInterval)
+}
+
+func TestSubmoduleSchedule(t *testing.T) {
+	sched, err := submoduleSchedule("weekly")
+	require.NoError(t, err)
+	assert.Equal(t, weeklySchedule, sched)
+
+	sched, err = submoduleSchedule("daily")
+	require.NoError(t, err)
+	assert.Equal(t, schedule{Interval: "daily"}, sched)
+
+	_, err = submoduleSchedule("")
+	assert.ErrorIs(t, err, errInvalidInterval)
+}
+
 func TestRunGenerateReturnAllModsError(t *testing.T) {
 	t.Cleanup(func(f func() (string, []*modfile.File, error)) func() {
 		return func() { allModsFunc = f }
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// registriesFile is the path of the private registries configuration. No
+// registries are configured if it is empty.
+var registriesFile string
+
+var errInvalidRegistries = errors.New("invalid registries configuration")
+
+// secretRef matches a reference to a Dependabot secret.
+var secretRef = regexp.MustCompile(`^\$\{\{\s*secrets\.[A-Za-z_][A-Za-z0-9_]*\s*\}\}$`)
+
+// registriesConfig is the configuration of the private registries read from
+// registriesFile. Each registry is emitted as is in the registries section,
+// and is added to the update entries of the listed package ecosystems.
+type registriesConfig struct {
+	Registries map[string]registryConfig
+}
+
+type registryConfig struct {
+	registry          `yaml:",inline"`
+	PackageEcosystems []string `yaml:"package-ecosystems"`
+}
+
+// registries are the private registries of a Dependabot configuration.
+type registries struct {
+	// defs are the registries section.
+	defs map[string]registry
+	// byEco are the sorted names of the registries used by the update
+	// entries of a package ecosystem.
+	byEco map[string][]string
+}
+
+// apply adds the registries to c and to its update entries.
+func (r registries) apply(c *dependabotConfig) {
+	if len(r.defs) == 0 {
+		return
+	}
+	c.Registries = r.defs
+	for i := range c.Updates {
+		c.Updates[i].Registries = r.byEco[c.Updates[i].PackageEcosystem]
+	}
+}
+
+// loadRegistries returns the registries configured in path. An empty path
+// configures no registries.
+func loadRegistries(path string) (registries, error) {
+	if path == "" {
+		return registries{}, nil
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return registries{}, fmt.Errorf("failed to read registries configuration file: %w", err)
+	}
+
+	var conf registriesConfig
+	if err := yaml.Unmarshal(data, &conf); err != nil {
+		return registries{}, fmt.Errorf("%w: %v", errInvalidRegistries, err)
+	}
+
+	r := registries{
+		defs:  make(map[string]registry, len(conf.Registries)),
+		byEco: make(map[string][]string),
+	}
+	for name, rc := range conf.Registries {
+		if err := validateRegistry(rc); err != nil {
+			return registries{}, fmt.Errorf("%w: registry %q: %v", errInvalidRegistries, name, err)
+		}
+		r.defs[name] = rc.registry
+		for _, eco := range rc.PackageEcosystems {
+			r.byEco[eco] = append(r.byEco[eco], name)
+		}
+	}
+	for _, names := range r.byEco {
+		sort.Strings(names)
+	}
+	return r, nil
+}
+
+func validateRegistry(rc registryConfig) error {
+	if rc.Type == "" {
+		return errors.New("missing type")
+	}
+	if rc.URL == "" {
+		return errors.New("missing url")
+	}
+	for _, cred := range []string{rc.Password, rc.Key, rc.Token} {
+		if cred != "" && !secretRef.MatchString(cred) {
+			return errors.New("credentials must reference a secret, e.g. ${{secrets.NAME}}")
+		}
+	}
+	for _, eco := range rc.PackageEcosystems {
+		switch eco {
+		case ghPkgEco, dockerPkgEco, gomodPkgEco, submodulePkgEco:
+		default:
+			return fmt.Errorf("unsupported package ecosystem %q", eco)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/modfile"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	goproxyRegistry = registry{
+		Type:     "goproxy-server",
+		URL:      "https://goproxy.example.com",
+		Username: "octocat",
+		Password: "${{secrets.GOPROXY_PASSWORD}}",
+	}
+	ghcrRegistry = registry{
+		Type:         "docker-registry",
+		URL:          "https://ghcr.example.com",
+		Username:     "octocat",
+		Password:     "${{secrets.GHCR_PASSWORD}}",
+		ReplacesBase: true,
+	}
+)
+
+func setRegistriesFile(t *testing.T, path string) {
+	t.Helper()
+	t.Cleanup(func(f string) func() { return func() { registriesFile = f } }(registriesFile))
+	registriesFile = path
+}
+
+func TestLoadRegistries(t *testing.T) {
+	regs, err := loadRegistries("")
+	require.NoError(t, err)
+	assert.Empty(t, regs.defs)
+
+	regs, err = loadRegistries(filepath.Join("testdata", "registries.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]registry{
+		"goproxy": goproxyRegistry,
+		"ghcr":    ghcrRegistry,
+	}, regs.defs)
+	assert.Equal(t, map[string][]string{
+		dockerPkgEco: {"ghcr"},
+		gomodPkgEco:  {"ghcr", "goproxy"},
+	}, regs.byEco)
+}
+
+func TestLoadRegistriesErrors(t *testing.T) {
+	_, err := loadRegistries(filepath.Join("testdata", "missing.yml"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	tests := []struct {
+		name string
+		conf string
+		want error
+	}{
+		{
+			name: "invalid yaml",
+			conf: "registries: [",
+			want: errInvalidRegistries,
+		},
+		{
+			name: "missing type",
+			conf: "registries: {a: {url: https://example.com}}",
+			want: errInvalidRegistries,
+		},
+		{
+			name: "missing url",
+			conf: "registries: {a: {type: goproxy-server}}",
+			want: errInvalidRegistries,
+		},
+		{
+			name: "plain password",
+			conf: "registries: {a: {type: goproxy-server, url: https://example.com, password: hunter2}}",
+			want: errInvalidRegistries,
+		},
+		{
+			name: "plain token",
+			conf: "registries: {a: {type: goproxy-server, url: https://example.com, token: abc}}",
+			want: errInvalidRegistries,
+		},
+		{
+			name: "unsupported ecosystem",
+			conf: "registries: {a: {type: npm-registry, url: https://example.com, package-ecosystems: [npm]}}",
+			want: errInvalidRegistries,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "registries.yml")
+			require.NoError(t, os.WriteFile(path, []byte(test.conf), 0o600))
+			_, err := loadRegistries(path)
+			assert.ErrorIs(t, err, test.want)
+		})
+	}
+}
+
+func TestBuildConfigRegistries(t *testing.T) {
+	setRegistriesFile(t, filepath.Join("testdata", "registries.yml"))
+
+	root := t.TempDir()
+	writeRepoFiles(t, root, ".github/workflows/ci.yml", "Dockerfile")
+	mods := []*modfile.File{
+		{Syntax: &modfile.FileSyntax{Name: filepath.Join(root, "go.mod")}},
+	}
+
+	got, err := buildConfig(root, mods)
+	require.NoError(t, err)
+
+	withRegistries := func(u update, names ...string) update {
+		u.Registries = names
+		return u
+	}
+	assert.Equal(t, &dependabotConfig{
+		Version: version2,
+		Registries: map[string]registry{
+			"goproxy": goproxyRegistry,
+			"ghcr":    ghcrRegistry,
+		},
+		Updates: []update{
+			newUpdate(ghPkgEco, "/", actionLabels),
+			withRegistries(newUpdate(dockerPkgEco, "/", dockerLabels), "ghcr"),
+			withRegistries(newUpdate(gomodPkgEco, "/", goLabels), "ghcr", "goproxy"),
+		},
+	}, got)
+}
+
+func TestRunFixRegistries(t *testing.T) {
+	setRegistriesFile(t, filepath.Join("testdata", "registries.yml"))
+	t.Cleanup(func(f func() (string, []*modfile.File, error)) func() {
+		return func() { allModsFunc = f }
+	}(allModsFunc))
+	allModsFunc = func() (string, []*modfile.File, error) {
+		return "/home/user/repo", []*modfile.File{
+			{Syntax: &modfile.FileSyntax{Name: "/home/user/repo/go.mod"}},
+			{Syntax: &modfile.FileSyntax{Name: "/home/user/repo/c/go.mod"}},
+		}, nil
+	}
+
+	path := copyTestdata(t, "dependabot.yml")
+	// Fixing twice must not duplicate the registries.
+	require.NoError(t, fix([]string{path}))
+	require.NoError(t, fix([]string{path}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var c dependabotConfig
+	require.NoError(t, yaml.Unmarshal(data, &c))
+	assert.Equal(t, map[string]registry{
+		"goproxy": goproxyRegistry,
+		"ghcr":    ghcrRegistry,
+	}, c.Registries)
+	require.Len(t, c.Updates, 3)
+	assert.Empty(t, c.Updates[0].Registries)
+	for _, u := range c.Updates[1:] {
+		assert.Equal(t, []string{"ghcr", "goproxy"}, u.Registries, u.Directory)
+	}
+
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal(data, &doc))
+	keys := doc.Content[0].Content
+	require.Len(t, keys, 6)
+	assert.Equal(t, []string{"version", "registries", "updates"}, []string{keys[0].Value, keys[2].Value, keys[4].Value})
+}
@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	formatDependabot = "dependabot"
+	formatRenovate   = "renovate"
+
+	renovateSchema = "https://docs.renovatebot.com/renovate-schema.json"
+)
+
+// outputFormat is the format of the generated configuration.
+var outputFormat = formatDependabot
+
+var (
+	errInvalidFormat       = errors.New("invalid output format")
+	errRenovateRegistries  = errors.New("private registries are only supported in the dependabot format")
+	errUnknownPkgEcosystem = errors.New("package ecosystem not supported by Renovate")
+)
+
+// renovateManagers maps Dependabot package ecosystems to Renovate managers.
+var renovateManagers = map[string]string{
+	ghPkgEco:        "github-actions",
+	dockerPkgEco:    "dockerfile",
+	gomodPkgEco:     "gomod",
+	submodulePkgEco: "git-submodules",
+}
+
+type renovateConfig struct {
+	Schema          string                `json:"$schema"`
+	Description     []string              `json:"description"`
+	EnabledManagers []string              `json:"enabledManagers"`
+	GitSubmodules   *renovateManager      `json:"git-submodules,omitempty"`
+	PackageRules    []renovatePackageRule `json:"packageRules"`
+}
+
+type renovateManager struct {
+	Enabled bool `json:"enabled"`
+}
+
+type renovatePackageRule struct {
+	MatchManagers        []string `json:"matchManagers"`
+	MatchPaths           []string `json:"matchPaths,omitempty"`
+	MatchPackageNames    []string `json:"matchPackageNames,omitempty"`
+	MatchPackagePatterns []string `json:"matchPackagePatterns,omitempty"`
+	GroupName            string   `json:"groupName,omitempty"`
+	Labels               []string `json:"labels,omitempty"`
+	Schedule             []string `json:"schedule,omitempty"`
+}
+
+// renovateSchedule returns the Renovate schedule of the Dependabot schedule
+// s. Daily updates are not restricted.
+func renovateSchedule(s schedule) []string {
+	switch s.Interval {
+	case "weekly":
+		day := s.Day
+		if day == "" {
+			day = "monday"
+		}
+		return []string{"on " + day}
+	case "monthly":
+		return []string{"on the first day of the month"}
+	default:
+		return nil
+	}
+}
+
+// buildRenovateConfig converts the Dependabot configuration c into a Renovate
+// configuration with a package rule for each update entry, and one for each
+// of its dependency groups.
+func buildRenovateConfig(c *dependabotConfig) (*renovateConfig, error) {
+	if len(c.Registries) > 0 {
+		return nil, errRenovateRegistries
+	}
+
+	rc := &renovateConfig{
+		Schema:      renovateSchema,
+		Description: []string{strings.TrimPrefix(header, "# ")},
+	}
+	managers := make(map[string]bool)
+	for _, u := range c.Updates {
+		manager, ok := renovateManagers[u.PackageEcosystem]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errUnknownPkgEcosystem, u.PackageEcosystem)
+		}
+		if !managers[manager] {
+			managers[manager] = true
+			rc.EnabledManagers = append(rc.EnabledManagers, manager)
+		}
+		if u.PackageEcosystem == submodulePkgEco {
+			// The git submodules manager is disabled by default.
+			rc.GitSubmodules = &renovateManager{Enabled: true}
+		}
+
+		var paths []string
+		switch u.PackageEcosystem {
+		case gomodPkgEco:
+			paths = []string{strings.TrimPrefix(strings.TrimSuffix(u.Directory, "/")+"/go.mod", "/")}
+		case dockerPkgEco:
+			paths = []string{strings.TrimPrefix(strings.TrimSuffix(u.Directory, "/")+"/*Dockerfile*", "/")}
+		}
+		rc.PackageRules = append(rc.PackageRules, renovatePackageRule{
+			MatchManagers: []string{manager},
+			MatchPaths:    paths,
+			Labels:        u.Labels,
+			Schedule:      renovateSchedule(u.Schedule),
+		})
+
+		names := make([]string, 0, len(u.Groups))
+		for name := range u.Groups {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			rule := renovatePackageRule{
+				MatchManagers: []string{manager},
+				MatchPaths:    paths,
+				GroupName:     name,
+			}
+			for _, p := range u.Groups[name].Patterns {
+				if p == "*" {
+					rule.MatchPackagePatterns = append(rule.MatchPackagePatterns, ".*")
+				} else {
+					rule.MatchPackageNames = append(rule.MatchPackageNames, p)
+				}
+			}
+			rc.PackageRules = append(rc.PackageRules, rule)
+		}
+	}
+	return rc, nil
+}
+
+// encodeRenovate writes the Renovate configuration converted from c to
+// output.
+func encodeRenovate(c *dependabotConfig) error {
+	rc, err := buildRenovateConfig(c)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(rc)
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRenovateConfig(t *testing.T) {
+	monthly := newUpdate(submodulePkgEco, "/", submodLabels)
+	monthly.Schedule = schedule{Interval: "monthly"}
+	tools := newUpdate(gomodPkgEco, "/tools", goLabels)
+	tools.Groups = map[string]group{toolsGroup: {Patterns: []string{"*"}}}
+	withTools := newUpdate(gomodPkgEco, "/a", goLabels)
+	withTools.Groups = map[string]group{toolsGroup: {Patterns: []string{"golang.org/x/tools"}}}
+
+	got, err := buildRenovateConfig(&dependabotConfig{
+		Version: version2,
+		Updates: []update{
+			newUpdate(ghPkgEco, "/", actionLabels),
+			newUpdate(dockerPkgEco, "/build", dockerLabels),
+			monthly,
+			newUpdate(gomodPkgEco, "/", goLabels),
+			withTools,
+			tools,
+		},
+	})
+	require.NoError(t, err)
+
+	weekly := []string{"on sunday"}
+	assert.Equal(t, &renovateConfig{
+		Schema:          renovateSchema,
+		Description:     []string{"File generated by dbotconf; DO NOT EDIT."},
+		EnabledManagers: []string{"github-actions", "dockerfile", "git-submodules", "gomod"},
+		GitSubmodules:   &renovateManager{Enabled: true},
+		PackageRules: []renovatePackageRule{
+			{MatchManagers: []string{"github-actions"}, Labels: actionLabels, Schedule: weekly},
+			{MatchManagers: []string{"dockerfile"}, MatchPaths: []string{"build/*Dockerfile*"}, Labels: dockerLabels, Schedule: weekly},
+			{MatchManagers: []string{"git-submodules"}, Labels: submodLabels, Schedule: []string{"on the first day of the month"}},
+			{MatchManagers: []string{"gomod"}, MatchPaths: []string{"go.mod"}, Labels: goLabels, Schedule: weekly},
+			{MatchManagers: []string{"gomod"}, MatchPaths: []string{"a/go.mod"}, Labels: goLabels, Schedule: weekly},
+			{MatchManagers: []string{"gomod"}, MatchPaths: []string{"a/go.mod"}, MatchPackageNames: []string{"golang.org/x/tools"}, GroupName: toolsGroup},
+			{MatchManagers: []string{"gomod"}, MatchPaths: []string{"tools/go.mod"}, Labels: goLabels, Schedule: weekly},
+			{MatchManagers: []string{"gomod"}, MatchPaths: []string{"tools/go.mod"}, MatchPackagePatterns: []string{".*"}, GroupName: toolsGroup},
+		},
+	}, got)
+}
+
+func TestBuildRenovateConfigErrors(t *testing.T) {
+	_, err := buildRenovateConfig(&dependabotConfig{
+		Registries: map[string]registry{"goproxy": {Type: "goproxy-server"}},
+	})
+	assert.ErrorIs(t, err, errRenovateRegistries)
+
+	_, err = buildRenovateConfig(&dependabotConfig{
+		Updates: []update{newUpdate("npm", "/", nil)},
+	})
+	assert.ErrorIs(t, err, errUnknownPkgEcosystem)
+}
+
+func TestRunGenerateRenovate(t *testing.T) {
+	var b bytes.Buffer
+	t.Cleanup(func(w io.Writer) func() { return func() { output = w } }(output))
+	output = &b
+	t.Cleanup(func(f string) func() { return func() { outputFormat = f } }(outputFormat))
+	outputFormat = formatRenovate
+	require.NoError(t, generate())
+
+	var c renovateConfig
+	require.NoError(t, json.NewDecoder(&b).Decode(&c))
+	assert.Equal(t, renovateSchema, c.Schema)
+	assert.NotEmpty(t, c.PackageRules)
+
+	outputFormat = "yaml"
+	assert.ErrorIs(t, generate(), errInvalidFormat)
+}
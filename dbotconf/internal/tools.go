@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"errors"
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+const (
+	toolsGroup   = "tools"
+	toolBuildTag = "tools"
+)
+
+// toolDependencies returns the paths of the modules required by mod to
+// provide tools, and whether all the direct dependencies of mod are tools.
+// Tools are declared with tool directives or imported by tools.go files,
+// which are constrained to the tools build tag.
+func toolDependencies(mod *modfile.File) ([]string, bool, error) {
+	pkgs := toolDirectives(mod)
+	imports, err := toolsFileImports(filepath.Dir(mod.Syntax.Name))
+	if err != nil {
+		return nil, false, err
+	}
+	pkgs = append(pkgs, imports...)
+
+	deps := make(map[string]struct{})
+	for _, pkg := range pkgs {
+		if m := providingModule(mod, pkg); m != "" {
+			deps[m] = struct{}{}
+		}
+	}
+	if len(deps) == 0 {
+		return nil, false, nil
+	}
+
+	onlyTools := true
+	for _, r := range mod.Require {
+		if _, ok := deps[r.Mod.Path]; !ok && !r.Indirect {
+			onlyTools = false
+			break
+		}
+	}
+
+	paths := make([]string, 0, len(deps))
+	for m := range deps {
+		paths = append(paths, m)
+	}
+	sort.Strings(paths)
+	return paths, onlyTools, nil
+}
+
+// toolDirectives returns the packages of the tool directives of mod.
+// golang.org/x/mod does not know these directives, they are read from the
+// file syntax.
+func toolDirectives(mod *modfile.File) []string {
+	if mod.Syntax == nil {
+		return nil
+	}
+
+	var pkgs []string
+	for _, stmt := range mod.Syntax.Stmt {
+		switch s := stmt.(type) {
+		case *modfile.Line:
+			if len(s.Token) == 2 && s.Token[0] == "tool" {
+				pkgs = append(pkgs, s.Token[1])
+			}
+		case *modfile.LineBlock:
+			if len(s.Token) != 1 || s.Token[0] != "tool" {
+				continue
+			}
+			for _, l := range s.Line {
+				if len(l.Token) == 1 {
+					pkgs = append(pkgs, l.Token[0])
+				}
+			}
+		}
+	}
+	return pkgs
+}
+
+// toolsFileImports returns the packages imported by the tools.go files of the
+// module in dir. Nested modules are not searched.
+func toolsFileImports(dir string) ([]string, error) {
+	var pkgs []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path == dir {
+				return nil
+			}
+			name := d.Name()
+			if name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		imports, err := toolsFileImportPaths(path)
+		if err != nil {
+			return err
+		}
+		pkgs = append(pkgs, imports...)
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return pkgs, err
+}
+
+// toolsFileImportPaths returns the packages imported by the Go file at path
+// if it is only built with the tools build tag, otherwise nil.
+func toolsFileImportPaths(path string) ([]string, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	isTools := false
+	for _, cg := range f.Comments {
+		if cg.Pos() > f.Package {
+			break
+		}
+		for _, c := range cg.List {
+			if !constraint.IsGoBuild(c.Text) && !constraint.IsPlusBuild(c.Text) {
+				continue
+			}
+			expr, err := constraint.Parse(c.Text)
+			if err != nil {
+				return nil, err
+			}
+			withTools := expr.Eval(func(tag string) bool { return tag == toolBuildTag })
+			without := expr.Eval(func(string) bool { return false })
+			isTools = withTools && !without
+		}
+	}
+	if !isTools {
+		return nil, nil
+	}
+
+	pkgs := make([]string, 0, len(f.Imports))
+	for _, imp := range f.Imports {
+		pkgs = append(pkgs, strings.Trim(imp.Path.Value, `"`))
+	}
+	return pkgs, nil
+}
+
+// providingModule returns the path of the module required by mod that
+// provides the package pkg, or an empty string if none does.
+func providingModule(mod *modfile.File, pkg string) string {
+	var provider string
+	for _, r := range mod.Require {
+		p := r.Mod.Path
+		if (pkg == p || strings.HasPrefix(pkg, p+"/")) && len(p) > len(provider) {
+			provider = p
+		}
+	}
+	return provider
+}
@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/modfile"
+)
+
+const toolsFile = `//go:build tools
+// +build tools
+
+package tools
+
+import (
+	_ "github.com/client9/misspell/cmd/misspell"
+	_ "github.com/golangci/golangci-lint/cmd/golangci-lint"
+)
+`
+
+// writeModule writes the files of a module to dir and returns its parsed
+// go.mod file.
+func writeModule(t *testing.T, dir string, files map[string]string) *modfile.File {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	goMod := filepath.Join(dir, "go.mod")
+	data, err := os.ReadFile(goMod)
+	require.NoError(t, err)
+	f, err := modfile.ParseLax(goMod, data, nil)
+	require.NoError(t, err)
+	return f
+}
+
+func TestToolDependencies(t *testing.T) {
+	testCases := []struct {
+		name      string
+		files     map[string]string
+		deps      []string
+		onlyTools bool
+	}{
+		{
+			name: "tools file",
+			files: map[string]string{
+				"go.mod": `module example.com/tools
+
+require (
+	github.com/client9/misspell v0.3.4
+	github.com/golangci/golangci-lint v1.50.1
+	golang.org/x/sys v0.1.0 // indirect
+)
+`,
+				"tools.go": toolsFile,
+			},
+			deps:      []string{"github.com/client9/misspell", "github.com/golangci/golangci-lint"},
+			onlyTools: true,
+		},
+		{
+			name: "tool directives",
+			files: map[string]string{
+				"go.mod": `module example.com/mod
+
+go 1.24
+
+require (
+	github.com/stretchr/testify v1.8.1
+	golang.org/x/tools v0.30.0
+)
+
+tool golang.org/x/tools/cmd/stringer
+
+tool (
+	golang.org/x/tools/cmd/goimports
+)
+`,
+				"mod.go": "package mod\n",
+			},
+			deps:      []string{"golang.org/x/tools"},
+			onlyTools: false,
+		},
+		{
+			name: "nested tools file",
+			files: map[string]string{
+				"go.mod": `module example.com/mod
+
+require (
+	github.com/client9/misspell v0.3.4
+	github.com/golangci/golangci-lint v1.50.1
+	github.com/stretchr/testify v1.8.1
+)
+`,
+				"internal/tools/tools.go": toolsFile,
+			},
+			deps:      []string{"github.com/client9/misspell", "github.com/golangci/golangci-lint"},
+			onlyTools: false,
+		},
+		{
+			name: "nested module tools file",
+			files: map[string]string{
+				"go.mod": `module example.com/mod
+
+require github.com/client9/misspell v0.3.4
+`,
+				"tools/go.mod":   "module example.com/mod/tools\n",
+				"tools/tools.go": toolsFile,
+			},
+		},
+		{
+			name: "no tools",
+			files: map[string]string{
+				"go.mod": `module example.com/mod
+
+require github.com/client9/misspell v0.3.4
+`,
+				"mod.go": "//go:build !tools\n\npackage mod\n\nimport _ \"github.com/client9/misspell\"\n",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mod := writeModule(t, t.TempDir(), tc.files)
+			deps, onlyTools, err := toolDependencies(mod)
+			require.NoError(t, err)
+			assert.Equal(t, tc.deps, deps)
+			assert.Equal(t, tc.onlyTools, onlyTools)
+		})
+	}
+}
+
+func TestBuildConfigToolGroups(t *testing.T) {
+	root := t.TempDir()
+	mods := []*modfile.File{
+		writeModule(t, root, map[string]string{
+			"go.mod": `module example.com/mod
+
+require (
+	github.com/stretchr/testify v1.8.1
+	golang.org/x/tools v0.30.0
+)
+
+tool golang.org/x/tools/cmd/stringer
+`,
+		}),
+		writeModule(t, filepath.Join(root, "tools"), map[string]string{
+			"go.mod": `module example.com/mod/tools
+
+require (
+	github.com/client9/misspell v0.3.4
+	github.com/golangci/golangci-lint v1.50.1
+)
+`,
+			"tools.go": toolsFile,
+		}),
+	}
+
+	got, err := buildConfig(root, mods)
+	require.NoError(t, err)
+	require.Len(t, got.Updates, 2)
+	assert.Equal(t, map[string]group{
+		toolsGroup: {Patterns: []string{"golang.org/x/tools"}},
+	}, got.Updates[0].Groups)
+	assert.Equal(t, map[string]group{
+		toolsGroup: {Patterns: []string{"*"}},
+	}, got.Updates[1].Groups)
+}
@@ -23,6 +23,8 @@ import (
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
 )
 
 var (
@@ -96,9 +98,16 @@ func verify(args []string) error {
 	return nil
 }
 
+// verifyFix is whether verify fixes the configuration in place instead of
+// reporting missing update checks.
+var verifyFix bool
+
 func runVerify(c *cobra.Command, args []string) {
-	if err := verify(args); err != nil {
-		fmt.Printf("%s: %v", c.CommandPath(), err)
-		os.Exit(1)
+	check := verify
+	if verifyFix {
+		check = fix
+	}
+	if err := check(args); err != nil {
+		logging.Fatalf("%s: %v", c.CommandPath(), err)
 	}
 }
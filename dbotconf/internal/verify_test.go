@@ -16,6 +16,8 @@ package internal
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -74,6 +76,66 @@ func TestRunVerifyMissing(t *testing.T) {
 	assert.ErrorIs(t, verify([]string{""}), errMissing)
 }
 
+func TestRunVerifyFix(t *testing.T) {
+	t.Cleanup(func(f func() (string, []*modfile.File, error)) func() {
+		return func() { allModsFunc = f }
+	}(allModsFunc))
+	allModsFunc = func() (string, []*modfile.File, error) {
+		return "/home/user/repo", []*modfile.File{
+			{Syntax: &modfile.FileSyntax{Name: "/home/user/repo/go.mod"}},
+			{Syntax: &modfile.FileSyntax{Name: "/home/user/repo/c/go.mod"}},
+		}, nil
+	}
+	t.Cleanup(func() { verifyFix = false })
+	verifyFix = true
+
+	const config = `version: 2
+updates:
+  # Hand-written, keep weekly.
+  - package-ecosystem: docker
+    directory: /
+    schedule:
+      interval: weekly
+  - package-ecosystem: gomod
+    directory: /
+    schedule:
+      interval: daily
+  - package-ecosystem: gomod
+    directory: /removed
+    schedule:
+      interval: weekly
+`
+	path := filepath.Join(t.TempDir(), "dependabot.yml")
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+
+	runVerify(verifyCmd, []string{path})
+	require.NoError(t, verify([]string{path}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `version: 2
+updates:
+  # Hand-written, keep weekly.
+  - package-ecosystem: docker
+    directory: /
+    schedule:
+      interval: weekly
+  - package-ecosystem: gomod
+    directory: /
+    schedule:
+      interval: daily
+  - package-ecosystem: gomod
+    directory: /c
+    labels:
+      - dependencies
+      - go
+      - Skip Changelog
+    schedule:
+      interval: weekly
+      day: sunday
+`, string(data))
+}
+
 func TestRunVerifyReturnAllModsError(t *testing.T) {
 	t.Cleanup(func(f func() (string, []*modfile.File, error)) func() {
 		return func() { allModsFunc = f }
@@ -14,14 +14,8 @@
 
 package main
 
-import (
-	"os"
-
-	"go.opentelemetry.io/build-tools/dbotconf/internal"
-)
+import "go.opentelemetry.io/build-tools/dbotconf/cmd"
 
 func main() {
-	if err := internal.BuildAndExecute(); err != nil {
-		os.Exit(1)
-	}
+	cmd.Execute()
 }
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package golden compares test output with the content of golden files.
+//
+// Golden files are updated, instead of compared with, when the tests are run
+// with the -update flag:
+//
+//	go test ./path/to/package -update
+//
+// Output containing values that change between runs, such as temporary
+// directories or timestamps, is normalized before it is compared or written.
+package golden
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files instead of comparing test output with them")
+
+// Update returns whether golden files are updated instead of compared with.
+func Update() bool {
+	return *update
+}
+
+// Normalizer rewrites test output before it is compared with, or written
+// to, a golden file.
+type Normalizer func([]byte) []byte
+
+// ReplaceString returns a Normalizer replacing all occurrences of old with
+// replacement.
+func ReplaceString(old, replacement string) Normalizer {
+	return func(b []byte) []byte {
+		if old == "" {
+			return b
+		}
+		return []byte(strings.ReplaceAll(string(b), old, replacement))
+	}
+}
+
+// ReplacePath returns a Normalizer replacing all occurrences of the file path
+// path, in both its native and slash separated form, with placeholder. It is
+// typically used with the temporary directory of a test.
+func ReplacePath(path, placeholder string) Normalizer {
+	native := ReplaceString(filepath.Clean(path), placeholder)
+	slash := ReplaceString(filepath.ToSlash(filepath.Clean(path)), placeholder)
+	return func(b []byte) []byte {
+		return slash(native(b))
+	}
+}
+
+// ReplaceRegexp returns a Normalizer replacing all matches of re with
+// replacement, which may refer to submatches as in regexp.ReplaceAll.
+func ReplaceRegexp(re *regexp.Regexp, replacement string) Normalizer {
+	return func(b []byte) []byte {
+		return re.ReplaceAll(b, []byte(replacement))
+	}
+}
+
+var timestampRE = regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})?`)
+
+// ReplaceTimestamps returns a Normalizer replacing all RFC 3339 timestamps,
+// with or without fractional seconds and time zone, with placeholder.
+func ReplaceTimestamps(placeholder string) Normalizer {
+	return func(b []byte) []byte {
+		return timestampRE.ReplaceAllLiteral(b, []byte(placeholder))
+	}
+}
+
+// Assert compares got, normalized by normalizers, with the content of the
+// golden file at path. With the -update flag the golden file is written with
+// the normalized output instead, creating its directory if needed.
+func Assert(t testing.TB, path string, got []byte, normalizers ...Normalizer) bool {
+	t.Helper()
+
+	for _, n := range normalizers {
+		got = n(got)
+	}
+
+	if *update {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750), "could not create golden file directory")
+		require.NoError(t, os.WriteFile(path, got, 0o600), "could not update golden file")
+		return true
+	}
+
+	want, err := os.ReadFile(filepath.Clean(path))
+	require.NoError(t, err, "could not read golden file, run the test with -update to create it")
+	return assert.Equal(t, string(want), string(got), "output differs from golden file %s, run the test with -update to update it", path)
+}
+
+// AssertFile is like Assert but compares the content of the file at
+// actualPath.
+func AssertFile(t testing.TB, path string, actualPath string, normalizers ...Normalizer) bool {
+	t.Helper()
+
+	got, err := os.ReadFile(filepath.Clean(actualPath))
+	require.NoError(t, err, "could not read test output")
+	return Assert(t, path, got, normalizers...)
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizers(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	got := []byte("wrote " + filepath.Join(dir, "a.txt") + " and " + filepath.ToSlash(dir) + "/b.txt at 2023-01-02T15:04:05.123Z, took 12ms")
+
+	for _, n := range []Normalizer{
+		ReplacePath(dir, "$DIR"),
+		ReplaceTimestamps("$TIME"),
+		ReplaceRegexp(regexp.MustCompile(`took \d+ms`), "took $$DURATION"),
+		ReplaceString("wrote", "created"),
+		ReplaceString("", "ignored"),
+	} {
+		got = n(got)
+	}
+
+	assert.Equal(t, "created "+filepath.Join("$DIR", "a.txt")+" and $DIR/b.txt at $TIME, took $DURATION", string(got))
+}
+
+func TestReplaceTimestamps(t *testing.T) {
+	n := ReplaceTimestamps("T")
+	assert.Equal(t, "T T T T", string(n([]byte("2023-01-02T15:04:05Z 2023-01-02 15:04:05 2023-01-02T15:04:05.999+01:00 2023-01-02T15:04:05-07:00"))))
+	assert.Equal(t, "2023-01-02", string(n([]byte("2023-01-02"))))
+}
+
+func TestAssert(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nested", "out.golden")
+
+	t.Cleanup(func(u bool) func() { return func() { *update = u } }(*update))
+
+	*update = true
+	assert.True(t, Update())
+	assert.True(t, Assert(t, path, []byte("output in "+dir), ReplacePath(dir, "$DIR")))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "output in $DIR", string(content))
+
+	*update = false
+	assert.False(t, Update())
+	assert.True(t, Assert(t, path, []byte("output in "+dir), ReplacePath(dir, "$DIR")))
+
+	actual := filepath.Join(dir, "actual.txt")
+	require.NoError(t, os.WriteFile(actual, []byte("output in $DIR"), 0o600))
+	assert.True(t, AssertFile(t, path, actual))
+
+	mock := &testing.T{}
+	assert.False(t, Assert(mock, path, []byte("other output")))
+	assert.True(t, mock.Failed())
+}
@@ -0,0 +1,282 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logging provides the leveled, optionally colorized logger shared
+// by the build tools.
+//
+// Messages are written to the output of the standard library log package
+// unless another output is set, so existing redirection of the standard
+// logger keeps working.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Level is the severity of a log message.
+type Level int
+
+const (
+	// LevelDebug is used for detailed progress messages, such as one line
+	// per file or module processed.
+	LevelDebug Level = iota
+	// LevelInfo is used for messages describing the overall progress.
+	LevelInfo
+	// LevelWarn is used for problems that do not stop the tool.
+	LevelWarn
+	// LevelError is used for problems that stop the tool.
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("Level(%d)", int(l))
+	}
+}
+
+const (
+	colorReset  = "\x1b[0m"
+	colorGray   = "\x1b[90m"
+	colorYellow = "\x1b[33m"
+	colorRed    = "\x1b[31m"
+)
+
+func (l Level) color() string {
+	switch l {
+	case LevelDebug:
+		return colorGray
+	case LevelWarn:
+		return colorYellow
+	case LevelError:
+		return colorRed
+	default:
+		return ""
+	}
+}
+
+// ColorMode determines when output is colorized.
+type ColorMode int
+
+const (
+	// ColorAuto colorizes output written to a terminal, unless the NO_COLOR
+	// environment variable is set.
+	ColorAuto ColorMode = iota
+	// ColorAlways always colorizes output.
+	ColorAlways
+	// ColorNever never colorizes output.
+	ColorNever
+)
+
+// Logger writes leveled messages. The zero value logs messages at
+// LevelInfo and above to the standard library log output.
+type Logger struct {
+	mu    sync.Mutex
+	out   io.Writer
+	level Level
+	color ColorMode
+}
+
+// New returns a Logger writing messages at level and above to out. If out
+// is nil the output of the standard library log package is used.
+func New(out io.Writer, level Level, color ColorMode) *Logger {
+	return &Logger{out: out, level: level, color: color}
+}
+
+// SetLevel sets the minimum level of messages written.
+func (l *Logger) SetLevel(level Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.level = level
+}
+
+// Level returns the minimum level of messages written.
+func (l *Logger) Level() Level {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.level
+}
+
+// Enabled returns whether messages at level are written.
+func (l *Logger) Enabled(level Level) bool {
+	return level >= l.Level()
+}
+
+// SetOutput sets the destination of messages. If out is nil the output of
+// the standard library log package is used.
+func (l *Logger) SetOutput(out io.Writer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.out = out
+}
+
+// SetColor sets when messages are colorized.
+func (l *Logger) SetColor(mode ColorMode) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.color = mode
+}
+
+func (l *Logger) useColor(out io.Writer) bool {
+	switch l.color {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	f, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Log writes msg at level if the level is enabled. Warnings and errors are
+// prefixed with their level; all levels but info are colorized if enabled.
+func (l *Logger) Log(level Level, msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if level < l.level {
+		return
+	}
+
+	out := l.out
+	if out == nil {
+		out = log.Writer()
+	}
+
+	msg = strings.TrimRight(msg, "\n")
+	if level >= LevelWarn {
+		msg = level.String() + ": " + msg
+	}
+	if c := level.color(); c != "" && l.useColor(out) {
+		msg = c + msg + colorReset
+	}
+	_, _ = io.WriteString(out, msg+"\n")
+}
+
+// Debugf logs a message at LevelDebug. Arguments are handled in the manner
+// of fmt.Printf.
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	l.Log(LevelDebug, fmt.Sprintf(format, args...))
+}
+
+// Infof logs a message at LevelInfo. Arguments are handled in the manner of
+// fmt.Printf.
+func (l *Logger) Infof(format string, args ...interface{}) {
+	l.Log(LevelInfo, fmt.Sprintf(format, args...))
+}
+
+// Warnf logs a message at LevelWarn. Arguments are handled in the manner of
+// fmt.Printf.
+func (l *Logger) Warnf(format string, args ...interface{}) {
+	l.Log(LevelWarn, fmt.Sprintf(format, args...))
+}
+
+// Errorf logs a message at LevelError. Arguments are handled in the manner
+// of fmt.Printf.
+func (l *Logger) Errorf(format string, args ...interface{}) {
+	l.Log(LevelError, fmt.Sprintf(format, args...))
+}
+
+// Fatalf logs a message at LevelError and exits with status 1.
+func (l *Logger) Fatalf(format string, args ...interface{}) {
+	l.Errorf(format, args...)
+	osExit(1)
+}
+
+// osExit is replaced in tests.
+var osExit = os.Exit
+
+var std = New(nil, LevelInfo, ColorAuto)
+
+// Default returns the logger used by the package level functions.
+func Default() *Logger { return std }
+
+// Configure sets the level of the default logger from the --quiet and
+// --verbose command line flags. Quiet takes precedence.
+func Configure(quiet, verbose bool) {
+	switch {
+	case quiet:
+		std.SetLevel(LevelError)
+	case verbose:
+		std.SetLevel(LevelDebug)
+	default:
+		std.SetLevel(LevelInfo)
+	}
+}
+
+// QuietUsage and VerboseUsage are the help texts of the --quiet and
+// --verbose flags, shared so every tool describes them identically.
+const (
+	QuietUsage   = "only log errors"
+	VerboseUsage = "log detailed progress messages"
+)
+
+// SetLevel sets the minimum level of messages written by the default logger.
+func SetLevel(level Level) { std.SetLevel(level) }
+
+// SetOutput sets the destination of the default logger.
+func SetOutput(out io.Writer) { std.SetOutput(out) }
+
+// SetColor sets when the default logger colorizes messages.
+func SetColor(mode ColorMode) { std.SetColor(mode) }
+
+// Enabled returns whether the default logger writes messages at level.
+func Enabled(level Level) bool { return std.Enabled(level) }
+
+// Debugf logs a message at LevelDebug with the default logger.
+func Debugf(format string, args ...interface{}) {
+	std.Log(LevelDebug, fmt.Sprintf(format, args...))
+}
+
+// Infof logs a message at LevelInfo with the default logger.
+func Infof(format string, args ...interface{}) {
+	std.Log(LevelInfo, fmt.Sprintf(format, args...))
+}
+
+// Warnf logs a message at LevelWarn with the default logger.
+func Warnf(format string, args ...interface{}) {
+	std.Log(LevelWarn, fmt.Sprintf(format, args...))
+}
+
+// Errorf logs a message at LevelError with the default logger.
+func Errorf(format string, args ...interface{}) {
+	std.Log(LevelError, fmt.Sprintf(format, args...))
+}
+
+// Fatalf logs a message at LevelError with the default logger and exits
+// with status 1.
+func Fatalf(format string, args ...interface{}) {
+	std.Log(LevelError, fmt.Sprintf(format, args...))
+	osExit(1)
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggerLevels(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, LevelInfo, ColorNever)
+
+	l.Debugf("debug %d", 1)
+	l.Infof("info %d", 2)
+	l.Warnf("warn %d", 3)
+	l.Errorf("error %d\n", 4)
+	assert.Equal(t, "info 2\nWARN: warn 3\nERROR: error 4\n", buf.String())
+
+	buf.Reset()
+	l.SetLevel(LevelDebug)
+	l.Debugf("debug")
+	assert.Equal(t, "debug\n", buf.String())
+
+	buf.Reset()
+	l.SetLevel(LevelError)
+	l.Infof("info")
+	l.Warnf("warn")
+	assert.Empty(t, buf.String())
+	assert.True(t, l.Enabled(LevelError))
+	assert.False(t, l.Enabled(LevelWarn))
+}
+
+func TestLoggerColor(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, LevelDebug, ColorAlways)
+
+	l.Infof("info")
+	l.Warnf("warn")
+	assert.Equal(t, "info\n"+colorYellow+"WARN: warn"+colorReset+"\n", buf.String())
+
+	// Non-terminal output is not colorized automatically.
+	buf.Reset()
+	l.SetColor(ColorAuto)
+	l.Errorf("error")
+	assert.Equal(t, "ERROR: error\n", buf.String())
+}
+
+func TestLoggerDefaultsToStandardLogOutput(t *testing.T) {
+	var buf bytes.Buffer
+	t.Cleanup(func(w io.Writer) func() {
+		return func() { log.SetOutput(w) }
+	}(log.Writer()))
+	log.SetOutput(&buf)
+
+	var l Logger
+	l.Infof("hello")
+	assert.Equal(t, "hello\n", buf.String())
+}
+
+func TestConfigure(t *testing.T) {
+	t.Cleanup(func() { Configure(false, false) })
+
+	Configure(true, true)
+	assert.Equal(t, LevelError, Default().Level())
+
+	Configure(false, true)
+	assert.Equal(t, LevelDebug, Default().Level())
+
+	Configure(false, false)
+	assert.Equal(t, LevelInfo, Default().Level())
+}
+
+func TestFatalf(t *testing.T) {
+	var buf bytes.Buffer
+	t.Cleanup(func() { osExit = os.Exit; SetOutput(nil) })
+	SetOutput(&buf)
+
+	var code int
+	osExit = func(c int) { code = c }
+	Fatalf("failed: %v", "reason")
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "ERROR: failed: reason\n", buf.String())
+}
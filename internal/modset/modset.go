@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package modset reads the module sets of multimod versioning files. It only
+// depends on the YAML parser so that tools other than multimod can read
+// versioning files.
+package modset
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ModuleSet is a set of modules released together at the same version.
+type ModuleSet struct {
+	Version string   `yaml:"version"`
+	Modules []string `yaml:"modules"`
+	// VersionOverrides hold back modules of the set at their own version
+	// instead of the version of the set.
+	VersionOverrides []VersionOverride `yaml:"version-overrides"`
+}
+
+// VersionOverride is the version of a module overriding the version of its
+// module set.
+type VersionOverride struct {
+	Module  string `yaml:"module"`
+	Version string `yaml:"version"`
+}
+
+// ModuleVersion returns the version of the module with import path mod, which
+// is the version of its override if any, otherwise the version of the set.
+func (s ModuleSet) ModuleVersion(mod string) string {
+	for _, override := range s.VersionOverrides {
+		if override.Module == mod {
+			return override.Version
+		}
+	}
+	return s.Version
+}
+
+// versioningFile is the part of a versioning file describing module sets.
+type versioningFile struct {
+	ModuleSets map[string]ModuleSet `yaml:"module-sets"`
+	// Include lists other versioning files, relative to the including file,
+	// whose module sets are merged into this one.
+	Include []string `yaml:"include"`
+}
+
+// Read returns the module sets of the versioning file at path and of the
+// versioning files it includes by name. Defining the same module set in more
+// than one file is an error.
+func Read(path string) (map[string]ModuleSet, error) {
+	return readIncludes(path, nil)
+}
+
+// readIncludes reads the module sets of the versioning file at path and,
+// recursively, of the versioning files it includes. including holds the files
+// currently being read to detect include cycles.
+func readIncludes(path string, including []string) (map[string]ModuleSet, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range including {
+		if f == absPath {
+			return nil, fmt.Errorf("versioning file %s includes itself", path)
+		}
+	}
+	including = append(including, absPath)
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	var f versioningFile
+	if err = yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	sets := f.ModuleSets
+	if sets == nil {
+		sets = make(map[string]ModuleSet)
+	}
+
+	for _, inc := range f.Include {
+		if !filepath.IsAbs(inc) {
+			inc = filepath.Join(filepath.Dir(path), inc)
+		}
+		incSets, err := readIncludes(inc, including)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s included by %s: %w", inc, path, err)
+		}
+		for name, set := range incSets {
+			if _, exists := sets[name]; exists {
+				return nil, fmt.Errorf("module set %s of %s is defined more than once", name, inc)
+			}
+			sets[name] = set
+		}
+	}
+	return sets, nil
+}
+
+// ModuleVersions returns the version of every module of the versioning file
+// at path and of the versioning files it includes by module path, honoring
+// the version overrides of the module sets.
+func ModuleVersions(path string) (map[string]string, error) {
+	sets, err := Read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make(map[string]string)
+	for _, set := range sets {
+		for _, mod := range set.Modules {
+			versions[mod] = set.ModuleVersion(mod)
+		}
+	}
+	return versions, nil
+}
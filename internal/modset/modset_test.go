@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modset
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeVersioningFile writes content to a versions.yaml file in a temporary
+// directory and returns its path.
+func writeVersioningFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "versions.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestRead(t *testing.T) {
+	path := writeVersioningFile(t, "module-sets:\n"+
+		"  stable:\n    version: v1.0.0\n    modules:\n      - example.com/repo\n      - example.com/repo/sdk\n"+
+		"  tools:\n    version: v0.2.0\n    modules:\n      - example.com/repo/tools\n"+
+		"excluded-modules:\n  - example.com/repo/internal\n")
+
+	sets, err := Read(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]ModuleSet{
+		"stable": {Version: "v1.0.0", Modules: []string{"example.com/repo", "example.com/repo/sdk"}},
+		"tools":  {Version: "v0.2.0", Modules: []string{"example.com/repo/tools"}},
+	}, sets)
+
+	sets, err = Read(writeVersioningFile(t, "excluded-modules:\n  - example.com/repo\n"))
+	require.NoError(t, err)
+	assert.Empty(t, sets)
+
+	_, err = Read(writeVersioningFile(t, "module-sets: [\n"))
+	assert.Error(t, err)
+
+	_, err = Read(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestReadIncludes(t *testing.T) {
+	path := writeVersioningFile(t, "module-sets:\n"+
+		"  stable:\n    version: v1.0.0\n    modules:\n      - example.com/repo\n"+
+		"include:\n  - contrib/versions.yaml\n")
+	dir := filepath.Dir(path)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "contrib"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "contrib", "versions.yaml"), []byte("module-sets:\n"+
+		"  contrib:\n    version: v0.3.0\n    modules:\n      - example.com/repo/contrib\n"+
+		"include:\n  - ../tools.yaml\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tools.yaml"), []byte("module-sets:\n"+
+		"  tools:\n    version: v0.2.0\n    modules:\n      - example.com/repo/tools\n"), 0600))
+
+	sets, err := Read(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]ModuleSet{
+		"stable":  {Version: "v1.0.0", Modules: []string{"example.com/repo"}},
+		"contrib": {Version: "v0.3.0", Modules: []string{"example.com/repo/contrib"}},
+		"tools":   {Version: "v0.2.0", Modules: []string{"example.com/repo/tools"}},
+	}, sets)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tools.yaml"), []byte("module-sets:\n"+
+		"  stable:\n    version: v0.2.0\n    modules:\n      - example.com/repo/tools\n"), 0600))
+	_, err = Read(path)
+	assert.ErrorContains(t, err, "module set stable")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tools.yaml"), []byte("include:\n  - versions.yaml\n"), 0600))
+	_, err = Read(path)
+	assert.ErrorContains(t, err, "includes itself")
+}
+
+func TestModuleVersions(t *testing.T) {
+	path := writeVersioningFile(t, "module-sets:\n"+
+		"  stable:\n    version: v1.0.0\n    modules:\n      - example.com/repo\n      - example.com/repo/sdk\n"+
+		"  tools:\n    version: v0.2.0\n    modules:\n      - example.com/repo/tools\n")
+
+	versions, err := ModuleVersions(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"example.com/repo":       "v1.0.0",
+		"example.com/repo/sdk":   "v1.0.0",
+		"example.com/repo/tools": "v0.2.0",
+	}, versions)
+
+	path = writeVersioningFile(t, "module-sets:\n"+
+		"  stable:\n    version: v1.2.0\n    modules:\n      - example.com/repo\n      - example.com/repo/sdk\n"+
+		"    version-overrides:\n      - module: example.com/repo/sdk\n        version: v1.1.0\n")
+
+	versions, err = ModuleVersions(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"example.com/repo":     "v1.2.0",
+		"example.com/repo/sdk": "v1.1.0",
+	}, versions)
+}
@@ -30,8 +30,9 @@ import (
 
 // FindRoot retrieves the root of the repository containing the current working directory.
 // Beginning at the current working directory (dir), the algorithm checks if joining the ".git"
-// suffix, such as "dir.get", is a valid file. Otherwise, it will continue checking the dir's
-// parent directory until it reaches the repo root or returns an error if it cannot be found.
+// suffix, such as "dir/.git", exists. It may be a directory or, for linked worktrees and
+// submodules, a file pointing at the git directory. Otherwise, it will continue checking the
+// dir's parent directory until it reaches the repo root or returns an error if it cannot be found.
 func FindRoot() (string, error) {
 	start, err := os.Getwd()
 	if err != nil {
@@ -93,7 +94,7 @@ func FindModules(root string) ([]*modfile.File, error) {
 			return err
 		}
 
-		mFile, err := modfile.Parse(goMod, b.Bytes(), nil)
+		mFile, err := ParseModFile(goMod, b.Bytes())
 		if err != nil {
 			return err
 		}
@@ -107,3 +108,33 @@ func FindModules(root string) ([]*modfile.File, error) {
 
 	return results, err
 }
+
+// newerDirectives are the go.mod directives unknown to the version of
+// golang.org/x/mod in use.
+var newerDirectives = map[string]bool{
+	"godebug":   true,
+	"tool":      true,
+	"toolchain": true,
+}
+
+// ParseModFile parses the go.mod file with name and content data. Files that
+// are only invalid because they use newer directives are parsed leniently, in
+// which case the directives are only available from the file syntax and its
+// replace and exclude directives are ignored.
+func ParseModFile(name string, data []byte) (*modfile.File, error) {
+	f, err := modfile.Parse(name, data, nil)
+	var errs modfile.ErrorList
+	if err == nil || !errors.As(err, &errs) {
+		return f, err
+	}
+	for _, e := range errs {
+		msg := e.Err.Error()
+		for _, prefix := range []string{"unknown directive: ", "unknown block type: "} {
+			msg = strings.TrimPrefix(msg, prefix)
+		}
+		if !newerDirectives[msg] {
+			return nil, err
+		}
+	}
+	return modfile.ParseLax(name, data, nil)
+}
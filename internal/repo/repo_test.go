@@ -35,6 +35,27 @@ func TestFindRepoRoot(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestFindRepoRootLinkedWorktree(t *testing.T) {
+	root := t.TempDir()
+	gitFile := filepath.Join(root, ".git")
+	require.NoError(t, os.WriteFile(gitFile, []byte("gitdir: /src/main/.git/worktrees/linked\n"), 0600))
+	sub := filepath.Join(root, "a", "b")
+	require.NoError(t, os.MkdirAll(sub, os.ModePerm))
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.Chdir(wd)) })
+	require.NoError(t, os.Chdir(sub))
+
+	expected, err := filepath.EvalSymlinks(root)
+	require.NoError(t, err)
+	actual, err := FindRoot()
+	require.NoError(t, err)
+	actual, err = filepath.EvalSymlinks(actual)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
 func TestFindModules(t *testing.T) {
 	root := t.TempDir()
 	dirs := []string{
@@ -63,6 +84,29 @@ func TestFindModules(t *testing.T) {
 	}
 }
 
+func TestFindModulesNewerDirectives(t *testing.T) {
+	root := t.TempDir()
+	goMod := filepath.Join(root, "go.mod")
+	content := []byte(`module fake.multi.mod.project
+
+go 1.24
+
+toolchain go1.24.1
+
+require golang.org/x/tools v0.30.0
+
+tool golang.org/x/tools/cmd/stringer
+`)
+	require.NoError(t, os.WriteFile(filepath.Clean(goMod), content, 0600))
+
+	got, err := FindModules(root)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "fake.multi.mod.project", got[0].Module.Mod.Path)
+	require.Len(t, got[0].Require, 1)
+	assert.Equal(t, "golang.org/x/tools", got[0].Require[0].Mod.Path)
+}
+
 func TestFindModulesReturnsErrorForInvalidGoModFile(t *testing.T) {
 	root := t.TempDir()
 	goMod := filepath.Join(root, "go.mod")
@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/github"
+	"github.com/joshdk/go-junit"
+	"go.uber.org/zap"
+)
+
+const (
+	// commitSHAKey is the environment variable holding the commit the check
+	// run is created for. It is only required for the checks output.
+	commitSHAKey = "CIRCLE_SHA1"
+
+	checkRunNameTemplate = `Test failures (job: ${jobName})`
+
+	// maxAnnotationsPerRequest is the maximum number of annotations the
+	// GitHub API accepts in a single check run request.
+	maxAnnotationsPerRequest = 50
+)
+
+// fileLineRegexp matches Go source locations, such as "foo_test.go:42", in
+// test failure output.
+var fileLineRegexp = regexp.MustCompile(`([\w\-./]+\.go):(\d+)`)
+
+// The check run types of github.com/google/go-github/github predate the final
+// Checks API and use outdated annotation fields, so the requests are defined
+// here.
+
+type checkRunAnnotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title,omitempty"`
+	Message         string `json:"message"`
+}
+
+type checkRunOutput struct {
+	Title       string               `json:"title"`
+	Summary     string               `json:"summary"`
+	Annotations []checkRunAnnotation `json:"annotations,omitempty"`
+}
+
+type checkRunRequest struct {
+	Name        string          `json:"name,omitempty"`
+	HeadSHA     string          `json:"head_sha,omitempty"`
+	DetailsURL  string          `json:"details_url,omitempty"`
+	Status      string          `json:"status,omitempty"`
+	Conclusion  string          `json:"conclusion,omitempty"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+	Output      *checkRunOutput `json:"output,omitempty"`
+}
+
+// createCheckRun creates a completed, failed GitHub Check Run for the
+// commit being built with inline annotations on the failing tests.
+func (rg *reportGenerator) createCheckRun() *github.CheckRun {
+	annotations := rg.getAnnotations(".")
+	output := &checkRunOutput{
+		Title:   fmt.Sprintf("%d failed tests", rg.countFailedTests()),
+		Summary: os.Expand(issueCommentTemplate, rg.templateHelper),
+	}
+	output.Annotations, annotations = splitAnnotations(annotations)
+
+	now := time.Now()
+	checkRun := new(github.CheckRun)
+	rg.sendCheckRunRequest(
+		http.MethodPost,
+		fmt.Sprintf("repos/%v/%v/check-runs", rg.envVariables[projectUsernameKey], rg.envVariables[projectRepoNameKey]),
+		checkRunRequest{
+			Name:        rg.getCheckRunName(),
+			HeadSHA:     rg.envVariables[commitSHAKey],
+			DetailsURL:  os.Getenv(circleBuildURLKey),
+			Status:      "completed",
+			Conclusion:  "failure",
+			CompletedAt: &now,
+			Output:      output,
+		},
+		http.StatusCreated,
+		checkRun,
+	)
+
+	// Annotations beyond the first batch are added by updating the check run.
+	for len(annotations) > 0 {
+		output.Annotations, annotations = splitAnnotations(annotations)
+		rg.sendCheckRunRequest(
+			http.MethodPatch,
+			fmt.Sprintf("repos/%v/%v/check-runs/%d", rg.envVariables[projectUsernameKey], rg.envVariables[projectRepoNameKey], checkRun.GetID()),
+			checkRunRequest{Output: output},
+			http.StatusOK,
+			nil,
+		)
+	}
+
+	return checkRun
+}
+
+func (rg *reportGenerator) sendCheckRunRequest(method, url string, body checkRunRequest, wantStatus int, v interface{}) {
+	req, err := rg.client.NewRequest(method, url, body)
+	if err != nil {
+		rg.logger.Fatal("Failed to create GitHub Check Run request", zap.Error(err))
+	}
+
+	response, err := rg.client.Do(rg.ctx, req, v)
+	if err != nil {
+		rg.logger.Fatal("Failed to send GitHub Check Run", zap.Error(err))
+	}
+
+	if response.StatusCode != wantStatus {
+		rg.handleBadResponses(response)
+	}
+}
+
+func (rg reportGenerator) getCheckRunName() string {
+	return strings.Replace(checkRunNameTemplate, "${jobName}", rg.envVariables[jobNameKey], 1)
+}
+
+func (rg reportGenerator) countFailedTests() int {
+	var count int
+	for _, s := range rg.testSuites {
+		for _, t := range s.Tests {
+			if t.Status == junit.StatusFailed {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// getAnnotations returns an annotation for every distinct source location
+// found in the output of failed tests. Locations are resolved relative to the
+// repository checked out at root, those that cannot be found are skipped.
+func (rg reportGenerator) getAnnotations(root string) []checkRunAnnotation {
+	var annotations []checkRunAnnotation
+	for _, s := range rg.testSuites {
+		for _, t := range s.Tests {
+			if t.Status != junit.StatusFailed {
+				continue
+			}
+
+			output := failureOutput(t)
+			seen := make(map[string]struct{})
+			for _, match := range fileLineRegexp.FindAllStringSubmatch(output, -1) {
+				if _, ok := seen[match[0]]; ok {
+					continue
+				}
+				seen[match[0]] = struct{}{}
+
+				line, err := strconv.Atoi(match[2])
+				if err != nil {
+					continue
+				}
+				filePath, ok := resolvePath(root, t.Classname, match[1])
+				if !ok {
+					rg.logger.Debug("Source file of test failure not found, not annotating",
+						zap.String("test", t.Name), zap.String("file", match[1]))
+					continue
+				}
+				annotations = append(annotations, checkRunAnnotation{
+					Path:            filePath,
+					StartLine:       line,
+					EndLine:         line,
+					AnnotationLevel: "failure",
+					Title:           t.Name,
+					Message:         output,
+				})
+			}
+		}
+	}
+	return annotations
+}
+
+// failureOutput returns the failure message and output of a test.
+func failureOutput(t junit.Test) string {
+	var parts []string
+	if t.Error != nil {
+		parts = append(parts, strings.TrimSpace(t.Error.Error()))
+	}
+	if out := strings.TrimSpace(t.SystemOut); out != "" {
+		parts = append(parts, out)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// resolvePath returns the path of file relative to the repository root and
+// whether it exists. Go test output usually only includes the base name of the
+// file, so it is looked up in the package directory, derived from the package
+// import path in classname by stripping leading path elements until a matching
+// file exists in root.
+func resolvePath(root, classname, file string) (string, bool) {
+	exists := func(p string) bool {
+		_, err := os.Stat(filepath.Join(root, filepath.FromSlash(p)))
+		return err == nil
+	}
+
+	pkgPath := classname
+	for pkgPath != "" {
+		if candidate := path.Join(pkgPath, file); exists(candidate) {
+			return candidate, true
+		}
+
+		i := strings.Index(pkgPath, "/")
+		if i < 0 {
+			break
+		}
+		pkgPath = pkgPath[i+1:]
+	}
+
+	// The file may already be relative to the root, or the package may be
+	// the module at the repository root.
+	return file, exists(file)
+}
+
+// splitAnnotations returns the next batch of annotations that fits in a
+// single request, and the remaining ones.
+func splitAnnotations(annotations []checkRunAnnotation) ([]checkRunAnnotation, []checkRunAnnotation) {
+	if len(annotations) <= maxAnnotationsPerRequest {
+		return annotations, nil
+	}
+	return annotations[:maxAnnotationsPerRequest], annotations[maxAnnotationsPerRequest:]
+}
@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/joshdk/go-junit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// writeFiles writes files, keyed by their slash separated path, to a new
+// temporary directory and returns it.
+func writeFiles(t *testing.T, files map[string]string) string {
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	return root
+}
+
+func TestResolvePath(t *testing.T) {
+	root := writeFiles(t, map[string]string{"pkg/sub/foo_test.go": "", "root_test.go": ""})
+
+	tests := []struct {
+		name      string
+		classname string
+		file      string
+		expected  string
+		exists    bool
+	}{
+		{
+			name:      "package in module",
+			classname: "example.com/repo/pkg/sub",
+			file:      "foo_test.go",
+			expected:  "pkg/sub/foo_test.go",
+			exists:    true,
+		},
+		{
+			name:      "package relative to root",
+			classname: "pkg/sub",
+			file:      "foo_test.go",
+			expected:  "pkg/sub/foo_test.go",
+			exists:    true,
+		},
+		{
+			name:      "file relative to root",
+			classname: "example.com/other",
+			file:      "pkg/sub/foo_test.go",
+			expected:  "pkg/sub/foo_test.go",
+			exists:    true,
+		},
+		{
+			name:      "module at root",
+			classname: "example.com/repo",
+			file:      "root_test.go",
+			expected:  "root_test.go",
+			exists:    true,
+		},
+		{
+			name:      "missing file",
+			classname: "example.com/repo/pkg/sub",
+			file:      "bar_test.go",
+			expected:  "bar_test.go",
+			exists:    false,
+		},
+		{
+			name:      "empty classname",
+			classname: "",
+			file:      "foo_test.go",
+			expected:  "foo_test.go",
+			exists:    false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, exists := resolvePath(root, tc.classname, tc.file)
+			assert.Equal(t, tc.expected, actual)
+			assert.Equal(t, tc.exists, exists)
+		})
+	}
+}
+
+func TestSplitAnnotations(t *testing.T) {
+	tests := []struct {
+		name              string
+		count             int
+		expectedBatch     int
+		expectedRemaining int
+	}{
+		{name: "none", count: 0, expectedBatch: 0, expectedRemaining: 0},
+		{name: "below limit", count: 10, expectedBatch: 10, expectedRemaining: 0},
+		{name: "at limit", count: maxAnnotationsPerRequest, expectedBatch: maxAnnotationsPerRequest, expectedRemaining: 0},
+		{name: "above limit", count: maxAnnotationsPerRequest + 1, expectedBatch: maxAnnotationsPerRequest, expectedRemaining: 1},
+		{name: "several batches", count: 2*maxAnnotationsPerRequest + 20, expectedBatch: maxAnnotationsPerRequest, expectedRemaining: maxAnnotationsPerRequest + 20},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			annotations := make([]checkRunAnnotation, tc.count)
+			for i := range annotations {
+				annotations[i].StartLine = i
+			}
+
+			batch, remaining := splitAnnotations(annotations)
+			assert.Len(t, batch, tc.expectedBatch)
+			assert.Len(t, remaining, tc.expectedRemaining)
+			// The annotations are kept in order across batches.
+			assert.Equal(t, annotations, append(batch, remaining...))
+		})
+	}
+}
+
+func TestGetAnnotations(t *testing.T) {
+	root := writeFiles(t, map[string]string{"pkg/foo_test.go": "", "pkg/helper.go": ""})
+
+	tests := []struct {
+		name     string
+		tests    []junit.Test
+		expected []checkRunAnnotation
+	}{
+		{
+			name: "passed test",
+			tests: []junit.Test{
+				{Name: "TestPass", Classname: "example.com/repo/pkg", Status: junit.StatusPassed, SystemOut: "foo_test.go:10: log"},
+			},
+		},
+		{
+			name: "failed test without location",
+			tests: []junit.Test{
+				{Name: "TestFail", Classname: "example.com/repo/pkg", Status: junit.StatusFailed, SystemOut: "panic: boom"},
+			},
+		},
+		{
+			name: "distinct locations",
+			tests: []junit.Test{
+				{
+					Name:      "TestFail",
+					Classname: "example.com/repo/pkg",
+					Status:    junit.StatusFailed,
+					Error:     errors.New("failed"),
+					SystemOut: "foo_test.go:12: unexpected value\nfoo_test.go:12: unexpected value\nhelper.go:40: called from here",
+				},
+			},
+			expected: []checkRunAnnotation{
+				{
+					Path:            "pkg/foo_test.go",
+					StartLine:       12,
+					EndLine:         12,
+					AnnotationLevel: "failure",
+					Title:           "TestFail",
+					Message:         "failed\nfoo_test.go:12: unexpected value\nfoo_test.go:12: unexpected value\nhelper.go:40: called from here",
+				},
+				{
+					Path:            "pkg/helper.go",
+					StartLine:       40,
+					EndLine:         40,
+					AnnotationLevel: "failure",
+					Title:           "TestFail",
+					Message:         "failed\nfoo_test.go:12: unexpected value\nfoo_test.go:12: unexpected value\nhelper.go:40: called from here",
+				},
+			},
+		},
+		{
+			name: "unknown file skipped",
+			tests: []junit.Test{
+				{Name: "TestFail", Classname: "example.com/repo/pkg", Status: junit.StatusFailed, SystemOut: "vendor.go:3: outside\nfoo_test.go:7: inside"},
+			},
+			expected: []checkRunAnnotation{
+				{
+					Path:            "pkg/foo_test.go",
+					StartLine:       7,
+					EndLine:         7,
+					AnnotationLevel: "failure",
+					Title:           "TestFail",
+					Message:         "vendor.go:3: outside\nfoo_test.go:7: inside",
+				},
+			},
+		},
+		{
+			name: "same location in several tests",
+			tests: []junit.Test{
+				{Name: "TestA", Classname: "example.com/repo/pkg", Status: junit.StatusFailed, SystemOut: "helper.go:5: bad"},
+				{Name: "TestB", Classname: "example.com/repo/pkg", Status: junit.StatusFailed, SystemOut: "helper.go:5: bad"},
+			},
+			expected: []checkRunAnnotation{
+				{Path: "pkg/helper.go", StartLine: 5, EndLine: 5, AnnotationLevel: "failure", Title: "TestA", Message: "helper.go:5: bad"},
+				{Path: "pkg/helper.go", StartLine: 5, EndLine: 5, AnnotationLevel: "failure", Title: "TestB", Message: "helper.go:5: bad"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rg := reportGenerator{
+				logger:     zap.NewNop(),
+				testSuites: []junit.Suite{{Name: "example.com/repo/pkg", Tests: tc.tests}},
+			}
+			assert.Equal(t, tc.expected, rg.getAnnotations(root))
+		})
+	}
+}
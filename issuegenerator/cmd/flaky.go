@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/joshdk/go-junit"
+	"go.uber.org/zap"
+)
+
+// flakyLabel is the label added to the GitHub issues created for flaky tests.
+const flakyLabel = "flaky"
+
+// flakyHistory is the content of the -flaky-state file: the failed tests of
+// the last runs of the job, oldest first.
+type flakyHistory struct {
+	Runs []flakyRun `json:"runs"`
+}
+
+// flakyRun holds the tests that failed in a run of the job.
+type flakyRun struct {
+	URL    string      `json:"url,omitempty"`
+	Failed []flakyTest `json:"failed"`
+}
+
+type flakyTest struct {
+	Classname string `json:"classname"`
+	Name      string `json:"name"`
+}
+
+// readFlakyHistory reads the flaky test history from path. A missing file is
+// an empty history, as on the first run.
+func readFlakyHistory(path string) (flakyHistory, error) {
+	var h flakyHistory
+	data, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return h, fmt.Errorf("could not read flaky test state: %w", err)
+	}
+	if err = json.Unmarshal(data, &h); err != nil {
+		return h, fmt.Errorf("invalid flaky test state %v: %w", path, err)
+	}
+	return h, nil
+}
+
+// writeFlakyHistory writes the flaky test history h to path.
+func writeFlakyHistory(path string, h flakyHistory) error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode flaky test state: %w", err)
+	}
+	if err = os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("could not write flaky test state: %w", err)
+	}
+	return nil
+}
+
+// record appends run to the history, keeping only the last window runs.
+func (h *flakyHistory) record(run flakyRun, window int) {
+	h.Runs = append(h.Runs, run)
+	if len(h.Runs) > window {
+		h.Runs = h.Runs[len(h.Runs)-window:]
+	}
+}
+
+// flakyTests returns how many runs of the history each test of the last run
+// failed in, for the tests that failed in at least threshold runs.
+func (h flakyHistory) flakyTests(threshold int) map[testKey]int {
+	if len(h.Runs) == 0 {
+		return nil
+	}
+	counts := make(map[testKey]int)
+	for _, run := range h.Runs {
+		seen := make(map[testKey]struct{})
+		for _, t := range run.Failed {
+			key := testKey{classname: t.Classname, name: t.Name}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			counts[key]++
+		}
+	}
+
+	flaky := make(map[testKey]int)
+	for _, t := range h.Runs[len(h.Runs)-1].Failed {
+		key := testKey{classname: t.Classname, name: t.Name}
+		if counts[key] >= threshold {
+			flaky[key] = counts[key]
+		}
+	}
+	return flaky
+}
+
+// createdIssueLabels returns the labels of a created issue: the issue labels,
+// and the flaky label if flaky tests were detected. The flaky label is not
+// used to look up existing issues, so issues opened before a test was found
+// to be flaky are still commented on.
+func (rg reportGenerator) createdIssueLabels() []string {
+	labels := append([]string(nil), rg.issueLabels...)
+	if len(rg.flakyTests) > 0 {
+		labels = append(labels, flakyLabel)
+	}
+	return labels
+}
+
+// failedTestsRun returns the run of the failed tests of the ingested reports.
+func (rg reportGenerator) failedTestsRun() flakyRun {
+	run := flakyRun{
+		URL:    os.Getenv(circleBuildURLKey),
+		Failed: []flakyTest{},
+	}
+	for _, s := range rg.testSuites {
+		for _, t := range s.Tests {
+			if t.Status == junit.StatusFailed {
+				run.Failed = append(run.Failed, flakyTest{Classname: t.Classname, Name: t.Name})
+			}
+		}
+	}
+	return run
+}
+
+// detectFlakyTests records the failed tests of the current run in the history
+// stored at statePath, keeping the last window runs, and sets the tests that
+// failed in at least threshold of them as the flaky tests of the report. It
+// returns whether any test failed in the current run.
+func (rg *reportGenerator) detectFlakyTests(statePath string, threshold, window int) bool {
+	h, err := readFlakyHistory(statePath)
+	if err != nil {
+		rg.logger.Fatal("Failed to read flaky test state", zap.Error(err))
+	}
+
+	run := rg.failedTestsRun()
+	h.record(run, window)
+	if err = writeFlakyHistory(statePath, h); err != nil {
+		rg.logger.Fatal("Failed to write flaky test state", zap.Error(err))
+	}
+
+	rg.flakyTests = h.flakyTests(threshold)
+	rg.flakyWindow = len(h.Runs)
+	rg.logger.Info("Flaky test state updated",
+		zap.String("path", statePath),
+		zap.Int("runs", len(h.Runs)),
+		zap.Int("failed_tests", len(run.Failed)),
+		zap.Int("flaky_tests", len(rg.flakyTests)),
+	)
+	return len(run.Failed) > 0
+}
+
+// flakySuffix returns how many of the recorded runs the failed test t failed
+// in, to be appended to its name, or an empty string if it is not flaky.
+func (rg reportGenerator) flakySuffix(t junit.Test) string {
+	n, ok := rg.flakyTests[testKey{classname: t.Classname, name: t.Name}]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(" (failed in %d of the last %d runs)", n, rg.flakyWindow)
+}
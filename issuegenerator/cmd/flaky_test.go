@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlakyHistoryRecord(t *testing.T) {
+	runs := func(urls ...string) []flakyRun {
+		var r []flakyRun
+		for _, u := range urls {
+			r = append(r, flakyRun{URL: u})
+		}
+		return r
+	}
+
+	tests := []struct {
+		name     string
+		history  []flakyRun
+		window   int
+		expected []flakyRun
+	}{
+		{
+			name:     "empty history",
+			window:   3,
+			expected: runs("new"),
+		},
+		{
+			name:     "below window",
+			history:  runs("1", "2"),
+			window:   3,
+			expected: runs("1", "2", "new"),
+		},
+		{
+			name:     "oldest run dropped",
+			history:  runs("1", "2", "3"),
+			window:   3,
+			expected: runs("2", "3", "new"),
+		},
+		{
+			name:     "window reduced",
+			history:  runs("1", "2", "3", "4"),
+			window:   2,
+			expected: runs("4", "new"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := flakyHistory{Runs: tt.history}
+			h.record(flakyRun{URL: "new"}, tt.window)
+			assert.Equal(t, tt.expected, h.Runs)
+		})
+	}
+}
+
+func TestFlakyHistoryFlakyTests(t *testing.T) {
+	a := flakyTest{Classname: "pkg", Name: "TestA"}
+	b := flakyTest{Classname: "pkg", Name: "TestB"}
+	run := func(failed ...flakyTest) flakyRun { return flakyRun{Failed: failed} }
+
+	tests := []struct {
+		name      string
+		runs      []flakyRun
+		threshold int
+		expected  map[testKey]int
+	}{
+		{
+			name:      "no runs",
+			threshold: 2,
+		},
+		{
+			name:      "one-off failure",
+			runs:      []flakyRun{run(), run(a)},
+			threshold: 2,
+			expected:  map[testKey]int{},
+		},
+		{
+			name:      "repeated failure",
+			runs:      []flakyRun{run(a), run(b), run(a, b)},
+			threshold: 2,
+			expected: map[testKey]int{
+				{classname: "pkg", name: "TestA"}: 2,
+				{classname: "pkg", name: "TestB"}: 2,
+			},
+		},
+		{
+			name:      "duplicate failures counted once per run",
+			runs:      []flakyRun{run(a, a), run(a, a, a)},
+			threshold: 3,
+			expected:  map[testKey]int{},
+		},
+		{
+			name:      "only tests of the last run",
+			runs:      []flakyRun{run(a), run(a), run(b)},
+			threshold: 1,
+			expected:  map[testKey]int{{classname: "pkg", name: "TestB"}: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := flakyHistory{Runs: tt.runs}
+			assert.Equal(t, tt.expected, h.flakyTests(tt.threshold))
+		})
+	}
+}
+
+func TestReadFlakyHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	h, err := readFlakyHistory(path)
+	require.NoError(t, err)
+	assert.Empty(t, h.Runs)
+
+	expected := flakyHistory{Runs: []flakyRun{{URL: "https://ci/1", Failed: []flakyTest{{Classname: "pkg", Name: "TestA"}}}}}
+	require.NoError(t, writeFlakyHistory(path, expected))
+	h, err = readFlakyHistory(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, h)
+}
+
+func TestCreatedIssueLabels(t *testing.T) {
+	rg := reportGenerator{issueLabels: []string{"ci"}}
+	assert.Equal(t, []string{"ci"}, rg.createdIssueLabels())
+
+	rg.flakyTests = map[testKey]int{{classname: "pkg", name: "TestA"}: 2}
+	assert.Equal(t, []string{"ci", flakyLabel}, rg.createdIssueLabels())
+	assert.Equal(t, []string{"ci"}, rg.issueLabels)
+}
@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/joshdk/go-junit"
+)
+
+// packageFailureTest is the name of the test reported for a package that
+// failed without a failed test, e.g. because it did not build or TestMain
+// panicked, as in the JUnit reports of gotestsum.
+const packageFailureTest = "TestMain"
+
+// goTestEvent is an event of the `go test -json` output, as converted by
+// test2json and written by gotestsum with --jsonfile.
+type goTestEvent struct {
+	Action  string
+	Package string
+	Test    string
+	Elapsed float64
+	Output  string
+	// ImportPath identifies the test build of a build-output event, and
+	// FailedBuild the failed test build of a package, since Go 1.24.
+	ImportPath  string
+	FailedBuild string
+}
+
+// isGoTestReport returns whether path is a `go test -json` report rather
+// than a JUnit one.
+func isGoTestReport(path string) bool {
+	return strings.HasSuffix(path, ".json")
+}
+
+// ingestReportFile ingests the test suites of a JUnit or `go test -json`
+// report file.
+func ingestReportFile(path string) ([]junit.Suite, error) {
+	if !isGoTestReport(path) {
+		return junit.IngestFile(path)
+	}
+
+	f, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ingestGoTestJSON(f)
+}
+
+// ingestGoTestJSON converts the events of a `go test -json` report into a
+// test suite per package, in the order they were first reported. Subtests
+// are kept as separate tests named after their full path, e.g.
+// TestFoo/bar, and the output of a failed test is its failure body, as in
+// the JUnit reports of go-junit-report. A package that failed without a
+// failed test is reported as a failed packageFailureTest test whose failure
+// body is the output of the package. Lines that are not JSON events, such as
+// build errors written to the same file, are ignored.
+func ingestGoTestJSON(r io.Reader) ([]junit.Suite, error) {
+	var pkgs []string
+	suites := make(map[string]*junit.Suite)
+	tests := make(map[string]map[string]int)
+	outputs := make(map[string]map[string]*strings.Builder)
+	failedPkgs := make(map[string]bool)
+	buildOutputs := make(map[string]*strings.Builder)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		b := bytes.TrimSpace(scanner.Bytes())
+		if len(b) == 0 || b[0] != '{' {
+			continue
+		}
+		var e goTestEvent
+		if err := json.Unmarshal(b, &e); err != nil {
+			return nil, fmt.Errorf("invalid go test event on line %d: %w", line, err)
+		}
+		if e.Action == "build-output" {
+			if buildOutputs[e.ImportPath] == nil {
+				buildOutputs[e.ImportPath] = &strings.Builder{}
+			}
+			buildOutputs[e.ImportPath].WriteString(e.Output)
+			continue
+		}
+		if e.Package == "" {
+			continue
+		}
+
+		s, ok := suites[e.Package]
+		if !ok {
+			s = &junit.Suite{Name: e.Package, Package: e.Package}
+			suites[e.Package] = s
+			tests[e.Package] = make(map[string]int)
+			outputs[e.Package] = map[string]*strings.Builder{"": {}}
+			pkgs = append(pkgs, e.Package)
+		}
+		if e.Test == "" {
+			switch e.Action {
+			case "output":
+				outputs[e.Package][""].WriteString(e.Output)
+			case "fail":
+				failedPkgs[e.Package] = true
+				if b := buildOutputs[e.FailedBuild]; b != nil {
+					outputs[e.Package][""].WriteString(b.String())
+				}
+			}
+			continue
+		}
+
+		i, ok := tests[e.Package][e.Test]
+		if !ok {
+			i = len(s.Tests)
+			tests[e.Package][e.Test] = i
+			outputs[e.Package][e.Test] = &strings.Builder{}
+			s.Tests = append(s.Tests, junit.Test{Name: e.Test, Classname: e.Package})
+		}
+		t := &s.Tests[i]
+		switch e.Action {
+		case "output":
+			outputs[e.Package][e.Test].WriteString(e.Output)
+		case "pass":
+			t.Status = junit.StatusPassed
+		case "skip":
+			t.Status = junit.StatusSkipped
+		case "fail":
+			t.Status = junit.StatusFailed
+		default:
+			continue
+		}
+		t.Duration = time.Duration(e.Elapsed * float64(time.Second))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]junit.Suite, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		s := suites[pkg]
+		ts := s.Tests[:0]
+		testFailed := false
+		for _, t := range s.Tests {
+			// A test without result was interrupted, e.g. by a timeout or a
+			// panic in another test, and only counts as failed if its package
+			// failed.
+			if t.Status == "" {
+				if !failedPkgs[pkg] {
+					continue
+				}
+				t.Status = junit.StatusFailed
+			}
+			if t.Status == junit.StatusFailed {
+				t.Error = junit.Error{Message: "Failed", Body: outputs[pkg][t.Name].String()}
+				testFailed = true
+			}
+			ts = append(ts, t)
+		}
+		if failedPkgs[pkg] && !testFailed {
+			ts = append(ts, junit.Test{
+				Name:      packageFailureTest,
+				Classname: pkg,
+				Status:    junit.StatusFailed,
+				Error:     junit.Error{Message: "Failed", Body: outputs[pkg][""].String()},
+			})
+		}
+		s.Tests = ts
+		s.Aggregate()
+		result = append(result, *s)
+	}
+	return result, nil
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/joshdk/go-junit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngestGoTestJSON(t *testing.T) {
+	// gotest.json was recorded with `go test -json` for packages whose tests
+	// pass, fail, have subtests, time out, panic in TestMain, and that do not
+	// build.
+	f, err := os.Open(filepath.Join("testdata", "gotest.json"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	suites, err := ingestGoTestJSON(f)
+	require.NoError(t, err)
+
+	type result struct {
+		name   string
+		status junit.Status
+		// body is a part of the failure body of a failed test.
+		body string
+	}
+	expected := []struct {
+		pkg   string
+		tests []result
+	}{
+		{
+			pkg: "example.com/gt/build",
+			tests: []result{
+				{name: packageFailureTest, status: junit.StatusFailed, body: "undefined: undefined"},
+			},
+		},
+		{
+			pkg: "example.com/gt/fail",
+			tests: []result{
+				{name: "TestFail", status: junit.StatusFailed, body: "unexpected value"},
+				{name: "TestSub", status: junit.StatusFailed, body: "--- FAIL: TestSub"},
+				{name: "TestSub/ok", status: junit.StatusPassed},
+				{name: "TestSub/bad", status: junit.StatusFailed, body: "bad input"},
+			},
+		},
+		{
+			pkg: "example.com/gt/pass",
+			tests: []result{
+				{name: "TestPass", status: junit.StatusPassed},
+				{name: "TestSkip", status: junit.StatusSkipped},
+			},
+		},
+		{
+			pkg: "example.com/gt/hang",
+			tests: []result{
+				{name: "TestQuick", status: junit.StatusPassed},
+				{name: "TestHang", status: junit.StatusFailed, body: "test timed out after 2s"},
+			},
+		},
+		{
+			pkg: "example.com/gt/testmain",
+			tests: []result{
+				{name: packageFailureTest, status: junit.StatusFailed, body: "panic: setup failed"},
+			},
+		},
+	}
+
+	require.Len(t, suites, len(expected))
+	for i, e := range expected {
+		s := suites[i]
+		assert.Equal(t, e.pkg, s.Package)
+		require.Len(t, s.Tests, len(e.tests), e.pkg)
+		for j, r := range e.tests {
+			test := s.Tests[j]
+			assert.Equal(t, r.name, test.Name, e.pkg)
+			assert.Equal(t, e.pkg, test.Classname)
+			assert.Equal(t, r.status, test.Status, test.Name)
+			if r.status == junit.StatusFailed {
+				require.IsType(t, junit.Error{}, test.Error, test.Name)
+				assert.Contains(t, test.Error.(junit.Error).Body, r.body, test.Name)
+			}
+		}
+	}
+}
+
+func TestIngestGoTestJSONPackageFailed(t *testing.T) {
+	// Passing tests of a package failing afterwards, e.g. with an exit in
+	// TestMain, are kept and the package failure is reported.
+	report := `{"Action":"run","Package":"example.com/a","Test":"TestA"}
+{"Action":"pass","Package":"example.com/a","Test":"TestA"}
+{"Action":"output","Package":"example.com/a","Output":"FAIL\texample.com/a\t0.01s\n"}
+{"Action":"fail","Package":"example.com/a"}
+FAIL example.com/a [setup failed]
+`
+	suites, err := ingestGoTestJSON(strings.NewReader(report))
+	require.NoError(t, err)
+	require.Len(t, suites, 1)
+	require.Len(t, suites[0].Tests, 2)
+	assert.Equal(t, junit.StatusPassed, suites[0].Tests[0].Status)
+	assert.Equal(t, packageFailureTest, suites[0].Tests[1].Name)
+	assert.Equal(t, junit.StatusFailed, suites[0].Tests[1].Status)
+	assert.Equal(t, junit.Error{Message: "Failed", Body: "FAIL\texample.com/a\t0.01s\n"}, suites[0].Tests[1].Error)
+	assert.Equal(t, 1, suites[0].Totals.Failed)
+}
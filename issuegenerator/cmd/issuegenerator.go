@@ -0,0 +1,437 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/google/go-github/github"
+	"github.com/joshdk/go-junit"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// Keys of required environment variables
+	projectUsernameKey = "CIRCLE_PROJECT_USERNAME"
+	projectRepoNameKey = "CIRCLE_PROJECT_REPONAME"
+	circleBuildURLKey  = "CIRCLE_BUILD_URL"
+	jobNameKey         = "CIRCLE_JOB"
+	// githubAPITokenKey is only required for the outputs creating GitHub
+	// issues or Check Runs.
+	githubAPITokenKey = "GITHUB_TOKEN" // #nosec G101
+)
+
+// Output modes selected with the -output flag.
+const (
+	outputIssue   = "issue"
+	outputChecks  = "checks"
+	outputSummary = "summary"
+	outputJira    = "jira"
+	outputWebhook = "webhook"
+)
+
+// Execute reports the failed CI job. By default it creates a GitHub issue, or
+// comments on the one created by a previous failure. With -output=checks it
+// creates a GitHub Check Run with inline annotations on the failing tests
+// instead, or in addition with -output=issue,checks. With -output=summary it
+// writes a summary of the failures, linking to the other reports, to the
+// GitHub Actions job summary. With -output=jira it creates, or comments on, a
+// Jira issue instead of a GitHub one. With -output=webhook it posts a message
+// to a Slack compatible webhook. With -flaky-state, the GitHub issue is only
+// created for tests that failed repeatedly in the last runs of the job.
+func Execute() {
+	var quiet, verbose bool
+	var output, label, flakyState string
+	var flakyThreshold, flakyWindow int
+	flag.BoolVar(&quiet, "quiet", false, "only log errors")
+	flag.BoolVar(&quiet, "q", false, "shorthand for -quiet")
+	flag.BoolVar(&verbose, "verbose", false, "log detailed progress messages")
+	flag.StringVar(&output, "output", outputIssue, "comma separated list of outputs to create: issue, checks, summary, jira, webhook")
+	flag.StringVar(&label, "label", "", "label of the created GitHub issues, existing issues are only looked up among the issues with it")
+	flag.StringVar(&flakyState, "flaky-state", "", "path of the file recording the failed tests of the last runs, only create an issue for tests failing repeatedly")
+	flag.IntVar(&flakyThreshold, "flaky-threshold", 2, "number of runs a test must fail in, within -flaky-window runs, to create an issue with -flaky-state")
+	flag.IntVar(&flakyWindow, "flaky-window", 10, "number of runs recorded in the -flaky-state file")
+	flag.Parse()
+
+	outputs, err := parseOutputs(output)
+	if err != nil {
+		fmt.Println(err)
+		flag.Usage()
+		os.Exit(2)
+	}
+	if flakyState != "" && (flakyThreshold < 1 || flakyWindow < flakyThreshold) {
+		fmt.Printf("invalid -flaky-threshold %d and -flaky-window %d, the threshold must be between 1 and the window\n", flakyThreshold, flakyWindow)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	reportArgs := flag.Args()
+
+	var requiredEnv []string
+	_, issueOutput := outputs[outputIssue]
+	_, checksOutput := outputs[outputChecks]
+	if issueOutput || checksOutput {
+		requiredEnv = append(requiredEnv, githubAPITokenKey)
+	}
+	if checksOutput {
+		requiredEnv = append(requiredEnv, commitSHAKey)
+	}
+	if _, ok := outputs[outputJira]; ok {
+		requiredEnv = append(requiredEnv, jiraURLKey, jiraProjectKeyKey, jiraAPITokenKey)
+	}
+	if _, ok := outputs[outputWebhook]; ok {
+		requiredEnv = append(requiredEnv, webhookURLKey)
+	}
+	if _, ok := outputs[outputSummary]; ok {
+		requiredEnv = append(requiredEnv, stepSummaryKey)
+	}
+	rg := newReportGenerator(reportArgs, logLevel(quiet, verbose), requiredEnv...)
+	if label != "" {
+		rg.issueLabels = append(rg.issueLabels, label)
+	}
+
+	if flakyState != "" {
+		if !rg.detectFlakyTests(flakyState, flakyThreshold, flakyWindow) {
+			rg.logger.Info("No failed tests, only the flaky test state was updated")
+			return
+		}
+		if len(rg.flakyTests) == 0 {
+			rg.logger.Info("No test failed often enough to be flaky, not reporting an issue",
+				zap.Int("flaky_threshold", flakyThreshold),
+				zap.Int("flaky_window", flakyWindow),
+			)
+			delete(outputs, outputIssue)
+		}
+	}
+
+	var links []summaryLink
+	for _, r := range rg.reporters(outputs) {
+		if link := r.report(links); link.URL != "" {
+			links = append(links, link)
+		}
+	}
+}
+
+// parseOutputs parses the comma separated list of outputs of the -output flag.
+func parseOutputs(output string) (map[string]struct{}, error) {
+	outputs := make(map[string]struct{})
+	for _, o := range strings.Split(output, ",") {
+		o = strings.TrimSpace(o)
+		switch o {
+		case outputIssue, outputChecks, outputSummary, outputJira, outputWebhook:
+			outputs[o] = struct{}{}
+		default:
+			return nil, fmt.Errorf("invalid output %q, must be one of %q, %q, %q, %q or %q", o, outputIssue, outputChecks, outputSummary, outputJira, outputWebhook)
+		}
+	}
+	return outputs, nil
+}
+
+// reportIssue creates a GitHub issue for the failed CI job, or comments on
+// the one created by a previous failure. It returns the URL of the created
+// issue or comment.
+func (rg *reportGenerator) reportIssue() string {
+	// Look for existing open GitHub Issue that resulted from previous
+	// failures of this job.
+	rg.logger.Debug("Searching GitHub for existing Issues")
+	existingIssue := rg.getExistingIssue()
+
+	if existingIssue == nil {
+		// If none exists, create a new GitHub Issue for the failure.
+		rg.logger.Debug("No existing Issues found, creating a new one.")
+		createdIssue := rg.createIssue()
+		rg.logger.Info("New GitHub Issue created", zap.String("html_url", *createdIssue.HTMLURL))
+		return createdIssue.GetHTMLURL()
+	}
+
+	// Otherwise, add a comment to the existing Issue.
+	rg.logger.Info(
+		"Updating GitHub Issue with latest failure",
+		zap.String("html_url", *existingIssue.HTMLURL),
+	)
+	createdIssueComment := rg.commentOnIssue(existingIssue)
+	rg.logger.Info("GitHub Issue updated", zap.String("html_url", *createdIssueComment.HTMLURL))
+	return createdIssueComment.GetHTMLURL()
+}
+
+// logLevel returns the minimum level logged given the -quiet and -verbose
+// flags. Quiet takes precedence.
+func logLevel(quiet, verbose bool) zapcore.Level {
+	switch {
+	case quiet:
+		return zapcore.ErrorLevel
+	case verbose:
+		return zapcore.DebugLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
+
+func newReportGenerator(reportArgs []string, level zapcore.Level, requiredEnv ...string) *reportGenerator {
+	cfg := zap.NewDevelopmentConfig()
+	cfg.Level = zap.NewAtomicLevelAt(level)
+	logger, err := cfg.Build()
+	if err != nil {
+		fmt.Printf("Failed to set up logger: %v", err)
+		os.Exit(1)
+	}
+
+	rg := &reportGenerator{
+		ctx:        context.Background(),
+		logger:     logger,
+		httpClient: http.DefaultClient,
+	}
+
+	rg.getRequiredEnv(requiredEnv...)
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: rg.envVariables[githubAPITokenKey]})
+	tc := oauth2.NewClient(rg.ctx, ts)
+	rg.client = github.NewClient(tc)
+
+	if len(reportArgs) > 0 {
+		files, err := findReportFiles(reportArgs)
+		if err != nil {
+			rg.logger.Warn(
+				"Failed to find test reports, omitting test results from report",
+				zap.Error(err),
+			)
+		}
+		rg.testSuites, rg.platforms = rg.ingestReports(files)
+	}
+
+	return rg
+}
+
+type reportGenerator struct {
+	ctx          context.Context
+	logger       *zap.Logger
+	client       *github.Client
+	httpClient   *http.Client
+	envVariables map[string]string
+	// issueLabels are the labels of the GitHub issues created for failures,
+	// existing issues are looked up among the issues with all of them.
+	issueLabels []string
+	testSuites  []junit.Suite
+	// platforms holds the platforms each failed test failed on, if the test
+	// reports are from more than one platform.
+	platforms map[testKey][]string
+	// flakyTests holds the number of recorded runs each flaky test failed
+	// in, out of flakyWindow runs, if -flaky-state is set.
+	flakyTests  map[testKey]int
+	flakyWindow int
+}
+
+// getRequiredEnv loads required environment variables for the main method.
+// Some of the environment variables are built-in in CircleCI, whereas others
+// need to be configured. See https://circleci.com/docs/2.0/env-vars/#built-in-environment-variables
+// for a list of built-in environment variables. Additional variables that are
+// required by the selected outputs are given as extraKeys.
+func (rg *reportGenerator) getRequiredEnv(extraKeys ...string) {
+	env := map[string]string{}
+
+	env[projectUsernameKey] = os.Getenv(projectUsernameKey)
+	env[projectRepoNameKey] = os.Getenv(projectRepoNameKey)
+	env[jobNameKey] = os.Getenv(jobNameKey)
+	for _, k := range extraKeys {
+		env[k] = os.Getenv(k)
+	}
+
+	for k, v := range env {
+		if v == "" {
+			rg.logger.Fatal(
+				"Required environment variable not set",
+				zap.String("env_var", k),
+			)
+		}
+	}
+
+	rg.envVariables = env
+}
+
+const (
+	issueTitleTemplate = `Bug report for failed CircleCI build (job: ${jobName})`
+	issueBodyTemplate  = `
+Auto-generated report for ${jobName} job build.
+
+Link to failed build: ${linkToBuild}
+
+${failedTests}
+
+**Note**: Information about any subsequent build failures that happen while
+this issue is open, will be added as comments with more information to this issue.
+`
+	issueCommentTemplate = `
+Link to latest failed build: ${linkToBuild}
+
+${failedTests}
+`
+)
+
+func (rg reportGenerator) templateHelper(param string) string {
+	switch param {
+	case "jobName":
+		return "`" + rg.envVariables[jobNameKey] + "`"
+	case "linkToBuild":
+		return os.Getenv(circleBuildURLKey)
+	case "failedTests":
+		return rg.getFailedTests()
+	default:
+		return ""
+	}
+}
+
+// getExistingIssue returns the open GitHub Issue created for previous
+// failures of the same job, with the same title and labels, or nil if there is
+// none. All the pages of open issues are searched, so a long list of open
+// issues does not hide it and cause a duplicate.
+func (rg *reportGenerator) getExistingIssue() *github.Issue {
+	opts := &github.IssueListByRepoOptions{
+		State:       "open",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	if len(rg.issueLabels) > 0 {
+		opts.Labels = rg.issueLabels
+	}
+
+	requiredTitle := rg.getIssueTitle()
+	for {
+		issues, response, err := rg.client.Issues.ListByRepo(
+			rg.ctx,
+			rg.envVariables[projectUsernameKey],
+			rg.envVariables[projectRepoNameKey],
+			opts,
+		)
+		if err != nil {
+			rg.logger.Fatal("Failed to search GitHub Issues", zap.Error(err))
+		}
+
+		if response.StatusCode != http.StatusOK {
+			rg.handleBadResponses(response)
+		}
+
+		for _, issue := range issues {
+			// Pull requests are listed as issues too.
+			if !issue.IsPullRequest() && issue.GetTitle() == requiredTitle {
+				return issue
+			}
+		}
+
+		if response.NextPage == 0 {
+			return nil
+		}
+		opts.Page = response.NextPage
+	}
+}
+
+// commentOnIssue adds a new comment on an existing GitHub issue with
+// information about the latest failure. This method is expected to be
+// called only if there's an existing open Issue for the current job.
+func (rg *reportGenerator) commentOnIssue(issue *github.Issue) *github.IssueComment {
+	body := os.Expand(issueCommentTemplate, rg.templateHelper)
+
+	issueComment, response, err := rg.client.Issues.CreateComment(
+		rg.ctx,
+		rg.envVariables[projectUsernameKey],
+		rg.envVariables[projectRepoNameKey],
+		*issue.Number,
+		&github.IssueComment{
+			Body: &body,
+		},
+	)
+	if err != nil {
+		rg.logger.Fatal("Failed to search GitHub Issues", zap.Error(err))
+	}
+
+	if response.StatusCode != http.StatusCreated {
+		rg.handleBadResponses(response)
+	}
+
+	return issueComment
+}
+
+// createIssue creates a new GitHub Issue corresponding to a build failure.
+func (rg *reportGenerator) createIssue() *github.Issue {
+	title := rg.getIssueTitle()
+	body := os.Expand(issueBodyTemplate, rg.templateHelper)
+
+	req := &github.IssueRequest{
+		Title: &title,
+		Body:  &body,
+		// TODO: Set Assignees
+	}
+	if labels := rg.createdIssueLabels(); len(labels) > 0 {
+		req.Labels = &labels
+	}
+
+	issue, response, err := rg.client.Issues.Create(
+		rg.ctx,
+		rg.envVariables[projectUsernameKey],
+		rg.envVariables[projectRepoNameKey],
+		req,
+	)
+	if err != nil {
+		rg.logger.Fatal("Failed to create GitHub Issue", zap.Error(err))
+	}
+
+	if response.StatusCode != http.StatusCreated {
+		rg.handleBadResponses(response)
+	}
+
+	return issue
+}
+
+func (rg reportGenerator) getIssueTitle() string {
+	return strings.Replace(issueTitleTemplate, "${jobName}", rg.envVariables[jobNameKey], 1)
+}
+
+// getFailedTests returns information about failed tests if available, otherwise
+// an empty string.
+func (rg reportGenerator) getFailedTests() string {
+	if len(rg.testSuites) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("#### Test Failures\n")
+
+	for _, s := range rg.testSuites {
+		for _, t := range s.Tests {
+			if t.Status != junit.StatusFailed {
+				continue
+			}
+			sb.WriteString("-  " + t.Name + rg.platformsSuffix(t) + rg.flakySuffix(t) + "\n")
+		}
+	}
+
+	return sb.String()
+}
+
+func (rg reportGenerator) handleBadResponses(response *github.Response) {
+	body, _ := io.ReadAll(response.Body)
+	rg.logger.Fatal(
+		"Unexpected response from GitHub",
+		zap.Int("status_code", response.StatusCode),
+		zap.String("response", string(body)),
+		zap.String("url", response.Request.URL.String()),
+	)
+}
@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-github/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParseOutputs(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		expected []string
+		err      string
+	}{
+		{name: "single", output: "issue", expected: []string{outputIssue}},
+		{
+			name:     "all",
+			output:   "issue,checks,summary,jira,webhook",
+			expected: []string{outputIssue, outputChecks, outputSummary, outputJira, outputWebhook},
+		},
+		{name: "spaces", output: " checks , summary ", expected: []string{outputChecks, outputSummary}},
+		{name: "duplicates", output: "issue,issue", expected: []string{outputIssue}},
+		{name: "unknown", output: "issue,email", err: `invalid output "email"`},
+		{name: "empty", output: "", err: `invalid output ""`},
+		{name: "trailing comma", output: "issue,", err: `invalid output ""`},
+		{name: "case sensitive", output: "Issue", err: `invalid output "Issue"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			outputs, err := parseOutputs(tc.output)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			expected := make(map[string]struct{}, len(tc.expected))
+			for _, o := range tc.expected {
+				expected[o] = struct{}{}
+			}
+			assert.Equal(t, expected, outputs)
+		})
+	}
+}
+
+// newGitHubClient returns a GitHub client sending its requests to server.
+func newGitHubClient(t *testing.T, server *httptest.Server) *github.Client {
+	client := github.NewClient(server.Client())
+	baseURL, err := url.Parse(server.URL + "/")
+	require.NoError(t, err)
+	client.BaseURL = baseURL
+	return client
+}
+
+func TestGetExistingIssue(t *testing.T) {
+	const title = "Bug report for failed CircleCI build (job: unit-tests)"
+	issue := func(number int, title string) *github.Issue {
+		return &github.Issue{Number: github.Int(number), Title: github.String(title)}
+	}
+	pullRequest := issue(1, title)
+	pullRequest.PullRequestLinks = &github.PullRequestLinks{URL: github.String("https://api.github.com/repos/org/repo/pulls/1")}
+
+	tests := []struct {
+		name           string
+		pages          [][]*github.Issue
+		expected       int
+		expectedPages  []string
+		expectedLabels string
+	}{
+		{
+			name:          "no issues",
+			pages:         [][]*github.Issue{{}},
+			expectedPages: []string{""},
+		},
+		{
+			name:          "first page",
+			pages:         [][]*github.Issue{{issue(2, "other"), issue(3, title)}, {issue(4, title)}},
+			expected:      3,
+			expectedPages: []string{""},
+		},
+		{
+			name: "last page",
+			pages: [][]*github.Issue{
+				{pullRequest, issue(2, "other")},
+				{issue(3, title+" (copy)")},
+				{issue(4, title)},
+			},
+			expected:      4,
+			expectedPages: []string{"", "2", "3"},
+		},
+		{
+			name:          "not found",
+			pages:         [][]*github.Issue{{issue(2, "other")}, {pullRequest}},
+			expectedPages: []string{"", "2"},
+		},
+		{
+			name:           "labels",
+			pages:          [][]*github.Issue{{issue(2, title)}},
+			expected:       2,
+			expectedPages:  []string{""},
+			expectedLabels: "ci,flaky",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var pages []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/repos/org/repo/issues", r.URL.Path)
+				query := r.URL.Query()
+				assert.Equal(t, "open", query.Get("state"))
+				assert.Equal(t, "100", query.Get("per_page"))
+				assert.Equal(t, tc.expectedLabels, query.Get("labels"))
+
+				page := query.Get("page")
+				pages = append(pages, page)
+				i := 0
+				if page != "" {
+					var err error
+					i, err = strconv.Atoi(page)
+					require.NoError(t, err)
+					i--
+				}
+				if i+1 < len(tc.pages) {
+					w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?page=%d>; rel="next"`, r.Host, r.URL.Path, i+2))
+				}
+				require.NoError(t, json.NewEncoder(w).Encode(tc.pages[i]))
+			}))
+			defer server.Close()
+
+			rg := &reportGenerator{
+				ctx:    context.Background(),
+				logger: zap.NewNop(),
+				client: newGitHubClient(t, server),
+				envVariables: map[string]string{
+					jobNameKey:         "unit-tests",
+					projectUsernameKey: "org",
+					projectRepoNameKey: "repo",
+				},
+			}
+			if tc.expectedLabels != "" {
+				rg.issueLabels = []string{"ci", "flaky"}
+			}
+
+			existing := rg.getExistingIssue()
+			if tc.expected == 0 {
+				assert.Nil(t, existing)
+			} else {
+				require.NotNil(t, existing)
+				assert.Equal(t, tc.expected, existing.GetNumber())
+			}
+			assert.Equal(t, tc.expectedPages, pages)
+		})
+	}
+}
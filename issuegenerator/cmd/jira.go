@@ -0,0 +1,280 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/joshdk/go-junit"
+	"go.uber.org/zap"
+)
+
+const (
+	// Keys of the environment variables configuring the Jira output. The
+	// issue type defaults to a bug and the user email is only needed to
+	// authenticate to Jira Cloud, the others are required.
+	jiraURLKey        = "JIRA_URL"
+	jiraProjectKeyKey = "JIRA_PROJECT_KEY"
+	jiraIssueTypeKey  = "JIRA_ISSUE_TYPE"
+	jiraUserEmailKey  = "JIRA_USER_EMAIL"
+	jiraAPITokenKey   = "JIRA_API_TOKEN" // #nosec G101
+
+	defaultJiraIssueType = "Bug"
+
+	jiraIssueBodyTemplate = `
+Auto-generated report for ${jobName} job build.
+
+Link to failed build: ${linkToBuild}
+
+${failedTests}
+
+*Note*: Information about any subsequent build failures that happen while
+this issue is open, will be added as comments with more information to this issue.
+`
+	jiraIssueCommentTemplate = `
+Link to latest failed build: ${linkToBuild}
+
+${failedTests}
+`
+)
+
+type jiraProject struct {
+	Key string `json:"key"`
+}
+
+type jiraIssueType struct {
+	Name string `json:"name"`
+}
+
+type jiraIssueFields struct {
+	Project     *jiraProject   `json:"project,omitempty"`
+	Summary     string         `json:"summary"`
+	Description string         `json:"description,omitempty"`
+	IssueType   *jiraIssueType `json:"issuetype,omitempty"`
+}
+
+type jiraIssue struct {
+	Key    string          `json:"key,omitempty"`
+	Fields jiraIssueFields `json:"fields"`
+}
+
+type jiraSearchRequest struct {
+	JQL        string   `json:"jql"`
+	Fields     []string `json:"fields"`
+	MaxResults int      `json:"maxResults"`
+}
+
+type jiraSearchResponse struct {
+	Issues []jiraIssue `json:"issues"`
+}
+
+type jiraComment struct {
+	ID   string `json:"id,omitempty"`
+	Body string `json:"body"`
+}
+
+// reportJiraIssue creates a Jira issue for the failed CI job, or comments on
+// the open one created by a previous failure. It returns the URL of the
+// created issue or comment.
+func (rg *reportGenerator) reportJiraIssue() string {
+	rg.logger.Debug("Searching Jira for existing issues")
+	existingIssue := rg.getExistingJiraIssue()
+
+	if existingIssue == nil {
+		rg.logger.Debug("No existing Jira issues found, creating a new one.")
+		createdIssue := rg.createJiraIssue()
+		issueURL := rg.jiraBrowseURL(createdIssue.Key)
+		rg.logger.Info("New Jira issue created", zap.String("html_url", issueURL))
+		return issueURL
+	}
+
+	rg.logger.Info(
+		"Updating Jira issue with latest failure",
+		zap.String("html_url", rg.jiraBrowseURL(existingIssue.Key)),
+	)
+	comment := rg.commentOnJiraIssue(existingIssue)
+	commentURL := rg.jiraBrowseURL(existingIssue.Key) + "?focusedCommentId=" + url.QueryEscape(comment.ID)
+	rg.logger.Info("Jira issue updated", zap.String("html_url", commentURL))
+	return commentURL
+}
+
+// getExistingJiraIssue returns the unresolved Jira issue of the project
+// related to previous failures of the same job, if any.
+func (rg *reportGenerator) getExistingJiraIssue() *jiraIssue {
+	title := rg.getIssueTitle()
+	jql := fmt.Sprintf(
+		"project = %s AND summary ~ %s AND statusCategory != Done ORDER BY created DESC",
+		jqlQuote(rg.envVariables[jiraProjectKeyKey]),
+		// Search for the exact phrase, the summary is compared below.
+		jqlQuote(`"`+jqlEscapePhrase(title)+`"`),
+	)
+
+	var result jiraSearchResponse
+	rg.sendJiraRequest(http.MethodPost, "rest/api/2/search", jiraSearchRequest{
+		JQL:        jql,
+		Fields:     []string{"summary"},
+		MaxResults: 50,
+	}, http.StatusOK, &result)
+
+	for i, issue := range result.Issues {
+		if issue.Fields.Summary == title {
+			return &result.Issues[i]
+		}
+	}
+	return nil
+}
+
+// createJiraIssue creates a new Jira issue corresponding to a build failure.
+func (rg *reportGenerator) createJiraIssue() *jiraIssue {
+	issueType := os.Getenv(jiraIssueTypeKey)
+	if issueType == "" {
+		issueType = defaultJiraIssueType
+	}
+
+	created := new(jiraIssue)
+	rg.sendJiraRequest(http.MethodPost, "rest/api/2/issue", jiraIssue{
+		Fields: jiraIssueFields{
+			Project:     &jiraProject{Key: rg.envVariables[jiraProjectKeyKey]},
+			Summary:     rg.getIssueTitle(),
+			Description: os.Expand(jiraIssueBodyTemplate, rg.jiraTemplateHelper),
+			IssueType:   &jiraIssueType{Name: issueType},
+		},
+	}, http.StatusCreated, created)
+	return created
+}
+
+// commentOnJiraIssue adds a comment on an existing Jira issue with
+// information about the latest failure.
+func (rg *reportGenerator) commentOnJiraIssue(issue *jiraIssue) *jiraComment {
+	comment := new(jiraComment)
+	rg.sendJiraRequest(
+		http.MethodPost,
+		"rest/api/2/issue/"+url.PathEscape(issue.Key)+"/comment",
+		jiraComment{Body: os.Expand(jiraIssueCommentTemplate, rg.jiraTemplateHelper)},
+		http.StatusCreated,
+		comment,
+	)
+	return comment
+}
+
+// jiraTemplateHelper expands the issue templates using Jira text formatting
+// instead of Markdown.
+func (rg reportGenerator) jiraTemplateHelper(param string) string {
+	switch param {
+	case "jobName":
+		return "{{" + rg.envVariables[jobNameKey] + "}}"
+	case "failedTests":
+		return rg.getJiraFailedTests()
+	default:
+		return rg.templateHelper(param)
+	}
+}
+
+// getJiraFailedTests returns information about failed tests if available,
+// otherwise an empty string.
+func (rg reportGenerator) getJiraFailedTests() string {
+	if len(rg.testSuites) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("h4. Test Failures\n")
+
+	for _, s := range rg.testSuites {
+		for _, t := range s.Tests {
+			if t.Status != junit.StatusFailed {
+				continue
+			}
+			sb.WriteString("* {{" + t.Name + "}}" + rg.platformsSuffix(t) + "\n")
+		}
+	}
+
+	return sb.String()
+}
+
+func (rg reportGenerator) jiraBrowseURL(key string) string {
+	return strings.TrimSuffix(rg.envVariables[jiraURLKey], "/") + "/browse/" + url.PathEscape(key)
+}
+
+// sendJiraRequest sends a request with the JSON encoded body to the Jira
+// REST API and decodes the response into v, if not nil. Jira Cloud is
+// authenticated with the user email and API token, other deployments with the
+// token as a personal access token.
+func (rg *reportGenerator) sendJiraRequest(method, apiPath string, body interface{}, wantStatus int, v interface{}) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		rg.logger.Fatal("Failed to encode Jira request", zap.Error(err))
+	}
+
+	reqURL := strings.TrimSuffix(rg.envVariables[jiraURLKey], "/") + "/" + apiPath
+	req, err := http.NewRequestWithContext(rg.ctx, method, reqURL, bytes.NewReader(data))
+	if err != nil {
+		rg.logger.Fatal("Failed to create Jira request", zap.Error(err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if email := os.Getenv(jiraUserEmailKey); email != "" {
+		req.SetBasicAuth(email, rg.envVariables[jiraAPITokenKey])
+	} else {
+		req.Header.Set("Authorization", "Bearer "+rg.envVariables[jiraAPITokenKey])
+	}
+
+	response, err := rg.httpClient.Do(req)
+	if err != nil {
+		rg.logger.Fatal("Failed to send Jira request", zap.Error(err))
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != wantStatus {
+		respBody, _ := io.ReadAll(response.Body)
+		rg.logger.Fatal(
+			"Unexpected response from Jira",
+			zap.Int("status_code", response.StatusCode),
+			zap.String("response", string(respBody)),
+			zap.String("url", reqURL),
+		)
+	}
+
+	if v != nil {
+		if err = json.NewDecoder(response.Body).Decode(v); err != nil {
+			rg.logger.Fatal("Failed to decode Jira response", zap.Error(err), zap.String("url", reqURL))
+		}
+	}
+}
+
+// jqlQuote returns s as a JQL string literal.
+func jqlQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// jqlEscapePhrase escapes the characters of s that are reserved in the text
+// search syntax of JQL.
+func jqlEscapePhrase(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`+-&|!(){}[]^~*?\:"`, r) {
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
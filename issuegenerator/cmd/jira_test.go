@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joshdk/go-junit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestJQLQuote(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "", expected: `""`},
+		{input: "PROJ", expected: `"PROJ"`},
+		{input: `say "hi"`, expected: `"say \"hi\""`},
+		{input: `C:\path`, expected: `"C:\\path"`},
+		{input: `\"`, expected: `"\\\""`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			assert.Equal(t, tc.expected, jqlQuote(tc.input))
+		})
+	}
+}
+
+func TestJQLEscapePhrase(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "plain words", expected: "plain words"},
+		{input: "Bug report (job: test)", expected: `Bug report \(job\: test\)`},
+		{input: `a+b-c&d|e!f`, expected: `a\+b\-c\&d\|e\!f`},
+		{input: `{x}[y]^~*?`, expected: `\{x\}\[y\]\^\~\*\?`},
+		{input: `"quoted" \ path`, expected: `\"quoted\" \\ path`},
+		{input: "ünïcode", expected: "ünïcode"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			assert.Equal(t, tc.expected, jqlEscapePhrase(tc.input))
+		})
+	}
+}
+
+// jiraRequest is a request received by a fakeJira.
+type jiraRequest struct {
+	method        string
+	path          string
+	authorization string
+	body          map[string]interface{}
+}
+
+// fakeJira is a Jira REST API server returning issues for searches, and
+// recording the requests it receives.
+type fakeJira struct {
+	*httptest.Server
+	issues   []jiraIssue
+	requests []jiraRequest
+}
+
+func newFakeJira(t *testing.T, issues ...jiraIssue) *fakeJira {
+	f := &fakeJira{issues: issues}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req := jiraRequest{method: r.Method, path: r.URL.Path, authorization: r.Header.Get("Authorization")}
+		require.NoError(t, json.Unmarshal(data, &req.body))
+		f.requests = append(f.requests, req)
+
+		switch r.URL.Path {
+		case "/rest/api/2/search":
+			require.NoError(t, json.NewEncoder(w).Encode(jiraSearchResponse{Issues: f.issues}))
+		case "/rest/api/2/issue":
+			w.WriteHeader(http.StatusCreated)
+			require.NoError(t, json.NewEncoder(w).Encode(jiraIssue{Key: "PROJ-3"}))
+		case "/rest/api/2/issue/PROJ-2/comment":
+			w.WriteHeader(http.StatusCreated)
+			require.NoError(t, json.NewEncoder(w).Encode(jiraComment{ID: "10100"}))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func newJiraReportGenerator(server *fakeJira) *reportGenerator {
+	return &reportGenerator{
+		ctx:        context.Background(),
+		logger:     zap.NewNop(),
+		httpClient: server.Client(),
+		envVariables: map[string]string{
+			jobNameKey:        "unit-tests",
+			jiraURLKey:        server.URL + "/",
+			jiraProjectKeyKey: "PROJ",
+			jiraAPITokenKey:   "token",
+		},
+		testSuites: []junit.Suite{{
+			Name: "pkg",
+			Tests: []junit.Test{
+				{Name: "TestFail", Classname: "pkg", Status: junit.StatusFailed},
+				{Name: "TestPass", Classname: "pkg", Status: junit.StatusPassed},
+			},
+		}},
+	}
+}
+
+// expectedSearch is the search request sent for the unit-tests job.
+var expectedSearch = map[string]interface{}{
+	"jql":        `project = "PROJ" AND summary ~ "\"Bug report for failed CircleCI build \\(job\\: unit\\-tests\\)\"" AND statusCategory != Done ORDER BY created DESC`,
+	"fields":     []interface{}{"summary"},
+	"maxResults": float64(50),
+}
+
+func TestReportJiraIssueCreate(t *testing.T) {
+	t.Setenv(circleBuildURLKey, "https://ci.example.com/build/1")
+	t.Setenv(jiraIssueTypeKey, "")
+	t.Setenv(jiraUserEmailKey, "")
+
+	// The issue of another job, whose title also matches the phrase search,
+	// is not reused.
+	server := newFakeJira(t, jiraIssue{Key: "PROJ-1", Fields: jiraIssueFields{Summary: "Bug report for failed CircleCI build (job: unit-tests-race)"}})
+	rg := newJiraReportGenerator(server)
+
+	assert.Equal(t, server.URL+"/browse/PROJ-3", rg.reportJiraIssue())
+	require.Len(t, server.requests, 2)
+
+	assert.Equal(t, jiraRequest{
+		method:        http.MethodPost,
+		path:          "/rest/api/2/search",
+		authorization: "Bearer token",
+		body:          expectedSearch,
+	}, server.requests[0])
+
+	assert.Equal(t, jiraRequest{
+		method:        http.MethodPost,
+		path:          "/rest/api/2/issue",
+		authorization: "Bearer token",
+		body: map[string]interface{}{
+			"fields": map[string]interface{}{
+				"project":   map[string]interface{}{"key": "PROJ"},
+				"summary":   "Bug report for failed CircleCI build (job: unit-tests)",
+				"issuetype": map[string]interface{}{"name": "Bug"},
+				"description": `
+Auto-generated report for {{unit-tests}} job build.
+
+Link to failed build: https://ci.example.com/build/1
+
+h4. Test Failures
+* {{TestFail}}
+
+
+*Note*: Information about any subsequent build failures that happen while
+this issue is open, will be added as comments with more information to this issue.
+`,
+			},
+		},
+	}, server.requests[1])
+}
+
+func TestReportJiraIssueComment(t *testing.T) {
+	t.Setenv(circleBuildURLKey, "https://ci.example.com/build/2")
+	t.Setenv(jiraIssueTypeKey, "Task")
+	t.Setenv(jiraUserEmailKey, "bot@example.com")
+
+	server := newFakeJira(t,
+		jiraIssue{Key: "PROJ-1", Fields: jiraIssueFields{Summary: "Bug report for failed CircleCI build (job: unit-tests-race)"}},
+		jiraIssue{Key: "PROJ-2", Fields: jiraIssueFields{Summary: "Bug report for failed CircleCI build (job: unit-tests)"}},
+	)
+	rg := newJiraReportGenerator(server)
+
+	assert.Equal(t, server.URL+"/browse/PROJ-2?focusedCommentId=10100", rg.reportJiraIssue())
+	require.Len(t, server.requests, 2)
+
+	basicAuth := "Basic Ym90QGV4YW1wbGUuY29tOnRva2Vu" // bot@example.com:token
+	assert.Equal(t, jiraRequest{
+		method:        http.MethodPost,
+		path:          "/rest/api/2/search",
+		authorization: basicAuth,
+		body:          expectedSearch,
+	}, server.requests[0])
+
+	assert.Equal(t, jiraRequest{
+		method:        http.MethodPost,
+		path:          "/rest/api/2/issue/PROJ-2/comment",
+		authorization: basicAuth,
+		body: map[string]interface{}{
+			"body": `
+Link to latest failed build: https://ci.example.com/build/2
+
+h4. Test Failures
+* {{TestFail}}
+
+`,
+		},
+	}, server.requests[1])
+}
+
+func TestCreateJiraIssueType(t *testing.T) {
+	t.Setenv(jiraIssueTypeKey, "Task")
+
+	server := newFakeJira(t)
+	rg := newJiraReportGenerator(server)
+
+	assert.Equal(t, "PROJ-3", rg.createJiraIssue().Key)
+	require.Len(t, server.requests, 1)
+	fields := server.requests[0].body["fields"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"name": "Task"}, fields["issuetype"])
+}
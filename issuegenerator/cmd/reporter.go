@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"go.uber.org/zap"
+)
+
+// reporter reports the failed CI job to one of the outputs. links are the
+// reports created by the outputs run before it. It returns the link to its
+// own report, with an empty URL if it does not create one.
+type reporter interface {
+	report(links []summaryLink) summaryLink
+}
+
+// reporterFunc adapts a function to the reporter interface.
+type reporterFunc func(links []summaryLink) summaryLink
+
+func (f reporterFunc) report(links []summaryLink) summaryLink {
+	return f(links)
+}
+
+// reporters returns the reporters of outputs, in the order they are run:
+// the outputs creating reports are run first, so the webhook message and the
+// job summary can link to them.
+func (rg *reportGenerator) reporters(outputs map[string]struct{}) []reporter {
+	ordered := []struct {
+		output string
+		r      reporter
+	}{
+		{outputIssue, reporterFunc(func([]summaryLink) summaryLink {
+			return summaryLink{Name: "GitHub Issue", URL: rg.reportIssue()}
+		})},
+		{outputChecks, reporterFunc(func([]summaryLink) summaryLink {
+			checkRun := rg.createCheckRun()
+			rg.logger.Info("GitHub Check Run created", zap.String("html_url", checkRun.GetHTMLURL()))
+			return summaryLink{Name: "GitHub Check Run", URL: checkRun.GetHTMLURL()}
+		})},
+		{outputJira, reporterFunc(func([]summaryLink) summaryLink {
+			return summaryLink{Name: "Jira Issue", URL: rg.reportJiraIssue()}
+		})},
+		{outputWebhook, reporterFunc(func(links []summaryLink) summaryLink {
+			rg.postWebhook(links)
+			rg.logger.Info("Webhook notification sent")
+			return summaryLink{}
+		})},
+		{outputSummary, reporterFunc(func(links []summaryLink) summaryLink {
+			rg.writeSummary(links)
+			rg.logger.Info("Job summary written", zap.String("path", rg.envVariables[stepSummaryKey]))
+			return summaryLink{}
+		})},
+	}
+
+	var reporters []reporter
+	for _, o := range ordered {
+		if _, ok := outputs[o.output]; ok {
+			reporters = append(reporters, o.r)
+		}
+	}
+	return reporters
+}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/joshdk/go-junit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// TestReporters runs the reporters against fake GitHub, Jira and webhook
+// servers, and checks the outputs creating reports are run first, so the
+// webhook message and the job summary link to them.
+func TestReporters(t *testing.T) {
+	issueLink := summaryLink{Name: "GitHub Issue", URL: "https://github.com/org/repo/issues/1"}
+	checkRunLink := summaryLink{Name: "GitHub Check Run", URL: "https://github.com/org/repo/runs/5"}
+
+	tests := []struct {
+		name          string
+		outputs       []string
+		expectedCalls []string
+		expectedLinks []string
+	}{
+		{
+			name:          "all outputs",
+			outputs:       []string{outputSummary, outputWebhook, outputJira, outputChecks, outputIssue},
+			expectedCalls: []string{"issue search", "issue", "check run", "jira", "jira", "webhook"},
+			expectedLinks: []string{"GitHub Issue", "GitHub Check Run", "Jira Issue"},
+		},
+		{
+			name:          "webhook and issue",
+			outputs:       []string{outputWebhook, outputIssue},
+			expectedCalls: []string{"issue search", "issue", "webhook"},
+			expectedLinks: []string{"GitHub Issue"},
+		},
+		{
+			name:    "summary only",
+			outputs: []string{outputSummary},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(circleBuildURLKey, "")
+			t.Setenv(jiraIssueTypeKey, "")
+			t.Setenv(jiraUserEmailKey, "")
+			summaryFile := filepath.Join(t.TempDir(), "summary.md")
+
+			var calls []string
+			gitHub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method + " " + r.URL.Path {
+				case "GET /repos/org/repo/issues":
+					calls = append(calls, "issue search")
+					_, _ = w.Write([]byte("[]"))
+				case "POST /repos/org/repo/issues":
+					calls = append(calls, "issue")
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write([]byte(`{"number":1,"html_url":"` + issueLink.URL + `"}`))
+				case "POST /repos/org/repo/check-runs":
+					calls = append(calls, "check run")
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write([]byte(`{"id":5,"html_url":"` + checkRunLink.URL + `"}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer gitHub.Close()
+
+			jira := newFakeJira(t)
+			jiraHandler := jira.Config.Handler
+			jira.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, "jira")
+				jiraHandler.ServeHTTP(w, r)
+			})
+
+			var webhookText string
+			webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, "webhook")
+				var msg webhookMessage
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+				webhookText = msg.Text
+				_, err := os.Stat(summaryFile)
+				assert.True(t, os.IsNotExist(err), "job summary written before the webhook")
+			}))
+			defer webhook.Close()
+
+			rg := &reportGenerator{
+				ctx:        context.Background(),
+				logger:     zap.NewNop(),
+				client:     newGitHubClient(t, gitHub),
+				httpClient: http.DefaultClient,
+				envVariables: map[string]string{
+					jobNameKey:         "unit-tests",
+					projectUsernameKey: "org",
+					projectRepoNameKey: "repo",
+					commitSHAKey:       "0123456789abcdef",
+					jiraURLKey:         jira.URL,
+					jiraProjectKeyKey:  "PROJ",
+					jiraAPITokenKey:    "token",
+					webhookURLKey:      webhook.URL,
+					stepSummaryKey:     summaryFile,
+				},
+				testSuites: []junit.Suite{{Name: "pkg", Tests: failedTests("pkg", "TestFail")}},
+			}
+
+			outputs := make(map[string]struct{})
+			for _, o := range tc.outputs {
+				outputs[o] = struct{}{}
+			}
+			reporters := rg.reporters(outputs)
+			require.Len(t, reporters, len(tc.outputs))
+
+			var links []summaryLink
+			for _, r := range reporters {
+				if link := r.report(links); link.URL != "" {
+					links = append(links, link)
+				}
+			}
+
+			assert.Equal(t, tc.expectedCalls, calls)
+			var names []string
+			for _, l := range links {
+				names = append(names, l.Name)
+			}
+			assert.Equal(t, tc.expectedLinks, names)
+
+			if _, ok := outputs[outputWebhook]; ok {
+				assert.Equal(t, rg.getWebhookText(links), webhookText)
+			}
+			if _, ok := outputs[outputSummary]; ok {
+				data, err := os.ReadFile(summaryFile)
+				require.NoError(t, err)
+				assert.Equal(t, rg.getSummary(".", links), string(data))
+			}
+		})
+	}
+}
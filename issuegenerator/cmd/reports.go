@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/joshdk/go-junit"
+	"go.uber.org/zap"
+)
+
+// reportFile is a JUnit or `go test -json` report file and the label of the platform, such as
+// the CI matrix job, that produced it.
+type reportFile struct {
+	label string
+	path  string
+}
+
+// testKey identifies a test across the reports of several platforms.
+type testKey struct {
+	classname string
+	name      string
+}
+
+// findReportFiles expands the report arguments into report files. An argument
+// is a JUnit or `go test -json` report file, a glob pattern matching report
+// files, or a directory searched recursively for *.xml and *.json report
+// files. It may be prefixed with "label=" to set the platform label of all its
+// reports. Otherwise reports found in a subdirectory of a directory argument
+// are labeled with the name of the subdirectory, and other reports with their
+// path without extension.
+func findReportFiles(args []string) ([]reportFile, error) {
+	var files []reportFile
+	for _, arg := range args {
+		label, pattern := "", arg
+		if i := strings.Index(arg, "="); i > 0 && !strings.ContainsAny(arg[:i], `/\`) {
+			label, pattern = arg[:i], arg[i+1:]
+		}
+
+		if fi, err := os.Stat(pattern); err == nil && fi.IsDir() {
+			dirFiles, err := findDirReportFiles(pattern, label)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, dirFiles...)
+			continue
+		}
+
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid report pattern %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no report found matching %q", pattern)
+		}
+		for _, m := range matches {
+			l := label
+			if l == "" {
+				l = strings.TrimSuffix(m, filepath.Ext(m))
+			}
+			files = append(files, reportFile{label: filepath.ToSlash(l), path: m})
+		}
+	}
+	return files, nil
+}
+
+// findDirReportFiles returns the *.xml and *.json report files in dir and its
+// subdirectories. If label is empty they are labeled as described by
+// findReportFiles.
+func findDirReportFiles(dir, label string) ([]reportFile, error) {
+	var files []reportFile
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || (filepath.Ext(path) != ".xml" && !isGoTestReport(path)) {
+			return nil
+		}
+
+		l := label
+		if l == "" {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			rel = filepath.ToSlash(rel)
+			if i := strings.Index(rel, "/"); i >= 0 {
+				l = rel[:i]
+			} else {
+				l = strings.TrimSuffix(rel, filepath.Ext(rel))
+			}
+		}
+		files = append(files, reportFile{label: l, path: path})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search reports in %s: %w", dir, err)
+	}
+	return files, nil
+}
+
+// ingestReports ingests and merges the test suites of the report files. A
+// test failing in several reports is only included once, with the failure
+// output of the first report. If the reports have more than one label, the
+// labels of the reports each failed test is included in are returned too.
+func (rg *reportGenerator) ingestReports(files []reportFile) ([]junit.Suite, map[testKey][]string) {
+	var suites []junit.Suite
+	platforms := make(map[testKey][]string)
+	labels := make(map[string]struct{})
+	for _, f := range files {
+		rg.logger.Info("Ingesting test report", zap.String("path", f.path), zap.String("platform", f.label))
+		fileSuites, err := ingestReportFile(f.path)
+		if err != nil {
+			rg.logger.Warn(
+				"Failed to ingest test report, omitting test results from report",
+				zap.String("path", f.path),
+				zap.Error(err),
+			)
+			continue
+		}
+		labels[f.label] = struct{}{}
+
+		for _, s := range fileSuites {
+			tests := make([]junit.Test, 0, len(s.Tests))
+			for _, t := range s.Tests {
+				if t.Status != junit.StatusFailed {
+					tests = append(tests, t)
+					continue
+				}
+				key := testKey{classname: t.Classname, name: t.Name}
+				seen, ok := platforms[key]
+				if !ok {
+					tests = append(tests, t)
+				}
+				if !containsString(seen, f.label) {
+					platforms[key] = append(seen, f.label)
+				}
+			}
+			s.Tests = tests
+			suites = append(suites, s)
+		}
+	}
+
+	if len(labels) < 2 {
+		return suites, nil
+	}
+	for _, l := range platforms {
+		sort.Strings(l)
+	}
+	return suites, platforms
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// platformsSuffix returns the platforms the failed test t failed on, to be
+// appended to its name, or an empty string if the test reports are not from
+// several platforms.
+func (rg reportGenerator) platformsSuffix(t junit.Test) string {
+	labels := rg.platforms[testKey{classname: t.Classname, name: t.Name}]
+	if len(labels) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(labels, ", ") + ")"
+}
@@ -0,0 +1,240 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/joshdk/go-junit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// junitReport returns a JUnit report with a suite of the tests of package
+// pkg. Tests are given as "name" for passed tests, or "name:output" for
+// failed tests.
+func junitReport(pkg string, tests ...string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<testsuites>\n<testsuite name=%q tests=\"%d\">\n", pkg, len(tests))
+	for _, test := range tests {
+		name, output, failed := strings.Cut(test, ":")
+		if !failed {
+			fmt.Fprintf(&sb, "<testcase classname=%q name=%q time=\"0\"></testcase>\n", pkg, name)
+			continue
+		}
+		fmt.Fprintf(&sb, "<testcase classname=%q name=%q time=\"0\"><failure message=\"Failed\">%s</failure></testcase>\n", pkg, name, output)
+	}
+	sb.WriteString("</testsuite>\n</testsuites>\n")
+	return sb.String()
+}
+
+func TestFindReportFiles(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"reports/linux/unit.xml":    "",
+		"reports/linux/gotest.json": "",
+		"reports/windows/unit.xml":  "",
+		"reports/top.xml":           "",
+		"reports/notes.txt":         "",
+		"single/report.xml":         "",
+		"odd/a=b.xml":               "",
+	})
+	path := func(name string) string {
+		return filepath.Join(root, filepath.FromSlash(name))
+	}
+	// label returns the label of a report given as a file or glob pattern.
+	label := func(name string) string {
+		return filepath.ToSlash(strings.TrimSuffix(path(name), filepath.Ext(name)))
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected []reportFile
+		err      string
+	}{
+		{
+			name: "directory",
+			args: []string{path("reports")},
+			expected: []reportFile{
+				{label: "linux", path: path("reports/linux/gotest.json")},
+				{label: "linux", path: path("reports/linux/unit.xml")},
+				{label: "top", path: path("reports/top.xml")},
+				{label: "windows", path: path("reports/windows/unit.xml")},
+			},
+		},
+		{
+			name: "labeled directory",
+			args: []string{"ci=" + path("reports/linux")},
+			expected: []reportFile{
+				{label: "ci", path: path("reports/linux/gotest.json")},
+				{label: "ci", path: path("reports/linux/unit.xml")},
+			},
+		},
+		{
+			name: "glob",
+			args: []string{path("reports/*/unit.xml")},
+			expected: []reportFile{
+				{label: label("reports/linux/unit.xml"), path: path("reports/linux/unit.xml")},
+				{label: label("reports/windows/unit.xml"), path: path("reports/windows/unit.xml")},
+			},
+		},
+		{
+			name: "labeled glob",
+			args: []string{"unit=" + path("reports/*/unit.xml")},
+			expected: []reportFile{
+				{label: "unit", path: path("reports/linux/unit.xml")},
+				{label: "unit", path: path("reports/windows/unit.xml")},
+			},
+		},
+		{
+			name: "several arguments",
+			args: []string{"linux=" + path("reports/linux/unit.xml"), path("single/report.xml")},
+			expected: []reportFile{
+				{label: "linux", path: path("reports/linux/unit.xml")},
+				{label: label("single/report.xml"), path: path("single/report.xml")},
+			},
+		},
+		{
+			name: "equal sign in path",
+			args: []string{path("odd/a=b.xml")},
+			expected: []reportFile{
+				{label: label("odd/a=b.xml"), path: path("odd/a=b.xml")},
+			},
+		},
+		{
+			name: "no match",
+			args: []string{path("missing/*.xml")},
+			err:  "no report found matching",
+		},
+		{
+			name: "invalid pattern",
+			args: []string{path("reports/[.xml")},
+			err:  "invalid report pattern",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			files, err := findReportFiles(tc.args)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, files)
+		})
+	}
+}
+
+// testNames returns the names of the tests of suites, and the output of the
+// failed ones, in the format of junitReport.
+func testNames(suites []junit.Suite) []string {
+	var names []string
+	for _, s := range suites {
+		for _, t := range s.Tests {
+			if t.Status == junit.StatusFailed {
+				names = append(names, t.Name+":"+failureOutput(t))
+				continue
+			}
+			names = append(names, t.Name)
+		}
+	}
+	return names
+}
+
+func TestIngestReports(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"linux.xml":   junitReport("pkg", "TestA:linux failure", "TestB", "TestC:linux failure"),
+		"windows.xml": junitReport("pkg", "TestA:windows failure", "TestB", "TestD:windows failure"),
+		"darwin.xml":  junitReport("pkg", "TestA:darwin failure"),
+		"broken.xml":  "<testsuites>",
+	})
+	report := func(label, name string) reportFile {
+		return reportFile{label: label, path: filepath.Join(root, name)}
+	}
+
+	tests := []struct {
+		name              string
+		files             []reportFile
+		expectedTests     []string
+		expectedPlatforms map[testKey][]string
+	}{
+		{
+			name:          "single report",
+			files:         []reportFile{report("linux", "linux.xml")},
+			expectedTests: []string{"TestA:linux failure", "TestB", "TestC:linux failure"},
+		},
+		{
+			name:  "merged platforms",
+			files: []reportFile{report("windows", "windows.xml"), report("linux", "linux.xml"), report("darwin", "darwin.xml")},
+			expectedTests: []string{
+				"TestA:windows failure", "TestB", "TestD:windows failure",
+				"TestB", "TestC:linux failure",
+			},
+			expectedPlatforms: map[testKey][]string{
+				{classname: "pkg", name: "TestA"}: {"darwin", "linux", "windows"},
+				{classname: "pkg", name: "TestC"}: {"linux"},
+				{classname: "pkg", name: "TestD"}: {"windows"},
+			},
+		},
+		{
+			name:  "same label",
+			files: []reportFile{report("ci", "linux.xml"), report("ci", "windows.xml")},
+			expectedTests: []string{
+				"TestA:linux failure", "TestB", "TestC:linux failure",
+				"TestB", "TestD:windows failure",
+			},
+		},
+		{
+			name:          "unreadable report skipped",
+			files:         []reportFile{report("linux", "linux.xml"), report("broken", "broken.xml")},
+			expectedTests: []string{"TestA:linux failure", "TestB", "TestC:linux failure"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rg := reportGenerator{logger: zap.NewNop()}
+			suites, platforms := rg.ingestReports(tc.files)
+			assert.Equal(t, tc.expectedTests, testNames(suites))
+			assert.Equal(t, tc.expectedPlatforms, platforms)
+		})
+	}
+}
+
+// TestIngestLabeledReports checks that a test failing in two reports labeled
+// on the command line is reported once, on both platforms.
+func TestIngestLabeledReports(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"amd64/unit.xml": junitReport("pkg", "TestA:amd64 failure", "TestB"),
+		"arm64/unit.xml": junitReport("pkg", "TestA:arm64 failure", "TestB"),
+	})
+
+	files, err := findReportFiles([]string{
+		"linux-arm64=" + filepath.Join(root, "arm64"),
+		"linux-amd64=" + filepath.Join(root, "amd64", "unit.xml"),
+	})
+	require.NoError(t, err)
+
+	rg := reportGenerator{logger: zap.NewNop()}
+	rg.testSuites, rg.platforms = rg.ingestReports(files)
+	assert.Equal(t, []string{"TestA:arm64 failure", "TestB", "TestB"}, testNames(rg.testSuites))
+	assert.Equal(t, " (linux-amd64, linux-arm64)", rg.platformsSuffix(rg.testSuites[0].Tests[0]))
+	assert.Equal(t, 1, rg.countFailedTests())
+}
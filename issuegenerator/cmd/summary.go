@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/joshdk/go-junit"
+	"go.uber.org/zap"
+)
+
+const (
+	// stepSummaryKey is the environment variable holding the path of the
+	// GitHub Actions job summary file. It is only required for the summary
+	// output.
+	stepSummaryKey = "GITHUB_STEP_SUMMARY"
+
+	// maxSummaryOutputLength is the maximum length of the failure output of
+	// a single test included in the summary.
+	maxSummaryOutputLength = 2000
+)
+
+// summaryLink is a link to a report created by another output.
+type summaryLink struct {
+	Name string
+	URL  string
+}
+
+// writeSummary appends a Markdown summary of the failed tests, grouped by
+// module, and links to the reports created by the other outputs to the
+// GitHub Actions job summary.
+func (rg *reportGenerator) writeSummary(links []summaryLink) {
+	f, err := os.OpenFile(rg.envVariables[stepSummaryKey], os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		rg.logger.Fatal("Failed to open job summary", zap.Error(err))
+	}
+
+	if _, err = f.WriteString(rg.getSummary(".", links)); err != nil {
+		_ = f.Close()
+		rg.logger.Fatal("Failed to write job summary", zap.Error(err))
+	}
+	if err = f.Close(); err != nil {
+		rg.logger.Fatal("Failed to write job summary", zap.Error(err))
+	}
+}
+
+// getSummary returns the Markdown job summary. Modules of the failed tests are
+// resolved relative to the repository checked out at root.
+func (rg reportGenerator) getSummary(root string, links []summaryLink) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Test failures (job: `%s`)\n\n", rg.envVariables[jobNameKey])
+
+	if buildURL := os.Getenv(circleBuildURLKey); buildURL != "" {
+		fmt.Fprintf(&sb, "- [Failed build](%s)\n", buildURL)
+	}
+	for _, l := range links {
+		fmt.Fprintf(&sb, "- [%s](%s)\n", l.Name, l.URL)
+	}
+	sb.WriteString("\n")
+
+	failures := make(map[string][]junit.Test)
+	modules := make(map[string]string)
+	for _, s := range rg.testSuites {
+		for _, t := range s.Tests {
+			if t.Status != junit.StatusFailed {
+				continue
+			}
+			mod, ok := modules[t.Classname]
+			if !ok {
+				mod = modulePath(root, t.Classname)
+				modules[t.Classname] = mod
+			}
+			failures[mod] = append(failures[mod], t)
+		}
+	}
+
+	if len(failures) == 0 {
+		sb.WriteString("No failed tests found in the test report.\n")
+		return sb.String()
+	}
+
+	names := make([]string, 0, len(failures))
+	for mod := range failures {
+		names = append(names, mod)
+	}
+	sort.Strings(names)
+
+	for _, mod := range names {
+		fmt.Fprintf(&sb, "### `%s`\n\n", mod)
+		for _, t := range failures[mod] {
+			output := failureOutput(t)
+			if output == "" {
+				fmt.Fprintf(&sb, "`%s`%s\n\n", t.Name, rg.platformsSuffix(t))
+				continue
+			}
+			if len(output) > maxSummaryOutputLength {
+				output = output[:maxSummaryOutputLength] + "\n..."
+			}
+			fmt.Fprintf(&sb, "<details><summary><code>%s</code>%s</summary>\n\n```\n%s\n```\n\n</details>\n\n", t.Name, rg.platformsSuffix(t), output)
+		}
+	}
+
+	return sb.String()
+}
+
+// modulePath returns the path of the Go module containing the package with the
+// import path pkgPath. The package directory is found the same way as source
+// files are for annotations, see resolvePath, and the module is read from the
+// closest go.mod file. If no module is found, pkgPath is returned.
+func modulePath(root, pkgPath string) string {
+	dir := pkgPath
+	for dir != "" {
+		if fi, err := os.Stat(filepath.Join(root, filepath.FromSlash(dir))); err == nil && fi.IsDir() {
+			break
+		}
+		i := strings.Index(dir, "/")
+		if i < 0 {
+			dir = ""
+			break
+		}
+		dir = dir[i+1:]
+	}
+
+	for {
+		if mod, ok := readModulePath(filepath.Join(root, filepath.FromSlash(dir), "go.mod")); ok {
+			return mod
+		}
+		if dir == "" || dir == "." {
+			return pkgPath
+		}
+		dir = path.Dir(dir)
+	}
+}
+
+// readModulePath returns the module path declared in the go.mod file at name.
+func readModulePath(name string) (string, bool) {
+	f, err := os.Open(filepath.Clean(name))
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module")), `"`), true
+		}
+	}
+	return "", false
+}
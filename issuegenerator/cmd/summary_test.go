@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/joshdk/go-junit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// summaryRepo returns a repository with a root module and a nested module.
+func summaryRepo(t *testing.T) string {
+	return writeFiles(t, map[string]string{
+		"go.mod":              "module example.com/repo\n\ngo 1.18\n",
+		"pkg/a/a.go":          "package a\n",
+		"exporter/go.mod":     "// Comment\nmodule \"example.com/repo/exporter\"\n",
+		"exporter/sub/sub.go": "package sub\n",
+	})
+}
+
+func TestModulePath(t *testing.T) {
+	root := summaryRepo(t)
+
+	tests := []struct {
+		pkgPath  string
+		expected string
+	}{
+		{pkgPath: "example.com/repo", expected: "example.com/repo"},
+		{pkgPath: "example.com/repo/pkg/a", expected: "example.com/repo"},
+		{pkgPath: "example.com/repo/exporter", expected: "example.com/repo/exporter"},
+		{pkgPath: "example.com/repo/exporter/sub", expected: "example.com/repo/exporter"},
+		// Packages not found in the repository are resolved from the root.
+		{pkgPath: "example.com/repo/missing", expected: "example.com/repo"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.pkgPath, func(t *testing.T) {
+			assert.Equal(t, tc.expected, modulePath(root, tc.pkgPath))
+		})
+	}
+
+	assert.Equal(t, "example.com/nomod", modulePath(t.TempDir(), "example.com/nomod"), "no go.mod file")
+}
+
+func TestGetSummary(t *testing.T) {
+	root := summaryRepo(t)
+	longOutput := strings.Repeat("x", maxSummaryOutputLength+10)
+
+	tests := []struct {
+		name      string
+		buildURL  string
+		links     []summaryLink
+		tests     []junit.Test
+		platforms map[testKey][]string
+		expected  string
+	}{
+		{
+			name:     "no failures",
+			buildURL: "https://ci.example.com/build/1",
+			links:    []summaryLink{{Name: "GitHub Issue", URL: "https://github.com/org/repo/issues/1"}},
+			tests:    []junit.Test{{Name: "TestPass", Classname: "example.com/repo/pkg/a", Status: junit.StatusPassed}},
+			expected: "## Test failures (job: `unit-tests`)\n\n" +
+				"- [Failed build](https://ci.example.com/build/1)\n" +
+				"- [GitHub Issue](https://github.com/org/repo/issues/1)\n\n" +
+				"No failed tests found in the test report.\n",
+		},
+		{
+			name: "grouped by module",
+			tests: []junit.Test{
+				{Name: "TestSub", Classname: "example.com/repo/exporter/sub", Status: junit.StatusFailed, Error: errors.New("sub failed")},
+				{Name: "TestA", Classname: "example.com/repo/pkg/a", Status: junit.StatusFailed},
+				{Name: "TestExporter", Classname: "example.com/repo/exporter", Status: junit.StatusFailed, SystemOut: "exporter output"},
+			},
+			platforms: map[testKey][]string{
+				{classname: "example.com/repo/pkg/a", name: "TestA"}: {"linux", "windows"},
+			},
+			expected: "## Test failures (job: `unit-tests`)\n\n\n" +
+				"### `example.com/repo`\n\n" +
+				"`TestA` (linux, windows)\n\n" +
+				"### `example.com/repo/exporter`\n\n" +
+				"<details><summary><code>TestSub</code></summary>\n\n```\nsub failed\n```\n\n</details>\n\n" +
+				"<details><summary><code>TestExporter</code></summary>\n\n```\nexporter output\n```\n\n</details>\n\n",
+		},
+		{
+			name:  "truncated output",
+			tests: []junit.Test{{Name: "TestLong", Classname: "example.com/repo/pkg/a", Status: junit.StatusFailed, SystemOut: longOutput}},
+			expected: "## Test failures (job: `unit-tests`)\n\n\n" +
+				"### `example.com/repo`\n\n" +
+				"<details><summary><code>TestLong</code></summary>\n\n```\n" + longOutput[:maxSummaryOutputLength] + "\n...\n```\n\n</details>\n\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(circleBuildURLKey, tc.buildURL)
+			rg := reportGenerator{
+				envVariables: map[string]string{jobNameKey: "unit-tests"},
+				testSuites:   []junit.Suite{{Name: "pkg", Tests: tc.tests}},
+				platforms:    tc.platforms,
+			}
+			assert.Equal(t, tc.expected, rg.getSummary(root, tc.links))
+		})
+	}
+}
+
+func TestWriteSummary(t *testing.T) {
+	t.Setenv(circleBuildURLKey, "")
+	summaryFile := filepath.Join(t.TempDir(), "summary.md")
+	require.NoError(t, os.WriteFile(summaryFile, []byte("# Previous step\n"), 0o600))
+
+	rg := &reportGenerator{
+		logger: zap.NewNop(),
+		envVariables: map[string]string{
+			jobNameKey:     "unit-tests",
+			stepSummaryKey: summaryFile,
+		},
+	}
+	rg.writeSummary(nil)
+
+	// The summary is appended to the summaries of the previous steps.
+	data, err := os.ReadFile(summaryFile)
+	require.NoError(t, err)
+	assert.Equal(t, "# Previous step\n"+rg.getSummary(".", nil), string(data))
+}
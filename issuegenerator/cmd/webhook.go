@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/joshdk/go-junit"
+	"go.uber.org/zap"
+)
+
+const (
+	// webhookURLKey is the environment variable holding the URL the webhook
+	// output posts to, e.g. a Slack incoming webhook. It is only required for
+	// the webhook output.
+	webhookURLKey = "WEBHOOK_URL" // #nosec G101
+
+	// maxWebhookTests is the maximum number of failed tests listed in a
+	// webhook message, to keep it short enough to read in a chat.
+	maxWebhookTests = 20
+)
+
+// webhookMessage is a Slack compatible incoming webhook payload.
+type webhookMessage struct {
+	Text string `json:"text"`
+}
+
+// postWebhook posts a message listing the failed tests, and linking to the
+// failed build and the reports created by the other outputs, to the webhook.
+func (rg *reportGenerator) postWebhook(links []summaryLink) {
+	data, err := json.Marshal(webhookMessage{Text: rg.getWebhookText(links)})
+	if err != nil {
+		rg.logger.Fatal("Failed to encode webhook message", zap.Error(err))
+	}
+
+	req, err := http.NewRequestWithContext(rg.ctx, http.MethodPost, rg.envVariables[webhookURLKey], bytes.NewReader(data))
+	if err != nil {
+		rg.logger.Fatal("Failed to create webhook request", zap.Error(err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	response, err := rg.httpClient.Do(req)
+	if err != nil {
+		rg.logger.Fatal("Failed to send webhook request", zap.Error(err))
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		respBody, _ := io.ReadAll(response.Body)
+		// The URL of the webhook is a secret, it is not logged.
+		rg.logger.Fatal(
+			"Unexpected response from webhook",
+			zap.Int("status_code", response.StatusCode),
+			zap.String("response", string(respBody)),
+		)
+	}
+}
+
+// getWebhookText returns the text of the webhook message, using Slack mrkdwn
+// formatting.
+func (rg reportGenerator) getWebhookText(links []summaryLink) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "*Failed CircleCI build (job: `%s`)*\n", rg.envVariables[jobNameKey])
+
+	var refs []string
+	if buildURL := os.Getenv(circleBuildURLKey); buildURL != "" {
+		refs = append(refs, slackLink(buildURL, "Failed build"))
+	}
+	for _, l := range links {
+		refs = append(refs, slackLink(l.URL, l.Name))
+	}
+	if len(refs) > 0 {
+		sb.WriteString(strings.Join(refs, " | ") + "\n")
+	}
+
+	var failed []string
+	for _, s := range rg.testSuites {
+		for _, t := range s.Tests {
+			if t.Status == junit.StatusFailed {
+				failed = append(failed, "• `"+t.Name+"`"+rg.platformsSuffix(t))
+			}
+		}
+	}
+	if len(failed) > maxWebhookTests {
+		failed = append(failed[:maxWebhookTests], fmt.Sprintf("• and %d more", len(failed)-maxWebhookTests))
+	}
+	for _, f := range failed {
+		sb.WriteString(f + "\n")
+	}
+	return sb.String()
+}
+
+// slackLink returns a Slack mrkdwn link to url with text.
+func slackLink(url, text string) string {
+	r := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	return "<" + url + "|" + r.Replace(text) + ">"
+}
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joshdk/go-junit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// failedTests returns failed tests of package pkg named after names.
+func failedTests(pkg string, names ...string) []junit.Test {
+	tests := make([]junit.Test, 0, len(names))
+	for _, name := range names {
+		tests = append(tests, junit.Test{Name: name, Classname: pkg, Status: junit.StatusFailed})
+	}
+	return tests
+}
+
+func TestGetWebhookText(t *testing.T) {
+	manyTests := make([]string, maxWebhookTests+3)
+	for i := range manyTests {
+		manyTests[i] = fmt.Sprintf("Test%02d", i)
+	}
+
+	tests := []struct {
+		name      string
+		buildURL  string
+		links     []summaryLink
+		tests     []junit.Test
+		platforms map[testKey][]string
+		expected  string
+	}{
+		{
+			name:     "no links nor failures",
+			expected: "*Failed CircleCI build (job: `unit-tests`)*\n",
+		},
+		{
+			name:     "links",
+			buildURL: "https://ci.example.com/build/1",
+			links: []summaryLink{
+				{Name: "GitHub Issue", URL: "https://github.com/org/repo/issues/1"},
+				{Name: "Jira <PROJ-1> & more", URL: "https://jira.example.com/browse/PROJ-1"},
+			},
+			expected: "*Failed CircleCI build (job: `unit-tests`)*\n" +
+				"<https://ci.example.com/build/1|Failed build> | " +
+				"<https://github.com/org/repo/issues/1|GitHub Issue> | " +
+				"<https://jira.example.com/browse/PROJ-1|Jira &lt;PROJ-1&gt; &amp; more>\n",
+		},
+		{
+			name: "failed tests",
+			tests: append(failedTests("pkg", "TestA", "TestB"),
+				junit.Test{Name: "TestPass", Classname: "pkg", Status: junit.StatusPassed}),
+			platforms: map[testKey][]string{
+				{classname: "pkg", name: "TestA"}: {"linux", "windows"},
+			},
+			expected: "*Failed CircleCI build (job: `unit-tests`)*\n" +
+				"• `TestA` (linux, windows)\n" +
+				"• `TestB`\n",
+		},
+		{
+			name:  "truncated failed tests",
+			tests: failedTests("pkg", manyTests...),
+			expected: "*Failed CircleCI build (job: `unit-tests`)*\n" +
+				"• `" + strings.Join(manyTests[:maxWebhookTests], "`\n• `") + "`\n" +
+				"• and 3 more\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(circleBuildURLKey, tc.buildURL)
+			rg := reportGenerator{
+				envVariables: map[string]string{jobNameKey: "unit-tests"},
+				testSuites:   []junit.Suite{{Name: "pkg", Tests: tc.tests}},
+				platforms:    tc.platforms,
+			}
+			assert.Equal(t, tc.expected, rg.getWebhookText(tc.links))
+		})
+	}
+}
+
+func TestSlackLink(t *testing.T) {
+	tests := []struct {
+		url      string
+		text     string
+		expected string
+	}{
+		{url: "https://example.com", text: "Failed build", expected: "<https://example.com|Failed build>"},
+		{url: "https://example.com/?a=1&b=2", text: "a & b", expected: "<https://example.com/?a=1&b=2|a &amp; b>"},
+		{url: "https://example.com", text: "<script>", expected: "<https://example.com|&lt;script&gt;>"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.text, func(t *testing.T) {
+			assert.Equal(t, tc.expected, slackLink(tc.url, tc.text))
+		})
+	}
+}
+
+func TestPostWebhook(t *testing.T) {
+	t.Setenv(circleBuildURLKey, "https://ci.example.com/build/1")
+
+	var requests []*http.Request
+	var messages []webhookMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg webhookMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		requests = append(requests, r)
+		messages = append(messages, msg)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	rg := &reportGenerator{
+		ctx:        context.Background(),
+		logger:     zap.NewNop(),
+		httpClient: server.Client(),
+		envVariables: map[string]string{
+			jobNameKey:    "unit-tests",
+			webhookURLKey: server.URL + "/hooks/secret",
+		},
+		testSuites: []junit.Suite{{Name: "pkg", Tests: failedTests("pkg", "TestA")}},
+	}
+	links := []summaryLink{{Name: "GitHub Issue", URL: "https://github.com/org/repo/issues/1"}}
+	rg.postWebhook(links)
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, http.MethodPost, requests[0].Method)
+	assert.Equal(t, "/hooks/secret", requests[0].URL.Path)
+	assert.Equal(t, "application/json", requests[0].Header.Get("Content-Type"))
+	assert.Equal(t, []webhookMessage{{Text: rg.getWebhookText(links)}}, messages)
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+	"go.opentelemetry.io/build-tools/internal/repo"
+	mlc "go.opentelemetry.io/build-tools/mdlinkcheck/internal"
+)
+
+var errBrokenLinks = errors.New("broken links found")
+
+type commandConfig struct {
+	config      mlc.Config
+	quiet       bool
+	verbose     bool
+	rootCommand cobra.Command
+}
+
+func newCommandConfig() *commandConfig {
+	c := &commandConfig{}
+
+	c.rootCommand = cobra.Command{
+		Use:   "mdlinkcheck",
+		Short: "Check links and anchors in the Markdown files of a repository",
+		Long: `Mdlinkcheck validates the links found in the Markdown files of a repository.
+		Relative links must point to an existing file or directory of the repository, and
+		anchors must match a heading or HTML anchor of the linked document. Links to external
+		URLs are only checked with --external, at a limited rate. Broken external URLs listed
+		in the baseline file are not reported.`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			logging.Configure(c.quiet, c.verbose)
+			if c.config.RootPath == "" {
+				rp, err := repo.FindRoot()
+				if err != nil {
+					return fmt.Errorf("could not find a valid repository: %w", err)
+				}
+				c.config.RootPath = rp
+			}
+			if c.config.UpdateBaseline && (!c.config.External || c.config.BaselineFile == "") {
+				return errors.New("--update-baseline requires --external and --baseline")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			problems, err := mlc.Check(cmd.Context(), c.config)
+			if err != nil {
+				return err
+			}
+			for _, p := range problems {
+				fmt.Fprintln(cmd.OutOrStdout(), p)
+			}
+			if len(problems) > 0 {
+				logging.Errorf("found %d broken links", len(problems))
+				return errBrokenLinks
+			}
+			return nil
+		},
+	}
+	return c
+}
+
+var (
+	comCfg = newCommandConfig()
+)
+
+// Execute runs the mdlinkcheck command line, exiting with a non-zero status
+// if it fails or broken links are found.
+func Execute() {
+	if err := comCfg.rootCommand.Execute(); err != nil {
+		if !errors.Is(err, errBrokenLinks) {
+			logging.Errorf("failed to execute: %v", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func init() {
+	flags := comCfg.rootCommand.Flags()
+	flags.StringVar(&comCfg.config.RootPath, "root", "", `path to the root directory of the repository. If --root flag is not provided mdlinkcheck will attempt to find a
+	git repository in the current or a parent directory.`)
+	flags.StringSliceVar(&comCfg.config.ExcludedPaths, "exclude", []string{}, "list of comma separated glob patterns, relative to the root, of files and directories to ignore. "+
+		"multiple calls of --exclude can be made")
+	flags.BoolVar(&comCfg.config.External, "external", false, "check links to external http and https URLs")
+	flags.Float64Var(&comCfg.config.RateLimit, "rate", 2, "maximum number of external requests per second, 0 disables the limit")
+	flags.DurationVar(&comCfg.config.Timeout, "timeout", 10*time.Second, "timeout of a single external request")
+	flags.StringVar(&comCfg.config.BaselineFile, "baseline", "", "path to a file listing external URLs known to be broken, one per line")
+	flags.BoolVar(&comCfg.config.UpdateBaseline, "update-baseline", false, "write the external URLs that are currently broken to the baseline file instead of reporting them")
+	flags.BoolVarP(&comCfg.verbose, "verbose", "v", false, "verbose output")
+	flags.BoolVarP(&comCfg.quiet, "quiet", "q", false, "only log errors, takes precedence over --verbose")
+}
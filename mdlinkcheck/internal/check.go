@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mdlinkcheck
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+)
+
+// skippedDirs are directories never searched for Markdown files.
+var skippedDirs = map[string]struct{}{
+	".git":         {},
+	"node_modules": {},
+	"vendor":       {},
+}
+
+// Config configures a link check.
+type Config struct {
+	// RootPath is the directory searched for Markdown files. Links starting
+	// with "/" are resolved relative to it.
+	RootPath string
+	// ExcludedPaths are glob patterns, relative to RootPath, of files and
+	// directories that are not checked.
+	ExcludedPaths []string
+	// External enables checking links to http and https URLs.
+	External bool
+	// RateLimit is the maximum number of requests per second sent when
+	// checking external links. Zero or less means no limit.
+	RateLimit float64
+	// Timeout is the timeout of a single external request.
+	Timeout time.Duration
+	// BaselineFile lists external URLs known to be broken. Failures of these
+	// URLs are not reported.
+	BaselineFile string
+	// UpdateBaseline rewrites BaselineFile with the external URLs that
+	// currently fail instead of reporting them.
+	UpdateBaseline bool
+	// Client sends the external requests. http.DefaultClient is used if nil.
+	Client *http.Client
+}
+
+// Problem is a broken link.
+type Problem struct {
+	// File is the path of the Markdown file relative to the root.
+	File   string
+	Line   int
+	Target string
+	Reason string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s:%d: %s: %s", p.File, p.Line, p.Target, p.Reason)
+}
+
+// location is where an external link is found.
+type location struct {
+	File string
+	Line int
+}
+
+// checker validates the links of the Markdown files found in a root.
+type checker struct {
+	cfg  Config
+	docs map[string]*document
+}
+
+// Check validates the links in the Markdown files found in cfg.RootPath and
+// returns the broken ones, ordered by file and line.
+func Check(ctx context.Context, cfg Config) ([]Problem, error) {
+	c := &checker{cfg: cfg, docs: make(map[string]*document)}
+
+	files, err := c.markdownFiles()
+	if err != nil {
+		return nil, err
+	}
+	logging.Debugf("checking %d Markdown files", len(files))
+
+	var problems []Problem
+	external := make(map[string][]location)
+	for _, file := range files {
+		doc, err := c.document(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range doc.Links {
+			u, err := url.Parse(l.Target)
+			if err != nil {
+				problems = append(problems, Problem{file, l.Line, l.Target, "invalid URL"})
+				continue
+			}
+			switch u.Scheme {
+			case "":
+				if reason := c.checkLocal(file, u); reason != "" {
+					problems = append(problems, Problem{file, l.Line, l.Target, reason})
+				}
+			case "http", "https":
+				u.Fragment = ""
+				external[u.String()] = append(external[u.String()], location{file, l.Line})
+			default:
+				logging.Debugf("%s:%d: skipping %s link", file, l.Line, u.Scheme)
+			}
+		}
+	}
+
+	if cfg.External {
+		ext, err := c.checkExternal(ctx, external)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, ext...)
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].File != problems[j].File {
+			return problems[i].File < problems[j].File
+		}
+		return problems[i].Line < problems[j].Line
+	})
+	return problems, nil
+}
+
+// markdownFiles returns the paths, relative to the root, of the Markdown
+// files that are not excluded.
+func (c *checker) markdownFiles() ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(c.cfg.RootPath, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(c.cfg.RootPath, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if _, ok := skippedDirs[d.Name()]; ok || c.excluded(rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.EqualFold(filepath.Ext(p), ".md") && !c.excluded(rel) {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	return files, err
+}
+
+func (c *checker) excluded(rel string) bool {
+	for _, pattern := range c.cfg.ExcludedPaths {
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// document returns the parsed Markdown file with path rel relative to the
+// root. Files are only parsed once.
+func (c *checker) document(rel string) (*document, error) {
+	if doc, ok := c.docs[rel]; ok {
+		return doc, nil
+	}
+	f, err := os.Open(filepath.Join(c.cfg.RootPath, filepath.FromSlash(rel)))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	doc, err := parseMarkdown(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", rel, err)
+	}
+	c.docs[rel] = doc
+	return doc, nil
+}
+
+// checkLocal validates the link u, found in file, to a file or anchor in the
+// repository. It returns why the link is broken, or an empty string.
+func (c *checker) checkLocal(file string, u *url.URL) string {
+	target := file
+	if u.Path != "" {
+		if strings.HasPrefix(u.Path, "/") {
+			target = path.Clean(strings.TrimPrefix(u.Path, "/"))
+		} else {
+			target = path.Join(path.Dir(file), u.Path)
+		}
+		if target == ".." || strings.HasPrefix(target, "../") {
+			return "target is outside of the repository"
+		}
+		info, err := os.Stat(filepath.Join(c.cfg.RootPath, filepath.FromSlash(target)))
+		if err != nil {
+			return "file not found"
+		}
+		if info.IsDir() {
+			return ""
+		}
+	}
+
+	if u.Fragment == "" || !strings.EqualFold(path.Ext(target), ".md") {
+		return ""
+	}
+	doc, err := c.document(target)
+	if err != nil {
+		return fmt.Sprintf("failed to read target: %v", err)
+	}
+	if _, ok := doc.Anchors[u.Fragment]; !ok {
+		return fmt.Sprintf("anchor #%s not found", u.Fragment)
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mdlinkcheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/testfiles"
+)
+
+func TestCheckLocalLinks(t *testing.T) {
+	root := t.TempDir()
+	testfiles.Write(t, root, map[string]string{
+		"README.md": "# Project\n" +
+			"\n" +
+			"- [Guide](docs/guide.md)\n" +
+			"- [Install](docs/guide.md#install)\n" +
+			"- [Missing anchor](docs/guide.md#uninstall)\n" +
+			"- [Missing file](docs/missing.md)\n" +
+			"- [Directory](docs)\n" +
+			"- [Top](#project)\n" +
+			"- [Bad self anchor](#nope)\n" +
+			"- [Rooted](/docs/guide.md#install)\n" +
+			"- [Outside](../outside.md)\n" +
+			"- [Mail](mailto:someone@example.com)\n" +
+			"- [External](https://example.com)\n",
+		"docs/guide.md":              "# Guide\n\n## Install\n\nBack to the [README](../README.md#project).\n",
+		"docs/image.png":             "",
+		"excluded/README.md":         "[Broken](missing.md)\n",
+		"node_modules/pkg/README.md": "[Broken](missing.md)\n",
+	})
+
+	problems, err := Check(context.Background(), Config{
+		RootPath:      root,
+		ExcludedPaths: []string{"excluded"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Problem{
+		{File: "README.md", Line: 5, Target: "docs/guide.md#uninstall", Reason: "anchor #uninstall not found"},
+		{File: "README.md", Line: 6, Target: "docs/missing.md", Reason: "file not found"},
+		{File: "README.md", Line: 9, Target: "#nope", Reason: "anchor #nope not found"},
+		{File: "README.md", Line: 11, Target: "../outside.md", Reason: "target is outside of the repository"},
+	}, problems)
+}
+
+func TestCheckAnchorInExcludedFile(t *testing.T) {
+	root := t.TempDir()
+	testfiles.Write(t, root, map[string]string{
+		"README.md":         "[Changes](CHANGELOG.md#v100)\n[Missing](CHANGELOG.md#v200)\n",
+		"CHANGELOG.md":      "# Changelog\n\n## v1.0.0\n",
+		"unrelated/docs.md": "[Broken](missing.md)\n",
+	})
+
+	problems, err := Check(context.Background(), Config{
+		RootPath:      root,
+		ExcludedPaths: []string{"CHANGELOG.md", "unrelated/*"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Problem{
+		{File: "README.md", Line: 2, Target: "CHANGELOG.md#v200", Reason: "anchor #v200 not found"},
+	}, problems)
+}
+
+func TestProblemString(t *testing.T) {
+	p := Problem{File: "docs/README.md", Line: 3, Target: "missing.md", Reason: "file not found"}
+	assert.Equal(t, "docs/README.md:3: missing.md: file not found", p.String())
+}
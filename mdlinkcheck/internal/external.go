@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mdlinkcheck
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+)
+
+// checkExternal requests every URL in links, at most cfg.RateLimit times per
+// second, and returns a Problem for each location of a broken URL that is not
+// in the baseline.
+func (c *checker) checkExternal(ctx context.Context, links map[string][]location) ([]Problem, error) {
+	baseline, err := readBaseline(c.cfg.BaselineFile)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]string, 0, len(links))
+	for u := range links {
+		urls = append(urls, u)
+	}
+	sort.Strings(urls)
+	logging.Infof("checking %d external URLs", len(urls))
+
+	client := c.cfg.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	var limiter <-chan time.Time
+	if c.cfg.RateLimit > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / c.cfg.RateLimit))
+		defer ticker.Stop()
+		limiter = ticker.C
+	}
+
+	var (
+		problems []Problem
+		broken   []string
+	)
+	for i, u := range urls {
+		if i > 0 && limiter != nil {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-limiter:
+			}
+		}
+
+		reason := checkURL(ctx, client, c.cfg.Timeout, u)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		_, known := baseline[u]
+		switch {
+		case reason == "":
+			if known {
+				logging.Warnf("%s is in the baseline but is no longer broken", u)
+			}
+			continue
+		case known:
+			logging.Debugf("ignoring broken URL %s in the baseline: %s", u, reason)
+		case !c.cfg.UpdateBaseline:
+			for _, loc := range links[u] {
+				problems = append(problems, Problem{loc.File, loc.Line, u, reason})
+			}
+		}
+		broken = append(broken, u)
+	}
+
+	if c.cfg.UpdateBaseline {
+		if err := writeBaseline(c.cfg.BaselineFile, broken); err != nil {
+			return nil, err
+		}
+		logging.Infof("wrote %d broken URLs to %s", len(broken), c.cfg.BaselineFile)
+	}
+	return problems, nil
+}
+
+// checkURL requests u and returns why it is broken, or an empty string. A
+// HEAD request is sent first and retried as a GET request if it fails, since
+// some servers do not support HEAD requests.
+func checkURL(ctx context.Context, client *http.Client, timeout time.Duration, u string) string {
+	reason := request(ctx, client, timeout, http.MethodHead, u)
+	if reason == "" {
+		return ""
+	}
+	logging.Debugf("HEAD %s: %s, retrying with GET", u, reason)
+	return request(ctx, client, timeout, http.MethodGet, u)
+}
+
+func request(ctx context.Context, client *http.Client, timeout time.Duration, method, u string) string {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return err.Error()
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return "timed out"
+		}
+		return err.Error()
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Sprintf("status %d", resp.StatusCode)
+	}
+	return ""
+}
+
+// readBaseline returns the URLs listed in the baseline file, one per line.
+// Blank lines and lines starting with "#" are ignored. A missing or unset
+// file is an empty baseline.
+func readBaseline(file string) (map[string]struct{}, error) {
+	baseline := make(map[string]struct{})
+	if file == "" {
+		return baseline, nil
+	}
+	f, err := os.Open(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return baseline, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		baseline[line] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read baseline %s: %w", file, err)
+	}
+	return baseline, nil
+}
+
+const baselineHeader = `# External URLs known to be broken. Generated by mdlinkcheck --update-baseline.
+`
+
+// writeBaseline replaces the baseline file with urls.
+func writeBaseline(file string, urls []string) error {
+	if file == "" {
+		return errors.New("no baseline file to update")
+	}
+	var b strings.Builder
+	b.WriteString(baselineHeader)
+	for _, u := range urls {
+		b.WriteString(u)
+		b.WriteByte('\n')
+	}
+	return os.WriteFile(file, []byte(b.String()), 0o644)
+}
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mdlinkcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/testfiles"
+)
+
+type testServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests map[string]int
+}
+
+func newTestServer(t *testing.T) *testServer {
+	s := &testServer{requests: make(map[string]int)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests[r.Method+" "+r.URL.Path]++
+		s.mu.Unlock()
+
+		switch r.URL.Path {
+		case "/ok", "/fixed":
+			w.WriteHeader(http.StatusOK)
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		case "/slow":
+			time.Sleep(100 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestCheckExternalLinks(t *testing.T) {
+	srv := newTestServer(t)
+	root := t.TempDir()
+	testfiles.Write(t, root, map[string]string{
+		"README.md": "[OK](" + srv.URL + "/ok)\n" +
+			"[No HEAD](" + srv.URL + "/no-head)\n" +
+			"[Broken](" + srv.URL + "/broken#section)\n" +
+			"[Known](" + srv.URL + "/known)\n" +
+			"[Fixed](" + srv.URL + "/fixed)\n",
+		"docs/README.md": "[Broken again](" + srv.URL + "/broken)\n" +
+			"[Slow](" + srv.URL + "/slow)\n",
+		"baseline.txt": "# Known broken URLs\n\n" + srv.URL + "/known\n" + srv.URL + "/fixed\n",
+	})
+
+	problems, err := Check(context.Background(), Config{
+		RootPath:     root,
+		External:     true,
+		Timeout:      50 * time.Millisecond,
+		BaselineFile: filepath.Join(root, "baseline.txt"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Problem{
+		{File: "README.md", Line: 3, Target: srv.URL + "/broken", Reason: "status 404"},
+		{File: "docs/README.md", Line: 1, Target: srv.URL + "/broken", Reason: "status 404"},
+		{File: "docs/README.md", Line: 2, Target: srv.URL + "/slow", Reason: "timed out"},
+	}, problems)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	assert.Equal(t, 1, srv.requests["HEAD /broken"], "duplicate URLs are requested once")
+	assert.Equal(t, 1, srv.requests["GET /no-head"])
+	assert.Zero(t, srv.requests["GET /ok"])
+}
+
+func TestCheckExternalLinksDisabled(t *testing.T) {
+	srv := newTestServer(t)
+	root := t.TempDir()
+	testfiles.Write(t, root, map[string]string{
+		"README.md": "[Broken](" + srv.URL + "/broken)\n",
+	})
+
+	problems, err := Check(context.Background(), Config{RootPath: root})
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	assert.Empty(t, srv.requests)
+}
+
+func TestCheckExternalLinksRateLimit(t *testing.T) {
+	srv := newTestServer(t)
+	root := t.TempDir()
+	testfiles.Write(t, root, map[string]string{
+		"README.md": "[A](" + srv.URL + "/ok?a)\n[B](" + srv.URL + "/ok?b)\n[C](" + srv.URL + "/ok?c)\n",
+	})
+
+	start := time.Now()
+	problems, err := Check(context.Background(), Config{
+		RootPath:  root,
+		External:  true,
+		RateLimit: 20,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestCheckUpdateBaseline(t *testing.T) {
+	srv := newTestServer(t)
+	root := t.TempDir()
+	baseline := filepath.Join(root, "baseline.txt")
+	testfiles.Write(t, root, map[string]string{
+		"README.md": "[OK](" + srv.URL + "/ok)\n" +
+			"[Broken](" + srv.URL + "/broken)\n" +
+			"[Known](" + srv.URL + "/known)\n" +
+			"[Fixed](" + srv.URL + "/fixed)\n",
+		"baseline.txt": srv.URL + "/known\n" + srv.URL + "/fixed\n",
+	})
+
+	problems, err := Check(context.Background(), Config{
+		RootPath:       root,
+		External:       true,
+		BaselineFile:   baseline,
+		UpdateBaseline: true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	got, err := os.ReadFile(baseline)
+	require.NoError(t, err)
+	assert.Equal(t, baselineHeader+srv.URL+"/broken\n"+srv.URL+"/known\n", string(got))
+}
+
+func TestReadBaselineMissingFile(t *testing.T) {
+	baseline, err := readBaseline(filepath.Join(t.TempDir(), "missing.txt"))
+	require.NoError(t, err)
+	assert.Empty(t, baseline)
+}
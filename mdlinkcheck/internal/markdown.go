@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mdlinkcheck
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	fenceRegexp       = regexp.MustCompile("^\\s{0,3}(```|~~~)")
+	atxHeadingRegexp  = regexp.MustCompile(`^\s{0,3}#{1,6}\s+(.*?)(?:\s+#+)?\s*$`)
+	setextRegexp      = regexp.MustCompile(`^\s{0,3}(?:=+|-+)\s*$`)
+	codeSpanRegexp    = regexp.MustCompile("`+[^`]*`+")
+	htmlAnchorRegexp  = regexp.MustCompile(`<[a-zA-Z][^>]*\s(?:name|id)="([^"]+)"`)
+	htmlTagRegexp     = regexp.MustCompile(`<[^>]*>`)
+	refDefRegexp      = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s*<?([^\s>]+)>?`)
+	autolinkRegexp    = regexp.MustCompile(`<(https?://[^>\s]+)>`)
+	inlineLinkRegexp  = regexp.MustCompile(`!?\[((?:[^\[\]]|\[[^\[\]]*\])*)\]\(\s*<?([^)\s>]*)>?(?:\s+(?:"[^"]*"|'[^']*'))?\s*\)`)
+	headingLinkRegexp = regexp.MustCompile(`!?\[((?:[^\[\]]|\[[^\[\]]*\])*)\]\([^)]*\)`)
+)
+
+// link is a link found in a Markdown document.
+type link struct {
+	Target string
+	Line   int
+}
+
+// document holds the links of a Markdown document and the anchors that can
+// be linked to within it.
+type document struct {
+	Links   []link
+	Anchors map[string]struct{}
+}
+
+// parseMarkdown returns the links and anchors of the Markdown document read
+// from r. Links and headings in code blocks and code spans are ignored.
+func parseMarkdown(r io.Reader) (*document, error) {
+	doc := &document{Anchors: make(map[string]struct{})}
+	slugs := make(map[string]int)
+	addHeading := func(text string) {
+		s := slug(text)
+		if n := slugs[s]; n > 0 {
+			doc.Anchors[fmt.Sprintf("%s-%d", s, n)] = struct{}{}
+		} else {
+			doc.Anchors[s] = struct{}{}
+		}
+		slugs[s]++
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var (
+		lineNum  int
+		fence    string
+		prevText string
+	)
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+
+		if m := fenceRegexp.FindStringSubmatch(line); m != nil {
+			switch fence {
+			case "":
+				fence = m[1]
+			case m[1]:
+				fence = ""
+			}
+			prevText = ""
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+
+		if setextRegexp.MatchString(line) && prevText != "" {
+			addHeading(prevText)
+			prevText = ""
+			continue
+		}
+
+		line = codeSpanRegexp.ReplaceAllString(line, "")
+		if m := atxHeadingRegexp.FindStringSubmatch(line); m != nil {
+			addHeading(m[1])
+			prevText = ""
+		} else {
+			prevText = strings.TrimSpace(line)
+		}
+
+		for _, m := range htmlAnchorRegexp.FindAllStringSubmatch(line, -1) {
+			doc.Anchors[m[1]] = struct{}{}
+		}
+		if m := refDefRegexp.FindStringSubmatch(line); m != nil {
+			doc.Links = append(doc.Links, link{Target: m[1], Line: lineNum})
+			continue
+		}
+		for _, m := range autolinkRegexp.FindAllStringSubmatch(line, -1) {
+			doc.Links = append(doc.Links, link{Target: m[1], Line: lineNum})
+		}
+		doc.Links = append(doc.Links, inlineLinks(line, lineNum)...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// inlineLinks returns the inline links and images in line, including those
+// nested in the text of another link, such as a badge image that is a link.
+func inlineLinks(line string, lineNum int) []link {
+	var links []link
+	for _, m := range inlineLinkRegexp.FindAllStringSubmatch(line, -1) {
+		links = append(links, inlineLinks(m[1], lineNum)...)
+		if m[2] != "" {
+			links = append(links, link{Target: m[2], Line: lineNum})
+		}
+	}
+	return links
+}
+
+// slug returns the anchor GitHub generates for a heading with text.
+func slug(text string) string {
+	text = headingLinkRegexp.ReplaceAllString(text, "$1")
+	text = htmlTagRegexp.ReplaceAllString(text, "")
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		switch {
+		case r == ' ':
+			b.WriteRune('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsNumber(r):
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mdlinkcheck
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMarkdown(t *testing.T) {
+	input := "# Project Title\n" +
+		"\n" +
+		"See [the guide](docs/guide.md#getting-started) and ![logo](img/logo.png \"Logo\").\n" +
+		"[![Badge](https://example.com/badge.svg)](https://example.com/ci)\n" +
+		"Visit <https://example.com/auto>.\n" +
+		"\n" +
+		"## Usage\n" +
+		"\n" +
+		"Use `[not a link](nowhere.md)` inline.\n" +
+		"\n" +
+		"```md\n" +
+		"[also not a link](nowhere.md)\n" +
+		"# Not a heading\n" +
+		"```\n" +
+		"\n" +
+		"## Usage\n" +
+		"\n" +
+		"Setext Heading\n" +
+		"--------------\n" +
+		"\n" +
+		"<a name=\"custom-anchor\"></a>\n" +
+		"[ref]: ../CONTRIBUTING.md\n"
+
+	doc, err := parseMarkdown(strings.NewReader(input))
+	require.NoError(t, err)
+
+	assert.Equal(t, []link{
+		{Target: "docs/guide.md#getting-started", Line: 3},
+		{Target: "img/logo.png", Line: 3},
+		{Target: "https://example.com/badge.svg", Line: 4},
+		{Target: "https://example.com/ci", Line: 4},
+		{Target: "https://example.com/auto", Line: 5},
+		{Target: "../CONTRIBUTING.md", Line: 22},
+	}, doc.Links)
+	assert.Equal(t, map[string]struct{}{
+		"project-title":  {},
+		"usage":          {},
+		"usage-1":        {},
+		"setext-heading": {},
+		"custom-anchor":  {},
+	}, doc.Anchors)
+}
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		heading string
+		want    string
+	}{
+		{heading: "Getting Started", want: "getting-started"},
+		{heading: "What's new in v1.2.0?", want: "whats-new-in-v120"},
+		{heading: "The `go.mod` file", want: "the-gomod-file"},
+		{heading: "Using [crosslink](./crosslink/README.md)", want: "using-crosslink"},
+		{heading: "snake_case and kebab-case", want: "snake_case-and-kebab-case"},
+		{heading: "Ünïcödé Héading", want: "ünïcödé-héading"},
+	}
+	for _, test := range tests {
+		t.Run(test.heading, func(t *testing.T) {
+			assert.Equal(t, test.want, slug(test.heading))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+	"go.opentelemetry.io/build-tools/multimod/internal/bump"
+)
+
+var (
+	increment string
+	preid     string
+)
+
+// bumpCmd represents the bump command
+var bumpCmd = &cobra.Command{
+	Use:   "bump",
+	Short: "Increments the version of a module set in the versioning file",
+	Long: `Bump computes the next version of a module set and writes it to the versioning file:
+- minor: v1.2.3 becomes v1.3.0, v1.3.0-rc.1 becomes v1.3.0.
+- patch: v1.2.3 becomes v1.2.4, v1.2.4-rc.1 becomes v1.2.4.
+- prerelease: v1.2.3 becomes v1.2.4-rc.1, v1.2.4-rc.1 becomes v1.2.4-rc.2.
+Only the version is rewritten, the comments and ordering of the file are preserved.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		logging.Infof("Using versioning file %v", versioningFile)
+
+		bump.Run(versioningFile, moduleSetName, increment, preid)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(bumpCmd)
+
+	bumpCmd.Flags().StringVarP(&moduleSetName, "module-set-name", "m", "",
+		"Name of module set to bump. Name must be listed in the module set versioning YAML.",
+	)
+	if err := bumpCmd.MarkFlagRequired("module-set-name"); err != nil {
+		logging.Fatalf("could not mark module-set-name flag as required: %v", err)
+	}
+
+	bumpCmd.Flags().StringVarP(&increment, "increment", "i", "",
+		"Part of the version to increment: "+bump.Minor+", "+bump.Patch+", or "+bump.Prerelease+".",
+	)
+	if err := bumpCmd.MarkFlagRequired("increment"); err != nil {
+		logging.Fatalf("could not mark increment flag as required: %v", err)
+	}
+
+	bumpCmd.Flags().StringVar(&preid, "preid", "rc",
+		"Identifier of the prerelease started when incrementing the prerelease of a release version.",
+	)
+}
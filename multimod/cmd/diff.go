@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+	"go.opentelemetry.io/build-tools/multimod/internal/diff"
+)
+
+var diffHead string
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Reports the modules of a module set changed since they were last tagged",
+	Long: `Diff compares, for each module of a module set, the commit of its latest version tag
+with the head commit and prints a JSON report of the modules with changed files:
+- Files are attributed to the innermost module containing them.
+- Modules never tagged are reported as changed.
+- The report is changed if any module of the set changed, so CI can decide whether a release is warranted.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		logging.Infof("Using versioning file %v", versioningFile)
+
+		diff.Run(versioningFile, moduleSetName, diffHead)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().StringVarP(&moduleSetName, "module-set-name", "m", "",
+		"Name of module set to diff. Name must be listed in the module set versioning YAML.",
+	)
+	if err := diffCmd.MarkFlagRequired("module-set-name"); err != nil {
+		logging.Fatalf("could not mark module-set-name flag as required: %v", err)
+	}
+
+	diffCmd.Flags().StringVar(&diffHead, "head", "HEAD",
+		"Git revision compared to the latest tags of the modules.",
+	)
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+	"go.opentelemetry.io/build-tools/multimod/internal/goversion"
+)
+
+var (
+	goVersionSet       string
+	goVersionToolchain string
+	goVersionInclude   []string
+	goVersionExclude   []string
+	goVersionVerify    bool
+)
+
+// goVersionCmd represents the goversion command
+var goVersionCmd = &cobra.Command{
+	Use:   "goversion",
+	Short: "Sets the go and toolchain directives of all go.mod files",
+	Long: `Goversion sets the go directive, and optionally the toolchain directive, of the go.mod
+file of every module in the repository, raising or lowering them:
+- Modules listed in the excluded-modules of the versioning file are skipped.
+- Only modules matching an --include pattern, if any, and no --exclude pattern are updated.
+- With --verify, no file is modified and the out-of-date go.mod files are listed, failing if there are any.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		logging.Infof("Using versioning file %v", versioningFile)
+
+		goversion.Run(versioningFile, goVersionSet, goVersionToolchain, goVersionInclude, goVersionExclude, goVersionVerify)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(goVersionCmd)
+
+	goVersionCmd.Flags().StringVar(&goVersionSet, "set", "",
+		"Go version of the go directive, e.g. 1.21 or 1.21.0.",
+	)
+	if err := goVersionCmd.MarkFlagRequired("set"); err != nil {
+		logging.Fatalf("could not mark set flag as required: %v", err)
+	}
+
+	goVersionCmd.Flags().StringVar(&goVersionToolchain, "toolchain", "",
+		"Toolchain of the toolchain directive, e.g. go1.21.3. Toolchain directives are left as is if unspecified.",
+	)
+
+	goVersionCmd.Flags().StringSliceVar(&goVersionInclude, "include", nil,
+		"Patterns of the module paths to update, as matched by path.Match. A pattern ending in /... also matches the modules below it. "+
+			"To specify multiple patterns, repeat the flag or specify them as comma-separated values.",
+	)
+
+	goVersionCmd.Flags().StringSliceVar(&goVersionExclude, "exclude", nil,
+		"Patterns of the module paths not to update, in the same form as --include.",
+	)
+
+	goVersionCmd.Flags().BoolVar(&goVersionVerify, "verify", false,
+		"Do not modify go.mod files, list those whose directives differ and exit with a non-zero status if there are any, e.g. in CI.",
+	)
+}
@@ -15,19 +15,23 @@
 package cmd
 
 import (
-	"fmt"
-	"log"
-
 	"github.com/spf13/cobra"
 
+	"go.opentelemetry.io/build-tools/internal/logging"
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
 	"go.opentelemetry.io/build-tools/multimod/internal/prerelease"
 )
 
 var (
 	allModuleSets           bool
 	moduleSetNames          []string
+	skipUnchanged           bool
 	skipGoModTidy           bool
 	commitToDifferentBranch bool
+	unfreeze                bool
+	updateChangelog         bool
+	signKeyPrerelease       string
+	baseRef                 string
 )
 
 // prereleaseCmd represents the prerelease command
@@ -36,11 +40,16 @@ var prereleaseCmd = &cobra.Command{
 	Short: "Prepares files for new version release",
 	Long: `Updates version numbers and commits to a new branch for release:
 - Checks that the working tree is clean.
+- Checks that the module set is not frozen, frozen sets are skipped with --all-module-sets.
 - Checks that Git tags do not already exist for the new module set version.
+- Checks that files of the module set changed since its latest tags, with --skip-unchanged.
+- Fetches and checks out the base ref, with --base-ref, refusing to run if the local branch of the same name has diverged from it.
 - Switches to a new branch called prerelease_<module set name>_<new version>.
 - Updates version.go files, if they exist.
 - Updates module versions in all go.mod files.
 - Attempts to call 'go mod tidy' in the directory of each modified go.mod file.
+- Runs the prerelease hooks of the versioning file.
+- Moves the Unreleased section of CHANGELOG.md under the new version, with --update-changelog.
 - Adds and commits changes to Git branch`,
 	PreRun: func(cmd *cobra.Command, args []string) {
 		if allModuleSets {
@@ -50,21 +59,29 @@ var prereleaseCmd = &cobra.Command{
 				cobra.BashCompOneRequiredFlag,
 				[]string{"false"},
 			); err != nil {
-				log.Fatalf("could not set module-set-names flag as not required flag: %v", err)
+				logging.Fatalf("could not set module-set-names flag as not required flag: %v", err)
 			}
 		}
 	},
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Using versioning file", versioningFile)
+		logging.Infof("Using versioning file %v", versioningFile)
 
-		prerelease.Run(versioningFile, moduleSetNames, allModuleSets, skipGoModTidy, commitToDifferentBranch)
+		prerelease.Run(cmd.Context(), prerelease.Options{
+			VersioningFile:          versioningFile,
+			ModuleSetNames:          moduleSetNames,
+			AllModuleSets:           allModuleSets,
+			SkipUnchanged:           skipUnchanged,
+			SkipModTidy:             skipGoModTidy,
+			CommitToDifferentBranch: commitToDifferentBranch,
+			Unfreeze:                unfreeze,
+			UpdateChangelog:         updateChangelog,
+			SignKeyPath:             signKeyPrerelease,
+			BaseRef:                 baseRef,
+		})
 	},
 }
 
 func init() {
-	// Plain log output, no timestamps.
-	log.SetFlags(0)
-
 	rootCmd.AddCommand(prereleaseCmd)
 
 	prereleaseCmd.Flags().BoolVarP(&allModuleSets, "all-module-sets", "a", false,
@@ -78,8 +95,11 @@ func init() {
 			"For example: --module-set-names=\"mod-set-1,mod-set-2\"",
 	)
 	if err := prereleaseCmd.MarkFlagRequired("module-set-names"); err != nil {
-		log.Fatalf("could not mark module-set-names flag as required: %v", err)
+		logging.Fatalf("could not mark module-set-names flag as required: %v", err)
 	}
+	prereleaseCmd.Flags().BoolVar(&skipUnchanged, "skip-unchanged", false,
+		"Skip module sets with no file changed since their latest version tags, e.g. with --all-module-sets.",
+	)
 	prereleaseCmd.Flags().BoolVarP(&skipGoModTidy, "skip-go-mod-tidy", "s", false,
 		"Specify this flag to skip calling 'go mod tidy'. "+
 			"To be used for debugging purposes. Should not be skipped during actual release.",
@@ -87,4 +107,19 @@ func init() {
 	prereleaseCmd.Flags().BoolVarP(&commitToDifferentBranch, "commit-to-different-branch", "b", true,
 		"Specify this flag to commit to a different branch.",
 	)
+	prereleaseCmd.Flags().BoolVar(&unfreeze, "unfreeze", false,
+		"Update module sets even if they are marked as frozen in the versioning file.",
+	)
+	prereleaseCmd.Flags().BoolVar(&updateChangelog, "update-changelog", false,
+		"Move the Unreleased section of CHANGELOG.md under a heading with the module set version and the current date.",
+	)
+	prereleaseCmd.Flags().StringVar(&baseRef, "base-ref", "",
+		"Git revision to create the prerelease branches from instead of HEAD, e.g. origin/main. "+
+			"The remote of a remote-tracking branch is fetched first, and the run is refused if the local branch of the same name has commits that are not on it.",
+	)
+	prereleaseCmd.Flags().StringVar(&signKeyPrerelease, "sign-key", "",
+		"Path of the OpenPGP or OpenSSH private key signing the commits. If unspecified, defaults to the path in the "+
+			common.CommitSignKeyEnv+" environment variable, and commits are not signed if it is not set either. "+
+			"The passphrase of an encrypted key is read from the "+common.SignKeyPassphraseEnv+" environment variable.",
+	)
 }
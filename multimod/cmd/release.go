@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+	"go.opentelemetry.io/build-tools/multimod/internal/release"
+)
+
+var (
+	moduleSetNameRelease   string
+	stateFileRelease       string
+	remoteRelease          string
+	baseRelease            string
+	yesRelease             bool
+	waitRelease            time.Duration
+	skipGoModTidyRelease   bool
+	updateChangelogRelease bool
+	signKeyRelease         string
+)
+
+// releaseCmd represents the release command
+var releaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Releases a module set, from verifying to pushing tags",
+	Long: `Chains the steps of a module set release:
+- verify: checks the versioning file.
+- prerelease: commits the new versions to the prerelease_<module set name>_<new version> branch.
+- pull-request: pushes the branch and opens a pull request merging it into --base.
+- approval: waits up to --wait for the pull request to be merged, skipped with --yes.
+- tag: tags the merge commit, or the prerelease commit with --yes, and pushes the tags.
+
+The completed steps are saved in the state file. Running release again after a
+failure, or once the pull request is merged, resumes from the first step not
+completed. The state file is removed once the tags are pushed.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		logging.Infof("Using versioning file %v", versioningFile)
+
+		release.Run(cmd.Context(), release.Options{
+			VersioningFile:  versioningFile,
+			ModuleSetName:   moduleSetNameRelease,
+			StateFile:       stateFileRelease,
+			Remote:          remoteRelease,
+			Base:            baseRelease,
+			Yes:             yesRelease,
+			Wait:            waitRelease,
+			SkipModTidy:     skipGoModTidyRelease,
+			UpdateChangelog: updateChangelogRelease,
+			SignKeyPath:     signKeyRelease,
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(releaseCmd)
+
+	releaseCmd.Flags().StringVarP(&moduleSetNameRelease, "module-set-name", "m", "",
+		"Name of module set being released. Name must be listed in the module set versioning YAML.",
+	)
+	if err := releaseCmd.MarkFlagRequired("module-set-name"); err != nil {
+		logging.Fatalf("could not mark module-set-name flag as required: %v", err)
+	}
+
+	releaseCmd.Flags().StringVar(&stateFileRelease, "state-file", "",
+		"Path of the file saving the completed steps. If unspecified, defaults to .git/multimod-release.json in the Git repo root.",
+	)
+	releaseCmd.Flags().StringVar(&remoteRelease, "remote", "upstream",
+		"Name of the GitHub remote to open the pull request on and push the tags to.",
+	)
+	releaseCmd.Flags().StringVar(&baseRelease, "base", "main",
+		"Name of the branch the pull request is merged into.",
+	)
+	releaseCmd.Flags().BoolVarP(&yesRelease, "yes", "y", false,
+		"Tag the prerelease commit without waiting for the pull request to be merged.",
+	)
+	releaseCmd.Flags().DurationVar(&waitRelease, "wait", 0,
+		"Maximum duration to wait for the pull request to be merged, e.g. 1h. "+
+			"If unspecified, release stops if it is not merged yet and resumes when run again.",
+	)
+	releaseCmd.Flags().BoolVar(&skipGoModTidyRelease, "skip-go-mod-tidy", false,
+		"Skip calling 'go mod tidy' in the prerelease step.",
+	)
+	releaseCmd.Flags().BoolVar(&updateChangelogRelease, "update-changelog", false,
+		"Move the Unreleased section of CHANGELOG.md under the new version in the prerelease step.",
+	)
+	releaseCmd.Flags().StringVar(&signKeyRelease, "sign-key", "",
+		"Path of the OpenPGP or OpenSSH private key signing the prerelease commit. If unspecified, defaults to the path in the "+
+			common.CommitSignKeyEnv+" environment variable, and commits are not signed if it is not set either. "+
+			"The passphrase of an encrypted key is read from the "+common.SignKeyPassphraseEnv+" environment variable.",
+	)
+}
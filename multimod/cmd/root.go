@@ -15,17 +15,28 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"go.opentelemetry.io/build-tools/internal/logging"
 	"go.opentelemetry.io/build-tools/internal/repo"
 )
 
 var (
 	versioningFile string
+	quiet          bool
+	verbose        bool
+	timeout        time.Duration
+
+	// cancelTimeout releases the resources of the --timeout context.
+	cancelTimeout context.CancelFunc = func() {}
 )
 
 const (
@@ -39,25 +50,56 @@ var rootCmd = &cobra.Command{
 	Short: "Enables the release of Go modules with flexible versioning",
 	Long: `A Golang release versioning and tagging tool that simplifies and
 automates versioning for repos with multiple Go modules.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		logging.Configure(quiet, verbose)
+
+		if versioningFile == "" {
+			repoRoot, err := repo.FindRoot()
+			if err != nil {
+				logging.Fatalf("could not find repo root: %v", err)
+			}
+			versioningFile = filepath.Join(repoRoot,
+				fmt.Sprintf("%v.%v", defaultVersionsConfigName, defaultVersionsConfigType))
+		}
+
+		if timeout > 0 {
+			var ctx context.Context
+			ctx, cancelTimeout = context.WithTimeout(cmd.Context(), timeout)
+			cmd.SetContext(ctx)
+		}
+	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		cancelTimeout()
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+//
+// The context passed to subcommands is canceled on SIGINT or SIGTERM, giving
+// them a chance to clean up partially created branches and tags. A second
+// signal terminates immediately.
 func Execute() {
-	cobra.CheckErr(rootCmd.Execute())
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		// Restore the default behavior for subsequent signals.
+		stop()
+	}()
+
+	cobra.CheckErr(rootCmd.ExecuteContext(ctx))
 }
 
 func init() {
-	cobra.OnInitialize()
-
-	repoRoot, err := repo.FindRoot()
-	if err != nil {
-		log.Fatalf("could not find repo root: %v", err)
-	}
-
-	versioningFileDefault := filepath.Join(repoRoot,
-		fmt.Sprintf("%v.%v", defaultVersionsConfigName, defaultVersionsConfigType))
-	rootCmd.PersistentFlags().StringVarP(&versioningFile, "versioning-file", "v", versioningFileDefault,
+	// The default versioning file is resolved when a command runs so that
+	// importing this package has no side effects.
+	rootCmd.PersistentFlags().StringVarP(&versioningFile, "versioning-file", "v", "",
 		"Path to versioning file that contains definitions of all module sets. "+
 			"If unspecified, defaults to versions.yaml in the Git repo root.")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, logging.QuietUsage)
+	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, logging.VerboseUsage)
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0,
+		"Maximum duration of the command, e.g. 10m. "+
+			"Partially created branches and tags are removed when it is exceeded. No limit if unspecified.")
 }
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+	"go.opentelemetry.io/build-tools/multimod/internal/status"
+)
+
+var (
+	statusHead   string
+	statusOutput string
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Reports what has been released of each module set",
+	Long: `Status compares, for each module set of the versioning file, its version with the highest
+version its modules are tagged with:
+- released: the version is the highest tagged version.
+- ahead: the version is higher than the highest tagged version, a release is pending.
+- behind: the version is lower than the highest tagged version, the versioning file is stale.
+- unreleased: no module of the set is tagged.
+It also reports whether the highest tag is on a commit reachable from the head commit.
+With --output json, the statuses are written as a JSON array.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		logging.Infof("Using versioning file %v", versioningFile)
+
+		status.Run(versioningFile, statusHead, statusOutput)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().StringVar(&statusHead, "head", "HEAD",
+		"Git revision checked to contain the latest tags of the module sets.",
+	)
+
+	statusCmd.Flags().StringVar(&statusOutput, "output", status.OutputText,
+		"Output format of the statuses, text or json.",
+	)
+}
@@ -16,11 +16,12 @@ package cmd
 
 import (
 	"fmt"
-	"log"
 	"path/filepath"
 
 	"github.com/spf13/cobra"
 
+	"go.opentelemetry.io/build-tools/internal/logging"
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
 	"go.opentelemetry.io/build-tools/multimod/internal/sync"
 )
 
@@ -30,6 +31,13 @@ var (
 	allModuleSetsSync   bool
 	moduleSetNamesSync  []string
 	skipGoModTidySync   bool
+	syncGoDirectives    bool
+	createPRSync        bool
+	dryRunSync          bool
+	remoteSync          string
+	baseSync            string
+	commitSync          string
+	signKeySync         string
 )
 
 // syncCmd represents the sync command
@@ -39,9 +47,13 @@ var syncCmd = &cobra.Command{
 	Long: `Updates version numbers of module sets from another repo:
 - Checks that the working tree is clean.
 - Switches to a new branch called prerelease_<module set name>_<new version>.
-- Updates module versions in all go.mod files.
+- Updates module versions in all go.mod files, or pseudo-versions of a commit of the
+  other repo with --commit.
+- Optionally raises the go and toolchain directives of the modules depending on
+  the module set to those used by the module set.
 - Attempts to call go mod tidy on the files.
-- Adds and commits changes to Git branch`,
+- Adds and commits changes to Git branch
+- Optionally pushes the branch and opens a pull request on GitHub.`,
 	PreRun: func(cmd *cobra.Command, args []string) {
 		if allModuleSetsSync {
 			// do not require module set names if operating on all module sets
@@ -50,36 +62,52 @@ var syncCmd = &cobra.Command{
 				cobra.BashCompOneRequiredFlag,
 				[]string{"false"},
 			); err != nil {
-				log.Fatalf("could not set module-set-names flag as not required flag: %v", err)
+				logging.Fatalf("could not set module-set-names flag as not required flag: %v", err)
 			}
 		}
 	},
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Using versioning file", versioningFile)
+		logging.Infof("Using versioning file %v", versioningFile)
 
 		if otherVersioningFile == "" {
-			otherVersioningFile = filepath.Join(otherRepoRoot,
-				fmt.Sprintf("%v.%v", defaultVersionsConfigName, defaultVersionsConfigType))
+			otherVersioningFile = fmt.Sprintf("%v.%v", defaultVersionsConfigName, defaultVersionsConfigType)
+			if !sync.IsRemoteRepo(otherRepoRoot) {
+				otherVersioningFile = filepath.Join(otherRepoRoot, otherVersioningFile)
+			}
 		}
-		sync.Run(versioningFile, otherVersioningFile, otherRepoRoot, moduleSetNamesSync, allModuleSetsSync, skipGoModTidySync)
+		sync.Run(cmd.Context(), sync.Options{
+			VersioningFile:      versioningFile,
+			OtherVersioningFile: otherVersioningFile,
+			OtherRepoRoot:       otherRepoRoot,
+			OtherModuleSetNames: moduleSetNamesSync,
+			AllModuleSets:       allModuleSetsSync,
+			SkipModTidy:         skipGoModTidySync,
+			SyncGoDirectives:    syncGoDirectives,
+			Commit:              commitSync,
+			DryRun:              dryRunSync,
+			CreatePR:            createPRSync,
+			Remote:              remoteSync,
+			Base:                baseSync,
+			SignKeyPath:         signKeySync,
+		})
 	},
 }
 
 func init() {
-	// Plain log output, no timestamps.
-	log.SetFlags(0)
-
 	rootCmd.AddCommand(syncCmd)
 
 	syncCmd.Flags().StringVarP(&otherRepoRoot, "other-repo-root", "o", "",
-		"File path of other repository root whose modules' versions need to be updated.")
+		"File path of other repository root whose modules' versions need to be updated. "+
+			"A GitHub repository URL or owner/repo[@ref] can be given instead, "+
+			"in which case only its versioning file is fetched.")
 	if err := syncCmd.MarkFlagRequired("other-repo-root"); err != nil {
-		log.Fatalf("could not mark other-repo-root flag as required: %v", err)
+		logging.Fatalf("could not mark other-repo-root flag as required: %v", err)
 	}
 
 	syncCmd.Flags().StringVar(&otherVersioningFile, "other-versioning-file", "",
 		"Path to other versioning file that contains all module set versions to sync. "+
-			"If unspecified, defaults to versions.yaml in the other Git repo root.")
+			"If unspecified, defaults to versions.yaml in the other Git repo root. "+
+			"Relative to the other repo root if it is a GitHub repository.")
 
 	syncCmd.Flags().BoolVarP(&allModuleSetsSync, "all-module-sets", "a", false,
 		"Specify this flag to update versions of modules in all sets listed in the versioning file.",
@@ -92,11 +120,46 @@ func init() {
 			"For example: --module-set-names=\"mod-set-1,mod-set-2\"",
 	)
 	if err := syncCmd.MarkFlagRequired("module-set-names"); err != nil {
-		log.Fatalf("could not mark module-set-names flag as required: %v", err)
+		logging.Fatalf("could not mark module-set-names flag as required: %v", err)
 	}
 
 	syncCmd.Flags().BoolVarP(&skipGoModTidySync, "skip-go-mod-tidy", "s", false,
 		"Specify this flag to skip invoking `go mod tidy`. "+
 			"To be used for debugging purposes. Should not be skipped during actual release.",
 	)
+
+	syncCmd.Flags().BoolVar(&syncGoDirectives, "sync-go-directive", false,
+		"Raise the go and toolchain directives of modules depending on the module set "+
+			"to the highest ones used by the modules of the set in the other repo.",
+	)
+
+	syncCmd.Flags().StringVar(&commitSync, "commit", "",
+		"Commit of the other repo to require the modules at, using their pseudo-versions instead of the "+
+			"released versions of the module sets. Requires a local checkout of the other repo.",
+	)
+
+	syncCmd.Flags().BoolVar(&createPRSync, "create-pr", false,
+		"Commit the changes to a new branch, push it to the remote and open a pull request on GitHub. "+
+			"Requires a token in MULTIMOD_GIT_TOKEN, GITHUB_TOKEN, or GH_TOKEN.",
+	)
+
+	syncCmd.Flags().BoolVar(&dryRunSync, "dry-run", false,
+		"Print the current and new version of every requirement that would be updated, "+
+			"without modifying any go.mod file.",
+	)
+	syncCmd.MarkFlagsMutuallyExclusive("dry-run", "create-pr")
+
+	syncCmd.Flags().StringVar(&signKeySync, "sign-key", "",
+		"Path of the OpenPGP or OpenSSH private key signing the commit made with --create-pr. If unspecified, defaults to the path in the "+
+			common.CommitSignKeyEnv+" environment variable, and commits are not signed if it is not set either. "+
+			"The passphrase of an encrypted key is read from the "+common.SignKeyPassphraseEnv+" environment variable.",
+	)
+
+	syncCmd.Flags().StringVar(&remoteSync, "remote", "origin",
+		"Name of the remote the branch is pushed to when --create-pr is set.",
+	)
+
+	syncCmd.Flags().StringVar(&baseSync, "base", "main",
+		"Branch the pull request is opened against when --create-pr is set.",
+	)
 }
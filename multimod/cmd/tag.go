@@ -15,20 +15,32 @@
 package cmd
 
 import (
-	"fmt"
-	"log"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"go.opentelemetry.io/build-tools/internal/logging"
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
 	"go.opentelemetry.io/build-tools/multimod/internal/tag"
 )
 
 var (
 	commitHash          string
 	deleteModuleSetTags bool
+	deleteRemote        string
+	dryRun              bool
+	force               bool
+	maxTagAge           time.Duration
 	moduleSetName       string
 	push                bool
 	remote              string
+	requireBranch       string
+	sign                bool
+	signKeyPath         string
+	tagMessage          string
+	tagModuleSetNames   []string
+	unfreezeTag         bool
+	yes                 bool
 )
 
 // tagCmd represents the tag command
@@ -36,43 +48,139 @@ var tagCmd = &cobra.Command{
 	Use:   "tag",
 	Short: "Applies Git tags to specified commit",
 	Long: `Tag script to add Git tags to a specified commit hash created by prerelease script:
-- Creates new Git tags for all modules being updated.
-- If tagging fails in the middle of the script, the recently created tags will be deleted.`,
+- Creates new Git tags for all modules being updated, of one or several module sets.
+- If tagging fails in the middle of the script, the recently created tags of all module sets will be deleted.`,
+	Args: cobra.NoArgs,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		if allModuleSets {
+			// do not require module set names if operating on all module sets
+			if err := cmd.Flags().SetAnnotation(
+				"module-set-name",
+				cobra.BashCompOneRequiredFlag,
+				[]string{"false"},
+			); err != nil {
+				logging.Fatalf("could not set module-set-name flag as not required flag: %v", err)
+			}
+		}
+	},
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Using versioning file", versioningFile)
+		logging.Infof("Using versioning file %v", versioningFile)
+
+		if deleteRemote != "" && !deleteModuleSetTags {
+			logging.Fatalf("--delete-remote requires --delete-module-set-tags")
+		}
 
-		tag.Run(versioningFile, moduleSetName, commitHash, deleteModuleSetTags, push, remote)
+		var keyPath string
+		if sign {
+			keyPath = signKeyPath
+		}
+		tag.Run(cmd.Context(), tag.Options{
+			VersioningFile:      versioningFile,
+			ModuleSetNames:      tagModuleSetNames,
+			AllModuleSets:       allModuleSets,
+			CommitHash:          commitHash,
+			DeleteModuleSetTags: deleteModuleSetTags,
+			DeleteRemote:        deleteRemote,
+			Yes:                 yes,
+			Force:               force,
+			MaxTagAge:           maxTagAge,
+			Push:                push,
+			Remote:              remote,
+			Unfreeze:            unfreezeTag,
+			SignKeyPath:         keyPath,
+			DryRun:              dryRun,
+			RequireBranch:       requireBranch,
+			TagMessage:          tagMessage,
+		})
 	},
 }
 
 func init() {
-	// Plain log output, no timestamps.
-	log.SetFlags(0)
-
 	rootCmd.AddCommand(tagCmd)
 
 	tagCmd.Flags().StringVarP(&commitHash, "commit-hash", "c", "",
 		"Git commit hash to tag.",
 	)
 	if err := tagCmd.MarkFlagRequired("commit-hash"); err != nil {
-		log.Fatalf("could not mark commit-hash flag as required: %v", err)
+		logging.Fatalf("could not mark commit-hash flag as required: %v", err)
 	}
 
-	tagCmd.Flags().StringVarP(&moduleSetName, "module-set-name", "m", "",
+	tagCmd.Flags().BoolVarP(&allModuleSets, "all-module-sets", "a", false,
+		"Specify this flag to tag all module sets listed in the versioning file, "+
+			"skipping frozen module sets and those already tagged.",
+	)
+
+	tagCmd.Flags().StringSliceVarP(&tagModuleSetNames, "module-set-name", "m", nil,
 		"Name of module set being tagged. "+
-			"Name must be listed in the module set versioning YAML. ",
+			"Name must be listed in the module set versioning YAML. "+
+			"To tag multiple module sets, repeat the flag or specify set names as comma-separated values.",
 	)
 	if err := tagCmd.MarkFlagRequired("module-set-name"); err != nil {
-		log.Fatalf("could not mark module-set-name flag as required: %v", err)
+		logging.Fatalf("could not mark module-set-name flag as required: %v", err)
 	}
+	tagCmd.MarkFlagsMutuallyExclusive("all-module-sets", "module-set-name")
 
 	tagCmd.Flags().BoolVarP(&deleteModuleSetTags, "delete-module-set-tags", "d", false,
 		"Specify this flag to delete all module tags associated with the version listed for the module set in the versioning file. Should only be used to undo recent tagging mistakes.",
 	)
+	tagCmd.MarkFlagsMutuallyExclusive("all-module-sets", "delete-module-set-tags")
+
+	tagCmd.Flags().StringVar(&deleteRemote, "delete-remote", "",
+		"With --delete-module-set-tags, also delete the tags from the given remote, upstream if none is given, before deleting them locally. "+
+			"Asks for confirmation unless --yes is given.",
+	)
+	tagCmd.Flags().Lookup("delete-remote").NoOptDefVal = "upstream"
+
+	tagCmd.Flags().BoolVarP(&yes, "yes", "y", false,
+		"Do not ask for confirmation before deleting tags from a remote with --delete-remote.",
+	)
+
+	tagCmd.Flags().BoolVar(&dryRun, "dry-run", false,
+		"Verify the module set can be tagged and print the tags that would be created, or deleted, without changing them.",
+	)
+
+	tagCmd.Flags().BoolVar(&force, "force", false,
+		"Delete module set tags even if they are protected because they are published on the module proxy or older than --max-tag-age.",
+	)
+
+	tagCmd.Flags().DurationVar(&maxTagAge, "max-tag-age", 24*time.Hour,
+		"Maximum age of module set tags that can be deleted without --force. 0 disables the check.",
+	)
+
+	tagCmd.Flags().StringVar(&requireBranch, "require-branch", "",
+		"Only tag the commit if it is reachable from the head of the given branch, e.g. main or upstream/main.",
+	)
+
+	tagCmd.Flags().BoolVar(&unfreezeTag, "unfreeze", false,
+		"Tag the module set even if it is marked as frozen in the versioning file.",
+	)
+
+	tagCmd.Flags().StringVar(&tagMessage, "tag-message", "",
+		"Go template of the message of the annotated tags, overriding the tag-message of the module sets. "+
+			"The template is given the .ModuleSet, .Version, .Module, .ModuleVersion, .Tag and .Commit fields.",
+	)
+
+	tagCmd.Flags().BoolVar(&sign, "sign", false,
+		"Create GPG-signed annotated tags with the private key given by --key instead of the git executable.",
+	)
+
+	tagCmd.Flags().StringVar(&signKeyPath, "key", "",
+		"Path of the OpenPGP private key signing tags with --sign. "+
+			"The passphrase of an encrypted key is read from the "+common.SignKeyPassphraseEnv+" environment variable.",
+	)
+	tagCmd.MarkFlagsRequiredTogether("sign", "key")
+
+	tagCmd.Flags().BoolVar(&push, "push", false,
+		"Push the created tags to the remote given by --remote-name. "+
+			"The local tags are removed if the push fails.",
+	)
 
 	tagCmd.Flags().BoolVarP(&push, "push-tags", "p", false, "Providing this"+
 		" flag will cause tags to be pushed to an upstream repository.")
+	if err := tagCmd.Flags().MarkDeprecated("push-tags", "use --push instead"); err != nil {
+		logging.Fatalf("could not mark push-tags flag as deprecated: %v", err)
+	}
 
 	tagCmd.Flags().StringVarP(&remote, "remote-name", "r", "upstream", "Name of the remote"+
-		"to push tags to.")
+		" to push tags to.")
 }
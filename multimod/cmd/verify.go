@@ -15,34 +15,47 @@
 package cmd
 
 import (
-	"fmt"
-	"log"
-
 	"github.com/spf13/cobra"
 
+	"go.opentelemetry.io/build-tools/internal/logging"
 	"go.opentelemetry.io/build-tools/multimod/internal/verify"
 )
 
+var (
+	fixStale     bool
+	verifyOutput string
+)
+
 // verifyCmd represents the verify command
 var verifyCmd = &cobra.Command{
 	Use:   "verify",
 	Short: "Verifies that the versioning file is valid",
 	Long: `verify checks that all modules listed in sets are valid by verifying the following properties:
+- All modules listed in sets or excluded exist in the repo, stale entries are removed with --fix.
 - All modules are contained in exactly one module set.
 - Versions conform to semver semantics.
 - No more than one set of modules exists for any non-zero major version.
-- Script warns if any stable modules depend on any unstable modules.
+- No modules of stable sets depend on modules of unstable (pre-1.0) sets.
+- All modules require the modules of the repo at the versions of their sets.
+
+With --output json, the results are written to stdout as a JSON array with one record per
+rule per module set, with the rule identifier, severity (error or info), module set, module
+and message, instead of stopping at the first failing rule.
 `,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Using versioning file", versioningFile)
+		logging.Infof("Using versioning file %v", versioningFile)
 
-		verify.Run(versioningFile)
+		verify.Run(versioningFile, fixStale, verifyOutput)
 	},
 }
 
 func init() {
-	// Plain log output, no timestamps.
-	log.SetFlags(0)
-
 	rootCmd.AddCommand(verifyCmd)
+
+	verifyCmd.Flags().BoolVar(&fixStale, "fix", false,
+		"Remove modules that no longer exist in the repo from the module sets and excluded modules of the versioning file.",
+	)
+	verifyCmd.Flags().StringVar(&verifyOutput, "output", verify.OutputText,
+		"Output format of the results, text or json.",
+	)
 }
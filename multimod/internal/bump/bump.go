@@ -0,0 +1,240 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bump
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/semver"
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+)
+
+// Increments of a version.
+const (
+	Minor      = "minor"
+	Patch      = "patch"
+	Prerelease = "prerelease"
+)
+
+var (
+	errInvalidIncrement = errors.New("invalid increment")
+	errInvalidPreid     = errors.New("invalid prerelease identifier")
+	errInvalidVersion   = errors.New("invalid version")
+	errModuleSetMissing = errors.New("module set not found")
+)
+
+func Run(versioningFile, moduleSetName, increment, preid string) {
+	modSet, err := common.GetModuleSet(moduleSetName, versioningFile)
+	if err != nil {
+		logging.Fatalf("could not read module set: %v", err)
+	}
+	if modSet.Version == "" {
+		logging.Fatalf("%v: %v", errModuleSetMissing, moduleSetName)
+	}
+
+	newVersion, err := nextVersion(modSet.Version, increment, preid)
+	if err != nil {
+		logging.Fatalf("could not bump version of module set %v: %v", moduleSetName, err)
+	}
+
+	if err = setVersion(versioningFile, moduleSetName, newVersion); err != nil {
+		logging.Fatalf("could not update versioning file: %v", err)
+	}
+
+	logging.Infof("Bumped module set %v from %v to %v", moduleSetName, modSet.Version, newVersion)
+}
+
+// nextVersion returns version incremented by increment. Prerelease
+// increments of a release version start a prerelease of the next patch
+// version, identified by preid. Build metadata is dropped.
+func nextVersion(version, increment, preid string) (string, error) {
+	if !semver.IsValid(version) || semver.Canonical(version) != strings.SplitN(version, "+", 2)[0] {
+		return "", fmt.Errorf("%w: %q", errInvalidVersion, version)
+	}
+
+	core := strings.TrimPrefix(semver.Canonical(version), "v")
+	pre := strings.TrimPrefix(semver.Prerelease(version), "-")
+	core = strings.TrimSuffix(core, semver.Prerelease(version))
+
+	parts := strings.Split(core, ".")
+	nums := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return "", fmt.Errorf("%w: %q", errInvalidVersion, version)
+		}
+		nums[i] = n
+	}
+	major, minor, patch := nums[0], nums[1], nums[2]
+
+	switch increment {
+	case Minor:
+		// The release of a prerelease of a new minor version.
+		if pre == "" || patch != 0 {
+			minor, patch = minor+1, 0
+		}
+		pre = ""
+	case Patch:
+		if pre == "" {
+			patch++
+		}
+		pre = ""
+	case Prerelease:
+		if pre == "" {
+			// The identifier must be a valid semver prerelease, without
+			// build metadata.
+			if preid == "" || semver.Prerelease("v0.0.0-"+preid) != "-"+preid {
+				return "", fmt.Errorf("%w: %q", errInvalidPreid, preid)
+			}
+			patch++
+			pre = preid + ".0"
+		}
+		pre = nextPrerelease(pre)
+	default:
+		return "", fmt.Errorf("%w: %q, must be %v, %v, or %v", errInvalidIncrement, increment, Minor, Patch, Prerelease)
+	}
+
+	v := fmt.Sprintf("v%d.%d.%d", major, minor, patch)
+	if pre != "" {
+		v += "-" + pre
+	}
+	if !semver.IsValid(v) {
+		return "", fmt.Errorf("%w: %q", errInvalidVersion, v)
+	}
+	return v, nil
+}
+
+// nextPrerelease increments the last numeric identifier of the prerelease
+// pre, or appends one if it has none.
+func nextPrerelease(pre string) string {
+	ids := strings.Split(pre, ".")
+	last := ids[len(ids)-1]
+	n, err := strconv.Atoi(last)
+	if err != nil {
+		return pre + ".1"
+	}
+	ids[len(ids)-1] = strconv.Itoa(n + 1)
+	return strings.Join(ids, ".")
+}
+
+// setVersion sets the version of the module set with modSetName to version in
+// the versioning file, or in the versioning file it includes that defines the
+// module set. Only the version is rewritten so the comments, ordering, and
+// formatting of the file are preserved.
+func setVersion(versioningFilename, modSetName, version string) error {
+	found, err := setVersionIncludes(versioningFilename, modSetName, version, nil)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("%w: %v", errModuleSetMissing, modSetName)
+	}
+	return nil
+}
+
+// setVersionIncludes sets the version of the module set in the versioning
+// file and, recursively, the versioning files it includes. including holds the
+// files currently being edited to detect include cycles.
+func setVersionIncludes(versioningFilename, modSetName, version string, including []string) (bool, error) {
+	absFilename, err := filepath.Abs(versioningFilename)
+	if err != nil {
+		return false, fmt.Errorf("could not get absolute path of versioning file: %w", err)
+	}
+	for _, f := range including {
+		if f == absFilename {
+			return false, fmt.Errorf("versioning file %v includes itself", versioningFilename)
+		}
+	}
+	including = append(including, absFilename)
+
+	info, err := os.Stat(versioningFilename)
+	if err != nil {
+		return false, fmt.Errorf("could not read versioning file: %w", err)
+	}
+	data, err := os.ReadFile(filepath.Clean(versioningFilename))
+	if err != nil {
+		return false, fmt.Errorf("could not read versioning file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err = yaml.Unmarshal(data, &doc); err != nil {
+		return false, fmt.Errorf("could not parse versioning file %v: %w", versioningFilename, err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return false, nil
+	}
+	root := doc.Content[0]
+
+	if sets := common.YAMLMappingValue(root, "module-sets"); sets != nil {
+		if n := common.YAMLMappingValue(common.YAMLMappingValue(sets, modSetName), "version"); n != nil && n.Kind == yaml.ScalarNode {
+			updated, err := replaceScalar(data, n, version)
+			if err != nil {
+				return false, fmt.Errorf("could not update version in %v: %w", versioningFilename, err)
+			}
+			if err = os.WriteFile(versioningFilename, updated, info.Mode().Perm()); err != nil {
+				return false, fmt.Errorf("could not write versioning file: %w", err)
+			}
+			return true, nil
+		}
+	}
+
+	if includes := common.YAMLMappingValue(root, "include"); includes != nil && includes.Kind == yaml.SequenceNode {
+		for _, n := range includes.Content {
+			inc := n.Value
+			if !filepath.IsAbs(inc) {
+				inc = filepath.Join(filepath.Dir(versioningFilename), inc)
+			}
+			found, err := setVersionIncludes(inc, modSetName, version, including)
+			if found || err != nil {
+				return found, err
+			}
+		}
+	}
+	return false, nil
+}
+
+// replaceScalar returns data with the value of the scalar node n, parsed from
+// data, replaced by value.
+func replaceScalar(data []byte, n *yaml.Node, value string) ([]byte, error) {
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	if n.Line < 1 || n.Line > len(lines) {
+		return nil, errors.New("invalid node position")
+	}
+	start := n.Column - 1
+	for _, l := range lines[:n.Line-1] {
+		start += len(l)
+	}
+
+	i := bytes.Index(data[start:], []byte(n.Value))
+	if i < 0 || i > 1 {
+		// The value is only preceded by a quote if it is quoted.
+		return nil, fmt.Errorf("value %q not found", n.Value)
+	}
+	start += i
+
+	out := make([]byte, 0, len(data)-len(n.Value)+len(value))
+	out = append(out, data[:start]...)
+	out = append(out, value...)
+	return append(out, data[start+len(n.Value):]...), nil
+}
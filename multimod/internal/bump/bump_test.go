@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bump
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextVersion(t *testing.T) {
+	testCases := []struct {
+		version   string
+		increment string
+		expected  string
+	}{
+		{version: "v1.2.3", increment: Patch, expected: "v1.2.4"},
+		{version: "v1.2.3", increment: Minor, expected: "v1.3.0"},
+		{version: "v1.2.3", increment: Prerelease, expected: "v1.2.4-rc.1"},
+		{version: "v1.2.3+meta", increment: Patch, expected: "v1.2.4"},
+		{version: "v1.2.4-rc.1", increment: Patch, expected: "v1.2.4"},
+		{version: "v1.2.4-rc.1", increment: Minor, expected: "v1.3.0"},
+		{version: "v1.3.0-rc.1", increment: Minor, expected: "v1.3.0"},
+		{version: "v1.3.0-rc.1", increment: Prerelease, expected: "v1.3.0-rc.2"},
+		{version: "v1.3.0-beta", increment: Prerelease, expected: "v1.3.0-beta.1"},
+		{version: "v0.9.0-alpha.1.9", increment: Prerelease, expected: "v0.9.0-alpha.1.10"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.version+" "+tc.increment, func(t *testing.T) {
+			actual, err := nextVersion(tc.version, tc.increment, "rc")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	_, err := nextVersion("v1.2.3", "major", "rc")
+	assert.ErrorIs(t, err, errInvalidIncrement)
+	for _, version := range []string{"1.2.3", "v1.2", "v1.2.3-"} {
+		_, err = nextVersion(version, Patch, "rc")
+		assert.ErrorIs(t, err, errInvalidVersion, version)
+	}
+
+	actual, err := nextVersion("v1.2.3", Prerelease, "alpha")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.4-alpha.1", actual)
+
+	actual, err = nextVersion("v1.2.3", Prerelease, "beta.2")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.4-beta.2.1", actual)
+	for _, preid := range []string{"", ".", "rc.", "rc..1", "01", "rc_1", "rc+1", "été"} {
+		_, err = nextVersion("v1.2.3", Prerelease, preid)
+		assert.ErrorIs(t, err, errInvalidPreid, preid)
+	}
+
+	// The prerelease identifier is only used to start a prerelease.
+	actual, err = nextVersion("v1.3.0-rc.1", Prerelease, "")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.3.0-rc.2", actual)
+}
+
+// copyTestData copies the versioning files of the test data to a temporary
+// directory and returns it.
+func copyTestData(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for _, name := range []string{"versions.yaml", "experimental/versions.yaml"} {
+		data, err := os.ReadFile(filepath.Join("test_data", name))
+		require.NoError(t, err)
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, data, 0600))
+	}
+	return dir
+}
+
+func TestSetVersion(t *testing.T) {
+	testCases := []struct {
+		modSetName string
+		version    string
+		file       string
+		old        string
+		new        string
+	}{
+		{
+			modSetName: "stable-v1",
+			version:    "v1.3.0",
+			file:       "versions.yaml",
+			old:        "version: v1.2.3 # Released monthly.",
+			new:        "version: v1.3.0 # Released monthly.",
+		},
+		{
+			modSetName: "quoted",
+			version:    "v0.4.0",
+			file:       "versions.yaml",
+			old:        `version: "v0.4.0-rc.1"`,
+			new:        `version: "v0.4.0"`,
+		},
+		{
+			modSetName: "experimental",
+			version:    "v0.10.0",
+			file:       "experimental/versions.yaml",
+			old:        "version:   v0.9.0",
+			new:        "version:   v0.10.0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.modSetName, func(t *testing.T) {
+			dir := copyTestData(t)
+			require.NoError(t, setVersion(filepath.Join(dir, "versions.yaml"), tc.modSetName, tc.version))
+
+			for _, name := range []string{"versions.yaml", "experimental/versions.yaml"} {
+				original, err := os.ReadFile(filepath.Join("test_data", name))
+				require.NoError(t, err)
+				actual, err := os.ReadFile(filepath.Join(dir, name))
+				require.NoError(t, err)
+
+				expected := string(original)
+				if name == tc.file {
+					expected = strings.Replace(expected, tc.old, tc.new, 1)
+				}
+				assert.Equal(t, expected, string(actual), name)
+			}
+		})
+	}
+
+	err := setVersion(filepath.Join(copyTestData(t), "versions.yaml"), "missing", "v1.0.0")
+	assert.ErrorIs(t, err, errModuleSetMissing)
+}
@@ -0,0 +1,263 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+)
+
+const (
+	// EnvGitToken is the environment variable holding a token used to
+	// authenticate HTTPS remote operations against any host.
+	EnvGitToken = "MULTIMOD_GIT_TOKEN"
+	// EnvGitUsername is the environment variable holding the username sent
+	// along with the token in EnvGitToken.
+	EnvGitUsername = "MULTIMOD_GIT_USERNAME"
+	// EnvSSHKey is the environment variable holding the path to a private
+	// key (e.g. a deploy key) used to authenticate SSH remote operations.
+	EnvSSHKey = "MULTIMOD_SSH_KEY"
+	// EnvSSHKeyPassphrase is the environment variable holding the passphrase
+	// of the private key in EnvSSHKey, if any.
+	EnvSSHKeyPassphrase = "MULTIMOD_SSH_KEY_PASSPHRASE"
+	// EnvGitHubServerURL is the environment variable holding the URL of the
+	// GitHub instance, as set by GitHub Actions on GitHub Enterprise Server.
+	EnvGitHubServerURL = "GITHUB_SERVER_URL"
+
+	defaultTokenUsername = "x-access-token"
+	defaultSSHUser       = "git"
+	defaultGitHubHost    = "github.com"
+)
+
+// tokenEnvVars are checked in order for a GitHub token. Only EnvGitToken is
+// used for the remotes of other hosts.
+var tokenEnvVars = []string{EnvGitToken, "GITHUB_TOKEN", "GH_TOKEN"}
+
+var (
+	// ErrAuthentication is returned when a remote operation fails because
+	// the credentials were missing or rejected.
+	ErrAuthentication = errors.New("git remote authentication failed")
+	// ErrNetwork is returned when a remote operation fails because the
+	// remote could not be reached.
+	ErrNetwork = errors.New("git remote unreachable")
+)
+
+// gitCredentialFillFunc is used to query the git credential helpers
+// configured by the user. It is a variable so it can be replaced in tests.
+var gitCredentialFillFunc = gitCredentialFill
+
+// RemoteAuth returns the authentication method to use for remote operations
+// against remoteURL. The following sources are tried in order:
+//
+// For SSH remotes:
+//   - The private key file named by MULTIMOD_SSH_KEY (e.g. a deploy key).
+//   - The running SSH agent.
+//
+// For HTTP(S) remotes:
+//   - A token from MULTIMOD_GIT_TOKEN or, for GitHub remotes only,
+//     GITHUB_TOKEN or GH_TOKEN.
+//   - The git credential helpers configured for the remote.
+//
+// A nil AuthMethod is returned if no credentials are found for an HTTP(S)
+// remote, or for any other kind of remote, so the operation is attempted
+// anonymously.
+func RemoteAuth(remoteURL string) (transport.AuthMethod, error) {
+	ep, err := transport.NewEndpoint(remoteURL)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse remote URL %v: %w", remoteURL, err)
+	}
+
+	switch ep.Protocol {
+	case "ssh":
+		return sshAuth(ep)
+	case "http", "https":
+		return httpAuth(ep)
+	default:
+		return nil, nil
+	}
+}
+
+// RepoRemoteAuth returns the authentication method to use for the remote
+// named remoteName of repo. See RemoteAuth for how it is determined.
+func RepoRemoteAuth(repo *git.Repository, remoteName string) (transport.AuthMethod, error) {
+	remote, err := repo.Remote(remoteName)
+	if err != nil {
+		return nil, fmt.Errorf("could not get remote %v: %w", remoteName, err)
+	}
+
+	urls := remote.Config().URLs
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("remote %v has no URL configured", remoteName)
+	}
+
+	return RemoteAuth(urls[0])
+}
+
+func sshAuth(ep *transport.Endpoint) (transport.AuthMethod, error) {
+	user := ep.User
+	if user == "" {
+		user = defaultSSHUser
+	}
+
+	if keyFile := os.Getenv(EnvSSHKey); keyFile != "" {
+		auth, err := ssh.NewPublicKeysFromFile(user, keyFile, os.Getenv(EnvSSHKeyPassphrase))
+		if err != nil {
+			return nil, fmt.Errorf("%w: could not load SSH key %v: %v", ErrAuthentication, keyFile, err)
+		}
+		return auth, nil
+	}
+
+	auth, err := ssh.NewSSHAgentAuth(user)
+	if err != nil {
+		return nil, fmt.Errorf("%w: no SSH key given in %v and SSH agent unavailable: %v", ErrAuthentication, EnvSSHKey, err)
+	}
+	return auth, nil
+}
+
+// Token returns the first token set in MULTIMOD_GIT_TOKEN, GITHUB_TOKEN, or
+// GH_TOKEN, or an empty string if none is set. It must only be sent to GitHub.
+func Token() string {
+	for _, env := range tokenEnvVars {
+		if token := os.Getenv(env); token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+func httpAuth(ep *transport.Endpoint) (transport.AuthMethod, error) {
+	if ep.User != "" && ep.Password != "" {
+		// Credentials embedded in the remote URL are used by go-git as is.
+		return nil, nil
+	}
+
+	token := os.Getenv(EnvGitToken)
+	if token == "" && isGitHubHost(ep.Host) {
+		token = Token()
+	}
+	if token != "" {
+		user := os.Getenv(EnvGitUsername)
+		if user == "" {
+			user = defaultTokenUsername
+		}
+		return &githttp.BasicAuth{Username: user, Password: token}, nil
+	}
+
+	user, password, err := gitCredentialFillFunc(ep)
+	if err != nil {
+		// No helper configured, or it has nothing for this remote. Public
+		// remotes can still be read anonymously.
+		return nil, nil
+	}
+	if password == "" {
+		return nil, nil
+	}
+	return &githttp.BasicAuth{Username: user, Password: password}, nil
+}
+
+// isGitHubHost reports whether host is github.com or the host of the GitHub
+// instance in GITHUB_SERVER_URL, the only hosts GITHUB_TOKEN and GH_TOKEN are
+// sent to.
+func isGitHubHost(host string) bool {
+	if strings.EqualFold(host, defaultGitHubHost) {
+		return true
+	}
+	serverURL, err := url.Parse(os.Getenv(EnvGitHubServerURL))
+	return err == nil && serverURL.Hostname() != "" && strings.EqualFold(host, serverURL.Hostname())
+}
+
+// gitCredentialFill asks the git credential helpers configured by the user
+// for the credentials of ep, without ever prompting on the terminal.
+func gitCredentialFill(ep *transport.Endpoint) (string, string, error) {
+	var in bytes.Buffer
+	fmt.Fprintf(&in, "protocol=%s\n", ep.Protocol)
+	host := ep.Host
+	if ep.Port != 0 {
+		host = fmt.Sprintf("%s:%d", ep.Host, ep.Port)
+	}
+	fmt.Fprintf(&in, "host=%s\n", host)
+	fmt.Fprintf(&in, "path=%s\n", strings.TrimPrefix(ep.Path, "/"))
+	if ep.User != "" {
+		fmt.Fprintf(&in, "username=%s\n", ep.User)
+	}
+	in.WriteString("\n")
+
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Stdin = &in
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_ASKPASS=", "SSH_ASKPASS=")
+	out, err := cmd.Output()
+	if err != nil {
+		return "", "", fmt.Errorf("git credential fill failed: %w", err)
+	}
+
+	var user, password string
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "username":
+			user = value
+		case "password":
+			password = value
+		}
+	}
+	return user, password, scanner.Err()
+}
+
+// ClassifyRemoteError wraps err, returned by a remote git operation, with
+// ErrAuthentication or ErrNetwork when the cause can be determined, so
+// callers can tell bad credentials apart from connectivity problems.
+// Errors that are neither are returned unchanged.
+func ClassifyRemoteError(err error) error {
+	if err == nil || errors.Is(err, ErrAuthentication) || errors.Is(err, ErrNetwork) {
+		return err
+	}
+
+	msg := strings.ToLower(err.Error())
+	switch {
+	case errors.Is(err, transport.ErrAuthenticationRequired),
+		errors.Is(err, transport.ErrAuthorizationFailed),
+		errors.Is(err, transport.ErrInvalidAuthMethod):
+		return fmt.Errorf("%w: %v", ErrAuthentication, err)
+	case strings.Contains(msg, "unable to authenticate"),
+		strings.Contains(msg, "permission denied"):
+		// SSH handshake failures are only exposed as strings.
+		return fmt.Errorf("%w: %v", ErrAuthentication, err)
+	}
+
+	// Connection, DNS and timeout errors, including those wrapped in a
+	// *url.Error by the HTTP transport, all implement net.Error.
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return fmt.Errorf("%w: %v", ErrNetwork, err)
+	}
+
+	return err
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clearAuthEnv unsets every environment variable RemoteAuth reads for the
+// duration of the test.
+func clearAuthEnv(t *testing.T) {
+	for _, env := range append(tokenEnvVars, EnvGitUsername, EnvSSHKey, EnvSSHKeyPassphrase, EnvGitHubServerURL) {
+		t.Setenv(env, "")
+	}
+}
+
+func stubCredentialFill(t *testing.T, user, password string, err error) {
+	t.Cleanup(func(f func(*transport.Endpoint) (string, string, error)) func() {
+		return func() { gitCredentialFillFunc = f }
+	}(gitCredentialFillFunc))
+	gitCredentialFillFunc = func(*transport.Endpoint) (string, string, error) {
+		return user, password, err
+	}
+}
+
+func TestRemoteAuthHTTPToken(t *testing.T) {
+	clearAuthEnv(t)
+	stubCredentialFill(t, "", "", assert.AnError)
+	t.Setenv("GITHUB_TOKEN", "gh-token")
+
+	auth, err := RemoteAuth("https://github.com/open-telemetry/opentelemetry-go.git")
+	require.NoError(t, err)
+	assert.Equal(t, &githttp.BasicAuth{Username: defaultTokenUsername, Password: "gh-token"}, auth)
+
+	// The multimod specific variable takes precedence.
+	t.Setenv(EnvGitToken, "multimod-token")
+	t.Setenv(EnvGitUsername, "bot")
+	auth, err = RemoteAuth("https://github.com/open-telemetry/opentelemetry-go.git")
+	require.NoError(t, err)
+	assert.Equal(t, &githttp.BasicAuth{Username: "bot", Password: "multimod-token"}, auth)
+}
+
+func TestRemoteAuthHTTPTokenOtherHost(t *testing.T) {
+	clearAuthEnv(t)
+	stubCredentialFill(t, "", "", assert.AnError)
+	t.Setenv("GITHUB_TOKEN", "gh-token")
+	t.Setenv("GH_TOKEN", "gh-token")
+
+	// GitHub tokens are not sent to other hosts.
+	auth, err := RemoteAuth("https://gitlab.example.com/group/project.git")
+	require.NoError(t, err)
+	assert.Nil(t, auth)
+
+	// Unless they are the GitHub instance the workflow runs on.
+	t.Setenv(EnvGitHubServerURL, "https://github.example.com")
+	auth, err = RemoteAuth("https://github.example.com/org/project.git")
+	require.NoError(t, err)
+	assert.Equal(t, &githttp.BasicAuth{Username: defaultTokenUsername, Password: "gh-token"}, auth)
+	auth, err = RemoteAuth("https://gitlab.example.com/group/project.git")
+	require.NoError(t, err)
+	assert.Nil(t, auth)
+
+	t.Setenv(EnvGitToken, "multimod-token")
+	auth, err = RemoteAuth("https://gitlab.example.com/group/project.git")
+	require.NoError(t, err)
+	assert.Equal(t, &githttp.BasicAuth{Username: defaultTokenUsername, Password: "multimod-token"}, auth)
+}
+
+func TestRemoteAuthHTTPCredentialHelper(t *testing.T) {
+	clearAuthEnv(t)
+	stubCredentialFill(t, "user", "secret", nil)
+
+	auth, err := RemoteAuth("https://github.com/open-telemetry/opentelemetry-go.git")
+	require.NoError(t, err)
+	assert.Equal(t, &githttp.BasicAuth{Username: "user", Password: "secret"}, auth)
+}
+
+func TestRemoteAuthHTTPAnonymous(t *testing.T) {
+	clearAuthEnv(t)
+	stubCredentialFill(t, "", "", assert.AnError)
+
+	auth, err := RemoteAuth("https://github.com/open-telemetry/opentelemetry-go.git")
+	require.NoError(t, err)
+	assert.Nil(t, auth)
+}
+
+func TestRemoteAuthFile(t *testing.T) {
+	clearAuthEnv(t)
+
+	auth, err := RemoteAuth(t.TempDir())
+	require.NoError(t, err)
+	assert.Nil(t, auth)
+}
+
+func TestRemoteAuthSSHKey(t *testing.T) {
+	clearAuthEnv(t)
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "deploy_key")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+	t.Setenv(EnvSSHKey, keyFile)
+
+	auth, err := RemoteAuth("git@github.com:open-telemetry/opentelemetry-go.git")
+	require.NoError(t, err)
+	require.IsType(t, &ssh.PublicKeys{}, auth)
+	assert.Equal(t, "git", auth.(*ssh.PublicKeys).User)
+}
+
+func TestRemoteAuthSSHKeyMissing(t *testing.T) {
+	clearAuthEnv(t)
+	t.Setenv(EnvSSHKey, filepath.Join(t.TempDir(), "does-not-exist"))
+
+	_, err := RemoteAuth("ssh://deploy@example.com/repo.git")
+	assert.ErrorIs(t, err, ErrAuthentication)
+}
+
+func TestClassifyRemoteError(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "authentication required",
+			err:  fmt.Errorf("push: %w", transport.ErrAuthenticationRequired),
+			want: ErrAuthentication,
+		},
+		{
+			name: "authorization failed",
+			err:  transport.ErrAuthorizationFailed,
+			want: ErrAuthentication,
+		},
+		{
+			name: "ssh handshake",
+			err:  fmt.Errorf("ssh: handshake failed: ssh: unable to authenticate, attempted methods [none publickey]"),
+			want: ErrAuthentication,
+		},
+		{
+			name: "dns",
+			err:  fmt.Errorf("dial: %w", &net.DNSError{Err: "no such host", Name: "example.invalid"}),
+			want: ErrNetwork,
+		},
+		{
+			name: "connection refused",
+			err:  &net.OpError{Op: "dial", Net: "tcp", Err: fmt.Errorf("connection refused")},
+			want: ErrNetwork,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ClassifyRemoteError(tc.err)
+			assert.ErrorIs(t, got, tc.want)
+		})
+	}
+
+	assert.NoError(t, ClassifyRemoteError(nil))
+	assert.Equal(t, assert.AnError, ClassifyRemoteError(assert.AnError))
+}
@@ -15,11 +15,15 @@
 package commontest
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 	"time"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
@@ -74,3 +78,59 @@ func InitNewRepoWithCommit(repoRoot string) (*git.Repository, plumbing.Hash, err
 
 	return repo, commitHash, nil
 }
+
+// NewSignEntity returns a new EdDSA OpenPGP key signing commits and tags, which
+// is much faster to generate than an RSA one.
+func NewSignEntity() (*openpgp.Entity, error) {
+	return openpgp.NewEntity("Test Author", "", "test@example.com", &packet.Config{
+		Algorithm: packet.PubKeyAlgoEdDSA,
+	})
+}
+
+// WriteSignKey writes the armored private key of e, encrypted with passphrase
+// if not empty, to the file at path.
+func WriteSignKey(path string, e *openpgp.Entity, passphrase []byte) error {
+	if len(passphrase) > 0 {
+		if err := e.PrivateKey.Encrypt(passphrase); err != nil {
+			return fmt.Errorf("could not encrypt private key: %w", err)
+		}
+		for _, sub := range e.Subkeys {
+			if err := sub.PrivateKey.Encrypt(passphrase); err != nil {
+				return fmt.Errorf("could not encrypt private subkey: %w", err)
+			}
+		}
+	}
+
+	var b bytes.Buffer
+	w, err := armor.Encode(&b, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		return err
+	}
+	if err = e.SerializePrivateWithoutSigning(w, nil); err != nil {
+		return fmt.Errorf("could not serialize private key: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(path, b.Bytes(), 0600); err != nil {
+		return fmt.Errorf("could not write sign key: %w", err)
+	}
+	return nil
+}
+
+// ArmoredPublicKey returns the armored public key of e.
+func ArmoredPublicKey(e *openpgp.Entity) (string, error) {
+	var b bytes.Buffer
+	w, err := armor.Encode(&b, openpgp.PublicKeyType, nil)
+	if err != nil {
+		return "", err
+	}
+	if err = e.Serialize(w); err != nil {
+		return "", fmt.Errorf("could not serialize public key: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
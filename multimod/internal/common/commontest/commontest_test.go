@@ -19,8 +19,10 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -51,3 +53,26 @@ func TestWriteTempFiles(t *testing.T) {
 		assert.Equal(t, expectedModFile, actual)
 	}
 }
+
+func TestWriteSignKey(t *testing.T) {
+	e, err := NewSignEntity()
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "key.asc")
+	require.NoError(t, WriteSignKey(path, e, []byte("secret")))
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	keys, err := openpgp.ReadArmoredKeyRing(f)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, e.PrimaryKey.KeyId, keys[0].PrimaryKey.KeyId)
+	assert.True(t, keys[0].PrivateKey.Encrypted)
+
+	publicKey, err := ArmoredPublicKey(e)
+	require.NoError(t, err)
+	keys, err = openpgp.ReadArmoredKeyRing(strings.NewReader(publicKey))
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Nil(t, keys[0].PrivateKey)
+}
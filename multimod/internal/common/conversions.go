@@ -28,18 +28,20 @@ const (
 func combineModuleTagNamesAndVersion(modTagNames []ModuleTagName, version string) []string {
 	var modFullTags []string
 	for _, modTagName := range modTagNames {
-		var newFullTag string
-		if modTagName == RepoRootTag {
-			newFullTag = version
-		} else {
-			newFullTag = string(modTagName) + "/" + version
-		}
-		modFullTags = append(modFullTags, newFullTag)
+		modFullTags = append(modFullTags, ModuleFullTagName(modTagName, version))
 	}
 
 	return modFullTags
 }
 
+// ModuleFullTagName returns the full tag name of version of the module with tag name modTagName.
+func ModuleFullTagName(modTagName ModuleTagName, version string) string {
+	if modTagName == RepoRootTag {
+		return version
+	}
+	return string(modTagName) + "/" + version
+}
+
 // ModulePathsToTagNames returns a list of tag names from a list of module's import paths.
 func ModulePathsToTagNames(modPaths []ModulePath, modPathMap ModulePathMap, repoRoot string) ([]ModuleTagName, error) {
 	modFilePaths, err := modulePathsToFilePaths(modPaths, modPathMap)
@@ -43,6 +43,11 @@ func TestCombineModuleTagNamesAndVersion(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestModuleFullTagName(t *testing.T) {
+	assert.Equal(t, "another/tag3/v1.2.3", ModuleFullTagName("another/tag3", "v1.2.3"))
+	assert.Equal(t, "v1.2.3", ModuleFullTagName(RepoRootTag, "v1.2.3"))
+}
+
 func TestModulePathsToTagNames(t *testing.T) {
 	modPaths := []ModulePath{
 		"go.opentelemetry.io/test/test1",
@@ -35,6 +35,15 @@ func (e ErrInconsistentGitTagsExist) Error() string {
 	return fmt.Sprintf("git tags inconsistent for module set (some but not all tags in module set):\n%s", strings.Join(e.tagNames, "\n"))
 }
 
+// ErrModuleSetFrozen is returned when releasing a frozen module set.
+type ErrModuleSetFrozen struct {
+	modSetName string
+}
+
+func (e ErrModuleSetFrozen) Error() string {
+	return fmt.Sprintf("module set %v is frozen, use --unfreeze to release it anyway", e.modSetName)
+}
+
 type errGetWorktreeFailed struct {
 	reason error
 }
@@ -15,17 +15,39 @@
 package common
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
-	"log"
+	"io"
+	"strings"
 
+	"github.com/go-git/go-billy/v5/util"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
 )
 
+// OpenRepo opens the git repository whose working tree is rooted at root.
+// Linked worktrees created with "git worktree add", where .git is a file
+// pointing at the main repository, are resolved to share its objects and refs.
+func OpenRepo(root string) (*git.Repository, error) {
+	return git.PlainOpenWithOptions(root, &git.PlainOpenOptions{EnableDotGitCommonDir: true})
+}
+
 // CommitChangesToNewBranch creates a new branch, commits to it, and returns to the original worktree.
-func CommitChangesToNewBranch(branchName string, commitMessage string, repo *git.Repository, customAuthor *object.Signature) (plumbing.Hash, error) {
+// If the commit cannot be made, including because ctx is done, the new branch is removed and the
+// original branch checked out again with the working tree left as is. The commit is signed by
+// signer unless it is nil.
+func CommitChangesToNewBranch(ctx context.Context, branchName string, commitMessage string, repo *git.Repository, customAuthor *object.Signature, signer CommitSigner) (plumbing.Hash, error) {
+	if err := ctx.Err(); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
 	// save reference to current head in storage
 	origRef, err := repo.Head()
 	if err != nil {
@@ -36,27 +58,38 @@ func CommitChangesToNewBranch(branchName string, commitMessage string, repo *git
 		return plumbing.ZeroHash, errors.New("could not store original head ref")
 	}
 
-	if _, err = checkoutNewBranch(branchName, repo); err != nil {
+	branchRefName, err := checkoutNewBranch(branchName, repo)
+	if err != nil {
 		return plumbing.ZeroHash, fmt.Errorf("createPrereleaseBranch failed: %w", err)
 	}
 
-	hash, err := CommitChanges(commitMessage, repo, customAuthor)
+	hash, err := CommitChanges(ctx, commitMessage, repo, customAuthor, signer)
 	if err != nil {
-		return plumbing.ZeroHash, fmt.Errorf("could not commit changes: %w", err)
+		err = fmt.Errorf("could not commit changes: %w", err)
+		if abandonErr := abandonNewBranch(origRef, branchRefName, repo); abandonErr != nil {
+			return plumbing.ZeroHash, multierr.Combine(err, abandonErr)
+		}
+		return plumbing.ZeroHash, err
 	}
 
 	// return to original branch
-	err = checkoutExistingBranch(origRef.Name(), repo)
+	err = CheckoutHead(origRef, repo)
 	if err != nil {
-		log.Fatal("unable to checkout original branch")
+		logging.Fatalf("unable to checkout original branch")
 	}
 
 	return hash, err
 }
 
-func CommitChanges(commitMessage string, repo *git.Repository, customAuthor *object.Signature) (plumbing.Hash, error) {
+// CommitChanges commits all changes in the working tree of repo. No commit is
+// made if ctx is already done. The commit is signed by signer unless it is nil.
+func CommitChanges(ctx context.Context, commitMessage string, repo *git.Repository, customAuthor *object.Signature, signer CommitSigner) (plumbing.Hash, error) {
+	if err := ctx.Err(); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
 	// commit changes to git
-	log.Printf("Committing changes to git with message '%v'\n", commitMessage)
+	logging.Infof("Committing changes to git with message '%v'", commitMessage)
 
 	worktree, err := GetWorktree(repo)
 	if err != nil {
@@ -75,14 +108,218 @@ func CommitChanges(commitMessage string, repo *git.Repository, customAuthor *obj
 		}
 	}
 
+	normalized, err := normalizeLineEndings(repo, worktree)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	// The changes are already staged with normalized line endings, staging
+	// them again would record the line endings of the working tree.
+	commitOptions.All = !normalized
+
 	hash, err := worktree.Commit(commitMessage, commitOptions)
 	if err != nil {
 		return plumbing.ZeroHash, fmt.Errorf("could not commit changes to git: %w", err)
 	}
 
+	if signer != nil {
+		if hash, err = signCommit(repo, hash, signer); err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+
 	return hash, nil
 }
 
+// normalizeLineEndings converts CRLF line endings to LF in the staged content
+// of the modified and added text files of worktree when core.autocrlf is
+// "true" or "input", as git itself would when adding them. go-git does not
+// apply this conversion, so without it commits made on Windows would record
+// CRLF line endings. The converted files are rewritten in the working tree
+// too, otherwise go-git would consider them modified and refuse to check out
+// another branch afterwards.
+// It reports whether the modified and deleted files of the working tree were
+// staged, which is only done when the line endings are normalized.
+func normalizeLineEndings(repo *git.Repository, worktree *git.Worktree) (bool, error) {
+	autocrlf, err := autoCRLF(repo)
+	if err != nil {
+		return false, err
+	}
+	if autocrlf != "true" && autocrlf != "input" {
+		return false, nil
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return false, fmt.Errorf("could not get worktree status: %w", err)
+	}
+	for path, fileStatus := range status {
+		if fileStatus.Worktree != git.Modified && fileStatus.Worktree != git.Deleted {
+			continue
+		}
+		if _, err = worktree.Add(path); err != nil {
+			return false, fmt.Errorf("could not stage %v: %w", path, err)
+		}
+	}
+
+	if status, err = worktree.Status(); err != nil {
+		return false, fmt.Errorf("could not get worktree status: %w", err)
+	}
+	idx, err := repo.Storer.Index()
+	if err != nil {
+		return false, fmt.Errorf("could not read index: %w", err)
+	}
+	for _, entry := range idx.Entries {
+		fileStatus, ok := status[entry.Name]
+		if !ok || (fileStatus.Staging != git.Modified && fileStatus.Staging != git.Added) {
+			continue
+		}
+
+		hash, data, err := normalizeBlob(repo, entry.Hash)
+		if err != nil {
+			return false, fmt.Errorf("could not normalize line endings of %v: %w", entry.Name, err)
+		}
+		if hash == entry.Hash {
+			continue
+		}
+		logging.Debugf("Normalizing line endings of %v", entry.Name)
+		if err = rewriteWorktreeFile(worktree, entry.Name, data); err != nil {
+			return false, fmt.Errorf("could not normalize line endings of %v: %w", entry.Name, err)
+		}
+		entry.Hash = hash
+		entry.Size = uint32(len(data))
+	}
+	if err = repo.Storer.SetIndex(idx); err != nil {
+		return false, fmt.Errorf("could not write index: %w", err)
+	}
+
+	return true, nil
+}
+
+// normalizeBlob stores a copy of the blob with the given hash with CRLF line
+// endings converted to LF and returns its hash and content. The hash of the
+// blob is returned unchanged if it is binary or has no CRLF line endings.
+func normalizeBlob(repo *git.Repository, hash plumbing.Hash) (plumbing.Hash, []byte, error) {
+	blob, err := repo.BlobObject(hash)
+	if err != nil {
+		return plumbing.ZeroHash, nil, err
+	}
+	r, err := blob.Reader()
+	if err != nil {
+		return plumbing.ZeroHash, nil, err
+	}
+	data, err := io.ReadAll(r)
+	if closeErr := r.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return plumbing.ZeroHash, nil, err
+	}
+	// Leave binary files alone, git only converts text.
+	if bytes.IndexByte(data, 0) != -1 || !bytes.Contains(data, []byte("\r\n")) {
+		return hash, data, nil
+	}
+
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	obj := repo.Storer.NewEncodedObject()
+	obj.SetType(plumbing.BlobObject)
+	obj.SetSize(int64(len(data)))
+	w, err := obj.Writer()
+	if err != nil {
+		return plumbing.ZeroHash, nil, err
+	}
+	if _, err = w.Write(data); err != nil {
+		return plumbing.ZeroHash, nil, err
+	}
+	if err = w.Close(); err != nil {
+		return plumbing.ZeroHash, nil, err
+	}
+	hash, err = repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return plumbing.ZeroHash, nil, err
+	}
+	return hash, data, nil
+}
+
+// rewriteWorktreeFile replaces the content of the file at path in worktree
+// with data, keeping its permissions.
+func rewriteWorktreeFile(worktree *git.Worktree, path string, data []byte) error {
+	info, err := worktree.Filesystem.Stat(path)
+	if err != nil {
+		return err
+	}
+	return util.WriteFile(worktree.Filesystem, path, data, info.Mode().Perm())
+}
+
+// autoCRLF returns the core.autocrlf setting of repo, looking at the
+// repository, global, and system configuration in that order.
+func autoCRLF(repo *git.Repository) (string, error) {
+	cfg, err := repo.Config()
+	if err != nil {
+		return "", fmt.Errorf("could not load repo config: %w", err)
+	}
+	if value := cfg.Raw.Section("core").Option("autocrlf"); value != "" {
+		return strings.ToLower(value), nil
+	}
+
+	for _, scope := range []config.Scope{config.GlobalScope, config.SystemScope} {
+		cfg, err = config.LoadConfig(scope)
+		if err != nil {
+			return "", fmt.Errorf("could not load git config: %w", err)
+		}
+		if value := cfg.Raw.Section("core").Option("autocrlf"); value != "" {
+			return strings.ToLower(value), nil
+		}
+	}
+
+	return "", nil
+}
+
+// headReference returns the HEAD reference pointing back to origRef, the
+// resolved HEAD returned by repo.Head(): a symbolic reference to its branch,
+// or its commit if HEAD was detached.
+func headReference(origRef *plumbing.Reference) *plumbing.Reference {
+	if origRef.Name() == plumbing.HEAD {
+		return plumbing.NewHashReference(plumbing.HEAD, origRef.Hash())
+	}
+	return plumbing.NewSymbolicReference(plumbing.HEAD, origRef.Name())
+}
+
+// abandonNewBranch points HEAD back to origRef and removes newRefName, a
+// branch created from it that has not been committed to. Neither the index nor
+// the working tree are touched.
+func abandonNewBranch(origRef *plumbing.Reference, newRefName plumbing.ReferenceName, repo *git.Repository) error {
+	logging.Debugf("Removing branch %v", newRefName.Short())
+
+	if err := repo.Storer.SetReference(headReference(origRef)); err != nil {
+		return fmt.Errorf("could not return to %v: %w", origRef.Name().Short(), err)
+	}
+	if err := repo.Storer.RemoveReference(newRefName); err != nil {
+		return fmt.Errorf("could not remove branch %v: %w", newRefName.Short(), err)
+	}
+	return nil
+}
+
+// DiscardChanges hard resets the working tree of repo to HEAD, discarding
+// all changes to tracked files. It is used to clean up after an interrupted
+// operation that started from a clean working tree.
+func DiscardChanges(repo *git.Repository) error {
+	worktree, err := GetWorktree(repo)
+	if err != nil {
+		return err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf("could not get repo head: %w", err)
+	}
+
+	logging.Debugf("git reset --hard %v", head.Hash())
+	if err = worktree.Reset(&git.ResetOptions{Commit: head.Hash(), Mode: git.HardReset}); err != nil {
+		return fmt.Errorf("could not discard changes: %w", err)
+	}
+	return nil
+}
+
 func checkoutExistingBranch(branchRefName plumbing.ReferenceName, repo *git.Repository) error {
 	worktree, err := repo.Worktree()
 	if err != nil {
@@ -95,7 +332,7 @@ func checkoutExistingBranch(branchRefName plumbing.ReferenceName, repo *git.Repo
 		Keep:   false,
 	}
 
-	log.Printf("git checkout %v\n", branchRefName)
+	logging.Debugf("git checkout %v", branchRefName)
 	if err = worktree.Checkout(checkoutOptions); err != nil {
 		return fmt.Errorf("could not check out new branch: %w", err)
 	}
@@ -103,6 +340,26 @@ func checkoutExistingBranch(branchRefName plumbing.ReferenceName, repo *git.Repo
 	return nil
 }
 
+// CheckoutHead checks out origRef, a resolved HEAD as returned by
+// repo.Head(): its branch, or its commit in detached HEAD mode if the
+// reference is HEAD itself.
+func CheckoutHead(origRef *plumbing.Reference, repo *git.Repository) error {
+	if origRef.Name() != plumbing.HEAD {
+		return checkoutExistingBranch(origRef.Name(), repo)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return &errGetWorktreeFailed{reason: err}
+	}
+
+	logging.Debugf("git checkout %v", origRef.Hash())
+	if err = worktree.Checkout(&git.CheckoutOptions{Hash: origRef.Hash()}); err != nil {
+		return fmt.Errorf("could not check out commit %v: %w", origRef.Hash(), err)
+	}
+	return nil
+}
+
 func checkoutNewBranch(branchName string, repo *git.Repository) (plumbing.ReferenceName, error) {
 	worktree, err := repo.Worktree()
 	if err != nil {
@@ -117,7 +374,7 @@ func checkoutNewBranch(branchName string, repo *git.Repository) (plumbing.Refere
 		Keep:   true,
 	}
 
-	log.Printf("git branch %v\n", branchName)
+	logging.Debugf("git branch %v", branchName)
 	if err = worktree.Checkout(checkoutOptions); err != nil {
 		return "", fmt.Errorf("could not check out new branch: %w", err)
 	}
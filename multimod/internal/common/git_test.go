@@ -0,0 +1,262 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common/commontest"
+)
+
+// initRepoWithModifiedFile returns a repository with a committed go.mod
+// file that has since been modified in the working tree.
+func initRepoWithModifiedFile(t *testing.T) (*git.Repository, string) {
+	tmpRootDir := t.TempDir()
+	repo, _, err := commontest.InitNewRepoWithCommit(tmpRootDir)
+	require.NoError(t, err)
+
+	modFile := filepath.Join(tmpRootDir, "go.mod")
+	require.NoError(t, os.WriteFile(modFile, []byte("module go.opentelemetry.io/test\n"), 0600))
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = worktree.Add("go.mod")
+	require.NoError(t, err)
+	_, err = CommitChanges(context.Background(), "add go.mod", repo, commontest.TestAuthor, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(modFile, []byte("module go.opentelemetry.io/test\n\ngo 1.16\n"), 0600))
+	return repo, modFile
+}
+
+func TestCommitChangesToNewBranchInterrupted(t *testing.T) {
+	repo, modFile := initRepoWithModifiedFile(t)
+	origHead, err := repo.Head()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = CommitChanges(ctx, "interrupted", repo, commontest.TestAuthor, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// Simulate an interruption after the branch was created.
+	branchRefName, err := checkoutNewBranch("interrupted", repo)
+	require.NoError(t, err)
+	require.NoError(t, abandonNewBranch(origHead, branchRefName, repo))
+
+	head, err := repo.Head()
+	require.NoError(t, err)
+	assert.Equal(t, origHead.Name(), head.Name())
+	assert.Equal(t, origHead.Hash(), head.Hash())
+
+	_, err = repo.Reference(plumbing.NewBranchReferenceName("interrupted"), false)
+	assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+
+	content, err := os.ReadFile(modFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "go 1.16", "working tree changes should be kept")
+
+	_, err = CommitChangesToNewBranch(ctx, "interrupted", "interrupted", repo, commontest.TestAuthor, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = repo.Reference(plumbing.NewBranchReferenceName("interrupted"), false)
+	assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+}
+
+func TestCheckoutHeadDetached(t *testing.T) {
+	repo, _ := initRepoWithModifiedFile(t)
+	require.NoError(t, DiscardChanges(repo))
+	origHead, err := repo.Head()
+	require.NoError(t, err)
+	headCommit, err := repo.CommitObject(origHead.Hash())
+	require.NoError(t, err)
+	firstHash := headCommit.ParentHashes[0]
+
+	require.NoError(t, CheckoutHead(plumbing.NewHashReference(plumbing.HEAD, firstHash), repo))
+	head, err := repo.Head()
+	require.NoError(t, err)
+	assert.Equal(t, plumbing.HEAD, head.Name())
+	assert.Equal(t, firstHash, head.Hash())
+
+	// New branches are created from the detached HEAD, which is checked out
+	// again after committing.
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(worktree.Filesystem.Root(), "version.txt"), []byte("v1.0.0"), 0600))
+	_, err = worktree.Add("version.txt")
+	require.NoError(t, err)
+	hash, err := CommitChangesToNewBranch(context.Background(), "prerelease_set_v1.0.0", "Prepare set for version v1.0.0", repo, commontest.TestAuthor, nil)
+	require.NoError(t, err)
+	commit, err := repo.CommitObject(hash)
+	require.NoError(t, err)
+	assert.Equal(t, []plumbing.Hash{firstHash}, commit.ParentHashes)
+
+	head, err = repo.Head()
+	require.NoError(t, err)
+	assert.Equal(t, plumbing.HEAD, head.Name())
+	assert.Equal(t, firstHash, head.Hash())
+
+	require.NoError(t, CheckoutHead(origHead, repo))
+	head, err = repo.Head()
+	require.NoError(t, err)
+	assert.Equal(t, origHead.Name(), head.Name())
+}
+
+func TestDiscardChanges(t *testing.T) {
+	repo, modFile := initRepoWithModifiedFile(t)
+
+	require.NoError(t, DiscardChanges(repo))
+	require.NoError(t, VerifyWorkingTreeClean(repo))
+
+	content, err := os.ReadFile(modFile)
+	require.NoError(t, err)
+	assert.Equal(t, "module go.opentelemetry.io/test\n", string(content))
+}
+
+func TestOpenRepoLinkedWorktree(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git executable not available")
+	}
+
+	repo, _ := initRepoWithModifiedFile(t)
+	require.NoError(t, DiscardChanges(repo))
+	mainWorktree, err := repo.Worktree()
+	require.NoError(t, err)
+	head, err := repo.Head()
+	require.NoError(t, err)
+
+	linkedDir := filepath.Join(t.TempDir(), "linked")
+	// #nosec G204
+	cmd := exec.Command("git", "worktree", "add", "-b", "release", linkedDir)
+	cmd.Dir = mainWorktree.Filesystem.Root()
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	linked, err := OpenRepo(linkedDir)
+	require.NoError(t, err)
+
+	linkedHead, err := linked.Head()
+	require.NoError(t, err)
+	assert.Equal(t, plumbing.NewBranchReferenceName("release"), linkedHead.Name())
+	assert.Equal(t, head.Hash(), linkedHead.Hash())
+
+	worktree, err := GetWorktree(linked)
+	require.NoError(t, err)
+	assert.Equal(t, linkedDir, worktree.Filesystem.Root())
+	require.NoError(t, VerifyWorkingTreeClean(linked))
+
+	// Tags created in the linked worktree are shared with the main repository.
+	_, err = linked.CreateTag("v1.0.0", linkedHead.Hash(), nil)
+	require.NoError(t, err)
+	_, err = repo.Tag("v1.0.0")
+	assert.NoError(t, err)
+}
+
+func TestCommitChangesNormalizesLineEndings(t *testing.T) {
+	crlf := "module go.opentelemetry.io/test\r\n\r\ngo 1.16\r\n"
+	lf := "module go.opentelemetry.io/test\n\ngo 1.16\n"
+	testCases := []struct {
+		autocrlf string
+		expected string
+	}{
+		{autocrlf: "true", expected: lf},
+		{autocrlf: "input", expected: lf},
+		{autocrlf: "false", expected: crlf},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.autocrlf, func(t *testing.T) {
+			repo, modFile := initRepoWithModifiedFile(t)
+			cfg, err := repo.Config()
+			require.NoError(t, err)
+			cfg.Raw.Section("core").SetOption("autocrlf", tc.autocrlf)
+			require.NoError(t, repo.SetConfig(cfg))
+
+			require.NoError(t, os.WriteFile(modFile, []byte(crlf), 0600))
+			addedFile := filepath.Join(filepath.Dir(modFile), "sub", "go.mod")
+			require.NoError(t, os.MkdirAll(filepath.Dir(addedFile), 0700))
+			require.NoError(t, os.WriteFile(addedFile, []byte(crlf), 0600))
+			worktree, err := repo.Worktree()
+			require.NoError(t, err)
+			_, err = worktree.Add("sub/go.mod")
+			require.NoError(t, err)
+
+			hash, err := CommitChanges(context.Background(), "crlf", repo, commontest.TestAuthor, nil)
+			require.NoError(t, err)
+
+			commit, err := repo.CommitObject(hash)
+			require.NoError(t, err)
+			for _, name := range []string{"go.mod", "sub/go.mod"} {
+				file, err := commit.File(name)
+				require.NoError(t, err, name)
+				content, err := file.Contents()
+				require.NoError(t, err, name)
+				assert.Equal(t, tc.expected, content, name)
+			}
+
+			// The working tree matches the committed files.
+			for _, filename := range []string{modFile, addedFile} {
+				data, err := os.ReadFile(filepath.Clean(filename))
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, string(data), filename)
+			}
+			status, err := worktree.Status()
+			require.NoError(t, err)
+			assert.True(t, status.IsClean(), status.String())
+		})
+	}
+}
+
+func TestCommitChangesToNewBranchNormalizesLineEndings(t *testing.T) {
+	for _, autocrlf := range []string{"true", "input"} {
+		t.Run(autocrlf, func(t *testing.T) {
+			repo, modFile := initRepoWithModifiedFile(t)
+			cfg, err := repo.Config()
+			require.NoError(t, err)
+			cfg.Raw.Section("core").SetOption("autocrlf", autocrlf)
+			require.NoError(t, repo.SetConfig(cfg))
+			origHead, err := repo.Head()
+			require.NoError(t, err)
+
+			require.NoError(t, os.WriteFile(modFile, []byte("module go.opentelemetry.io/test\r\n\r\ngo 1.16\r\n"), 0600))
+
+			hash, err := CommitChangesToNewBranch(context.Background(), "crlf", "crlf", repo, commontest.TestAuthor, nil)
+			require.NoError(t, err)
+
+			head, err := repo.Head()
+			require.NoError(t, err)
+			assert.Equal(t, origHead.Name(), head.Name())
+			assert.Equal(t, origHead.Hash(), head.Hash())
+
+			branch, err := repo.Reference(plumbing.NewBranchReferenceName("crlf"), false)
+			require.NoError(t, err)
+			assert.Equal(t, hash, branch.Hash())
+			commit, err := repo.CommitObject(hash)
+			require.NoError(t, err)
+			file, err := commit.File("go.mod")
+			require.NoError(t, err)
+			content, err := file.Contents()
+			require.NoError(t, err)
+			assert.Equal(t, "module go.opentelemetry.io/test\n\ngo 1.16\n", content)
+		})
+	}
+}
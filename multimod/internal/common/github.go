@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+const (
+	// EnvGitHubAPIURL is the environment variable overriding the GitHub API
+	// endpoint, as set by GitHub Actions on GitHub Enterprise Server.
+	EnvGitHubAPIURL     = "GITHUB_API_URL"
+	defaultGitHubAPIURL = "https://api.github.com"
+)
+
+// ErrNoToken is returned by GitHub API calls when no token is available.
+var ErrNoToken = errors.New("no GitHub token found in MULTIMOD_GIT_TOKEN, GITHUB_TOKEN, or GH_TOKEN")
+
+// PullRequest is the body of a GitHub create pull request call.
+type PullRequest struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+}
+
+// PullRequestStatus is the state of a GitHub pull request.
+type PullRequestStatus struct {
+	Number         int    `json:"number"`
+	HTMLURL        string `json:"html_url"`
+	State          string `json:"state"`
+	Merged         bool   `json:"merged"`
+	MergeCommitSHA string `json:"merge_commit_sha"`
+}
+
+// PushBranch pushes the branch named branch to remote.
+func PushBranch(ctx context.Context, repo *git.Repository, remote, branch string) error {
+	auth, err := RepoRemoteAuth(repo, remote)
+	if err != nil {
+		return fmt.Errorf("unable to get credentials for remote %s: %w", remote, err)
+	}
+
+	ref := plumbing.NewBranchReferenceName(branch)
+	err = repo.PushContext(ctx, &git.PushOptions{
+		RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", ref, ref))},
+		RemoteName: remote,
+		Auth:       auth,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("error pushing branch %s to %s: %w", branch, remote, ClassifyRemoteError(err))
+	}
+	return nil
+}
+
+// GitHubRepo returns the owner and name of the GitHub repository the remote
+// named remote of repo points to.
+func GitHubRepo(repo *git.Repository, remote string) (string, string, error) {
+	r, err := repo.Remote(remote)
+	if err != nil {
+		return "", "", fmt.Errorf("could not get remote %v: %w", remote, err)
+	}
+	urls := r.Config().URLs
+	if len(urls) == 0 {
+		return "", "", fmt.Errorf("remote %v has no URL configured", remote)
+	}
+	return parseGitHubURL(urls[0])
+}
+
+// parseGitHubURL returns the owner and name of the GitHub repository at
+// remoteURL, in any of the URL forms accepted by git.
+func parseGitHubURL(remoteURL string) (string, string, error) {
+	ep, err := transport.NewEndpoint(remoteURL)
+	if err != nil {
+		return "", "", fmt.Errorf("could not parse remote URL %v: %w", remoteURL, err)
+	}
+	path := strings.TrimSuffix(strings.Trim(ep.Path, "/"), ".git")
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("remote URL %v does not point to a GitHub repository", remoteURL)
+	}
+	return parts[0], parts[1], nil
+}
+
+// CreatePullRequest opens pr on the GitHub repository owner/name.
+func CreatePullRequest(ctx context.Context, client *http.Client, owner, name string, pr PullRequest) (PullRequestStatus, error) {
+	payload, err := json.Marshal(pr)
+	if err != nil {
+		return PullRequestStatus{}, fmt.Errorf("could not encode pull request: %w", err)
+	}
+
+	var created PullRequestStatus
+	path := fmt.Sprintf("repos/%s/%s/pulls", owner, name)
+	if err := gitHubAPI(ctx, client, http.MethodPost, path, payload, http.StatusCreated, &created); err != nil {
+		return PullRequestStatus{}, fmt.Errorf("could not create pull request: %w", err)
+	}
+	return created, nil
+}
+
+// GetPullRequest returns the status of the pull request number on the GitHub
+// repository owner/name.
+func GetPullRequest(ctx context.Context, client *http.Client, owner, name string, number int) (PullRequestStatus, error) {
+	var status PullRequestStatus
+	path := fmt.Sprintf("repos/%s/%s/pulls/%d", owner, name, number)
+	if err := gitHubAPI(ctx, client, http.MethodGet, path, nil, http.StatusOK, &status); err != nil {
+		return PullRequestStatus{}, fmt.Errorf("could not get pull request %d: %w", number, err)
+	}
+	return status, nil
+}
+
+// gitHubAPI calls the GitHub API endpoint at path with payload as the request
+// body, if not nil, and decodes the response into out. It fails if the
+// response status is not wantStatus.
+func gitHubAPI(ctx context.Context, client *http.Client, method, path string, payload []byte, wantStatus int, out interface{}) error {
+	token := Token()
+	if token == "" {
+		return ErrNoToken
+	}
+
+	apiURL := os.Getenv(EnvGitHubAPIURL)
+	if apiURL == "" {
+		apiURL = defaultGitHubAPIURL
+	}
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	url := strings.TrimSuffix(apiURL, "/") + "/" + path
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("could not create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("could not read response: %w", err)
+	}
+	if resp.StatusCode != wantStatus {
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("could not decode response: %w", err)
+	}
+	return nil
+}
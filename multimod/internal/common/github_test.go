@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGitHubURL(t *testing.T) {
+	testCases := []struct {
+		url       string
+		wantOwner string
+		wantName  string
+		wantErr   bool
+	}{
+		{url: "https://github.com/open-telemetry/opentelemetry-go-contrib.git", wantOwner: "open-telemetry", wantName: "opentelemetry-go-contrib"},
+		{url: "https://github.com/open-telemetry/opentelemetry-go-contrib", wantOwner: "open-telemetry", wantName: "opentelemetry-go-contrib"},
+		{url: "git@github.com:open-telemetry/opentelemetry-go-contrib.git", wantOwner: "open-telemetry", wantName: "opentelemetry-go-contrib"},
+		{url: "ssh://git@github.com/open-telemetry/opentelemetry-go-contrib.git", wantOwner: "open-telemetry", wantName: "opentelemetry-go-contrib"},
+		{url: "https://github.com/open-telemetry", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.url, func(t *testing.T) {
+			owner, name, err := parseGitHubURL(tc.url)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantOwner, owner)
+			assert.Equal(t, tc.wantName, name)
+		})
+	}
+}
+
+func TestCreatePullRequest(t *testing.T) {
+	pr := PullRequest{Title: "title", Body: "body", Head: "sync_branch", Base: "main"}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/repos/owner/repo/pulls", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		var got PullRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		assert.Equal(t, pr, got)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"html_url": "https://github.com/owner/repo/pull/1"}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv(EnvGitHubAPIURL, srv.URL)
+	t.Setenv(EnvGitToken, "secret")
+
+	created, err := CreatePullRequest(context.Background(), srv.Client(), "owner", "repo", pr)
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/owner/repo/pull/1", created.HTMLURL)
+}
+
+func TestCreatePullRequestError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv(EnvGitHubAPIURL, srv.URL)
+	t.Setenv(EnvGitToken, "secret")
+
+	_, err := CreatePullRequest(context.Background(), srv.Client(), "owner", "repo", PullRequest{})
+	assert.ErrorContains(t, err, "Validation Failed")
+}
+
+func TestCreatePullRequestNoToken(t *testing.T) {
+	for _, env := range []string{EnvGitToken, "GITHUB_TOKEN", "GH_TOKEN"} {
+		t.Setenv(env, "")
+	}
+
+	_, err := CreatePullRequest(context.Background(), http.DefaultClient, "owner", "repo", PullRequest{})
+	assert.ErrorIs(t, err, ErrNoToken)
+}
+
+func TestGetPullRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/repos/owner/repo/pulls/42", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		_, _ = w.Write([]byte(`{"number": 42, "html_url": "https://github.com/owner/repo/pull/42", "state": "closed", "merged": true, "merge_commit_sha": "abc123"}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv(EnvGitHubAPIURL, srv.URL)
+	t.Setenv(EnvGitToken, "secret")
+
+	status, err := GetPullRequest(context.Background(), srv.Client(), "owner", "repo", 42)
+	require.NoError(t, err)
+	assert.Equal(t, PullRequestStatus{
+		Number:         42,
+		HTMLURL:        "https://github.com/owner/repo/pull/42",
+		State:          "closed",
+		Merged:         true,
+		MergeCommitSHA: "abc123",
+	}, status)
+}
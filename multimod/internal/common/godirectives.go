@@ -0,0 +1,292 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+)
+
+// GoDirectives holds the versions of the go and toolchain directives of a
+// go.mod file. Missing directives are empty.
+type GoDirectives struct {
+	Go        string
+	Toolchain string
+}
+
+// goDirectiveLines returns the go and toolchain lines of the go.mod file f.
+// They are read from the syntax tree, as golang.org/x/mod ignores toolchain
+// directives.
+func goDirectiveLines(f *modfile.File) (goLine, toolchainLine *modfile.Line) {
+	for _, stmt := range f.Syntax.Stmt {
+		line, ok := stmt.(*modfile.Line)
+		if !ok || len(line.Token) != 2 {
+			continue
+		}
+		switch line.Token[0] {
+		case "go":
+			goLine = line
+		case "toolchain":
+			toolchainLine = line
+		}
+	}
+	return goLine, toolchainLine
+}
+
+// directiveVersion returns the version of the go or toolchain directive line
+// and its byte offsets in data. It is read from data as golang.org/x/mod
+// truncates go versions such as 1.21.0 to 1.21 in the syntax tree.
+func directiveVersion(data []byte, line *modfile.Line) (string, int, int, error) {
+	start, end := line.Start.Byte, line.End.Byte
+	if start < 0 || end > len(data) || start > end {
+		return "", 0, 0, errors.New("invalid syntax position")
+	}
+	fields := strings.Fields(string(data[start:end]))
+	if len(fields) != 2 {
+		return "", 0, 0, fmt.Errorf("invalid %v directive", line.Token[0])
+	}
+	version := fields[1]
+	return version, end - len(version), end, nil
+}
+
+func readGoDirectives(modFilePath ModuleFilePath) (GoDirectives, error) {
+	data, err := os.ReadFile(filepath.Clean(string(modFilePath)))
+	if err != nil {
+		return GoDirectives{}, fmt.Errorf("could not read go.mod file: %w", err)
+	}
+	f, err := modfile.ParseLax(string(modFilePath), data, nil)
+	if err != nil {
+		return GoDirectives{}, fmt.Errorf("could not parse go.mod file: %w", err)
+	}
+
+	var d GoDirectives
+	goLine, toolchainLine := goDirectiveLines(f)
+	if goLine != nil {
+		if d.Go, _, _, err = directiveVersion(data, goLine); err != nil {
+			return GoDirectives{}, err
+		}
+	}
+	if toolchainLine != nil {
+		if d.Toolchain, _, _, err = directiveVersion(data, toolchainLine); err != nil {
+			return GoDirectives{}, err
+		}
+	}
+	return d, nil
+}
+
+// MaxGoDirectives returns the highest go and toolchain versions used by the
+// go.mod files in modFilePaths.
+func MaxGoDirectives(modFilePaths []ModuleFilePath) (GoDirectives, error) {
+	var max GoDirectives
+	for _, modFilePath := range modFilePaths {
+		d, err := readGoDirectives(modFilePath)
+		if err != nil {
+			return GoDirectives{}, fmt.Errorf("could not read go directives of %v: %w", modFilePath, err)
+		}
+		if CompareGoVersions(d.Go, max.Go) > 0 {
+			max.Go = d.Go
+		}
+		if CompareGoVersions(strings.TrimPrefix(d.Toolchain, "go"), strings.TrimPrefix(max.Toolchain, "go")) > 0 {
+			max.Toolchain = d.Toolchain
+		}
+	}
+	return max, nil
+}
+
+// CompareGoVersions compares two Go versions, such as 1.21, 1.21.3 or
+// 1.21rc1, in the manner of semver.Compare. The empty version is lower than
+// all others.
+func CompareGoVersions(a, b string) int {
+	return semver.Compare(goSemver(a), goSemver(b))
+}
+
+// IsGoVersion returns whether v is a Go version, such as 1.21, 1.21.3 or
+// 1.21rc1.
+func IsGoVersion(v string) bool {
+	return strings.Contains(v, ".") && semver.IsValid(goSemver(v))
+}
+
+// goSemver converts a Go version to a semantic version.
+func goSemver(v string) string {
+	if v == "" {
+		return ""
+	}
+	num, pre := v, ""
+	if i := strings.IndexFunc(v, func(r rune) bool { return r != '.' && (r < '0' || r > '9') }); i >= 0 {
+		num, pre = v[:i], "-"+v[i:]
+	}
+	if strings.Count(num, ".") == 1 {
+		num += ".0"
+	}
+	return "v" + num + pre
+}
+
+// RaiseGoDirectives raises the go and toolchain directives of the go.mod files
+// in modFilePaths to those in want, if they are lower. Directives are never
+// lowered, and a missing toolchain directive is added after the go directive
+// unless it is lower than the go version of the module.
+func RaiseGoDirectives(modFilePaths []ModuleFilePath, want GoDirectives) error {
+	for _, modFilePath := range modFilePaths {
+		data, err := os.ReadFile(filepath.Clean(string(modFilePath)))
+		if err != nil {
+			return fmt.Errorf("could not read go.mod file %v: %w", modFilePath, err)
+		}
+
+		newData, err := raiseGoDirectives(string(modFilePath), data, want)
+		if err != nil {
+			return fmt.Errorf("could not update go directives of %v: %w", modFilePath, err)
+		}
+		if bytes.Equal(data, newData) {
+			continue
+		}
+
+		logging.Debugf("... Updating go directives of %v", modFilePath)
+		if err := os.WriteFile(string(modFilePath), newData, 0600); err != nil {
+			return fmt.Errorf("error overwriting go.mod file: %w", err)
+		}
+	}
+	return nil
+}
+
+// SetGoDirectives sets the go and toolchain directives of the go.mod files in
+// modFilePaths to the non-empty versions in want, raising or lowering them.
+// It returns the files whose directives differed from want, which are only
+// rewritten unless dryRun is set.
+func SetGoDirectives(modFilePaths []ModuleFilePath, want GoDirectives, dryRun bool) ([]ModuleFilePath, error) {
+	var changed []ModuleFilePath
+	for _, modFilePath := range modFilePaths {
+		data, err := os.ReadFile(filepath.Clean(string(modFilePath)))
+		if err != nil {
+			return nil, fmt.Errorf("could not read go.mod file %v: %w", modFilePath, err)
+		}
+
+		newData, err := updateGoDirectives(string(modFilePath), data, want, true)
+		if err != nil {
+			return nil, fmt.Errorf("could not update go directives of %v: %w", modFilePath, err)
+		}
+		if bytes.Equal(data, newData) {
+			continue
+		}
+		changed = append(changed, modFilePath)
+		if dryRun {
+			continue
+		}
+
+		logging.Debugf("... Updating go directives of %v", modFilePath)
+		if err := os.WriteFile(string(modFilePath), newData, 0600); err != nil {
+			return nil, fmt.Errorf("error overwriting go.mod file: %w", err)
+		}
+	}
+	return changed, nil
+}
+
+// raiseGoDirectives returns the content of the go.mod file with the given name
+// and content data with its go and toolchain directives raised to want.
+func raiseGoDirectives(name string, data []byte, want GoDirectives) ([]byte, error) {
+	return updateGoDirectives(name, data, want, false)
+}
+
+// updateGoDirectives returns the content of the go.mod file with the given
+// name and content data with its go and toolchain directives updated to want,
+// only raising them unless lower is set. Like setRequireVersions, only the
+// version tokens are rewritten.
+func updateGoDirectives(name string, data []byte, want GoDirectives, lower bool) ([]byte, error) {
+	f, err := modfile.ParseLax(name, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse go.mod file: %w", err)
+	}
+	goLine, toolchainLine := goDirectiveLines(f)
+
+	type edit struct {
+		start, end int
+		text       string
+	}
+	var edits []edit
+
+	update := func(line *modfile.Line, version string, cmp func(a, b string) int) error {
+		current, start, end, err := directiveVersion(data, line)
+		if err != nil {
+			return err
+		}
+		if version == "" || current == version || (!lower && cmp(current, version) >= 0) {
+			return nil
+		}
+		edits = append(edits, edit{start, end, version})
+		return nil
+	}
+	compareToolchains := func(a, b string) int {
+		return CompareGoVersions(strings.TrimPrefix(a, "go"), strings.TrimPrefix(b, "go"))
+	}
+
+	switch {
+	case goLine != nil:
+		if err := update(goLine, want.Go, CompareGoVersions); err != nil {
+			return nil, err
+		}
+	case want.Go != "" && f.Module != nil:
+		edits = append(edits, edit{f.Module.Syntax.End.Byte, f.Module.Syntax.End.Byte, "\n\ngo " + want.Go})
+	}
+
+	// A toolchain lower than the go version of the module is meaningless.
+	goVersion := want.Go
+	if goLine != nil {
+		current, _, _, err := directiveVersion(data, goLine)
+		if err != nil {
+			return nil, err
+		}
+		if want.Go == "" || (!lower && CompareGoVersions(current, goVersion) > 0) {
+			goVersion = current
+		}
+	}
+	if compareToolchains(want.Toolchain, "go"+goVersion) < 0 {
+		want.Toolchain = ""
+	}
+
+	switch {
+	case toolchainLine != nil:
+		if err := update(toolchainLine, want.Toolchain, compareToolchains); err != nil {
+			return nil, err
+		}
+	case want.Toolchain != "" && goLine != nil:
+		edits = append(edits, edit{goLine.End.Byte, goLine.End.Byte, "\n\ntoolchain " + want.Toolchain})
+	case want.Toolchain != "" && want.Go != "" && f.Module != nil:
+		// The go directive is added after the module directive.
+		edits[len(edits)-1].text += "\n\ntoolchain " + want.Toolchain
+	}
+
+	if len(edits) == 0 {
+		return data, nil
+	}
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+
+	var out []byte
+	last := 0
+	for _, e := range edits {
+		out = append(out, data[last:e.start]...)
+		out = append(out, e.text...)
+		last = e.end
+	}
+	return append(out, data[last:]...), nil
+}
@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareGoVersions(t *testing.T) {
+	for _, tc := range []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.21", b: "1.21.0", want: 0},
+		{a: "1.21", b: "1.20", want: 1},
+		{a: "1.9", b: "1.20", want: -1},
+		{a: "1.21.3", b: "1.21.10", want: -1},
+		{a: "1.21rc1", b: "1.21.0", want: -1},
+		{a: "1.21rc2", b: "1.21rc1", want: 1},
+		{a: "", b: "1.16", want: -1},
+		{a: "", b: "", want: 0},
+	} {
+		assert.Equal(t, tc.want, CompareGoVersions(tc.a, tc.b), "%q %q", tc.a, tc.b)
+	}
+}
+
+func TestMaxGoDirectives(t *testing.T) {
+	tmpRootDir := t.TempDir()
+	modFiles := map[string][]byte{
+		filepath.Join(tmpRootDir, "a", "go.mod"): []byte("module a\n\ngo 1.20\n"),
+		filepath.Join(tmpRootDir, "b", "go.mod"): []byte("module b\n\ngo 1.21.0\n\ntoolchain go1.21.3\n"),
+		filepath.Join(tmpRootDir, "c", "go.mod"): []byte("module c\n\ngo 1.19\n\ntoolchain go1.21.1\n"),
+	}
+	var paths []ModuleFilePath
+	for p, data := range modFiles {
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o700))
+		require.NoError(t, os.WriteFile(p, data, 0o600))
+		paths = append(paths, ModuleFilePath(p))
+	}
+
+	got, err := MaxGoDirectives(paths)
+	require.NoError(t, err)
+	assert.Equal(t, GoDirectives{Go: "1.21.0", Toolchain: "go1.21.3"}, got)
+
+	_, err = MaxGoDirectives([]ModuleFilePath{ModuleFilePath(filepath.Join(tmpRootDir, "missing", "go.mod"))})
+	assert.Error(t, err)
+}
+
+func TestRaiseGoDirectives(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		input    string
+		want     GoDirectives
+		expected string
+	}{
+		{
+			name:     "raise go",
+			input:    "module test\n\ngo 1.19 // comment\n\nrequire foo.bar/baz v1.2.3\n",
+			want:     GoDirectives{Go: "1.20"},
+			expected: "module test\n\ngo 1.20 // comment\n\nrequire foo.bar/baz v1.2.3\n",
+		},
+		{
+			name:     "never lower",
+			input:    "module test\n\ngo 1.21\n\ntoolchain go1.21.5\n",
+			want:     GoDirectives{Go: "1.20", Toolchain: "go1.21.3"},
+			expected: "module test\n\ngo 1.21\n\ntoolchain go1.21.5\n",
+		},
+		{
+			name:     "raise toolchain",
+			input:    "module test\n\ngo 1.21\n\ntoolchain go1.21.1\n",
+			want:     GoDirectives{Go: "1.21", Toolchain: "go1.21.3"},
+			expected: "module test\n\ngo 1.21\n\ntoolchain go1.21.3\n",
+		},
+		{
+			name:     "add toolchain",
+			input:    "module test\n\ngo 1.20\n\nrequire foo.bar/baz v1.2.3\n",
+			want:     GoDirectives{Go: "1.21.0", Toolchain: "go1.21.3"},
+			expected: "module test\n\ngo 1.21.0\n\ntoolchain go1.21.3\n\nrequire foo.bar/baz v1.2.3\n",
+		},
+		{
+			name:     "add go and toolchain",
+			input:    "module test\n\nrequire foo.bar/baz v1.2.3\n",
+			want:     GoDirectives{Go: "1.21.0", Toolchain: "go1.21.3"},
+			expected: "module test\n\ngo 1.21.0\n\ntoolchain go1.21.3\n\nrequire foo.bar/baz v1.2.3\n",
+		},
+		{
+			name:     "toolchain lower than go",
+			input:    "module test\n\ngo 1.22\n",
+			want:     GoDirectives{Go: "1.21.0", Toolchain: "go1.21.3"},
+			expected: "module test\n\ngo 1.22\n",
+		},
+		{
+			name:     "patch version",
+			input:    "module test\n\ngo 1.21.0\n",
+			want:     GoDirectives{Go: "1.21.4"},
+			expected: "module test\n\ngo 1.21.4\n",
+		},
+		{
+			name:     "nothing wanted",
+			input:    "module test\n\ngo 1.20\n",
+			want:     GoDirectives{},
+			expected: "module test\n\ngo 1.20\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := raiseGoDirectives("go.mod", []byte(tc.input), tc.want)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(got))
+		})
+	}
+}
+
+func TestSetGoDirectives(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		input    string
+		want     GoDirectives
+		expected string
+	}{
+		{
+			name:     "raise go",
+			input:    "module test\n\ngo 1.19 // comment\n",
+			want:     GoDirectives{Go: "1.21"},
+			expected: "module test\n\ngo 1.21 // comment\n",
+		},
+		{
+			name:     "lower go and toolchain",
+			input:    "module test\n\ngo 1.22\n\ntoolchain go1.22.1\n",
+			want:     GoDirectives{Go: "1.21", Toolchain: "go1.21.3"},
+			expected: "module test\n\ngo 1.21\n\ntoolchain go1.21.3\n",
+		},
+		{
+			name:     "toolchain left as is",
+			input:    "module test\n\ngo 1.21\n\ntoolchain go1.21.5\n",
+			want:     GoDirectives{Go: "1.21.0"},
+			expected: "module test\n\ngo 1.21.0\n\ntoolchain go1.21.5\n",
+		},
+		{
+			name:     "up to date",
+			input:    "module test\n\ngo 1.21\n",
+			want:     GoDirectives{Go: "1.21"},
+			expected: "module test\n\ngo 1.21\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := updateGoDirectives("go.mod", []byte(tc.input), tc.want, true)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(got))
+		})
+	}
+
+	tmpRootDir := t.TempDir()
+	upToDate := ModuleFilePath(filepath.Join(tmpRootDir, "a", "go.mod"))
+	outdated := ModuleFilePath(filepath.Join(tmpRootDir, "b", "go.mod"))
+	for p, data := range map[ModuleFilePath]string{
+		upToDate: "module a\n\ngo 1.21\n",
+		outdated: "module b\n\ngo 1.20\n",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(string(p)), 0o700))
+		require.NoError(t, os.WriteFile(string(p), []byte(data), 0o600))
+	}
+	paths := []ModuleFilePath{upToDate, outdated}
+
+	changed, err := SetGoDirectives(paths, GoDirectives{Go: "1.21"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, []ModuleFilePath{outdated}, changed)
+	content, err := os.ReadFile(string(outdated))
+	require.NoError(t, err)
+	assert.Equal(t, "module b\n\ngo 1.20\n", string(content), "dry run must not modify files")
+
+	changed, err = SetGoDirectives(paths, GoDirectives{Go: "1.21"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, []ModuleFilePath{outdated}, changed)
+	content, err = os.ReadFile(string(outdated))
+	require.NoError(t, err)
+	assert.Equal(t, "module b\n\ngo 1.21\n", string(content))
+}
+
+func TestIsGoVersion(t *testing.T) {
+	for _, v := range []string{"1.21", "1.21.3", "1.22rc1"} {
+		assert.True(t, IsGoVersion(v), v)
+	}
+	for _, v := range []string{"", "go1.21", "latest", "1"} {
+		assert.False(t, IsGoVersion(v), v)
+	}
+}
@@ -19,7 +19,6 @@ import (
 	"path/filepath"
 
 	"github.com/go-git/go-git/v5"
-	"github.com/go-git/go-git/v5/plumbing"
 )
 
 // ModuleSetRelease contains info about a specific set of modules in the versioning file to be updated.
@@ -77,48 +76,68 @@ func (modRelease ModuleSetRelease) ModSetPaths() []ModulePath {
 	return modRelease.ModSet.Modules
 }
 
+// CheckNotFrozen returns an ErrModuleSetFrozen error if the module set to
+// update is frozen, unless unfreeze is true.
+func (modRelease ModuleSetRelease) CheckNotFrozen(unfreeze bool) error {
+	if modRelease.ModSet.Frozen && !unfreeze {
+		return ErrModuleSetFrozen{modSetName: modRelease.ModSetName}
+	}
+	return nil
+}
+
+// ModuleVersion gets the version of the module with import path modPath in
+// the module set to update, honoring the version overrides of the set.
+func (modRelease ModuleSetRelease) ModuleVersion(modPath ModulePath) string {
+	return modRelease.ModSet.ModuleVersion(modPath)
+}
+
 // ModuleFullTagNames gets the full tag names (including the version) of all modules in the module set to update.
 func (modRelease ModuleSetRelease) ModuleFullTagNames() []string {
-	return combineModuleTagNamesAndVersion(modRelease.TagNames, modRelease.ModSetVersion())
+	var modFullTags []string
+	for i, modTagName := range modRelease.TagNames {
+		modFullTags = append(modFullTags, ModuleFullTagName(modTagName, modRelease.ModuleVersion(modRelease.ModSet.Modules[i])))
+	}
+	return modFullTags
+}
+
+// NewModuleFullTagNames gets the full tag names of the modules in the module
+// set to update, leaving out the tags in tagIndex of modules held back at a
+// version override, as these versions were released before.
+func (modRelease ModuleSetRelease) NewModuleFullTagNames(tagIndex TagIndex) []string {
+	var modFullTags []string
+	for i, modFullTag := range modRelease.ModuleFullTagNames() {
+		if modRelease.ModSet.IsHeldBack(modRelease.ModSet.Modules[i]) && tagIndex.Contains(modFullTag) {
+			continue
+		}
+		modFullTags = append(modFullTags, modFullTag)
+	}
+	return modFullTags
 }
 
 // CheckGitTagsAlreadyExist checks if Git tags have already been created that match the specific module tag name
 // and version number for the modules being updated. If the tag already exists, an error is returned.
+// Existing tags of modules held back at a version override are ignored.
 func (modRelease ModuleSetRelease) CheckGitTagsAlreadyExist(repo *git.Repository) error {
-	newTags := make(map[string]bool)
-
-	modFullTags := modRelease.ModuleFullTagNames()
-
-	for _, newFullTag := range modFullTags {
-		newTags[newFullTag] = true
-	}
-
-	existingTags, err := repo.Tags()
+	tagIndex, err := NewTagIndex(repo)
 	if err != nil {
-		return fmt.Errorf("error getting repo tags: %w", err)
+		return err
 	}
 
-	var existingGitTagNames []string
-
-	err = existingTags.ForEach(func(ref *plumbing.Reference) error {
-		existingTagName := ref.Name().Short()
-
-		if _, exists := newTags[existingTagName]; exists {
-			existingGitTagNames = append(existingGitTagNames, existingTagName)
+	var newTags, existingGitTagNames []string
+	for i, newFullTag := range modRelease.ModuleFullTagNames() {
+		if !tagIndex.Contains(newFullTag) {
+			newTags = append(newTags, newFullTag)
+		} else if !modRelease.ModSet.IsHeldBack(modRelease.ModSet.Modules[i]) {
+			existingGitTagNames = append(existingGitTagNames, newFullTag)
 		}
-
-		return nil
-	})
-	if err != nil {
-		return fmt.Errorf("could not check all git tags: %w", err)
 	}
 
-	switch len(existingGitTagNames) {
-	case len(newTags):
+	switch {
+	case len(newTags) == 0:
 		return ErrGitTagsAlreadyExist{
 			tagNames: existingGitTagNames,
 		}
-	case 0:
+	case len(existingGitTagNames) == 0:
 		return nil
 	default:
 		return ErrInconsistentGitTagsExist{
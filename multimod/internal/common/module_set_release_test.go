@@ -223,9 +223,10 @@ func TestCheckGitTagsAlreadyExist(t *testing.T) {
 	}
 
 	testCases := []struct {
-		name          string
-		modSetName    string
-		expectedError error
+		name               string
+		versioningFilename string
+		modSetName         string
+		expectedError      error
 	}{
 		{
 			name:       "multiple git tags exist",
@@ -251,11 +252,26 @@ func TestCheckGitTagsAlreadyExist(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:               "held back git tag exists",
+			versioningFilename: filepath.Join(testDataDir, "verify_git_tags_do_not_already_exist/versions_overrides.yaml"),
+			modSetName:         "mod-set-1",
+			expectedError:      nil,
+		},
+		{
+			name:               "only held back git tags exist",
+			versioningFilename: filepath.Join(testDataDir, "verify_git_tags_do_not_already_exist/versions_overrides.yaml"),
+			modSetName:         "mod-set-3",
+			expectedError:      ErrGitTagsAlreadyExist{},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			modSetRelease, err := NewModuleSetRelease(versioningFilename, tc.modSetName, repoRoot)
+			if tc.versioningFilename == "" {
+				tc.versioningFilename = versioningFilename
+			}
+			modSetRelease, err := NewModuleSetRelease(tc.versioningFilename, tc.modSetName, repoRoot)
 			require.NoError(t, err)
 
 			repo, err := git.PlainOpen(repoRoot)
@@ -266,3 +282,18 @@ func TestCheckGitTagsAlreadyExist(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckNotFrozen(t *testing.T) {
+	frozen := ModuleSetRelease{
+		ModSetName: "mod-set-frozen",
+		ModSet:     ModuleSet{Version: "v0.20.0", Frozen: true},
+	}
+	assert.Equal(t, ErrModuleSetFrozen{modSetName: "mod-set-frozen"}, frozen.CheckNotFrozen(false))
+	assert.NoError(t, frozen.CheckNotFrozen(true))
+
+	thawed := ModuleSetRelease{
+		ModSetName: "mod-set-1",
+		ModSet:     ModuleSet{Version: "v1.0.0"},
+	}
+	assert.NoError(t, thawed.CheckNotFrozen(false))
+}
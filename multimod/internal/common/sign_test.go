@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common/commontest"
+)
+
+var signTestDataDir = filepath.Join("test_data", "sign")
+
+func TestLoadSignKey(t *testing.T) {
+	e, err := commontest.NewSignEntity()
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "key.asc")
+	require.NoError(t, commontest.WriteSignKey(path, e, nil))
+
+	key, err := LoadSignKey(path, nil)
+	require.NoError(t, err)
+	assert.Equal(t, e.PrimaryKey.KeyId, key.PrimaryKey.KeyId)
+	assert.False(t, key.PrivateKey.Encrypted)
+
+	_, err = LoadSignKey(filepath.Join(t.TempDir(), "missing.asc"), nil)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	publicKey, err := commontest.ArmoredPublicKey(e)
+	require.NoError(t, err)
+	public := filepath.Join(t.TempDir(), "public.asc")
+	require.NoError(t, os.WriteFile(public, []byte(publicKey), 0600))
+	_, err = LoadSignKey(public, nil)
+	assert.ErrorIs(t, err, ErrNoPrivateKey)
+}
+
+func TestLoadSignKeyEncrypted(t *testing.T) {
+	e, err := commontest.NewSignEntity()
+	require.NoError(t, err)
+	passphrase := []byte("secret")
+	path := filepath.Join(t.TempDir(), "key.asc")
+	require.NoError(t, commontest.WriteSignKey(path, e, passphrase))
+
+	_, err = LoadSignKey(path, nil)
+	assert.ErrorContains(t, err, SignKeyPassphraseEnv)
+
+	_, err = LoadSignKey(path, []byte("wrong"))
+	assert.Error(t, err)
+
+	key, err := LoadSignKey(path, passphrase)
+	require.NoError(t, err)
+	assert.False(t, key.PrivateKey.Encrypted)
+}
+
+func TestNewCommitSigner(t *testing.T) {
+	t.Setenv(CommitSignKeyEnv, "")
+	t.Setenv(SignKeyPassphraseEnv, "")
+
+	signer, err := NewCommitSigner("")
+	require.NoError(t, err)
+	assert.Nil(t, signer)
+
+	e, err := commontest.NewSignEntity()
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "key.asc")
+	require.NoError(t, commontest.WriteSignKey(path, e, nil))
+	signer, err = NewCommitSigner(path)
+	require.NoError(t, err)
+	assert.IsType(t, openPGPSigner{}, signer)
+
+	t.Setenv(CommitSignKeyEnv, filepath.Join(signTestDataDir, "id_ed25519"))
+	signer, err = NewCommitSigner("")
+	require.NoError(t, err)
+	assert.IsType(t, sshSigner{}, signer)
+
+	encrypted := filepath.Join(signTestDataDir, "id_ed25519_encrypted")
+	_, err = NewCommitSigner(encrypted)
+	assert.ErrorContains(t, err, SignKeyPassphraseEnv)
+
+	t.Setenv(SignKeyPassphraseEnv, "secret")
+	signer, err = NewCommitSigner(encrypted)
+	require.NoError(t, err)
+	assert.IsType(t, sshSigner{}, signer)
+
+	_, err = NewCommitSigner(filepath.Join(signTestDataDir, "missing"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestSSHSignerSign(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join(signTestDataDir, "id_ed25519"))
+	require.NoError(t, err)
+	key, err := ssh.ParsePrivateKey(data)
+	require.NoError(t, err)
+
+	armored, err := sshSigner{signer: key}.Sign(strings.NewReader("commit content"))
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(armored, "\n"), "\n")
+	require.Greater(t, len(lines), 2)
+	assert.Equal(t, "-----BEGIN SSH SIGNATURE-----", lines[0])
+	assert.Equal(t, "-----END SSH SIGNATURE-----", lines[len(lines)-1])
+	for _, l := range lines[1 : len(lines)-1] {
+		assert.LessOrEqual(t, len(l), 70)
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(strings.Join(lines[1:len(lines)-1], ""))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(blob, []byte("SSHSIG")))
+
+	var sshsig struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}
+	require.NoError(t, ssh.Unmarshal(blob[len("SSHSIG"):], &sshsig))
+	assert.Equal(t, uint32(1), sshsig.Version)
+	assert.Equal(t, "git", sshsig.Namespace)
+	assert.Equal(t, "sha512", sshsig.HashAlgorithm)
+	assert.Equal(t, key.PublicKey().Marshal(), sshsig.PublicKey)
+
+	var sig ssh.Signature
+	require.NoError(t, ssh.Unmarshal(sshsig.Signature, &sig))
+	hash := sha512.Sum512([]byte("commit content"))
+	signed := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{"git", "", "sha512", hash[:]})...)
+	assert.NoError(t, key.PublicKey().Verify(signed, &sig))
+}
+
+func TestCommitChangesSigned(t *testing.T) {
+	repo, _ := initRepoWithModifiedFile(t)
+
+	e, err := commontest.NewSignEntity()
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "key.asc")
+	require.NoError(t, commontest.WriteSignKey(path, e, nil))
+	key, err := LoadSignKey(path, nil)
+	require.NoError(t, err)
+
+	hash, err := CommitChangesToNewBranch(context.Background(), "signed", "signed commit", repo, commontest.TestAuthor, openPGPSigner{key: key})
+	require.NoError(t, err)
+
+	ref, err := repo.Reference("refs/heads/signed", true)
+	require.NoError(t, err)
+	assert.Equal(t, hash, ref.Hash())
+
+	commit, err := repo.CommitObject(hash)
+	require.NoError(t, err)
+	assert.Equal(t, "signed commit", commit.Message)
+	publicKey, err := commontest.ArmoredPublicKey(e)
+	require.NoError(t, err)
+	signer, err := commit.Verify(publicKey)
+	require.NoError(t, err)
+	assert.Equal(t, e.PrimaryKey.KeyId, signer.PrimaryKey.KeyId)
+}
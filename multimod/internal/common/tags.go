@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"golang.org/x/mod/semver"
+)
+
+// TagIndex maps the short names of the tags of a repository to their
+// references.
+//
+// Looking up tags one by one reads the packed refs of the repository on every
+// lookup, which is slow in repositories with thousands of tags. A TagIndex
+// reads all tag references once so lookups of the tags of large module sets
+// are fast.
+type TagIndex map[string]*plumbing.Reference
+
+// NewTagIndex returns a TagIndex of all tags of repo.
+func NewTagIndex(repo *git.Repository) (TagIndex, error) {
+	tags, err := repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("error getting repo tags: %w", err)
+	}
+
+	index := make(TagIndex)
+	err = tags.ForEach(func(ref *plumbing.Reference) error {
+		index[ref.Name().Short()] = ref
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not read all git tags: %w", err)
+	}
+	return index, nil
+}
+
+// Contains returns whether a tag named tagName exists.
+func (index TagIndex) Contains(tagName string) bool {
+	_, exists := index[tagName]
+	return exists
+}
+
+// LatestVersion returns the full name of the tag of the highest version of the
+// module with tag name modTagName. It returns false if the module has no
+// version tags.
+func (index TagIndex) LatestVersion(modTagName ModuleTagName) (string, bool) {
+	versions := index.Versions(modTagName)
+	if len(versions) == 0 {
+		return "", false
+	}
+	return ModuleFullTagName(modTagName, versions[len(versions)-1]), true
+}
+
+// Versions returns the versions of the module with tag name modTagName that
+// are tagged, sorted in increasing semver order.
+func (index TagIndex) Versions(modTagName ModuleTagName) []string {
+	prefix := string(modTagName) + "/"
+	if modTagName == RepoRootTag {
+		prefix = ""
+	}
+
+	var versions []string
+	for tagName := range index {
+		if !strings.HasPrefix(tagName, prefix) {
+			continue
+		}
+		version := strings.TrimPrefix(tagName, prefix)
+		if !semver.IsValid(version) {
+			// Also excludes the tags of nested modules.
+			continue
+		}
+		versions = append(versions, version)
+	}
+	semver.Sort(versions)
+	return versions
+}
+
+// CommitHash returns the hash of the commit the tag tagName points to, for
+// both annotated and lightweight tags. It returns false if the tag does not
+// exist.
+func (index TagIndex) CommitHash(repo *git.Repository, tagName string) (plumbing.Hash, bool, error) {
+	ref, exists := index[tagName]
+	if !exists {
+		return plumbing.ZeroHash, false, nil
+	}
+
+	tagObj, err := repo.TagObject(ref.Hash())
+	switch {
+	case errors.Is(err, plumbing.ErrObjectNotFound):
+		// Lightweight tags point directly at the commit.
+		return ref.Hash(), true, nil
+	case err != nil:
+		return plumbing.ZeroHash, true, fmt.Errorf("unable to get tag object of %v: %w", tagName, err)
+	}
+
+	commit, err := tagObj.Commit()
+	if err != nil {
+		if errors.Is(err, object.ErrUnsupportedObject) {
+			return plumbing.ZeroHash, true, fmt.Errorf("tag %v does not point to a commit", tagName)
+		}
+		return plumbing.ZeroHash, true, fmt.Errorf("could not get tag object commit of %v: %w", tagName, err)
+	}
+	return commit.Hash, true, nil
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+	"go.opentelemetry.io/build-tools/multimod/internal/common/commontest"
+	"go.opentelemetry.io/build-tools/multimod/releasetest"
+)
+
+func TestTagIndex(t *testing.T) {
+	repo, firstHash, err := releasetest.InitNewMemoryRepoWithCommit(nil)
+	require.NoError(t, err)
+
+	secondHash, err := common.CommitChangesToNewBranch(context.Background(), "test_commit", "commit used in a test", repo, commontest.TestAuthor, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, releasetest.CreateTags(repo, firstHash, "annotated/v1.0.0"))
+	_, err = repo.CreateTag("lightweight/v1.0.0", secondHash, nil)
+	require.NoError(t, err)
+
+	index, err := common.NewTagIndex(repo)
+	require.NoError(t, err)
+
+	assert.Len(t, index, 2)
+	assert.True(t, index.Contains("annotated/v1.0.0"))
+	assert.True(t, index.Contains("lightweight/v1.0.0"))
+	assert.False(t, index.Contains("annotated/v2.0.0"))
+
+	testCases := []struct {
+		tagName        string
+		expectedHash   plumbing.Hash
+		expectedExists bool
+	}{
+		{
+			tagName:        "annotated/v1.0.0",
+			expectedHash:   firstHash,
+			expectedExists: true,
+		},
+		{
+			tagName:        "lightweight/v1.0.0",
+			expectedHash:   secondHash,
+			expectedExists: true,
+		},
+		{
+			tagName:        "missing/v1.0.0",
+			expectedHash:   plumbing.ZeroHash,
+			expectedExists: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tagName, func(t *testing.T) {
+			hash, exists, err := index.CommitHash(repo, tc.tagName)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedExists, exists)
+			assert.Equal(t, tc.expectedHash, hash)
+		})
+	}
+}
+
+func TestTagIndexLatestVersion(t *testing.T) {
+	index := make(common.TagIndex)
+	for _, tagName := range []string{
+		"v1.0.0",
+		"v1.2.0",
+		"v1.10.0-rc.1",
+		"a/v0.1.0",
+		"a/v0.10.0",
+		"a/b/v2.0.0",
+		"a/not-a-version",
+	} {
+		index[tagName] = nil
+	}
+
+	testCases := []struct {
+		modTagName common.ModuleTagName
+		expected   string
+	}{
+		{modTagName: common.RepoRootTag, expected: "v1.10.0-rc.1"},
+		{modTagName: "a", expected: "a/v0.10.0"},
+		{modTagName: "a/b", expected: "a/b/v2.0.0"},
+		{modTagName: "c", expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.modTagName), func(t *testing.T) {
+			actual, exists := index.LatestVersion(tc.modTagName)
+			assert.Equal(t, tc.expected, actual)
+			assert.Equal(t, tc.expected != "", exists)
+		})
+	}
+}
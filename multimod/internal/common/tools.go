@@ -15,16 +15,23 @@
 package common
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
-	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
 
+	"go.uber.org/multierr"
+	"golang.org/x/mod/modfile"
 	"golang.org/x/mod/semver"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
 )
 
 // IsStableVersion returns true if modSet.Version is stable (i.e. version major greater than
@@ -49,6 +56,22 @@ func GetAllModuleSetNames(versioningFile string, repoRoot string) ([]string, err
 	return modSetNames, nil
 }
 
+// GetModuleSetNames returns the name of all module sets given in a
+// versioningFile, without looking for the modules in the repo.
+func GetModuleSetNames(versioningFile string) ([]string, error) {
+	vCfg, err := readVersioningFile(versioningFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading versioning file %v: %w", versioningFile, err)
+	}
+
+	modSetNames := make([]string, 0, len(vCfg.ModuleSets))
+	for modSetName := range vCfg.ModuleSets {
+		modSetNames = append(modSetNames, modSetName)
+	}
+	sort.Strings(modSetNames)
+	return modSetNames, nil
+}
+
 func GetModuleSet(modSetName, versioningFilename string) (ModuleSet, error) {
 	vCfg, err := readVersioningFile(versioningFilename)
 	if err != nil {
@@ -60,24 +83,26 @@ func GetModuleSet(modSetName, versioningFilename string) (ModuleSet, error) {
 }
 
 // updateGoModVersions updates one go.mod file, given by modFilePath, by updating all modules listed in
-// newModPaths to use the newVersion given.
+// newModPaths to use the newVersion given. The file is only written if it changed.
 func updateGoModVersions(modFilePath ModuleFilePath, newModPaths []ModulePath, newVersion string) error {
 	if !strings.HasSuffix(string(modFilePath), "go.mod") {
 		return errors.New("cannot update file passed that does not end with go.mod")
 	}
 
-	newGoModFile, err := os.ReadFile(filepath.Clean(string(modFilePath)))
+	goModFile, err := os.ReadFile(filepath.Clean(string(modFilePath)))
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("could not read go.mod file: %w", err)
 	}
 
-	for _, modPath := range newModPaths {
-		newGoModFile, err = replaceModVersion(modPath, newVersion, newGoModFile)
-		if err != nil {
-			return err
-		}
+	newGoModFile, err := setRequireVersions(string(modFilePath), goModFile, newModPaths, newVersion)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(goModFile, newGoModFile) {
+		return nil
 	}
 
+	logging.Debugf("... Updating file %v", modFilePath)
 	// once all module versions have been updated, overwrite the go.mod file
 	if err := os.WriteFile(string(modFilePath), newGoModFile, 0600); err != nil {
 		return fmt.Errorf("error overwriting go.mod file: %w", err)
@@ -86,24 +111,68 @@ func updateGoModVersions(modFilePath ModuleFilePath, newModPaths []ModulePath, n
 	return nil
 }
 
-func replaceModVersion(modPath ModulePath, version string, newGoModFile []byte) ([]byte, error) {
-	oldVersionRegex := `(?m:` + filePathToRegex(string(modPath)) + `\s+` + SemverRegex + `(\s*\/\/\s*indirect\s*?)?$)`
-	r, err := regexp.Compile(oldVersionRegex)
+// setRequireVersions returns the content of the go.mod file with the given
+// name and content data, with every requirement on one of modPaths set to
+// version. Only the version tokens are rewritten, so comments and formatting
+// are preserved exactly. The file is parsed leniently so directives unknown to
+// golang.org/x/mod, such as toolchain, are kept as they are.
+func setRequireVersions(name string, data []byte, modPaths []ModulePath, version string) ([]byte, error) {
+	f, err := modfile.ParseLax(name, data, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error compiling regex: %w", err)
+		return nil, fmt.Errorf("could not parse go.mod file: %w", err)
+	}
+
+	update := make(map[string]struct{}, len(modPaths))
+	for _, modPath := range modPaths {
+		update[string(modPath)] = struct{}{}
+	}
+
+	// Requirements are listed in file order, so the spans of the version
+	// tokens are increasing.
+	var out []byte
+	last := 0
+	for _, r := range f.Require {
+		if _, ok := update[r.Mod.Path]; !ok || r.Mod.Version == version {
+			continue
+		}
+
+		start, end, err := lastTokenSpan(data, r.Syntax)
+		if err != nil {
+			return nil, fmt.Errorf("could not update %v: %w", r.Mod.Path, err)
+		}
+		out = append(out, data[last:start]...)
+		out = append(out, version...)
+		last = end
+	}
+	if out == nil {
+		return data, nil
 	}
+	return append(out, data[last:]...), nil
+}
 
-	newModVersionString := string(modPath) + " " + version
+// lastTokenSpan returns the byte offsets in data of the last token of line,
+// which for a requirement is its version.
+func lastTokenSpan(data []byte, line *modfile.Line) (int, int, error) {
+	if line == nil || len(line.Token) == 0 {
+		return 0, 0, errors.New("missing syntax information")
+	}
+	tok := line.Token[len(line.Token)-1]
+	lineStart, lineEnd := line.Start.Byte, line.End.Byte
+	if lineStart < 0 || lineEnd > len(data) || lineStart > lineEnd {
+		return 0, 0, errors.New("invalid syntax position")
+	}
 
-	// ${6} is the capture group that has " // indirect" if it was present in the original
-	newGoModFile = r.ReplaceAll(newGoModFile, []byte(newModVersionString+"${6}"))
-	return newGoModFile, nil
+	i := bytes.LastIndex(data[lineStart:lineEnd], []byte(tok))
+	if i < 0 {
+		return 0, 0, fmt.Errorf("token %q not found", tok)
+	}
+	return lineStart + i, lineStart + i + len(tok), nil
 }
 
 // UpdateGoModFiles updates the go.mod files in modFilePaths by updating all modules listed in
 // newModPaths to use the newVersion given.
 func UpdateGoModFiles(modFilePaths []ModuleFilePath, newModPaths []ModulePath, newVersion string) error {
-	log.Println("Updating all module versions in go.mod files...")
+	logging.Infof("Updating all module versions in go.mod files...")
 	for _, modFilePath := range modFilePaths {
 		if err := updateGoModVersions(
 			modFilePath,
@@ -116,22 +185,74 @@ func UpdateGoModFiles(modFilePaths []ModuleFilePath, newModPaths []ModulePath, n
 	return nil
 }
 
-func filePathToRegex(fpath string) string {
-	quotedMeta := regexp.QuoteMeta(fpath)
-	replacedSlashes := strings.ReplaceAll(quotedMeta, string(filepath.Separator), `\/`)
-	return replacedSlashes
+// UpdateGoModFilesToModuleSet updates the go.mod files in modFilePaths by
+// updating all modules of modSet to use their version in the set, honoring
+// its version overrides.
+func UpdateGoModFilesToModuleSet(modFilePaths []ModuleFilePath, modSet ModuleSet) error {
+	var versions []string
+	modPathsByVersion := make(map[string][]ModulePath)
+	for _, modPath := range modSet.Modules {
+		version := modSet.ModuleVersion(modPath)
+		if _, ok := modPathsByVersion[version]; !ok {
+			versions = append(versions, version)
+		}
+		modPathsByVersion[version] = append(modPathsByVersion[version], modPath)
+	}
+
+	for _, version := range versions {
+		if err := UpdateGoModFiles(modFilePaths, modPathsByVersion[version], version); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // RunGoModTidy takes a ModulePathMap and runs "go mod tidy" at each module file path.
-func RunGoModTidy(modPathMap ModulePathMap) error {
+// Modules are tidied concurrently, at most runtime.NumCPU at a time. Running
+// "go mod tidy" processes are killed if ctx is done.
+func RunGoModTidy(ctx context.Context, modPathMap ModulePathMap) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs error
+	)
+	sem := make(chan struct{}, runtime.NumCPU())
 	for _, modFilePath := range modPathMap {
-		cmd := exec.Command("go", "mod", "tidy", "-compat=1.17")
-		cmd.Dir = filepath.Dir(string(modFilePath))
-
-		if out, err := cmd.CombinedOutput(); err != nil {
-			return fmt.Errorf("go mod tidy failed [%v]: %w", string(out), err)
+		if err := ctx.Err(); err != nil {
+			break
 		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(dir string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := goModTidy(ctx, dir); err != nil {
+				mu.Lock()
+				errs = multierr.Append(errs, err)
+				mu.Unlock()
+			}
+		}(filepath.Dir(string(modFilePath)))
 	}
+	wg.Wait()
 
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errs
+}
+
+func goModTidy(ctx context.Context, dir string) error {
+	logging.Debugf("go mod tidy in %v", dir)
+
+	cmd := exec.CommandContext(ctx, "go", "mod", "tidy", "-compat=1.17")
+	cmd.Dir = dir
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("go mod tidy failed in %v [%v]: %w", dir, string(out), err)
+	}
 	return nil
 }
@@ -15,6 +15,7 @@
 package common
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -139,29 +140,38 @@ func TestUpdateGoModVersions(t *testing.T) {
 	}
 }
 
-func TestFilePathToRegex(t *testing.T) {
-	testCases := []struct {
-		fpath    string
-		expected string
-	}{
-		{
-			fpath:    "go.opentelemetry.io/test/test1",
-			expected: `go\.opentelemetry\.io\/test\/test1`,
+func TestUpdateGoModFilesToModuleSet(t *testing.T) {
+	modFilePath := filepath.Join(t.TempDir(), "go.mod")
+	require.NoError(t, os.WriteFile(modFilePath, []byte("module go.opentelemetry.io/test/testroot\n\n"+
+		"go 1.16\n\n"+
+		"require (\n\t"+
+		"go.opentelemetry.io/test/test1 v0.44.0\n\t"+
+		"go.opentelemetry.io/test/test2 v0.44.0\n"+
+		")\n"), 0600))
+
+	modSet := ModuleSet{
+		Version: "v0.45.0",
+		Modules: []ModulePath{
+			"go.opentelemetry.io/test/test1",
+			"go.opentelemetry.io/test/test2",
 		},
-		{
-			fpath:    "go.opentelemetry.io/test/hyphen-test1",
-			expected: `go\.opentelemetry\.io\/test\/hyphen-test1`,
+		VersionOverrides: []VersionOverride{
+			{Module: "go.opentelemetry.io/test/test2", Version: "v0.44.1"},
 		},
 	}
+	require.NoError(t, UpdateGoModFilesToModuleSet([]ModuleFilePath{ModuleFilePath(modFilePath)}, modSet))
 
-	for _, tc := range testCases {
-		actual := filePathToRegex(tc.fpath)
-
-		assert.Equal(t, tc.expected, actual)
-	}
+	actual, err := os.ReadFile(filepath.Clean(modFilePath))
+	require.NoError(t, err)
+	assert.Equal(t, "module go.opentelemetry.io/test/testroot\n\n"+
+		"go 1.16\n\n"+
+		"require (\n\t"+
+		"go.opentelemetry.io/test/test1 v0.45.0\n\t"+
+		"go.opentelemetry.io/test/test2 v0.44.1\n"+
+		")\n", string(actual))
 }
 
-func TestReplaceModVersion(t *testing.T) {
+func TestSetRequireVersions(t *testing.T) {
 	for _, s := range []struct {
 		name     string
 		input    []byte
@@ -227,9 +237,108 @@ require (
 )
 `),
 		},
+		{
+			name: "single line",
+			input: []byte(`module test
+
+go 1.17
+
+require foo.bar/baz v1.2.3
+`),
+			expected: []byte(`module test
+
+go 1.17
+
+require foo.bar/baz v1.2.4
+`),
+		},
+		{
+			name: "comments and formatting",
+			input: []byte(`// Leading comment.
+module test
+
+go 1.17
+
+require (
+	// Pinned for the next release.
+	foo.bar/baz   v1.2.3 // indirect; keep
+	foo.bar/baz/v2 v2.0.0
+	foo.bar/bazooka v1.2.3
+)
+`),
+			expected: []byte(`// Leading comment.
+module test
+
+go 1.17
+
+require (
+	// Pinned for the next release.
+	foo.bar/baz   v1.2.4 // indirect; keep
+	foo.bar/baz/v2 v2.0.0
+	foo.bar/bazooka v1.2.3
+)
+`),
+		},
+		{
+			name: "replace not updated",
+			input: []byte(`module test
+
+go 1.17
+
+require foo.bar/baz v1.2.3
+
+replace foo.bar/baz v1.2.3 => foo.bar/baz v1.2.3
+`),
+			expected: []byte(`module test
+
+go 1.17
+
+require foo.bar/baz v1.2.4
+
+replace foo.bar/baz v1.2.3 => foo.bar/baz v1.2.3
+`),
+		},
+		{
+			name: "not required",
+			input: []byte(`module test
+
+go 1.17
+`),
+			expected: []byte(`module test
+
+go 1.17
+`),
+		},
+		{
+			name: "toolchain",
+			input: []byte(`module test
+
+go 1.21.0
+
+toolchain go1.21.3
+
+require foo.bar/baz v1.2.3
+`),
+			expected: []byte(`module test
+
+go 1.21.0
+
+toolchain go1.21.3
+
+require foo.bar/baz v1.2.4
+`),
+		},
+		{
+			name: "invalid",
+			input: []byte(`module test
+
+require foo.bar/baz
+`),
+			err: true,
+		},
 	} {
 		t.Run(s.name, func(t *testing.T) {
-			got, err := replaceModVersion("foo.bar/baz", "v1.2.4", s.input)
+			got, err := setRequireVersions("go.mod", s.input, []ModulePath{"foo.bar/baz"}, "v1.2.4")
 			assert.Equal(t, string(s.expected), string(got))
 			if s.err {
 				assert.Error(t, err)
@@ -239,3 +348,11 @@ require (
 		})
 	}
 }
+
+func TestRunGoModTidyInterrupted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := RunGoModTidy(ctx, ModulePathMap{"go.opentelemetry.io/test": ModuleFilePath(filepath.Join(t.TempDir(), "go.mod"))})
+	assert.ErrorIs(t, err, context.Canceled)
+}
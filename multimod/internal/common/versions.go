@@ -22,6 +22,8 @@ import (
 
 	"github.com/spf13/viper"
 	"golang.org/x/mod/modfile"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
 )
 
 const (
@@ -33,6 +35,22 @@ const (
 type versionConfig struct {
 	ModuleSets      ModuleSetMap `mapstructure:"module-sets"`
 	ExcludedModules []ModulePath `mapstructure:"excluded-modules"`
+	// Include lists other versioning files, relative to the including file,
+	// that are merged into this one.
+	Include []string `mapstructure:"include"`
+	// PrereleaseHooks are run by prerelease before committing the changes.
+	PrereleaseHooks []Hook `mapstructure:"prerelease-hooks"`
+}
+
+// Hook is a command run at a step of the release process.
+type Hook struct {
+	// Name identifies the hook in logs and errors.
+	Name string `mapstructure:"name"`
+	// Command is the executable and its arguments, it is not run by a shell.
+	Command []string `mapstructure:"command"`
+	// ContinueOnError makes a failure of the hook a warning instead of
+	// stopping the release.
+	ContinueOnError bool `mapstructure:"continue-on-error"`
 }
 
 // excludedModules functions as a set containing all module paths that are excluded
@@ -46,6 +64,64 @@ type ModuleSetMap map[string]ModuleSet
 type ModuleSet struct {
 	Version string       `mapstructure:"version"`
 	Modules []ModulePath `mapstructure:"modules"`
+	// Frozen module sets, such as those of deprecated modules, are not
+	// released unless explicitly unfrozen.
+	Frozen bool `mapstructure:"frozen"`
+	// VersionFiles are the files of the modules holding the version of the
+	// module set in their source. Defaults to the version.go file of each
+	// module.
+	VersionFiles []VersionFile `mapstructure:"version-files"`
+	// VersionOverrides hold back modules of the set at their own version
+	// instead of the version of the set.
+	VersionOverrides []VersionOverride `mapstructure:"version-overrides"`
+	// TagMessage is the Go template of the message of the annotated tags
+	// of the modules of the set.
+	TagMessage string `mapstructure:"tag-message"`
+}
+
+// VersionOverride is the version of a module overriding the version of its
+// module set.
+type VersionOverride struct {
+	Module  ModulePath `mapstructure:"module"`
+	Version string     `mapstructure:"version"`
+}
+
+// ModuleVersion returns the version of the module with import path modPath,
+// which is the version of its override if any, otherwise the version of the
+// module set.
+func (modSet ModuleSet) ModuleVersion(modPath ModulePath) string {
+	for _, override := range modSet.VersionOverrides {
+		if override.Module == modPath {
+			return override.Version
+		}
+	}
+	return modSet.Version
+}
+
+// contains reports whether modPath is a module of the set.
+func (modSet ModuleSet) contains(modPath ModulePath) bool {
+	for _, m := range modSet.Modules {
+		if m == modPath {
+			return true
+		}
+	}
+	return false
+}
+
+// IsHeldBack reports whether the module with import path modPath is held
+// back at a version differing from the version of the module set.
+func (modSet ModuleSet) IsHeldBack(modPath ModulePath) bool {
+	return modSet.ModuleVersion(modPath) != modSet.Version
+}
+
+// VersionFile is a file of a module holding the version of its module set.
+type VersionFile struct {
+	// Path is relative to the module directory and can be a glob pattern.
+	Path string `mapstructure:"path"`
+	// Pattern is a regular expression matching the version to update. If it
+	// has a group named version, only the text matched by the group is
+	// replaced. Defaults to any semantic version without the "v" prefix.
+	Pattern string `mapstructure:"pattern"`
 }
 
 // ModulePath holds the module import path, such as "go.opentelemetry.io/otel".
@@ -73,24 +149,72 @@ type ModulePathMap map[ModulePath]ModuleFilePath
 type ModuleTagName string
 
 // readVersioningFile reads in a versioning file (typically given as versions.yaml) and returns
-// a versionConfig struct.
+// a versionConfig struct. The versioning files it includes are merged into the returned
+// versionConfig, defining the same module set in more than one file is an error.
 func readVersioningFile(versioningFilename string) (versionConfig, error) {
-	viper.SetConfigFile(versioningFilename)
+	versionCfg, err := readVersioningFileIncludes(versioningFilename, nil)
+	if err != nil {
+		return versionConfig{}, err
+	}
+	versionCfg.Include = nil
+	return versionCfg, nil
+}
+
+// readVersioningFileIncludes reads the versioning file and, recursively, the
+// versioning files it includes. including holds the files currently being
+// read to detect include cycles.
+func readVersioningFileIncludes(versioningFilename string, including []string) (versionConfig, error) {
+	absFilename, err := filepath.Abs(versioningFilename)
+	if err != nil {
+		return versionConfig{}, fmt.Errorf("could not get absolute path of versioning file: %w", err)
+	}
+	for _, f := range including {
+		if f == absFilename {
+			return versionConfig{}, fmt.Errorf("versioning file %v includes itself", versioningFilename)
+		}
+	}
+	including = append(including, absFilename)
+
+	versionCfg, err := readSingleVersioningFile(versioningFilename)
+	if err != nil {
+		return versionConfig{}, err
+	}
+
+	for _, inc := range versionCfg.Include {
+		if !filepath.IsAbs(inc) {
+			inc = filepath.Join(filepath.Dir(versioningFilename), inc)
+		}
+		incCfg, err := readVersioningFileIncludes(inc, including)
+		if err != nil {
+			return versionConfig{}, fmt.Errorf("error reading versioning file %v included by %v: %w", inc, versioningFilename, err)
+		}
+		if err = versionCfg.merge(incCfg); err != nil {
+			return versionConfig{}, fmt.Errorf("could not merge versioning file %v into %v: %w", inc, versioningFilename, err)
+		}
+	}
+
+	return versionCfg, nil
+}
+
+// readSingleVersioningFile reads in a versioning file without its includes.
+func readSingleVersioningFile(versioningFilename string) (versionConfig, error) {
+	v := viper.New()
+	v.SetConfigFile(versioningFilename)
 
 	var versionCfg versionConfig
 
-	if err := viper.ReadInConfig(); err != nil {
+	if err := v.ReadInConfig(); err != nil {
 		return versionConfig{}, fmt.Errorf("error reading versionsConfig file: %w", err)
 	}
 
-	if err := viper.Unmarshal(&versionCfg); err != nil {
+	if err := v.Unmarshal(&versionCfg); err != nil {
 		return versionConfig{}, fmt.Errorf("unable to unmarshal versionsConfig: %w", err)
 	}
 
-	if viper.ConfigFileUsed() != versioningFilename {
+	if v.ConfigFileUsed() != versioningFilename {
 		return versionConfig{}, fmt.Errorf(
 			"config file used (%v) does not match input file (%v)",
-			viper.ConfigFileUsed(),
+			v.ConfigFileUsed(),
 			versioningFilename,
 		)
 	}
@@ -98,6 +222,30 @@ func readVersioningFile(versioningFilename string) (versionConfig, error) {
 	return versionCfg, nil
 }
 
+// merge adds the module sets, excluded modules, and hooks of other to versionCfg.
+// Module sets must only be defined once.
+func (versionCfg *versionConfig) merge(other versionConfig) error {
+	if versionCfg.ModuleSets == nil && len(other.ModuleSets) > 0 {
+		versionCfg.ModuleSets = make(ModuleSetMap, len(other.ModuleSets))
+	}
+	for name, modSet := range other.ModuleSets {
+		if _, exists := versionCfg.ModuleSets[name]; exists {
+			return fmt.Errorf("module set %v is defined more than once", name)
+		}
+		versionCfg.ModuleSets[name] = modSet
+	}
+
+	excluded := versionCfg.getExcludedModules()
+	for _, mod := range other.ExcludedModules {
+		if _, exists := excluded[mod]; !exists {
+			versionCfg.ExcludedModules = append(versionCfg.ExcludedModules, mod)
+		}
+	}
+
+	versionCfg.PrereleaseHooks = append(versionCfg.PrereleaseHooks, other.PrereleaseHooks...)
+	return nil
+}
+
 // buildModuleSetsMap creates a map with module set names as keys and ModuleSet structs as values.
 func (versionCfg versionConfig) buildModuleSetsMap() ModuleSetMap {
 	return versionCfg.ModuleSets
@@ -109,6 +257,12 @@ func (versionCfg versionConfig) buildModuleMap() (ModuleInfoMap, error) {
 	modMap := make(ModuleInfoMap)
 
 	for setName, moduleSet := range versionCfg.ModuleSets {
+		for _, override := range moduleSet.VersionOverrides {
+			if !moduleSet.contains(override.Module) {
+				return nil, fmt.Errorf("version override of module %v which is not in set %v", override.Module, setName)
+			}
+		}
+
 		for _, modPath := range moduleSet.Modules {
 			// Check if module has already been added to the map
 			if _, exists := modMap[modPath]; exists {
@@ -120,7 +274,7 @@ func (versionCfg versionConfig) buildModuleMap() (ModuleInfoMap, error) {
 			if versionCfg.shouldExcludeModule(modPath) {
 				return nil, fmt.Errorf("module %v is an excluded module and should not be versioned", modPath)
 			}
-			modMap[modPath] = ModuleInfo{setName, moduleSet.Version}
+			modMap[modPath] = ModuleInfo{setName, moduleSet.ModuleVersion(modPath)}
 		}
 	}
 
@@ -152,7 +306,7 @@ func (versionCfg versionConfig) BuildModulePathMap(root string) (ModulePathMap,
 
 	findGoMod := func(filePath string, info fs.FileInfo, err error) error {
 		if err != nil {
-			fmt.Printf("Warning: file could not be read during filepath.Walk(): %v", err)
+			logging.Warnf("file could not be read during filepath.Walk(): %v", err)
 			return nil
 		}
 		if filepath.Base(filePath) == "go.mod" {
@@ -183,3 +337,36 @@ func (versionCfg versionConfig) BuildModulePathMap(root string) (ModulePathMap,
 
 	return modPathMap, nil
 }
+
+// ReadExcludedModules returns the modules listed in the excluded modules
+// section of a versioning file and the versioning files it includes.
+func ReadExcludedModules(versioningFilename string) ([]ModulePath, error) {
+	versionCfg, err := readVersioningFile(versioningFilename)
+	if err != nil {
+		return nil, err
+	}
+	return versionCfg.ExcludedModules, nil
+}
+
+// ReadPrereleaseHooks returns the prerelease hooks of a versioning file,
+// followed by those of the versioning files it includes. Hooks without a
+// command are an error.
+func ReadPrereleaseHooks(versioningFilename string) ([]Hook, error) {
+	versionCfg, err := readVersioningFile(versioningFilename)
+	if err != nil {
+		return nil, err
+	}
+	for i, hook := range versionCfg.PrereleaseHooks {
+		if len(hook.Command) == 0 {
+			return nil, fmt.Errorf("prerelease hook %d (%q) has no command", i, hook.Name)
+		}
+	}
+	return versionCfg.PrereleaseHooks, nil
+}
+
+// FindModules creates a map with the module paths of all go.mod files in
+// root, including those of excluded modules, as keys and go.mod file paths as
+// values.
+func FindModules(root string) (ModulePathMap, error) {
+	return versionConfig{}.BuildModulePathMap(root)
+}
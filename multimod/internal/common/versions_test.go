@@ -63,11 +63,55 @@ func TestReadVersioningFile(t *testing.T) {
 						"go.opentelemetry.io/test3",
 					},
 				},
+				"mod-set-frozen": ModuleSet{
+					Version: "v0.20.0",
+					Modules: []ModulePath{
+						"go.opentelemetry.io/test4",
+					},
+					Frozen: true,
+				},
+			},
+			ExpectedExcludedModules: []ModulePath{
+				"go.opentelemetry.io/excluded1",
+			},
+		},
+		{
+			name:               "included versioning file",
+			versioningFilename: filepath.Join(testDataDir, "read_versioning_filename/versions_include.yaml"),
+			ShouldError:        false,
+			ExpectedModuleSets: ModuleSetMap{
+				"mod-set-1": ModuleSet{
+					Version: "v1.2.3",
+					Modules: []ModulePath{
+						"go.opentelemetry.io/test/test1",
+					},
+				},
+				"mod-set-experimental": ModuleSet{
+					Version: "v0.1.0",
+					Modules: []ModulePath{
+						"go.opentelemetry.io/test3",
+					},
+				},
 			},
 			ExpectedExcludedModules: []ModulePath{
 				"go.opentelemetry.io/excluded1",
+				"go.opentelemetry.io/excluded2",
 			},
 		},
+		{
+			name:                    "module set defined in included versioning file",
+			versioningFilename:      filepath.Join(testDataDir, "read_versioning_filename/versions_include_conflict.yaml"),
+			ShouldError:             true,
+			ExpectedModuleSets:      nil,
+			ExpectedExcludedModules: nil,
+		},
+		{
+			name:                    "include cycle",
+			versioningFilename:      filepath.Join(testDataDir, "read_versioning_filename/versions_include_cycle.yaml"),
+			ShouldError:             true,
+			ExpectedModuleSets:      nil,
+			ExpectedExcludedModules: nil,
+		},
 		{
 			name:                    "invalid version file syntax",
 			versioningFilename:      filepath.Join(testDataDir, "read_versioning_filename/versions_invalid_syntax.yaml"),
@@ -205,6 +249,52 @@ func TestBuildModuleMap(t *testing.T) {
 			shouldError: true,
 			expected:    nil,
 		},
+		{
+			name: "version override",
+			vCfg: versionConfig{
+				ModuleSets: ModuleSetMap{
+					"mod-set-1": ModuleSet{
+						Version: "v0.45.0",
+						Modules: []ModulePath{
+							"go.opentelemetry.io/test/test1",
+							"go.opentelemetry.io/test/test2",
+						},
+						VersionOverrides: []VersionOverride{
+							{Module: "go.opentelemetry.io/test/test2", Version: "v0.44.1"},
+						},
+					},
+				},
+			},
+			shouldError: false,
+			expected: ModuleInfoMap{
+				"go.opentelemetry.io/test/test1": ModuleInfo{
+					ModuleSetName: "mod-set-1",
+					Version:       "v0.45.0",
+				},
+				"go.opentelemetry.io/test/test2": ModuleInfo{
+					ModuleSetName: "mod-set-1",
+					Version:       "v0.44.1",
+				},
+			},
+		},
+		{
+			name: "version override of module not in set",
+			vCfg: versionConfig{
+				ModuleSets: ModuleSetMap{
+					"mod-set-1": ModuleSet{
+						Version: "v0.45.0",
+						Modules: []ModulePath{
+							"go.opentelemetry.io/test/test1",
+						},
+						VersionOverrides: []VersionOverride{
+							{Module: "go.opentelemetry.io/test/test2", Version: "v0.44.1"},
+						},
+					},
+				},
+			},
+			shouldError: true,
+			expected:    nil,
+		},
 		{
 			name: "module listed in set and excluded",
 			vCfg: versionConfig{
@@ -354,3 +444,48 @@ func TestBuildModulePathMap(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, expected, actual)
 }
+
+func TestReadExcludedModules(t *testing.T) {
+	actual, err := ReadExcludedModules(filepath.Join(testDataDir, "read_versioning_filename/versions_include.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, []ModulePath{"go.opentelemetry.io/excluded1", "go.opentelemetry.io/excluded2"}, actual)
+
+	_, err = ReadExcludedModules(filepath.Join(testDataDir, "read_versioning_filename/versions_invalid_syntax.yaml"))
+	assert.Error(t, err)
+}
+
+func TestReadPrereleaseHooks(t *testing.T) {
+	actual, err := ReadPrereleaseHooks(filepath.Join(testDataDir, "read_versioning_filename/versions_hooks.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, []Hook{
+		{Name: "lint", Command: []string{"make", "lint"}},
+		{Name: "generate", Command: []string{"go", "generate", "./..."}, ContinueOnError: true},
+	}, actual)
+
+	actual, err = ReadPrereleaseHooks(filepath.Join(testDataDir, "read_versioning_filename/versions_valid.yaml"))
+	require.NoError(t, err)
+	assert.Empty(t, actual)
+
+	_, err = ReadPrereleaseHooks(filepath.Join(testDataDir, "read_versioning_filename/versions_hooks_invalid.yaml"))
+	assert.ErrorContains(t, err, `prerelease hook 0 ("lint") has no command`)
+}
+
+func TestFindModules(t *testing.T) {
+	tmpRootDir := t.TempDir()
+	modFiles := map[string][]byte{
+		filepath.Join(tmpRootDir, "go.mod"):                  []byte("module go.opentelemetry.io/testroot/v2\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "test", "test2", "go.mod"): []byte("module \"go.opentelemetry.io/test/testexcluded\"\n\ngo 1.16\n"),
+	}
+
+	require.NoError(t, commontest.WriteTempFiles(modFiles), "could not create go mod file tree")
+
+	expected := ModulePathMap{
+		"go.opentelemetry.io/testroot/v2":       ModuleFilePath(filepath.Join(tmpRootDir, "go.mod")),
+		"go.opentelemetry.io/test/testexcluded": ModuleFilePath(filepath.Join(tmpRootDir, "test", "test2", "go.mod")),
+	}
+
+	actual, err := FindModules(tmpRootDir)
+
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
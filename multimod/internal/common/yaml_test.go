@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestYAMLMappingValue(t *testing.T) {
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte("module-sets:\n  stable:\n    version: v1.0.0\nexcluded-modules:\n  - example.com/tools\n"), &doc))
+	root := doc.Content[0]
+
+	version := YAMLMappingValue(YAMLMappingValue(YAMLMappingValue(root, "module-sets"), "stable"), "version")
+	require.NotNil(t, version)
+	assert.Equal(t, "v1.0.0", version.Value)
+
+	assert.Nil(t, YAMLMappingValue(root, "include"))
+	assert.Nil(t, YAMLMappingValue(YAMLMappingValue(root, "excluded-modules"), "stable"))
+	assert.Nil(t, YAMLMappingValue(nil, "module-sets"))
+}
@@ -0,0 +1,253 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+	"go.opentelemetry.io/build-tools/internal/repo"
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+)
+
+func Run(versioningFile, moduleSetName, head string) {
+	repoRoot, err := repo.FindRoot()
+	if err != nil {
+		logging.Fatalf("unable to find repo root: %v", err)
+	}
+	repoRoot, err = filepath.Abs(repoRoot)
+	if err != nil {
+		logging.Fatalf("could not get absolute path of repo root: %v", err)
+	}
+
+	modRelease, err := common.NewModuleSetRelease(versioningFile, moduleSetName, repoRoot)
+	if err != nil {
+		logging.Fatalf("Error creating new module set release struct: %v", err)
+	}
+
+	gitRepo, err := common.OpenRepo(repoRoot)
+	if err != nil {
+		logging.Fatalf("could not open repo at %v: %v", repoRoot, err)
+	}
+
+	headHash, err := gitRepo.ResolveRevision(plumbing.Revision(head))
+	if err != nil {
+		logging.Fatalf("could not resolve %v: %v", head, err)
+	}
+
+	modDirs, err := moduleDirs(repoRoot)
+	if err != nil {
+		logging.Fatalf("could not find modules: %v", err)
+	}
+
+	r, err := newReport(gitRepo, modRelease, *headHash, modDirs)
+	if err != nil {
+		logging.Fatalf("could not diff module set %v: %v", moduleSetName, err)
+	}
+
+	if err = r.write(os.Stdout); err != nil {
+		logging.Fatalf("could not write report: %v", err)
+	}
+}
+
+// Changed returns whether a module of modRelease was never tagged, or has
+// files changed between its latest version tag and the head commit.
+func Changed(gitRepo *git.Repository, modRelease common.ModuleSetRelease, repoRoot string, head plumbing.Hash) (bool, error) {
+	modDirs, err := moduleDirs(repoRoot)
+	if err != nil {
+		return false, fmt.Errorf("could not find modules: %w", err)
+	}
+	r, err := newReport(gitRepo, modRelease, head, modDirs)
+	if err != nil {
+		return false, err
+	}
+	return r.Changed, nil
+}
+
+// report describes the modules of a module set changed since they were last
+// tagged.
+type report struct {
+	ModuleSet string `json:"module_set"`
+	Version   string `json:"version"`
+	Head      string `json:"head"`
+	// Changed is true if any module of the module set changed.
+	Changed bool           `json:"changed"`
+	Modules []moduleReport `json:"modules"`
+}
+
+type moduleReport struct {
+	Path string `json:"path"`
+	// LastTag is empty if the module was never tagged.
+	LastTag string `json:"last_tag,omitempty"`
+	// Changed is true if the module was never tagged or files of the module
+	// changed since LastTag.
+	Changed bool     `json:"changed"`
+	Files   []string `json:"files,omitempty"`
+}
+
+// moduleDirs returns the directories, relative to repoRoot and slash
+// separated, of all modules in the repo. The directory of the root module is
+// empty.
+func moduleDirs(repoRoot string) ([]string, error) {
+	modPathMap, err := common.FindModules(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := make([]string, 0, len(modPathMap))
+	for _, modFilePath := range modPathMap {
+		dir, err := filepath.Rel(repoRoot, filepath.Dir(string(modFilePath)))
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, moduleDir(filepath.ToSlash(dir)))
+	}
+	return dirs, nil
+}
+
+// moduleDir returns the directory of the module with tag name modTagName.
+func moduleDir(modTagName string) string {
+	if modTagName == "." || modTagName == string(common.RepoRootTag) {
+		return ""
+	}
+	return modTagName
+}
+
+// owner returns the directory of the module containing the file at path,
+// the module with the longest directory that is a parent of the file.
+func owner(path string, modDirs []string) string {
+	var found string
+	for _, dir := range modDirs {
+		if dir != "" && !strings.HasPrefix(path, dir+"/") {
+			continue
+		}
+		if len(dir) >= len(found) {
+			found = dir
+		}
+	}
+	return found
+}
+
+// newReport returns the report of the modules of modRelease changed between
+// their latest version tag and the head commit.
+func newReport(gitRepo *git.Repository, modRelease common.ModuleSetRelease, head plumbing.Hash, modDirs []string) (report, error) {
+	tagIndex, err := common.NewTagIndex(gitRepo)
+	if err != nil {
+		return report{}, err
+	}
+
+	headCommit, err := gitRepo.CommitObject(head)
+	if err != nil {
+		return report{}, fmt.Errorf("could not get commit %v: %w", head, err)
+	}
+
+	r := report{
+		ModuleSet: modRelease.ModSetName,
+		Version:   modRelease.ModSetVersion(),
+		Head:      head.String(),
+	}
+
+	// Modules are often tagged on the same commit, compute each diff once.
+	changedFiles := make(map[plumbing.Hash][]string)
+	for i, modPath := range modRelease.ModSet.Modules {
+		modTagName := modRelease.TagNames[i]
+		m := moduleReport{Path: string(modPath)}
+
+		lastTag, tagged := tagIndex.LatestVersion(modTagName)
+		if !tagged {
+			m.Changed = true
+			r.Changed = true
+			r.Modules = append(r.Modules, m)
+			continue
+		}
+		m.LastTag = lastTag
+
+		tagCommit, _, err := tagIndex.CommitHash(gitRepo, lastTag)
+		if err != nil {
+			return report{}, err
+		}
+		files, ok := changedFiles[tagCommit]
+		if !ok {
+			if files, err = diffFiles(gitRepo, tagCommit, headCommit); err != nil {
+				return report{}, err
+			}
+			changedFiles[tagCommit] = files
+		}
+
+		dir := moduleDir(string(modTagName))
+		for _, f := range files {
+			if owner(f, modDirs) == dir {
+				m.Files = append(m.Files, f)
+			}
+		}
+		m.Changed = len(m.Files) > 0
+		r.Changed = r.Changed || m.Changed
+		r.Modules = append(r.Modules, m)
+	}
+	return r, nil
+}
+
+// diffFiles returns the sorted paths of the files changed between the commit
+// with hash from and the commit to.
+func diffFiles(gitRepo *git.Repository, from plumbing.Hash, to *object.Commit) ([]string, error) {
+	fromCommit, err := gitRepo.CommitObject(from)
+	if err != nil {
+		return nil, fmt.Errorf("could not get commit %v: %w", from, err)
+	}
+	fromTree, err := fromCommit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("could not get tree of commit %v: %w", from, err)
+	}
+	toTree, err := to.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("could not get tree of commit %v: %w", to.Hash, err)
+	}
+
+	changes, err := object.DiffTree(fromTree, toTree)
+	if err != nil {
+		return nil, fmt.Errorf("could not diff commits %v and %v: %w", from, to.Hash, err)
+	}
+
+	seen := make(map[string]struct{})
+	var files []string
+	for _, c := range changes {
+		for _, name := range []string{c.From.Name, c.To.Name} {
+			if _, ok := seen[name]; ok || name == "" {
+				continue
+			}
+			seen[name] = struct{}{}
+			files = append(files, name)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// write writes r as indented JSON to w.
+func (r report) write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
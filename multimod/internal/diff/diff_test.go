@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+	"go.opentelemetry.io/build-tools/multimod/internal/common/commontest"
+	"go.opentelemetry.io/build-tools/multimod/releasetest"
+)
+
+var (
+	testDataDir, _ = filepath.Abs("./test_data")
+)
+
+// TestMain performs setup for the tests and suppress printing logs.
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func TestNewReport(t *testing.T) {
+	tmpRootDir := t.TempDir()
+	repo, _, err := commontest.InitNewRepoWithCommit(tmpRootDir)
+	require.NoError(t, err)
+
+	tagged, err := releasetest.CommitFiles(repo, map[string][]byte{
+		"go.mod":     []byte("module go.opentelemetry.io/test\n\ngo 1.18\n"),
+		"a/go.mod":   []byte("module go.opentelemetry.io/test/a\n\ngo 1.18\n"),
+		"a/a.go":     []byte("package a\n"),
+		"a/b/go.mod": []byte("module go.opentelemetry.io/test/a/b\n\ngo 1.18\n"),
+		"a/b/b.go":   []byte("package b\n"),
+		"c/go.mod":   []byte("module go.opentelemetry.io/test/c\n\ngo 1.18\n"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, releasetest.CreateTags(repo, tagged, "a/v1.0.0", "a/b/v1.0.0", "v1.0.0"))
+
+	// a is changed on a commit after it was tagged again.
+	aTagged, err := releasetest.CommitFiles(repo, map[string][]byte{"a/a.go": []byte("package a\n\n// A is new.\nconst A = 1\n")})
+	require.NoError(t, err)
+	require.NoError(t, releasetest.CreateTags(repo, aTagged, "a/v1.0.1"))
+
+	head, err := releasetest.CommitFiles(repo, map[string][]byte{
+		"a/b/b.go":  []byte("package b\n\n// B is new.\nconst B = 1\n"),
+		"a/b/c.go":  []byte("package b\n"),
+		"README.md": []byte("# Test\n"),
+	})
+	require.NoError(t, err)
+
+	modDirs, err := moduleDirs(tmpRootDir)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"", "a", "a/b", "c"}, modDirs)
+
+	versioningFilename := filepath.Join(testDataDir, "versions_valid.yaml")
+
+	modRelease, err := common.NewModuleSetRelease(versioningFilename, "mod-set-1", tmpRootDir)
+	require.NoError(t, err)
+	r, err := newReport(repo, modRelease, head, modDirs)
+	require.NoError(t, err)
+	assert.Equal(t, report{
+		ModuleSet: "mod-set-1",
+		Version:   "v1.1.0",
+		Head:      head.String(),
+		Changed:   true,
+		Modules: []moduleReport{
+			{Path: "go.opentelemetry.io/test/a", LastTag: "a/v1.0.1"},
+			{
+				Path:    "go.opentelemetry.io/test/a/b",
+				LastTag: "a/b/v1.0.0",
+				Changed: true,
+				Files:   []string{"a/b/b.go", "a/b/c.go"},
+			},
+			{Path: "go.opentelemetry.io/test/c", Changed: true},
+		},
+	}, r)
+
+	modRelease, err = common.NewModuleSetRelease(versioningFilename, "mod-set-2", tmpRootDir)
+	require.NoError(t, err)
+	r, err = newReport(repo, modRelease, aTagged, modDirs)
+	require.NoError(t, err)
+	assert.Equal(t, report{
+		ModuleSet: "mod-set-2",
+		Version:   "v1.1.0",
+		Head:      aTagged.String(),
+		Modules:   []moduleReport{{Path: "go.opentelemetry.io/test", LastTag: "v1.0.0"}},
+	}, r)
+
+	r, err = newReport(repo, modRelease, head, modDirs)
+	require.NoError(t, err)
+	assert.True(t, r.Changed)
+	assert.Equal(t, []string{"README.md"}, r.Modules[0].Files)
+
+	var b bytes.Buffer
+	require.NoError(t, r.write(&b))
+	var decoded report
+	require.NoError(t, json.Unmarshal(b.Bytes(), &decoded))
+	assert.Equal(t, r, decoded)
+	assert.Contains(t, b.String(), `"last_tag": "v1.0.0"`)
+
+	changed, err := Changed(repo, modRelease, tmpRootDir, aTagged)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	changed, err = Changed(repo, modRelease, tmpRootDir, head)
+	require.NoError(t, err)
+	assert.True(t, changed)
+}
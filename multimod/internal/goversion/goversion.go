@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goversion
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+	"go.opentelemetry.io/build-tools/internal/repo"
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+)
+
+func Run(versioningFile, goVersion, toolchain string, include, exclude []string, verify bool) {
+	want := common.GoDirectives{Go: goVersion, Toolchain: toolchain}
+	if err := checkDirectives(want); err != nil {
+		logging.Fatalf("%v", err)
+	}
+
+	repoRoot, err := repo.FindRoot()
+	if err != nil {
+		logging.Fatalf("unable to find repo root: %v", err)
+	}
+	repoRoot, err = filepath.Abs(repoRoot)
+	if err != nil {
+		logging.Fatalf("could not get absolute path of repo root: %v", err)
+	}
+
+	modVersioning, err := common.NewModuleVersioning(versioningFile, repoRoot)
+	if err != nil {
+		logging.Fatalf("unable to load versioning file: %v", err)
+	}
+
+	modFilePaths := selectModules(modVersioning.ModPathMap, include, exclude)
+	if len(modFilePaths) == 0 {
+		logging.Fatalf("no module matches the include and exclude patterns")
+	}
+
+	changed, err := common.SetGoDirectives(modFilePaths, want, verify)
+	if err != nil {
+		logging.Fatalf("could not set go directives: %v", err)
+	}
+
+	if verify {
+		if len(changed) == 0 {
+			logging.Infof("The go directives of all %d modules are up to date.", len(modFilePaths))
+			return
+		}
+		writeFiles(os.Stdout, repoRoot, changed)
+		logging.Fatalf("The go directives of %d of %d modules are not up to date, run goversion without --verify to update them.", len(changed), len(modFilePaths))
+	}
+
+	for _, modFilePath := range changed {
+		logging.Infof("Updated %v", relPath(repoRoot, modFilePath))
+	}
+	logging.Infof("Updated %d of %d modules.", len(changed), len(modFilePaths))
+}
+
+// checkDirectives returns an error if want has no go version, or one of its
+// versions is not a valid Go version.
+func checkDirectives(want common.GoDirectives) error {
+	if !common.IsGoVersion(want.Go) {
+		return fmt.Errorf("invalid go version %q, must be a Go version such as 1.21 or 1.21.3", want.Go)
+	}
+	if want.Toolchain == "" {
+		return nil
+	}
+	if !strings.HasPrefix(want.Toolchain, "go") || !common.IsGoVersion(strings.TrimPrefix(want.Toolchain, "go")) {
+		return fmt.Errorf("invalid toolchain %q, must be a toolchain name such as go1.21.3", want.Toolchain)
+	}
+	if common.CompareGoVersions(strings.TrimPrefix(want.Toolchain, "go"), want.Go) < 0 {
+		return fmt.Errorf("toolchain %v is lower than go version %v", want.Toolchain, want.Go)
+	}
+	return nil
+}
+
+// matchModule returns whether the module path modPath matches pattern, as
+// matched by path.Match. A pattern ending in /... also matches the modules
+// below it.
+func matchModule(pattern string, modPath common.ModulePath) bool {
+	if prefix := strings.TrimSuffix(pattern, "/..."); prefix != pattern {
+		if strings.HasPrefix(string(modPath), prefix+"/") {
+			return true
+		}
+		pattern = prefix
+	}
+	ok, _ := path.Match(pattern, string(modPath))
+	return ok
+}
+
+func matchAny(patterns []string, modPath common.ModulePath) bool {
+	for _, p := range patterns {
+		if matchModule(p, modPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectModules returns the go.mod files of the modules in modPathMap
+// matching one of the include patterns, or all of them if there are none,
+// and none of the exclude patterns, sorted by path.
+func selectModules(modPathMap common.ModulePathMap, include, exclude []string) []common.ModuleFilePath {
+	var modFilePaths []common.ModuleFilePath
+	for modPath, modFilePath := range modPathMap {
+		if len(include) > 0 && !matchAny(include, modPath) {
+			continue
+		}
+		if matchAny(exclude, modPath) {
+			continue
+		}
+		modFilePaths = append(modFilePaths, modFilePath)
+	}
+	sort.Slice(modFilePaths, func(i, j int) bool { return modFilePaths[i] < modFilePaths[j] })
+	return modFilePaths
+}
+
+func relPath(repoRoot string, modFilePath common.ModuleFilePath) string {
+	rel, err := filepath.Rel(repoRoot, string(modFilePath))
+	if err != nil {
+		return string(modFilePath)
+	}
+	return rel
+}
+
+// writeFiles writes the go.mod files relative to repoRoot to w, one per line.
+func writeFiles(w io.Writer, repoRoot string, modFilePaths []common.ModuleFilePath) {
+	for _, modFilePath := range modFilePaths {
+		fmt.Fprintln(w, relPath(repoRoot, modFilePath))
+	}
+}
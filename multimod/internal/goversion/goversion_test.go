@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goversion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+)
+
+func TestCheckDirectives(t *testing.T) {
+	for _, tc := range []struct {
+		want    common.GoDirectives
+		wantErr string
+	}{
+		{want: common.GoDirectives{Go: "1.21"}},
+		{want: common.GoDirectives{Go: "1.21.0", Toolchain: "go1.21.3"}},
+		{want: common.GoDirectives{Go: "1.22rc1"}},
+		{want: common.GoDirectives{}, wantErr: `invalid go version ""`},
+		{want: common.GoDirectives{Go: "go1.21"}, wantErr: `invalid go version "go1.21"`},
+		{want: common.GoDirectives{Go: "1.21", Toolchain: "1.21.3"}, wantErr: `invalid toolchain "1.21.3"`},
+		{want: common.GoDirectives{Go: "1.21", Toolchain: "go1.20.5"}, wantErr: "toolchain go1.20.5 is lower than go version 1.21"},
+	} {
+		err := checkDirectives(tc.want)
+		if tc.wantErr == "" {
+			assert.NoError(t, err, "%+v", tc.want)
+		} else {
+			assert.ErrorContains(t, err, tc.wantErr)
+		}
+	}
+}
+
+func TestMatchModule(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		modPath common.ModulePath
+		want    bool
+	}{
+		{pattern: "go.opentelemetry.io/otel", modPath: "go.opentelemetry.io/otel", want: true},
+		{pattern: "go.opentelemetry.io/otel", modPath: "go.opentelemetry.io/otel/trace", want: false},
+		{pattern: "go.opentelemetry.io/otel/...", modPath: "go.opentelemetry.io/otel", want: true},
+		{pattern: "go.opentelemetry.io/otel/...", modPath: "go.opentelemetry.io/otel/exporters/otlp", want: true},
+		{pattern: "go.opentelemetry.io/otel/...", modPath: "go.opentelemetry.io/otelfoo", want: false},
+		{pattern: "go.opentelemetry.io/otel/exporters/*", modPath: "go.opentelemetry.io/otel/exporters/zipkin", want: true},
+		{pattern: "go.opentelemetry.io/otel/exporters/*", modPath: "go.opentelemetry.io/otel/exporters/otlp/otlptrace", want: false},
+	} {
+		assert.Equal(t, tc.want, matchModule(tc.pattern, tc.modPath), "%v %v", tc.pattern, tc.modPath)
+	}
+}
+
+func TestSelectModules(t *testing.T) {
+	modPathMap := common.ModulePathMap{
+		"example.com/root":               "/repo/go.mod",
+		"example.com/root/a":             "/repo/a/go.mod",
+		"example.com/root/b":             "/repo/b/go.mod",
+		"example.com/root/tools":         "/repo/tools/go.mod",
+		"example.com/root/internal/test": "/repo/internal/test/go.mod",
+	}
+
+	assert.Equal(t, []common.ModuleFilePath{
+		"/repo/a/go.mod",
+		"/repo/b/go.mod",
+		"/repo/go.mod",
+		"/repo/internal/test/go.mod",
+		"/repo/tools/go.mod",
+	}, selectModules(modPathMap, nil, nil))
+
+	assert.Equal(t, []common.ModuleFilePath{
+		"/repo/a/go.mod",
+		"/repo/b/go.mod",
+		"/repo/go.mod",
+	}, selectModules(modPathMap, nil, []string{"example.com/root/tools", "example.com/root/internal/..."}))
+
+	assert.Equal(t, []common.ModuleFilePath{
+		"/repo/b/go.mod",
+	}, selectModules(modPathMap, []string{"example.com/root/?"}, []string{"example.com/root/a"}))
+}
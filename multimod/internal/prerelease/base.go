@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prerelease
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+)
+
+// splitRemoteRef returns the remote and branch of baseRef if it names a
+// remote-tracking branch of repo, e.g. origin/main, or an empty remote
+// otherwise.
+func splitRemoteRef(repo *git.Repository, baseRef string) (remote, branch string, err error) {
+	ref := strings.TrimPrefix(baseRef, "refs/remotes/")
+	remotes, err := repo.Remotes()
+	if err != nil {
+		return "", "", fmt.Errorf("could not list remotes: %w", err)
+	}
+	for _, r := range remotes {
+		name := r.Config().Name
+		if strings.HasPrefix(ref, name+"/") {
+			return name, strings.TrimPrefix(ref, name+"/"), nil
+		}
+	}
+	return "", "", nil
+}
+
+// fetchRemote fetches remote so that its remote-tracking branches are up to
+// date.
+func fetchRemote(ctx context.Context, repo *git.Repository, remote string) error {
+	auth, err := common.RepoRemoteAuth(repo, remote)
+	if err != nil {
+		return fmt.Errorf("unable to get credentials for remote %s: %w", remote, err)
+	}
+	logging.Infof("Fetching %v...", remote)
+	err = repo.FetchContext(ctx, &git.FetchOptions{RemoteName: remote, Auth: auth})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("error fetching %s: %w", remote, common.ClassifyRemoteError(err))
+	}
+	return nil
+}
+
+// resolveBaseRef fetches the remote of baseRef if it is a remote-tracking
+// branch, and returns the commit it resolves to. An error is returned if the
+// local branch of the same name, e.g. main for origin/main, has commits that
+// are not on baseRef, as releasing from it would leave them out.
+func resolveBaseRef(ctx context.Context, repo *git.Repository, baseRef string) (plumbing.Hash, error) {
+	remote, branch, err := splitRemoteRef(repo, baseRef)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if remote != "" {
+		if err = fetchRemote(ctx, repo, remote); err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+
+	baseHash, err := repo.ResolveRevision(plumbing.Revision(baseRef))
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("could not resolve base ref %v: %w", baseRef, err)
+	}
+	if remote == "" {
+		return *baseHash, nil
+	}
+
+	local, err := repo.Reference(plumbing.NewBranchReferenceName(branch), true)
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return *baseHash, nil
+	}
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("could not get local branch %v: %w", branch, err)
+	}
+
+	localCommit, err := repo.CommitObject(local.Hash())
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("could not get head commit of local branch %v: %w", branch, err)
+	}
+	baseCommit, err := repo.CommitObject(*baseHash)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("could not get commit of base ref %v: %w", baseRef, err)
+	}
+	ok, err := localCommit.IsAncestor(baseCommit)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("could not check if local branch %v is behind %v: %w", branch, baseRef, err)
+	}
+	if !ok {
+		return plumbing.ZeroHash, fmt.Errorf("local branch %v has diverged from %v, push or reset its commits before releasing", branch, baseRef)
+	}
+	return *baseHash, nil
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prerelease
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+	"go.opentelemetry.io/build-tools/multimod/internal/common/commontest"
+)
+
+// commitFile writes a file named name in the working tree of repo and
+// commits it to the checked out branch.
+func commitFile(t *testing.T, repo *git.Repository, name string) plumbing.Hash {
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(worktree.Filesystem.Root(), name), []byte(name), 0600))
+	_, err = worktree.Add(name)
+	require.NoError(t, err)
+	hash, err := common.CommitChanges(context.Background(), "add "+name, repo, commontest.TestAuthor, nil)
+	require.NoError(t, err)
+	return hash
+}
+
+func TestResolveBaseRef(t *testing.T) {
+	upstreamDir := t.TempDir()
+	upstream, _, err := commontest.InitNewRepoWithCommit(upstreamDir)
+	require.NoError(t, err)
+	upstreamHead, err := upstream.Head()
+	require.NoError(t, err)
+	branch := upstreamHead.Name().Short()
+
+	local, err := git.PlainClone(t.TempDir(), false, &git.CloneOptions{URL: upstreamDir})
+	require.NoError(t, err)
+
+	// The base ref is fetched before being resolved.
+	newUpstreamHash := commitFile(t, upstream, "upstream.txt")
+	hash, err := resolveBaseRef(context.Background(), local, "origin/"+branch)
+	require.NoError(t, err)
+	assert.Equal(t, newUpstreamHash, hash)
+
+	// A local branch behind the base ref is fine, one with its own commits
+	// is not.
+	localHash := commitFile(t, local, "local.txt")
+	_, err = resolveBaseRef(context.Background(), local, "origin/"+branch)
+	assert.ErrorContains(t, err, "local branch "+branch+" has diverged from origin/"+branch)
+
+	// Local revisions are resolved as is.
+	hash, err = resolveBaseRef(context.Background(), local, branch)
+	require.NoError(t, err)
+	assert.Equal(t, localHash, hash)
+
+	_, err = resolveBaseRef(context.Background(), local, "origin/unknown")
+	assert.ErrorContains(t, err, "could not resolve base ref origin/unknown")
+}
+
+func TestRunBaseRefRestoresHead(t *testing.T) {
+	dir := t.TempDir()
+	repo, baseHash, err := commontest.InitNewRepoWithCommit(dir)
+	require.NoError(t, err)
+	// The versioning file is missing at the base ref, so reading it fails
+	// once the base ref is checked out.
+	versioningFile := filepath.Join(dir, "versions.yaml")
+	require.NoError(t, os.WriteFile(versioningFile, []byte("module-sets:\n"), 0600))
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = worktree.Add("versions.yaml")
+	require.NoError(t, err)
+	_, err = common.CommitChanges(context.Background(), "add versions.yaml", repo, commontest.TestAuthor, nil)
+	require.NoError(t, err)
+	origHead, err := repo.Head()
+	require.NoError(t, err)
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { require.NoError(t, os.Chdir(wd)) })
+
+	err = run(context.Background(), Options{
+		VersioningFile:          versioningFile,
+		ModuleSetNames:          []string{"mod-set-1"},
+		CommitToDifferentBranch: true,
+		BaseRef:                 baseHash.String(),
+	})
+	assert.ErrorContains(t, err, "could not read prerelease hooks")
+
+	head, err := repo.Head()
+	require.NoError(t, err)
+	assert.Equal(t, origHead.Name(), head.Name())
+	assert.Equal(t, origHead.Hash(), head.Hash())
+	assert.FileExists(t, versioningFile)
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prerelease
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+)
+
+// changelogFile is the changelog, at the repository root, rolled up by
+// prerelease with --update-changelog.
+const changelogFile = "CHANGELOG.md"
+
+var (
+	errNoUnreleasedSection = errors.New("no Unreleased section found")
+
+	unreleasedHeadingRegexp = regexp.MustCompile(`(?m)^## \[?Unreleased\]?[ \t]*\r?\n`)
+	sectionHeadingRegexp    = regexp.MustCompile(`(?m)^## `)
+)
+
+// updateChangelog moves the changes of the Unreleased section of the
+// changelog at path under a new heading for version, released on date. The
+// Unreleased heading is kept, with an empty section, for the next changes.
+// The changelog is not modified if the Unreleased section is empty, e.g.
+// because it was already rolled up for another module set.
+func updateChangelog(path, version string, date time.Time) error {
+	changelog, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+
+	loc := unreleasedHeadingRegexp.FindIndex(changelog)
+	if loc == nil {
+		return fmt.Errorf("%w in %v", errNoUnreleasedSection, path)
+	}
+
+	section := changelog[loc[1]:]
+	if next := sectionHeadingRegexp.FindIndex(section); next != nil {
+		section = section[:next[0]]
+	}
+	if len(bytes.TrimSpace(section)) == 0 {
+		logging.Infof("Unreleased section of %v is empty, not updating it", path)
+		return nil
+	}
+
+	heading := fmt.Sprintf("## [%s] %s\n", strings.TrimPrefix(version, "v"), date.Format("2006-01-02"))
+
+	var updated []byte
+	updated = append(updated, changelog[:loc[1]]...)
+	updated = append(updated, '\n')
+	updated = append(updated, heading...)
+	updated = append(updated, changelog[loc[1]:]...)
+
+	if err := os.WriteFile(path, updated, 0600); err != nil {
+		return fmt.Errorf("error overwriting changelog: %w", err)
+	}
+	logging.Infof("Moved Unreleased changes of %v under version %v", path, version)
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prerelease
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateChangelog(t *testing.T) {
+	date := time.Date(2022, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		changelog string
+		expected  string
+		expectErr error
+	}{
+		{
+			name: "rollup",
+			changelog: "# Changelog\n\n## [Unreleased]\n\n### Added\n\n- New feature.\n\n" +
+				"## [1.0.0] 2022-01-01\n\n### Added\n\n- First release.\n",
+			expected: "# Changelog\n\n## [Unreleased]\n\n## [1.1.0] 2022-10-19\n\n### Added\n\n- New feature.\n\n" +
+				"## [1.0.0] 2022-01-01\n\n### Added\n\n- First release.\n",
+		},
+		{
+			name:      "heading_without_brackets",
+			changelog: "## Unreleased\n\n- New feature.\n",
+			expected:  "## Unreleased\n\n## [1.1.0] 2022-10-19\n\n- New feature.\n",
+		},
+		{
+			name:      "empty_unreleased",
+			changelog: "## [Unreleased]\n\n## [1.0.0] 2022-01-01\n\n- First release.\n",
+			expected:  "## [Unreleased]\n\n## [1.0.0] 2022-01-01\n\n- First release.\n",
+		},
+		{
+			name:      "no_unreleased",
+			changelog: "## [1.0.0] 2022-01-01\n\n- First release.\n",
+			expected:  "## [1.0.0] 2022-01-01\n\n- First release.\n",
+			expectErr: errNoUnreleasedSection,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), changelogFile)
+			require.NoError(t, os.WriteFile(path, []byte(tc.changelog), 0600))
+
+			err := updateChangelog(path, "v1.1.0", date)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			actual, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(actual))
+		})
+	}
+}
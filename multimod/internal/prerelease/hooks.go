@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prerelease
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+)
+
+// Environment variables describing the module set being released to hooks.
+const (
+	hookModuleSetEnv = "MULTIMOD_MODULE_SET"
+	hookVersionEnv   = "MULTIMOD_VERSION"
+)
+
+// runHooks runs hooks in order in dir for the release of msr. A failing hook
+// stops the run unless it continues on error.
+func runHooks(ctx context.Context, hooks []common.Hook, dir string, msr common.ModuleSetRelease) error {
+	env := append(os.Environ(),
+		hookModuleSetEnv+"="+msr.ModSetName,
+		hookVersionEnv+"="+msr.ModSetVersion(),
+	)
+
+	for _, hook := range hooks {
+		name := hook.Name
+		if name == "" {
+			name = strings.Join(hook.Command, " ")
+		}
+		logging.Infof("Running prerelease hook %v...", name)
+
+		// #nosec G204 -- hooks are configured in the versioning file.
+		cmd := exec.CommandContext(ctx, hook.Command[0], hook.Command[1:]...)
+		cmd.Dir = dir
+		cmd.Env = env
+		output, err := cmd.CombinedOutput()
+		logging.Debugf("%s", output)
+		if err == nil {
+			continue
+		}
+
+		err = fmt.Errorf("prerelease hook %v failed: %q: %w", name, string(output), err)
+		if hook.ContinueOnError && ctx.Err() == nil {
+			logging.Warnf("%v", err)
+			continue
+		}
+		return err
+	}
+	return nil
+}
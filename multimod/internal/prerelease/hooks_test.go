@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prerelease
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+)
+
+const hookHelperEnv = "MULTIMOD_TEST_HOOK_HELPER"
+
+// TestHookHelperProcess is not a test, it is run by the hooks of the tests
+// below. It writes the module set and version it is run for to a file named
+// after its argument in the working directory, and fails if asked to.
+func TestHookHelperProcess(t *testing.T) {
+	if os.Getenv(hookHelperEnv) == "" {
+		t.Skip("only run as a hook")
+	}
+	args := os.Args
+	for i, arg := range args {
+		if arg == "--" {
+			args = args[i+1:]
+			break
+		}
+	}
+	content := os.Getenv(hookModuleSetEnv) + " " + os.Getenv(hookVersionEnv)
+	if err := os.WriteFile(args[0], []byte(content), 0600); err != nil {
+		os.Exit(2)
+	}
+	if len(args) > 1 && args[1] == "fail" {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func helperHook(name string, continueOnError bool, args ...string) common.Hook {
+	return common.Hook{
+		Name:            name,
+		Command:         append([]string{os.Args[0], "-test.run=^TestHookHelperProcess$", "--"}, args...),
+		ContinueOnError: continueOnError,
+	}
+}
+
+func TestRunHooks(t *testing.T) {
+	t.Setenv(hookHelperEnv, "1")
+
+	versioningFilename := filepath.Join(testDataDir, "new_prerelease", "versions_valid.yaml")
+	repoRoot := t.TempDir()
+	for _, mod := range []string{"go.mod", "test/go.mod", "test/test1/go.mod"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(repoRoot, filepath.Dir(mod)), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(repoRoot, mod), []byte("module go.opentelemetry.io/"+filepath.Dir(mod)+"\n"), 0600))
+	}
+	msr, err := common.NewModuleSetRelease(versioningFilename, "mod-set-1", repoRoot)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		hooks      []common.Hook
+		shouldRun  []string
+		shouldSkip []string
+		wantErr    bool
+	}{
+		{
+			name:      "success",
+			hooks:     []common.Hook{helperHook("first", false, "first"), helperHook("second", false, "second")},
+			shouldRun: []string{"first", "second"},
+		},
+		{
+			name:       "failure",
+			hooks:      []common.Hook{helperHook("first", false, "first", "fail"), helperHook("second", false, "second")},
+			shouldRun:  []string{"first"},
+			shouldSkip: []string{"second"},
+			wantErr:    true,
+		},
+		{
+			name:      "continue on error",
+			hooks:     []common.Hook{helperHook("first", true, "first", "fail"), helperHook("second", false, "second")},
+			shouldRun: []string{"first", "second"},
+		},
+		{
+			name:    "missing executable",
+			hooks:   []common.Hook{{Name: "missing", Command: []string{filepath.Join(repoRoot, "missing")}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			err := runHooks(context.Background(), tc.hooks, dir, msr)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			for _, name := range tc.shouldRun {
+				content, err := os.ReadFile(filepath.Join(dir, name))
+				require.NoError(t, err, name)
+				assert.Equal(t, "mod-set-1 "+msr.ModSetVersion(), string(content))
+			}
+			for _, name := range tc.shouldSkip {
+				_, err := os.Stat(filepath.Join(dir, name))
+				assert.ErrorIs(t, err, os.ErrNotExist, name)
+			}
+		})
+	}
+}
@@ -15,88 +15,244 @@
 package prerelease
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"go.uber.org/multierr"
 
+	"go.opentelemetry.io/build-tools/internal/logging"
 	"go.opentelemetry.io/build-tools/internal/repo"
 	"go.opentelemetry.io/build-tools/multimod/internal/common"
+	"go.opentelemetry.io/build-tools/multimod/internal/diff"
 )
 
-func Run(versioningFile string, moduleSetNames []string, allModuleSets bool, skipModTidy bool, commitToDifferentBranch bool) {
+// Options configure Run.
+type Options struct {
+	// VersioningFile is the path of the versioning file.
+	VersioningFile string
+	// ModuleSetNames are the names of the module sets to update.
+	ModuleSetNames []string
+	// AllModuleSets updates all the module sets of the versioning file
+	// instead of ModuleSetNames, skipping frozen module sets.
+	AllModuleSets bool
+	// SkipUnchanged skips module sets with no file changed since their
+	// latest tags.
+	SkipUnchanged bool
+	// SkipModTidy skips running go mod tidy in the updated modules.
+	SkipModTidy bool
+	// CommitToDifferentBranch commits the changes of each module set to a new
+	// prerelease branch.
+	CommitToDifferentBranch bool
+	// Unfreeze updates module sets even if they are frozen.
+	Unfreeze bool
+	// UpdateChangelog moves the Unreleased section of CHANGELOG.md under the
+	// version of the module set.
+	UpdateChangelog bool
+	// SignKeyPath is the path of the key signing the commits. If empty, the
+	// key in the environment is used, if any.
+	SignKeyPath string
+	// BaseRef is the revision the prerelease branches are created from
+	// instead of HEAD, if not empty. It requires CommitToDifferentBranch.
+	BaseRef string
+}
+
+// Run updates the versions of the module sets of opts and commits the
+// changes.
+func Run(ctx context.Context, opts Options) {
+	if err := run(ctx, opts); err != nil {
+		logging.Fatalf("%v", err)
+	}
+
+	logging.Infof(`=========
+Prerelease finished successfully. Now checkout the new branch(es) and verify the changes.
+
+Then, if necessary, commit changes and push to upstream/make a pull request.`)
+}
+
+// run updates the versions of the module sets of opts and commits the
+// changes. If opts.BaseRef is set, the original branch is checked out again
+// before returning, discarding the changes left in the working tree if the
+// run failed.
+func run(ctx context.Context, opts Options) (err error) {
+	moduleSetNames := opts.ModuleSetNames
 	repoRoot, err := repo.FindRoot()
 	if err != nil {
-		log.Fatalf("unable to find repo root: %v", err)
+		return fmt.Errorf("unable to find repo root: %w", err)
 	}
-	log.Printf("Using repo with root at %s\n\n", repoRoot)
+	logging.Infof("Using repo with root at %s", repoRoot)
 
-	if allModuleSets {
-		moduleSetNames, err = common.GetAllModuleSetNames(versioningFile, repoRoot)
+	if opts.AllModuleSets {
+		moduleSetNames, err = common.GetAllModuleSetNames(opts.VersioningFile, repoRoot)
 		if err != nil {
-			log.Fatalf("could not automatically get all module set names: %v", err)
+			return fmt.Errorf("could not automatically get all module set names: %w", err)
 		}
 	}
 
-	repo, err := git.PlainOpen(repoRoot)
+	repo, err := common.OpenRepo(repoRoot)
 	if err != nil {
-		log.Fatalf("could not open repo at %v: %v", repoRoot, err)
+		return fmt.Errorf("could not open repo at %v: %w", repoRoot, err)
 	}
 
 	if err = common.VerifyWorkingTreeClean(repo); err != nil {
-		log.Fatalf("VerifyWorkingTreeClean failed: %v", err)
+		return fmt.Errorf("VerifyWorkingTreeClean failed: %w", err)
+	}
+
+	if opts.BaseRef != "" {
+		if !opts.CommitToDifferentBranch {
+			return errors.New("a base ref requires committing to a different branch")
+		}
+		var origHead *plumbing.Reference
+		if origHead, err = repo.Head(); err != nil {
+			return fmt.Errorf("could not get HEAD: %w", err)
+		}
+		var baseHash plumbing.Hash
+		if baseHash, err = resolveBaseRef(ctx, repo, opts.BaseRef); err != nil {
+			return err
+		}
+		// The module sets are read from, and their branches created from,
+		// the base ref. The original branch is checked out again once done.
+		logging.Infof("Creating prerelease branches from %v (%v)", opts.BaseRef, baseHash)
+		if err = common.CheckoutHead(plumbing.NewHashReference(plumbing.HEAD, baseHash), repo); err != nil {
+			return err
+		}
+		defer func() {
+			err = multierr.Append(err, restoreHead(repo, origHead, err != nil))
+		}()
+	}
+
+	signer, err := common.NewCommitSigner(opts.SignKeyPath)
+	if err != nil {
+		return fmt.Errorf("could not load commit signing key: %w", err)
+	}
+
+	hooks, err := common.ReadPrereleaseHooks(opts.VersioningFile)
+	if err != nil {
+		return fmt.Errorf("could not read prerelease hooks: %w", err)
+	}
+
+	// Changes are detected against the commit checked out before any module
+	// set is committed.
+	head, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf("could not get HEAD: %w", err)
 	}
 
 	for _, moduleSetName := range moduleSetNames {
-		p, err := newPrerelease(versioningFile, moduleSetName, repoRoot)
+		if err = ctx.Err(); err != nil {
+			return fmt.Errorf("interrupted before module set %v: %w", moduleSetName, err)
+		}
+
+		p, err := newPrerelease(opts.VersioningFile, moduleSetName, repoRoot)
 		if err != nil {
-			log.Fatalf("Error creating new prerelease struct: %v", err)
+			return err
 		}
 
-		log.Printf("===== Module Set: %v =====\n", moduleSetName)
+		logging.Infof("===== Module Set: %v =====", moduleSetName)
+
+		if err = p.CheckNotFrozen(opts.Unfreeze); err != nil {
+			if opts.AllModuleSets {
+				logging.Infof("Module set is frozen. Skipping...")
+				continue
+			}
+			return err
+		}
+		if p.ModSet.Frozen {
+			logging.Warnf("Module set %v is frozen, updating it anyway", moduleSetName)
+		}
 
 		modSetUpToDate, err := p.checkModuleSetUpToDate(repo)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		if modSetUpToDate {
-			log.Println("Module set already up to date (git tags already exist). Skipping...")
+			logging.Infof("Module set already up to date (git tags already exist). Skipping...")
 			continue
-		} else {
-			log.Println("Updating versions for module set...")
 		}
 
+		if opts.SkipUnchanged {
+			changed, err := diff.Changed(repo, p.ModuleSetRelease, repoRoot, head.Hash())
+			if err != nil {
+				return fmt.Errorf("could not diff module set %v: %w", moduleSetName, err)
+			}
+			if !changed {
+				logging.Infof("No files of the module set changed since its latest tags. Skipping...")
+				continue
+			}
+			logging.Infof("Files of the module set changed since its latest tags.")
+		}
+		logging.Infof("Updating versions for module set...")
+
 		if err = p.updateAllVersionGo(); err != nil {
-			log.Fatalf("updateAllVersionGo failed: %v", err)
+			return fmt.Errorf("updateAllVersionGo failed: %w", err)
 		}
 
 		if err = p.updateAllGoModFiles(); err != nil {
-			log.Fatalf("updateAllGoModFiles failed: %v", err)
+			return fmt.Errorf("updateAllGoModFiles failed: %w", err)
 		}
 
-		if skipModTidy {
-			log.Println("Skipping 'go mod tidy'...")
+		if opts.SkipModTidy {
+			logging.Infof("Skipping 'go mod tidy'...")
 		} else {
-			if err = common.RunGoModTidy(p.ModuleSetRelease.ModuleVersioning.ModPathMap); err != nil {
-				log.Fatal("could not run Go Mod Tidy: ", err)
+			if err = common.RunGoModTidy(ctx, p.ModuleSetRelease.ModuleVersioning.ModPathMap); err != nil {
+				discardIfInterrupted(ctx, repo)
+				return fmt.Errorf("could not run Go Mod Tidy: %w", err)
 			}
 		}
 
-		if err = commitChanges(p.ModuleSetRelease, commitToDifferentBranch, repo); err != nil {
-			log.Fatalf("commitChangesToNewBranch failed: %v", err)
+		if err = runHooks(ctx, hooks, repoRoot, p.ModuleSetRelease); err != nil {
+			discardIfInterrupted(ctx, repo)
+			return err
+		}
+
+		if opts.UpdateChangelog {
+			if err = updateChangelog(filepath.Join(repoRoot, changelogFile), p.ModuleSetRelease.ModSetVersion(), time.Now()); err != nil {
+				discardIfInterrupted(ctx, repo)
+				return fmt.Errorf("could not update %v: %w", changelogFile, err)
+			}
+		}
+
+		if err = commitChanges(ctx, p.ModuleSetRelease, opts.CommitToDifferentBranch, repo, signer); err != nil {
+			discardIfInterrupted(ctx, repo)
+			return fmt.Errorf("commitChangesToNewBranch failed: %w", err)
 		}
 	}
 
-	log.Println(`=========
-Prerelease finished successfully. Now checkout the new branch(es) and verify the changes.
+	return nil
+}
 
-Then, if necessary, commit changes and push to upstream/make a pull request.`)
+// restoreHead checks out origHead again, after discarding the changes left in
+// the working tree if discard is set.
+func restoreHead(repo *git.Repository, origHead *plumbing.Reference, discard bool) error {
+	if discard {
+		logging.Warnf("discarding changes to the working tree...")
+		if err := common.DiscardChanges(repo); err != nil {
+			return err
+		}
+	}
+	if err := common.CheckoutHead(origHead, repo); err != nil {
+		return fmt.Errorf("could not check out %v again: %w", origHead.Name().Short(), err)
+	}
+	return nil
+}
+
+// discardIfInterrupted discards the changes made to the working tree, which
+// was verified to be clean, if ctx is done.
+func discardIfInterrupted(ctx context.Context, repo *git.Repository) {
+	if ctx.Err() == nil {
+		return
+	}
+	logging.Warnf("interrupted, discarding changes to the working tree...")
+	if err := common.DiscardChanges(repo); err != nil {
+		logging.Errorf("%v", err)
+	}
 }
 
 // prerelease holds fields needed to update one module set at a time.
@@ -130,65 +286,87 @@ func (p prerelease) checkModuleSetUpToDate(repo *git.Repository) (bool, error) {
 	return false, nil
 }
 
-// updateAllVersionGo updates the version.go file containing a hardcoded semver version string
-// for modules within a set, if the file exists.
-func (p prerelease) updateAllVersionGo() error {
-	for _, modPath := range p.ModuleSetRelease.ModSetPaths() {
-		modFilePath := p.ModuleSetRelease.ModuleVersioning.ModPathMap[modPath]
+// defaultVersionFiles are updated in modules of module sets not configuring
+// their version files.
+var defaultVersionFiles = []common.VersionFile{{Path: "version.go"}}
 
-		versionGoDir := filepath.Dir(string(modFilePath))
-		versionGoFilePath := filepath.Join(versionGoDir, "version.go")
+// updateAllVersionGo updates the version files, by default version.go,
+// containing a hardcoded semver version string for modules within a set, if
+// the files exist.
+func (p prerelease) updateAllVersionGo() error {
+	versionFiles := p.ModuleSetRelease.ModSet.VersionFiles
+	if len(versionFiles) == 0 {
+		versionFiles = defaultVersionFiles
+	}
 
-		// check if version.go file exists
-		_, err := os.Stat(versionGoFilePath)
-		if err != nil {
-			if os.IsNotExist(err) {
-				continue
-			} else {
-				return fmt.Errorf("could not check existence of %v: %w", versionGoFilePath, err)
-			}
+	for _, vf := range versionFiles {
+		pattern := vf.Pattern
+		if pattern == "" {
+			pattern = common.SemverRegexNumberOnly
 		}
-		if err = updateVersionGoFile(versionGoFilePath, p.ModuleSetRelease.ModSetVersion()); err != nil {
-			return fmt.Errorf("could not update %v: %w", versionGoFilePath, err)
+		r, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern of version file %v: %w", vf.Path, err)
 		}
 
+		for _, modPath := range p.ModuleSetRelease.ModSetPaths() {
+			modFilePath := p.ModuleSetRelease.ModuleVersioning.ModPathMap[modPath]
+
+			versionFilePaths, err := filepath.Glob(filepath.Join(filepath.Dir(string(modFilePath)), vf.Path))
+			if err != nil {
+				return fmt.Errorf("invalid path of version file %v: %w", vf.Path, err)
+			}
+			for _, versionFilePath := range versionFilePaths {
+				if err = updateVersionFile(versionFilePath, r, p.ModuleSetRelease.ModuleVersion(modPath)); err != nil {
+					return fmt.Errorf("could not update %v: %w", versionFilePath, err)
+				}
+			}
+		}
 	}
 	return nil
 }
 
-// updateVersionGoFile updates one version.go file.
+// updateVersionFile updates the versions matched by r in one version file.
+// If r has a group named version, only the text it matches is replaced.
 // TODO: a potential improvement is to use an AST package rather than regex to perform replacement.
-func updateVersionGoFile(filePath string, newVersion string) error {
-	if !strings.HasSuffix(filePath, "version.go") {
-		return errors.New("cannot update file passed that does not end with version.go")
-	}
-	log.Printf("... Updating file %v\n", filePath)
+func updateVersionFile(filePath string, r *regexp.Regexp, newVersion string) error {
+	logging.Debugf("... Updating file %v", filePath)
 
-	newVersionGoFile, err := os.ReadFile(filepath.Clean(filePath))
+	versionFile, err := os.ReadFile(filepath.Clean(filePath))
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	oldVersionRegex := common.SemverRegexNumberOnly
-	r, err := regexp.Compile(oldVersionRegex)
-	if err != nil {
-		return fmt.Errorf("error compiling regex: %w", err)
-	}
+	newVersionNumberOnly := []byte(strings.TrimPrefix(newVersion, "v"))
 
-	newVersionNumberOnly := strings.TrimPrefix(newVersion, "v")
+	group := 0
+	if i := r.SubexpIndex("version"); i > 0 {
+		group = i
+	}
 
-	newVersionGoFile = r.ReplaceAll(newVersionGoFile, []byte(newVersionNumberOnly))
+	var newVersionFile []byte
+	last := 0
+	for _, m := range r.FindAllSubmatchIndex(versionFile, -1) {
+		start, end := m[2*group], m[2*group+1]
+		if start < 0 {
+			continue
+		}
+		newVersionFile = append(newVersionFile, versionFile[last:start]...)
+		newVersionFile = append(newVersionFile, newVersionNumberOnly...)
+		last = end
+	}
+	newVersionFile = append(newVersionFile, versionFile[last:]...)
 
-	// overwrite the version.go file
-	if err := os.WriteFile(filePath, newVersionGoFile, 0600); err != nil {
-		return fmt.Errorf("error overwriting go.mod file: %w", err)
+	// overwrite the version file
+	if err := os.WriteFile(filePath, newVersionFile, 0600); err != nil {
+		return fmt.Errorf("error overwriting version file: %w", err)
 	}
 
 	return nil
 }
 
-// updateAllGoModFiles updates ALL modules' requires sections to use the newVersion number
-// for the modules given in newModPaths.
+// updateAllGoModFiles updates ALL modules' requires sections to use the new
+// versions of the modules in the module set, honoring its version overrides.
 func (p prerelease) updateAllGoModFiles() error {
 	modFilePaths := make([]common.ModuleFilePath, 0, len(p.ModuleSetRelease.ModuleVersioning.ModPathMap))
 
@@ -196,28 +374,32 @@ func (p prerelease) updateAllGoModFiles() error {
 		modFilePaths = append(modFilePaths, filePath)
 	}
 
-	if err := common.UpdateGoModFiles(modFilePaths, p.ModuleSetRelease.ModSetPaths(), p.ModuleSetRelease.ModSetVersion()); err != nil {
+	if err := common.UpdateGoModFilesToModuleSet(modFilePaths, p.ModuleSetRelease.ModSet); err != nil {
 		return fmt.Errorf("could not update all go mod files: %w", err)
 	}
 
 	return nil
 }
 
-func commitChanges(msr common.ModuleSetRelease, commitToDifferentBranch bool, repo *git.Repository) error {
+// BranchName returns the name of the branch prerelease commits the changes
+// releasing msr to.
+func BranchName(msr common.ModuleSetRelease) string {
+	return strings.Join([]string{"prerelease", msr.ModSetName, msr.ModSetVersion()}, "_")
+}
+
+func commitChanges(ctx context.Context, msr common.ModuleSetRelease, commitToDifferentBranch bool, repo *git.Repository, signer common.CommitSigner) error {
 	commitMessage := fmt.Sprintf("Prepare %v for version %v", msr.ModSetName, msr.ModSetVersion())
 
 	var hash plumbing.Hash
 	var err error
 	if commitToDifferentBranch {
-		branchNameElements := []string{"prerelease", msr.ModSetName, msr.ModSetVersion()}
-		branchName := strings.Join(branchNameElements, "_")
-		hash, err = common.CommitChangesToNewBranch(branchName, commitMessage, repo, nil)
+		hash, err = common.CommitChangesToNewBranch(ctx, BranchName(msr), commitMessage, repo, nil, signer)
 	} else {
-		hash, err = common.CommitChanges(commitMessage, repo, nil)
+		hash, err = common.CommitChanges(ctx, commitMessage, repo, nil, signer)
 	}
 	if err != nil {
 		return err
 	}
-	log.Printf("Commit successful. Hash of commit: %s\n", hash)
+	logging.Infof("Commit successful. Hash of commit: %s", hash)
 	return nil
 }
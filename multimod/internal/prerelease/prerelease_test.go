@@ -25,8 +25,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/build-tools/golden"
 	"go.opentelemetry.io/build-tools/multimod/internal/common"
 	"go.opentelemetry.io/build-tools/multimod/internal/common/commontest"
+	"go.opentelemetry.io/build-tools/multimod/releasetest"
 )
 
 var (
@@ -283,119 +285,65 @@ func TestUpdateAllVersionGo(t *testing.T) {
 	}
 }
 
+func TestUpdateAllVersionFiles(t *testing.T) {
+	versioningFilename := filepath.Join(testDataDir, "update_all_version_go", "versions_version_files.yaml")
+
+	tmpRootDir := t.TempDir()
+	modFiles := map[string][]byte{
+		filepath.Join(tmpRootDir, "test", "test1", "go.mod"): []byte("module \"go.opentelemetry.io/test/test1\"\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "test", "go.mod"):          []byte("module go.opentelemetry.io/test2\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "test", "test2", "go.mod"): []byte("module \"go.opentelemetry.io/test/testexcluded\"\n\ngo 1.16\n"),
+	}
+	versionFile := func(version string) []byte {
+		return []byte("package version\n\n" +
+			"// Compatible with 0.9.0 and later.\n" +
+			"func Version() string {\n\t" +
+			"return \"" + version + "\"\n" +
+			"}\n")
+	}
+	versionFiles := map[string][]byte{
+		filepath.Join(tmpRootDir, "test", "test1", "internal", "version", "version.go"): versionFile("1.0.0-OLD"),
+		filepath.Join(tmpRootDir, "test", "test1", "internal", "other", "version.go"):   versionFile("1.0.0-OLD"),
+		filepath.Join(tmpRootDir, "test", "test1", "version.go"):                        versionFile("1.0.0-OLD"),
+	}
+	require.NoError(t, commontest.WriteTempFiles(modFiles), "could not create go mod file tree")
+	require.NoError(t, commontest.WriteTempFiles(versionFiles), "could not create version file tree")
+
+	p, err := newPrerelease(versioningFilename, "mod-set-1", tmpRootDir)
+	require.NoError(t, err)
+	require.NoError(t, p.updateAllVersionGo())
+
+	expected := map[string][]byte{
+		// Only the version matched by the pattern is updated.
+		filepath.Join(tmpRootDir, "test", "test1", "internal", "version", "version.go"): versionFile("1.2.3"),
+		filepath.Join(tmpRootDir, "test", "test1", "internal", "other", "version.go"):   versionFile("1.2.3"),
+		// The default version.go is not updated when version files are set.
+		filepath.Join(tmpRootDir, "test", "test1", "version.go"): versionFile("1.0.0-OLD"),
+	}
+	for path, content := range expected {
+		actual, err := os.ReadFile(filepath.Clean(path))
+		require.NoError(t, err)
+		assert.Equal(t, string(content), string(actual), path)
+	}
+}
+
 func TestUpdateAllGoModFiles(t *testing.T) {
 	testName := "update_all_go_mod_files"
 	versionsYamlDir := filepath.Join(testDataDir, testName)
 
+	modFilePaths := []string{
+		filepath.Join("test", "test1", "go.mod"),
+		filepath.Join("test", "test2", "go.mod"),
+		filepath.Join("test", "go.mod"),
+		"go.mod",
+	}
+
 	testCases := []struct {
-		modSetName             string
-		expectedOutputModFiles map[string][]byte
+		modSetName string
 	}{
-		{
-			modSetName: "mod-set-1",
-			expectedOutputModFiles: map[string][]byte{
-				filepath.Join("test", "test1", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test1\n\n" +
-					"go 1.16\n\n" +
-					"require (\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test2 v1.2.3-RC1+meta\n\t" +
-					"go.opentelemetry.io/other/test/test1 v1.0.0\n\t" +
-					"go.opentelemetry.io/other/testroot/v2 v2.2.2\n" +
-					")"),
-				filepath.Join("test", "test2", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test2\n\n" +
-					"go 1.16\n\n" +
-					"require (\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test1 v1.2.3-RC1+meta\n\t" +
-					"go.opentelemetry.io/other/test/test1 v1.0.0\n\t" +
-					"go.opentelemetry.io/other/testroot/v2 v2.2.2\n" +
-					")"),
-				filepath.Join("test", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/prerelease/test3\n\n" +
-					"go 1.16\n\n" +
-					"require (\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test1 v1.2.3-RC1+meta\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test2 v1.2.3-RC1+meta\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/testroot v0.1.0-shouldBe2\n\t" +
-					"go.opentelemetry.io/other/test2 v0.1.0\n" +
-					")"),
-				"go.mod": []byte("module go.opentelemetry.io/build-tools/multimod/internal/prerelease/testroot\n\n" +
-					"go 1.16\n\n" +
-					"require (\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test1 v1.2.3-RC1+meta\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test2 v1.2.3-RC1+meta\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/test3 v0.1.0-OLD\n\t" +
-					"go.opentelemetry.io/other/test/test1 v1.0.0\n\t" +
-					")"),
-			},
-		},
-		{
-			modSetName: "mod-set-2",
-			expectedOutputModFiles: map[string][]byte{
-				filepath.Join("test", "test1", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test1\n\n" +
-					"go 1.16\n\n" +
-					"require (\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test2 v1.2.3-OLD\n\t" +
-					"go.opentelemetry.io/other/test/test1 v1.0.0\n\t" +
-					"go.opentelemetry.io/other/testroot/v2 v2.2.2\n" +
-					")"),
-				filepath.Join("test", "test2", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test2\n\n" +
-					"go 1.16\n\n" +
-					"require (\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test1 v1.2.3-OLD\n\t" +
-					"go.opentelemetry.io/other/test/test1 v1.0.0\n\t" +
-					"go.opentelemetry.io/other/testroot/v2 v2.2.2\n" +
-					")"),
-				filepath.Join("test", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/prerelease/test3\n\n" +
-					"go 1.16\n\n" +
-					"require (\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test1 v1.2.3-OLD\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test2 v1.2.3-OLD\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/testroot v0.1.0-shouldBe2\n\t" +
-					"go.opentelemetry.io/other/test2 v0.1.0\n" +
-					")"),
-				"go.mod": []byte("module go.opentelemetry.io/build-tools/multimod/internal/prerelease/testroot\n\n" +
-					"go 1.16\n\n" +
-					"require (\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test1 v1.2.3-OLD\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test2 v1.2.3-OLD\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/test3 v0.1.0\n\t" +
-					"go.opentelemetry.io/other/test/test1 v1.0.0\n\t" +
-					")"),
-			},
-		},
-		{
-			modSetName: "mod-set-3",
-			expectedOutputModFiles: map[string][]byte{
-				filepath.Join("test", "test1", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test1\n\n" +
-					"go 1.16\n\n" +
-					"require (\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test2 v1.2.3-OLD\n\t" +
-					"go.opentelemetry.io/other/test/test1 v1.0.0\n\t" +
-					"go.opentelemetry.io/other/testroot/v2 v2.2.2\n" +
-					")"),
-				filepath.Join("test", "test2", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test2\n\n" +
-					"go 1.16\n\n" +
-					"require (\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test1 v1.2.3-OLD\n\t" +
-					"go.opentelemetry.io/other/test/test1 v1.0.0\n\t" +
-					"go.opentelemetry.io/other/testroot/v2 v2.2.2\n" +
-					")"),
-				filepath.Join("test", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/prerelease/test3\n\n" +
-					"go 1.16\n\n" +
-					"require (\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test1 v1.2.3-OLD\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test2 v1.2.3-OLD\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/testroot v0.2.0\n\t" +
-					"go.opentelemetry.io/other/test2 v0.1.0\n" +
-					")"),
-				"go.mod": []byte("module go.opentelemetry.io/build-tools/multimod/internal/prerelease/testroot\n\n" +
-					"go 1.16\n\n" +
-					"require (\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test1 v1.2.3-OLD\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/test/test2 v1.2.3-OLD\n\t" +
-					"go.opentelemetry.io/build-tools/multimod/internal/prerelease/test3 v0.1.0-OLD\n\t" +
-					"go.opentelemetry.io/other/test/test1 v1.0.0\n\t" +
-					")"),
-			},
-		},
+		{modSetName: "mod-set-1"},
+		{modSetName: "mod-set-2"},
+		{modSetName: "mod-set-3"},
 	}
 
 	for _, tc := range testCases {
@@ -444,12 +392,64 @@ func TestUpdateAllGoModFiles(t *testing.T) {
 			err = p.updateAllGoModFiles()
 			require.NoError(t, err)
 
-			for modFilePathSuffix, expectedByteOutput := range tc.expectedOutputModFiles {
-				actual, err := os.ReadFile(filepath.Clean(filepath.Join(tmpRootDir, modFilePathSuffix)))
-				require.NoError(t, err)
+			for _, modFilePathSuffix := range modFilePaths {
+				goldenFile := filepath.Join(versionsYamlDir, "golden", tc.modSetName, modFilePathSuffix+".golden")
+				golden.AssertFile(t, goldenFile, filepath.Join(tmpRootDir, modFilePathSuffix))
+			}
+		})
+	}
+}
 
-				assert.Equal(t, expectedByteOutput, actual)
+func TestCheckModuleSetUpToDate(t *testing.T) {
+	modRelease, err := releasetest.NewBuilder(t.TempDir()).
+		ModuleSet("mod-set-1", "v1.2.3",
+			releasetest.Module{Path: "go.opentelemetry.io/test/test1", Dir: "test/test1"},
+			releasetest.Module{Path: "go.opentelemetry.io/testroot/v2", Dir: "."},
+		).
+		ModuleSetRelease("mod-set-1")
+	require.NoError(t, err)
+	p := prerelease{ModuleSetRelease: modRelease}
+
+	testCases := []struct {
+		name          string
+		tags          []string
+		expected      bool
+		expectedError bool
+	}{
+		{
+			name:     "no_tags",
+			expected: false,
+		},
+		{
+			name:     "all_tags",
+			tags:     []string{"test/test1/v1.2.3", "v1.2.3"},
+			expected: true,
+		},
+		{
+			name:          "some_tags",
+			tags:          []string{"v1.2.3"},
+			expectedError: true,
+		},
+		{
+			name:     "other_versions",
+			tags:     []string{"test/test1/v1.2.2", "v1.2.2"},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo, hash, err := releasetest.InitNewMemoryRepoWithCommit(nil)
+			require.NoError(t, err)
+			require.NoError(t, releasetest.CreateTags(repo, hash, tc.tags...))
+
+			actual, err := p.checkModuleSetUpToDate(repo)
+			if tc.expectedError {
+				assert.ErrorAs(t, err, &common.ErrInconsistentGitTagsExist{})
+				return
 			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
 		})
 	}
 }
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"errors"
+	"fmt"
+)
+
+var errNotApproved = errors.New("pull request not merged yet")
+
+type errOtherRelease struct {
+	path       string
+	modSetName string
+	version    string
+}
+
+func (e *errOtherRelease) Error() string {
+	return fmt.Sprintf("%v holds the state of the release of module set %v %v, remove it to start another release", e.path, e.modSetName, e.version)
+}
+
+type errPullRequestClosed struct {
+	url string
+}
+
+func (e *errPullRequestClosed) Error() string {
+	return fmt.Sprintf("pull request %v was closed without being merged", e.url)
+}
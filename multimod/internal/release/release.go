@@ -0,0 +1,343 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"text/template"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+	"go.opentelemetry.io/build-tools/internal/repo"
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+	"go.opentelemetry.io/build-tools/multimod/internal/prerelease"
+	"go.opentelemetry.io/build-tools/multimod/internal/tag"
+	"go.opentelemetry.io/build-tools/multimod/internal/verify"
+)
+
+// Steps of a release, in order.
+const (
+	stepVerify      = "verify"
+	stepPrerelease  = "prerelease"
+	stepPullRequest = "pull-request"
+	stepApproval    = "approval"
+	stepTag         = "tag"
+)
+
+var steps = []string{stepVerify, stepPrerelease, stepPullRequest, stepApproval, stepTag}
+
+// defaultStateFile is the state file, relative to the repository root, used
+// if none is given. It is in the .git directory to keep the working tree
+// clean.
+var defaultStateFile = filepath.Join(".git", "multimod-release.json")
+
+// pollInterval is the interval between checks of the pull request while
+// waiting for it to be merged.
+var pollInterval = 30 * time.Second
+
+var prBodyTemplate = template.Must(template.New("body").Parse(`Release module set {{.Name}} {{.Version}}:
+{{range .Modules}}
+- {{.}}{{end}}
+
+The modules are tagged once this pull request is merged.
+This pull request was created by ` + "`multimod release`" + `.
+`))
+
+// releaser runs the steps of the release of a module set.
+type releaser struct {
+	common.ModuleSetRelease
+	repo           *git.Repository
+	client         *http.Client
+	versioningFile string
+	remote         string
+	base           string
+	yes            bool
+	wait           time.Duration
+	skipModTidy    bool
+	updateLog      bool
+	signKeyPath    string
+
+	state     *state
+	stateFile string
+}
+
+// Options configure Run.
+type Options struct {
+	// VersioningFile is the path of the versioning file.
+	VersioningFile string
+	// ModuleSetName is the name of the module set to release.
+	ModuleSetName string
+	// StateFile is the path of the file saving the completed steps. If
+	// empty, .git/multimod-release.json in the repository root is used.
+	StateFile string
+	// Remote is the remote the pull request is opened on and the tags are
+	// pushed to.
+	Remote string
+	// Base is the branch the pull request is merged into.
+	Base string
+	// Yes skips waiting for the pull request to be merged and tags the
+	// prerelease commit.
+	Yes bool
+	// Wait is how long to wait for the pull request to be merged.
+	Wait time.Duration
+	// SkipModTidy skips running go mod tidy in the updated modules.
+	SkipModTidy bool
+	// UpdateChangelog moves the Unreleased section of CHANGELOG.md under the
+	// version of the module set.
+	UpdateChangelog bool
+	// SignKeyPath is the path of the key signing the prerelease commit. If
+	// empty, the key in the environment is used, if any.
+	SignKeyPath string
+}
+
+// Run releases the module set of opts: it verifies the versioning file,
+// commits the prerelease changes to a new branch, opens a pull request
+// merging it into the base branch on the remote, waits for the pull request
+// to be merged, unless Yes is set, and tags the merged commit and pushes the
+// tags to the remote. The completed steps are saved in the state file, so
+// running it again after a failure resumes from the failed step.
+func Run(ctx context.Context, opts Options) {
+	repoRoot, err := repo.FindRoot()
+	if err != nil {
+		logging.Fatalf("unable to find repo root: %v", err)
+	}
+	stateFile := opts.StateFile
+	if stateFile == "" {
+		stateFile = filepath.Join(repoRoot, defaultStateFile)
+	}
+
+	msr, err := common.NewModuleSetRelease(opts.VersioningFile, opts.ModuleSetName, repoRoot)
+	if err != nil {
+		logging.Fatalf("%v", err)
+	}
+	r, err := common.OpenRepo(repoRoot)
+	if err != nil {
+		logging.Fatalf("could not open repo at %v: %v", repoRoot, err)
+	}
+	s, err := loadState(stateFile, opts.ModuleSetName, msr.ModSetVersion())
+	if err != nil {
+		logging.Fatalf("%v", err)
+	}
+
+	rel := releaser{
+		ModuleSetRelease: msr,
+		repo:             r,
+		client:           http.DefaultClient,
+		versioningFile:   opts.VersioningFile,
+		remote:           opts.Remote,
+		base:             opts.Base,
+		yes:              opts.Yes,
+		wait:             opts.Wait,
+		skipModTidy:      opts.SkipModTidy,
+		updateLog:        opts.UpdateChangelog,
+		signKeyPath:      opts.SignKeyPath,
+		state:            s,
+		stateFile:        stateFile,
+	}
+
+	for _, step := range steps {
+		if s.done(step) {
+			logging.Infof("Step %v already completed, skipping", step)
+			continue
+		}
+		if err = ctx.Err(); err != nil {
+			logging.Fatalf("interrupted before step %v: %v", step, err)
+		}
+
+		logging.Infof("===== Step: %v =====", step)
+		if err = rel.run(ctx, step); err != nil {
+			logging.Fatalf("release step %v failed, run release again to retry it: %v", step, err)
+		}
+		if err = s.complete(step, stateFile); err != nil {
+			logging.Fatalf("%v", err)
+		}
+	}
+
+	if err = os.Remove(stateFile); err != nil {
+		logging.Warnf("could not remove release state: %v", err)
+	}
+	logging.Infof("Released module set %v %v", opts.ModuleSetName, msr.ModSetVersion())
+}
+
+// run runs step. The verify, prerelease and tag steps exit on failure, as
+// when run as their own commands, so they are run again by the next release.
+func (r releaser) run(ctx context.Context, step string) error {
+	switch step {
+	case stepVerify:
+		verify.Run(r.versioningFile, false, verify.OutputText)
+	case stepPrerelease:
+		return r.prerelease(ctx)
+	case stepPullRequest:
+		return r.openPullRequest(ctx)
+	case stepApproval:
+		return r.waitForApproval(ctx)
+	case stepTag:
+		tag.Run(ctx, tag.Options{
+			VersioningFile: r.versioningFile,
+			ModuleSetNames: []string{r.ModSetName},
+			CommitHash:     r.state.TagCommit,
+			Push:           true,
+			Remote:         r.remote,
+		})
+	}
+	return nil
+}
+
+// prerelease commits the prerelease changes to a new branch.
+func (r releaser) prerelease(ctx context.Context) error {
+	prerelease.Run(ctx, prerelease.Options{
+		VersioningFile:          r.versioningFile,
+		ModuleSetNames:          []string{r.ModSetName},
+		SkipModTidy:             r.skipModTidy,
+		CommitToDifferentBranch: true,
+		UpdateChangelog:         r.updateLog,
+		SignKeyPath:             r.signKeyPath,
+	})
+
+	branch := prerelease.BranchName(r.ModuleSetRelease)
+	ref, err := r.repo.Reference(plumbing.NewBranchReferenceName(branch), true)
+	if err != nil {
+		return fmt.Errorf("could not find prerelease branch %v: %w", branch, err)
+	}
+	r.state.PrereleaseCommit = ref.Hash().String()
+	return nil
+}
+
+// openPullRequest pushes the prerelease branch to the remote and opens a pull
+// request merging it into the base branch.
+func (r releaser) openPullRequest(ctx context.Context) error {
+	branch := prerelease.BranchName(r.ModuleSetRelease)
+	if err := common.PushBranch(ctx, r.repo, r.remote, branch); err != nil {
+		return err
+	}
+	logging.Infof("Pushed branch %v to %v", branch, r.remote)
+
+	pr, err := r.newPullRequest(branch)
+	if err != nil {
+		return err
+	}
+	owner, name, err := common.GitHubRepo(r.repo, r.remote)
+	if err != nil {
+		return err
+	}
+	created, err := common.CreatePullRequest(ctx, r.client, owner, name, pr)
+	if err != nil {
+		return err
+	}
+	logging.Infof("Opened pull request %v", created.HTMLURL)
+
+	r.state.PullRequest = created.Number
+	r.state.PullRequestURL = created.HTMLURL
+	return nil
+}
+
+// newPullRequest returns the pull request merging the prerelease branch
+// into the base branch.
+func (r releaser) newPullRequest(branch string) (common.PullRequest, error) {
+	var body bytes.Buffer
+	err := prBodyTemplate.Execute(&body, struct {
+		Name    string
+		Version string
+		Modules []common.ModulePath
+	}{r.ModSetName, r.ModSetVersion(), r.ModSetPaths()})
+	if err != nil {
+		return common.PullRequest{}, fmt.Errorf("could not render pull request body: %w", err)
+	}
+	return common.PullRequest{
+		Title: fmt.Sprintf("Release %v %v", r.ModSetName, r.ModSetVersion()),
+		Body:  body.String(),
+		Head:  branch,
+		Base:  r.base,
+	}, nil
+}
+
+// waitForApproval waits for the pull request to be merged and fetches its
+// merge commit to tag it. With --yes the prerelease commit is tagged without
+// waiting.
+func (r releaser) waitForApproval(ctx context.Context) error {
+	if r.yes {
+		logging.Infof("Skipping approval of pull request %v", r.state.PullRequestURL)
+		r.state.TagCommit = r.state.PrereleaseCommit
+		return nil
+	}
+
+	owner, name, err := common.GitHubRepo(r.repo, r.remote)
+	if err != nil {
+		return err
+	}
+	status, err := pollPullRequest(ctx, r.client, owner, name, r.state.PullRequest, r.wait)
+	if err != nil {
+		return err
+	}
+	logging.Infof("Pull request %v merged as commit %v", status.HTMLURL, status.MergeCommitSHA)
+
+	if err = r.fetch(ctx); err != nil {
+		return err
+	}
+	r.state.TagCommit = status.MergeCommitSHA
+	return nil
+}
+
+// pollPullRequest returns the status of the pull request number once it is
+// merged, checking it every pollInterval for up to wait. It fails if the pull
+// request is closed without being merged or not merged in time.
+func pollPullRequest(ctx context.Context, client *http.Client, owner, name string, number int, wait time.Duration) (common.PullRequestStatus, error) {
+	deadline := time.Now().Add(wait)
+	for {
+		status, err := common.GetPullRequest(ctx, client, owner, name, number)
+		if err != nil {
+			return common.PullRequestStatus{}, err
+		}
+		if status.Merged {
+			return status, nil
+		}
+		if status.State == "closed" {
+			return common.PullRequestStatus{}, &errPullRequestClosed{url: status.HTMLURL}
+		}
+
+		if !time.Now().Add(pollInterval).Before(deadline) {
+			return common.PullRequestStatus{}, fmt.Errorf("%w: %v, merge it or pass --yes", errNotApproved, status.HTMLURL)
+		}
+		logging.Infof("Waiting for pull request %v to be merged...", status.HTMLURL)
+		select {
+		case <-ctx.Done():
+			return common.PullRequestStatus{}, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// fetch fetches the remote, so that the merge commit of the pull request is
+// available to tag.
+func (r releaser) fetch(ctx context.Context) error {
+	auth, err := common.RepoRemoteAuth(r.repo, r.remote)
+	if err != nil {
+		return fmt.Errorf("unable to get credentials for remote %s: %w", r.remote, err)
+	}
+	err = r.repo.FetchContext(ctx, &git.FetchOptions{RemoteName: r.remote, Auth: auth})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("error fetching %s: %w", r.remote, common.ClassifyRemoteError(err))
+	}
+	return nil
+}
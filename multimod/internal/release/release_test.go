@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+)
+
+// TestMain performs setup for the tests and suppress printing logs.
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func TestState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "release.json")
+
+	s, err := loadState(path, "mod-set-1", "v1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, &state{ModuleSet: "mod-set-1", Version: "v1.2.0"}, s)
+	assert.False(t, s.done(stepVerify))
+
+	require.NoError(t, s.complete(stepVerify, path))
+	s.PrereleaseCommit = "abc123"
+	require.NoError(t, s.complete(stepPrerelease, path))
+
+	resumed, err := loadState(path, "mod-set-1", "v1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, s, resumed)
+	assert.True(t, resumed.done(stepVerify))
+	assert.True(t, resumed.done(stepPrerelease))
+	assert.False(t, resumed.done(stepPullRequest))
+
+	_, err = loadState(path, "mod-set-1", "v1.3.0")
+	assert.Equal(t, &errOtherRelease{path: path, modSetName: "mod-set-1", version: "v1.2.0"}, err)
+}
+
+func TestNewPullRequest(t *testing.T) {
+	r := releaser{
+		ModuleSetRelease: common.ModuleSetRelease{
+			ModSetName: "mod-set-1",
+			ModSet: common.ModuleSet{
+				Version: "v1.2.0",
+				Modules: []common.ModulePath{"go.opentelemetry.io/test/test1", "go.opentelemetry.io/test/test2"},
+			},
+		},
+		base: "main",
+	}
+
+	pr, err := r.newPullRequest("prerelease_mod-set-1_v1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, common.PullRequest{
+		Title: "Release mod-set-1 v1.2.0",
+		Body: "Release module set mod-set-1 v1.2.0:\n\n" +
+			"- go.opentelemetry.io/test/test1\n" +
+			"- go.opentelemetry.io/test/test2\n\n" +
+			"The modules are tagged once this pull request is merged.\n" +
+			"This pull request was created by `multimod release`.\n",
+		Head: "prerelease_mod-set-1_v1.2.0",
+		Base: "main",
+	}, pr)
+}
+
+func TestPollPullRequest(t *testing.T) {
+	t.Cleanup(func(d time.Duration) func() { return func() { pollInterval = d } }(pollInterval))
+	pollInterval = time.Millisecond
+
+	testCases := []struct {
+		name      string
+		responses []string
+		wait      time.Duration
+		expected  common.PullRequestStatus
+		expectErr string
+	}{
+		{
+			name: "merged_after_poll",
+			responses: []string{
+				`{"number": 1, "state": "open"}`,
+				`{"number": 1, "state": "closed", "merged": true, "merge_commit_sha": "abc123"}`,
+			},
+			wait:     time.Minute,
+			expected: common.PullRequestStatus{Number: 1, State: "closed", Merged: true, MergeCommitSHA: "abc123"},
+		},
+		{
+			name:      "not_merged",
+			responses: []string{`{"number": 1, "state": "open"}`},
+			expectErr: "pull request not merged yet",
+		},
+		{
+			name:      "closed",
+			responses: []string{`{"number": 1, "state": "closed", "html_url": "https://github.com/owner/repo/pull/1"}`},
+			wait:      time.Minute,
+			expectErr: "pull request https://github.com/owner/repo/pull/1 was closed without being merged",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/repos/owner/repo/pulls/1", r.URL.Path)
+				resp := tc.responses[len(tc.responses)-1]
+				if calls < len(tc.responses) {
+					resp = tc.responses[calls]
+				}
+				calls++
+				_, _ = w.Write([]byte(resp))
+			}))
+			defer srv.Close()
+			t.Setenv(common.EnvGitHubAPIURL, srv.URL)
+			t.Setenv(common.EnvGitToken, "secret")
+
+			status, err := pollPullRequest(context.Background(), srv.Client(), "owner", "repo", 1, tc.wait)
+			if tc.expectErr != "" {
+				assert.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, status)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// state is the progress of the release of a module set version, saved in the
+// state file after each completed step so that a failed release resumes from
+// the failed step.
+type state struct {
+	ModuleSet string   `json:"module_set"`
+	Version   string   `json:"version"`
+	Completed []string `json:"completed_steps"`
+
+	// PrereleaseCommit is the commit created by the prerelease step.
+	PrereleaseCommit string `json:"prerelease_commit,omitempty"`
+	// PullRequest is the number of the pull request opened for the
+	// prerelease commit.
+	PullRequest    int    `json:"pull_request,omitempty"`
+	PullRequestURL string `json:"pull_request_url,omitempty"`
+	// TagCommit is the approved commit to tag: the merge commit of the pull
+	// request, or the prerelease commit with --yes.
+	TagCommit string `json:"tag_commit,omitempty"`
+}
+
+// loadState returns the state saved in path for the release of version of
+// the module set modSetName, or a new state if path does not exist. It fails
+// if path holds the state of another release.
+func loadState(path, modSetName, version string) (*state, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return &state{ModuleSet: modSetName, Version: version}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read release state: %w", err)
+	}
+
+	var s state
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("could not parse release state %v: %w", path, err)
+	}
+	if s.ModuleSet != modSetName || s.Version != version {
+		return nil, &errOtherRelease{path: path, modSetName: s.ModuleSet, version: s.Version}
+	}
+	return &s, nil
+}
+
+// done returns whether step was completed.
+func (s *state) done(step string) bool {
+	for _, c := range s.Completed {
+		if c == step {
+			return true
+		}
+	}
+	return false
+}
+
+// complete marks step as completed and saves the state to path.
+func (s *state) complete(step, path string) error {
+	s.Completed = append(s.Completed, step)
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode release state: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("could not save release state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"golang.org/x/mod/semver"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+	"go.opentelemetry.io/build-tools/internal/repo"
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+)
+
+// Output formats of Run.
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+// States of a module set.
+const (
+	// StateReleased is the state of a module set whose version is the
+	// highest tagged version.
+	StateReleased = "released"
+	// StateAhead is the state of a module set whose version is higher than
+	// the highest tagged version, a release is pending.
+	StateAhead = "ahead"
+	// StateBehind is the state of a module set whose version is lower than
+	// the highest tagged version, the versioning file is stale.
+	StateBehind = "behind"
+	// StateUnreleased is the state of a module set none of whose modules
+	// are tagged.
+	StateUnreleased = "unreleased"
+)
+
+func Run(versioningFile, head, output string) {
+	if output != OutputText && output != OutputJSON {
+		logging.Fatalf("invalid output %q, must be %q or %q", output, OutputText, OutputJSON)
+	}
+
+	repoRoot, err := repo.FindRoot()
+	if err != nil {
+		logging.Fatalf("unable to find repo root: %v", err)
+	}
+	repoRoot, err = filepath.Abs(repoRoot)
+	if err != nil {
+		logging.Fatalf("could not get absolute path of repo root: %v", err)
+	}
+
+	modVersioning, err := common.NewModuleVersioning(versioningFile, repoRoot)
+	if err != nil {
+		logging.Fatalf("unable to load versioning file: %v", err)
+	}
+
+	gitRepo, err := common.OpenRepo(repoRoot)
+	if err != nil {
+		logging.Fatalf("could not open repo at %v: %v", repoRoot, err)
+	}
+
+	headHash, err := gitRepo.ResolveRevision(plumbing.Revision(head))
+	if err != nil {
+		logging.Fatalf("could not resolve %v: %v", head, err)
+	}
+
+	statuses, err := moduleSetStatuses(gitRepo, modVersioning, repoRoot, *headHash)
+	if err != nil {
+		logging.Fatalf("could not get status of module sets: %v", err)
+	}
+
+	if output == OutputJSON {
+		err = writeJSON(os.Stdout, statuses)
+	} else {
+		err = writeText(os.Stdout, statuses)
+	}
+	if err != nil {
+		logging.Fatalf("could not write status: %v", err)
+	}
+}
+
+// moduleSetStatus is the release status of a module set.
+type moduleSetStatus struct {
+	ModuleSet string `json:"module_set"`
+	// Version is the version of the module set in the versioning file.
+	Version string `json:"version"`
+	// LatestTag is the tag of the highest version of the modules of the set,
+	// empty if none is tagged.
+	LatestTag     string `json:"latest_tag,omitempty"`
+	LatestVersion string `json:"latest_version,omitempty"`
+	// InHead is whether LatestTag is on a commit reachable from the head
+	// commit.
+	InHead bool   `json:"in_head"`
+	State  string `json:"state"`
+}
+
+// moduleSetStatuses returns the status of every module set of modVersioning,
+// sorted by name. Modules held back at a version override are left out as
+// they are not released with their set.
+func moduleSetStatuses(gitRepo *git.Repository, modVersioning common.ModuleVersioning, repoRoot string, head plumbing.Hash) ([]moduleSetStatus, error) {
+	tagIndex, err := common.NewTagIndex(gitRepo)
+	if err != nil {
+		return nil, err
+	}
+	headCommit, err := gitRepo.CommitObject(head)
+	if err != nil {
+		return nil, fmt.Errorf("could not get commit %v: %w", head, err)
+	}
+
+	names := make([]string, 0, len(modVersioning.ModSetMap))
+	for name := range modVersioning.ModSetMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	statuses := make([]moduleSetStatus, 0, len(names))
+	for _, name := range names {
+		modSet := modVersioning.ModSetMap[name]
+		s := moduleSetStatus{ModuleSet: name, Version: modSet.Version, State: StateUnreleased}
+
+		for _, modPath := range modSet.Modules {
+			if modSet.IsHeldBack(modPath) {
+				continue
+			}
+			tagNames, err := common.ModulePathsToTagNames([]common.ModulePath{modPath}, modVersioning.ModPathMap, repoRoot)
+			if err != nil {
+				return nil, err
+			}
+			versions := tagIndex.Versions(tagNames[0])
+			if len(versions) == 0 {
+				continue
+			}
+			latest := versions[len(versions)-1]
+			if s.LatestVersion == "" || semver.Compare(latest, s.LatestVersion) > 0 {
+				s.LatestTag = common.ModuleFullTagName(tagNames[0], latest)
+				s.LatestVersion = latest
+			}
+		}
+
+		if s.LatestTag != "" {
+			tagHash, _, err := tagIndex.CommitHash(gitRepo, s.LatestTag)
+			if err != nil {
+				return nil, err
+			}
+			tagCommit, err := gitRepo.CommitObject(tagHash)
+			if err != nil {
+				return nil, fmt.Errorf("could not get commit of tag %v: %w", s.LatestTag, err)
+			}
+			if s.InHead, err = tagCommit.IsAncestor(headCommit); err != nil {
+				return nil, fmt.Errorf("could not check if tag %v is in %v: %w", s.LatestTag, head, err)
+			}
+
+			switch c := semver.Compare(modSet.Version, s.LatestVersion); {
+			case c > 0:
+				s.State = StateAhead
+			case c < 0:
+				s.State = StateBehind
+			default:
+				s.State = StateReleased
+			}
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses, nil
+}
+
+// writeText writes statuses to w as a table.
+func writeText(w io.Writer, statuses []moduleSetStatus) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "MODULE SET\tVERSION\tLATEST TAG\tIN HEAD\tSTATE")
+	for _, s := range statuses {
+		latestTag, inHead := "-", "-"
+		if s.LatestTag != "" {
+			latestTag = s.LatestTag
+			inHead = fmt.Sprint(s.InHead)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", s.ModuleSet, s.Version, latestTag, inHead, s.State)
+	}
+	return tw.Flush()
+}
+
+// writeJSON writes statuses to w as an indented JSON array.
+func writeJSON(w io.Writer, statuses []moduleSetStatus) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(statuses)
+}
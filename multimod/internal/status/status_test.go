@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package status
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+	"go.opentelemetry.io/build-tools/multimod/internal/common/commontest"
+	"go.opentelemetry.io/build-tools/multimod/releasetest"
+)
+
+var (
+	testDataDir, _ = filepath.Abs("./test_data")
+)
+
+// TestMain performs setup for the tests and suppress printing logs.
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+func TestModuleSetStatuses(t *testing.T) {
+	tmpRootDir := t.TempDir()
+	repo, _, err := commontest.InitNewRepoWithCommit(tmpRootDir)
+	require.NoError(t, err)
+
+	first, err := releasetest.CommitFiles(repo, map[string][]byte{
+		"go.mod":        []byte("module go.opentelemetry.io/test\n\ngo 1.18\n"),
+		"a/go.mod":      []byte("module go.opentelemetry.io/test/a\n\ngo 1.18\n"),
+		"a/held/go.mod": []byte("module go.opentelemetry.io/test/a/held\n\ngo 1.18\n"),
+		"b/go.mod":      []byte("module go.opentelemetry.io/test/b\n\ngo 1.18\n"),
+		"c/go.mod":      []byte("module go.opentelemetry.io/test/c\n\ngo 1.18\n"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, releasetest.CreateTags(repo, first, "v1.0.0", "b/v1.0.0", "a/held/v2.0.0"))
+	second, err := releasetest.CommitFiles(repo, map[string][]byte{"a/a.go": []byte("package a\n")})
+	require.NoError(t, err)
+	require.NoError(t, releasetest.CreateTags(repo, second, "a/v0.9.0", "a/v1.0.0"))
+
+	modVersioning, err := common.NewModuleVersioning(filepath.Join(testDataDir, "versions_valid.yaml"), tmpRootDir)
+	require.NoError(t, err)
+
+	statuses, err := moduleSetStatuses(repo, modVersioning, tmpRootDir, second)
+	require.NoError(t, err)
+	assert.Equal(t, []moduleSetStatus{
+		{ModuleSet: "ahead", Version: "v1.1.0", LatestTag: "b/v1.0.0", LatestVersion: "v1.0.0", InHead: true, State: StateAhead},
+		{ModuleSet: "behind", Version: "v0.9.0", LatestTag: "v1.0.0", LatestVersion: "v1.0.0", InHead: true, State: StateBehind},
+		// The tag of the held back module is not the latest of the set.
+		{ModuleSet: "released", Version: "v1.0.0", LatestTag: "a/v1.0.0", LatestVersion: "v1.0.0", InHead: true, State: StateReleased},
+		{ModuleSet: "unreleased", Version: "v0.1.0", State: StateUnreleased},
+	}, statuses)
+
+	statuses, err = moduleSetStatuses(repo, modVersioning, tmpRootDir, first)
+	require.NoError(t, err)
+	assert.False(t, statuses[2].InHead, "tag on a later commit than head")
+
+	var b bytes.Buffer
+	require.NoError(t, writeText(&b, statuses))
+	assert.Equal(t, `MODULE SET  VERSION  LATEST TAG  IN HEAD  STATE
+ahead       v1.1.0   b/v1.0.0    true     ahead
+behind      v0.9.0   v1.0.0      true     behind
+released    v1.0.0   a/v1.0.0    false    released
+unreleased  v0.1.0   -           -        unreleased
+`, b.String())
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+)
+
+// shortHashLength is the length of the commit hash in pseudo-versions.
+const shortHashLength = 12
+
+// commitVersions computes the pseudo-versions of the modules of the other
+// repo at one of its commits, as the go command would for that commit.
+type commitVersions struct {
+	repo       *git.Repository
+	commit     *object.Commit
+	tags       common.TagIndex
+	modPathMap common.ModulePathMap
+	repoRoot   string
+}
+
+func newCommitVersions(otherVersioningFilename, otherRepoRoot, rev string) (commitVersions, error) {
+	otherRepoRoot, err := filepath.Abs(otherRepoRoot)
+	if err != nil {
+		return commitVersions{}, fmt.Errorf("could not get absolute path of other repo root: %w", err)
+	}
+
+	repo, err := common.OpenRepo(otherRepoRoot)
+	if err != nil {
+		return commitVersions{}, fmt.Errorf("could not open other repo at %v: %w", otherRepoRoot, err)
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return commitVersions{}, fmt.Errorf("could not resolve commit %v in %v: %w", rev, otherRepoRoot, err)
+	}
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return commitVersions{}, fmt.Errorf("could not get commit %v: %w", hash, err)
+	}
+
+	tags, err := common.NewTagIndex(repo)
+	if err != nil {
+		return commitVersions{}, err
+	}
+
+	modVersioning, err := common.NewModuleVersioning(otherVersioningFilename, otherRepoRoot)
+	if err != nil {
+		return commitVersions{}, fmt.Errorf("could not get other ModuleVersioning: %w", err)
+	}
+
+	return commitVersions{
+		repo:       repo,
+		commit:     commit,
+		tags:       tags,
+		modPathMap: modVersioning.ModPathMap,
+		repoRoot:   otherRepoRoot,
+	}, nil
+}
+
+// shortHash returns the abbreviated hash of the commit used in
+// pseudo-versions.
+func (c commitVersions) shortHash() string {
+	return c.commit.Hash.String()[:shortHashLength]
+}
+
+// pseudoVersion returns the pseudo-version of the module modPath at the
+// commit. It is based on the highest version of the module tagged on an
+// ancestor of the commit, if any.
+func (c commitVersions) pseudoVersion(modPath common.ModulePath) (string, error) {
+	_, pathMajor, ok := module.SplitPathVersion(string(modPath))
+	if !ok {
+		return "", fmt.Errorf("invalid module path %v", modPath)
+	}
+
+	older, err := c.latestAncestorVersion(modPath, pathMajor)
+	if err != nil {
+		return "", err
+	}
+
+	major := strings.TrimLeft(pathMajor, "/.")
+	return module.PseudoVersion(major, older, c.commit.Committer.When, c.shortHash()), nil
+}
+
+// latestAncestorVersion returns the highest version of the module modPath,
+// compatible with its major version suffix pathMajor, that is tagged on the
+// commit or one of its ancestors, or an empty string if there is none.
+func (c commitVersions) latestAncestorVersion(modPath common.ModulePath, pathMajor string) (string, error) {
+	tagNames, err := common.ModulePathsToTagNames([]common.ModulePath{modPath}, c.modPathMap, c.repoRoot)
+	if err != nil {
+		return "", err
+	}
+
+	versions := c.tags.Versions(tagNames[0])
+	for i := len(versions) - 1; i >= 0; i-- {
+		version := versions[i]
+		if module.CheckPathMajor(version, pathMajor) != nil || semver.Build(version) != "" {
+			continue
+		}
+
+		tagName := common.ModuleFullTagName(tagNames[0], version)
+		hash, _, err := c.tags.CommitHash(c.repo, tagName)
+		if err != nil {
+			return "", err
+		}
+		tagCommit, err := c.repo.CommitObject(hash)
+		if err != nil {
+			return "", fmt.Errorf("could not get commit of tag %v: %w", tagName, err)
+		}
+		isAncestor, err := tagCommit.IsAncestor(c.commit)
+		if err != nil {
+			return "", fmt.Errorf("could not check if tag %v is an ancestor of %v: %w", tagName, c.commit.Hash, err)
+		}
+		if isAncestor {
+			return version, nil
+		}
+	}
+	return "", nil
+}
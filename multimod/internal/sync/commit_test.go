@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/module"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+	"go.opentelemetry.io/build-tools/multimod/internal/common/commontest"
+	"go.opentelemetry.io/build-tools/multimod/releasetest"
+)
+
+func TestCommitVersions(t *testing.T) {
+	root := t.TempDir()
+	repo, _, err := commontest.InitNewRepoWithCommit(root)
+	require.NoError(t, err)
+
+	versioningFile := filepath.Join(root, "versions.yaml")
+	require.NoError(t, commontest.WriteTempFiles(map[string][]byte{
+		versioningFile: []byte("module-sets:\n" +
+			"  mod-set-1:\n" +
+			"    version: v1.2.0\n" +
+			"    modules:\n" +
+			"      - go.opentelemetry.io/other/test1\n" +
+			"      - go.opentelemetry.io/other/test2/v2\n"),
+		filepath.Join(root, "test", "test1", "go.mod"): []byte("module go.opentelemetry.io/other/test1\n\ngo 1.18\n"),
+		filepath.Join(root, "test", "test2", "go.mod"): []byte("module go.opentelemetry.io/other/test2/v2\n\ngo 1.18\n"),
+	}))
+	worktree, err := common.GetWorktree(repo)
+	require.NoError(t, err)
+	require.NoError(t, worktree.AddGlob("."))
+	released, err := common.CommitChanges(context.Background(), "add modules", repo, commontest.TestAuthor, nil)
+	require.NoError(t, err)
+	require.NoError(t, releasetest.CreateTags(repo, released, "test/test1/v1.2.0"))
+
+	// Tags of commits that are not ancestors of the synced commit are ignored.
+	unmerged, err := common.CommitChangesToNewBranch(context.Background(), "unmerged", "unmerged change", repo, commontest.TestAuthor, nil)
+	require.NoError(t, err)
+	require.NoError(t, releasetest.CreateTags(repo, unmerged, "test/test1/v1.3.0"))
+
+	head, err := common.CommitChanges(context.Background(), "unreleased change", repo, commontest.TestAuthor, nil)
+	require.NoError(t, err)
+
+	c, err := newCommitVersions(versioningFile, root, head.String()[:8])
+	require.NoError(t, err)
+	assert.Equal(t, head, c.commit.Hash)
+	assert.Equal(t, head.String()[:12], c.shortHash())
+
+	when := c.commit.Committer.When
+	testCases := []struct {
+		modPath  common.ModulePath
+		expected string
+	}{
+		{
+			modPath:  "go.opentelemetry.io/other/test1",
+			expected: module.PseudoVersion("", "v1.2.0", when, c.shortHash()),
+		},
+		{
+			modPath:  "go.opentelemetry.io/other/test2/v2",
+			expected: module.PseudoVersion("v2", "", when, c.shortHash()),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.modPath), func(t *testing.T) {
+			actual, err := c.pseudoVersion(tc.modPath)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+	assert.Regexp(t, `^v1\.2\.1-0\.\d{14}-`+head.String()[:12]+`$`, testCases[0].expected)
+	assert.Regexp(t, `^v2\.0\.0-\d{14}-`+head.String()[:12]+`$`, testCases[1].expected)
+}
+
+func TestCommitVersionsUnknownCommit(t *testing.T) {
+	root := t.TempDir()
+	_, _, err := commontest.InitNewRepoWithCommit(root)
+	require.NoError(t, err)
+
+	_, err = newCommitVersions(filepath.Join(root, "versions.yaml"), root, "does_not_exist")
+	assert.ErrorContains(t, err, "could not resolve commit does_not_exist")
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"golang.org/x/mod/modfile"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+)
+
+// versionChange is a requirement of one of my modules on a module of the
+// other module set that sync would update.
+type versionChange struct {
+	Module   string
+	Requires string
+	Current  string
+	New      string
+}
+
+// moduleSetVersions returns the version of each module of the other module
+// set, honoring its version overrides.
+func (s sync) moduleSetVersions() map[common.ModulePath]string {
+	versions := make(map[common.ModulePath]string, len(s.OtherModuleSet.Modules))
+	for _, modPath := range s.OtherModuleSet.Modules {
+		versions[modPath] = s.OtherModuleSet.ModuleVersion(modPath)
+	}
+	return versions
+}
+
+// commitVersions returns the pseudo-version at the commit of commitVers of
+// each module of the other module set.
+func (s sync) commitVersions(commitVers commitVersions) (map[common.ModulePath]string, error) {
+	versions := make(map[common.ModulePath]string, len(s.OtherModuleSet.Modules))
+	for _, modPath := range s.OtherModuleSet.Modules {
+		version, err := commitVers.pseudoVersion(modPath)
+		if err != nil {
+			return nil, fmt.Errorf("could not get pseudo-version of %v: %w", modPath, err)
+		}
+		versions[modPath] = version
+	}
+	return versions, nil
+}
+
+// dryRunChanges returns the requirements of my modules that syncing the other
+// module set would change, using the pseudo-versions of commitVers if it is
+// set.
+func (s sync) dryRunChanges(commitVers commitVersions) ([]versionChange, error) {
+	versions := s.moduleSetVersions()
+	if commitVers.commit != nil {
+		var err error
+		if versions, err = s.commitVersions(commitVers); err != nil {
+			return nil, err
+		}
+	}
+	return s.plannedChanges(versions)
+}
+
+// plannedChanges returns the requirements of my modules that updating the
+// modules of the other module set to versions would change, without
+// modifying any go.mod file.
+func (s sync) plannedChanges(versions map[common.ModulePath]string) ([]versionChange, error) {
+	var changes []versionChange
+	for _, modFilePath := range s.MyModuleVersioning.ModPathMap {
+		data, err := os.ReadFile(filepath.Clean(string(modFilePath)))
+		if err != nil {
+			return nil, fmt.Errorf("could not read go.mod file %v: %w", modFilePath, err)
+		}
+		f, err := modfile.ParseLax(string(modFilePath), data, nil)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse go.mod file %v: %w", modFilePath, err)
+		}
+		for _, r := range f.Require {
+			version, ok := versions[common.ModulePath(r.Mod.Path)]
+			if !ok || version == r.Mod.Version {
+				continue
+			}
+			changes = append(changes, versionChange{
+				Module:   f.Module.Mod.Path,
+				Requires: r.Mod.Path,
+				Current:  r.Mod.Version,
+				New:      version,
+			})
+		}
+	}
+	sortChanges(changes)
+	return changes, nil
+}
+
+// sortChanges sorts changes by module, then by required module.
+func sortChanges(changes []versionChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Module != changes[j].Module {
+			return changes[i].Module < changes[j].Module
+		}
+		return changes[i].Requires < changes[j].Requires
+	})
+}
+
+// writeChanges writes changes to w as a table.
+func writeChanges(w io.Writer, changes []versionChange) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "MODULE\tREQUIRES\tCURRENT\tNEW")
+	for _, c := range changes {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", c.Module, c.Requires, c.Current, c.New)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common/commontest"
+)
+
+func TestPlannedChanges(t *testing.T) {
+	testName := "update_all_go_mod_files"
+	versionsYamlDir := filepath.Join(testDataDir, testName)
+
+	myVersioningFilename := filepath.Join(versionsYamlDir, "versions_valid.yaml")
+	otherVersioningFilename := filepath.Join(versionsYamlDir, "other_versions_valid.yaml")
+
+	tmpRootDir := t.TempDir()
+
+	modFiles := map[string][]byte{
+		filepath.Join(tmpRootDir, "my", "test", "test1", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/sync/test/test1\n\n" +
+			"go 1.16\n\n" +
+			"require (\n\t" +
+			"go.opentelemetry.io/build-tools/multimod/internal/sync/test/test2 v1.2.3-RC1+meta\n\t" +
+			"go.opentelemetry.io/other/test/test1 v1.0.0-old\n\t" +
+			"go.opentelemetry.io/other/testroot/v2 v2.2.2\n" +
+			")"),
+		filepath.Join(tmpRootDir, "my", "test", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/sync/test3\n\n" +
+			"go 1.16\n\n" +
+			"require (\n\t" +
+			"go.opentelemetry.io/build-tools/multimod/internal/sync/test/test1 v1.2.3-RC1+meta\n\t" +
+			"go.opentelemetry.io/other/test2 v0.1.0-old\n" +
+			")"),
+		filepath.Join(tmpRootDir, "my", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/sync/testroot/v2\n\n" +
+			"go 1.16\n\n" +
+			"require (\n\t" +
+			"go.opentelemetry.io/build-tools/multimod/internal/sync/test/test1 v1.2.3-RC1+meta\n\t" +
+			"go.opentelemetry.io/other/test/test1 v1.0.0-old\n" +
+			")"),
+	}
+	require.NoError(t, commontest.WriteTempFiles(modFiles), "could not create go mod file tree")
+
+	testCases := []struct {
+		modSetName string
+		expected   []versionChange
+	}{
+		{
+			modSetName: "other-mod-set-1",
+			expected: []versionChange{
+				{
+					Module:   "go.opentelemetry.io/build-tools/multimod/internal/sync/test/test1",
+					Requires: "go.opentelemetry.io/other/test/test1",
+					Current:  "v1.0.0-old",
+					New:      "v1.2.3-RC1+meta",
+				},
+				{
+					Module:   "go.opentelemetry.io/build-tools/multimod/internal/sync/testroot/v2",
+					Requires: "go.opentelemetry.io/other/test/test1",
+					Current:  "v1.0.0-old",
+					New:      "v1.2.3-RC1+meta",
+				},
+			},
+		},
+		{
+			modSetName: "other-mod-set-2",
+			expected: []versionChange{
+				{
+					Module:   "go.opentelemetry.io/build-tools/multimod/internal/sync/test3",
+					Requires: "go.opentelemetry.io/other/test2",
+					Current:  "v0.1.0-old",
+					New:      "v0.1.0",
+				},
+			},
+		},
+		{
+			// Already up to date.
+			modSetName: "other-mod-set-3",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.modSetName, func(t *testing.T) {
+			s, err := newSync(myVersioningFilename, otherVersioningFilename, tc.modSetName, tmpRootDir)
+			require.NoError(t, err)
+
+			changes, err := s.plannedChanges(s.moduleSetVersions())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, changes)
+		})
+	}
+
+	for modFilePath, expected := range modFiles {
+		actual, err := os.ReadFile(filepath.Clean(modFilePath))
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual, "go.mod file modified by a dry run")
+	}
+}
+
+func TestWriteChanges(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeChanges(&buf, []versionChange{
+		{Module: "example.com/a", Requires: "example.com/other", Current: "v1.0.0", New: "v1.1.0"},
+		{Module: "example.com/longer/b", Requires: "example.com/other", Current: "v0.9.0", New: "v1.1.0"},
+	}))
+
+	expected := "MODULE                REQUIRES           CURRENT  NEW\n" +
+		"example.com/a         example.com/other  v1.0.0   v1.1.0\n" +
+		"example.com/longer/b  example.com/other  v0.9.0   v1.1.0\n"
+	assert.Equal(t, expected, buf.String())
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+)
+
+var (
+	prTitleTemplate = template.Must(template.New("title").Parse(
+		`Sync {{range $i, $s := .}}{{if $i}}, {{end}}{{$s.Name}} {{$s.Version}}{{end}}`,
+	))
+	prBodyTemplate = template.Must(template.New("body").Parse(`Update the requirements on the following module sets:
+{{range .}}
+### {{.Name}} {{.Version}}
+{{range .Modules}}
+- {{.}}{{end}}
+{{end}}
+This pull request was created by ` + "`multimod sync`" + `.
+`))
+)
+
+// syncedModuleSet is a module set whose requirements were updated by sync.
+type syncedModuleSet struct {
+	Name    string
+	Version string
+	Modules []common.ModulePath
+}
+
+// branchName returns the name of the branch holding the changes syncing
+// sets.
+func branchName(sets []syncedModuleSet) string {
+	parts := make([]string, 0, 2*len(sets)+1)
+	parts = append(parts, "sync")
+	for _, s := range sets {
+		parts = append(parts, s.Name, s.Version)
+	}
+	return strings.Join(parts, "_")
+}
+
+// newPullRequest returns the pull request merging head into base, with a
+// title and body listing sets.
+func newPullRequest(sets []syncedModuleSet, head, base string) (common.PullRequest, error) {
+	var title, body bytes.Buffer
+	if err := prTitleTemplate.Execute(&title, sets); err != nil {
+		return common.PullRequest{}, fmt.Errorf("could not render pull request title: %w", err)
+	}
+	if err := prBodyTemplate.Execute(&body, sets); err != nil {
+		return common.PullRequest{}, fmt.Errorf("could not render pull request body: %w", err)
+	}
+	return common.PullRequest{
+		Title: title.String(),
+		Body:  body.String(),
+		Head:  head,
+		Base:  base,
+	}, nil
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+)
+
+var testSyncedSets = []syncedModuleSet{
+	{
+		Name:    "mod-set-1",
+		Version: "v1.2.0",
+		Modules: []common.ModulePath{"go.opentelemetry.io/other/test1", "go.opentelemetry.io/other/test2"},
+	},
+	{
+		Name:    "mod-set-2",
+		Version: "v0.30.0",
+		Modules: []common.ModulePath{"go.opentelemetry.io/other/test3"},
+	},
+}
+
+func TestNewPullRequest(t *testing.T) {
+	head := branchName(testSyncedSets)
+	assert.Equal(t, "sync_mod-set-1_v1.2.0_mod-set-2_v0.30.0", head)
+
+	pr, err := newPullRequest(testSyncedSets, head, "main")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Sync mod-set-1 v1.2.0, mod-set-2 v0.30.0", pr.Title)
+	assert.Equal(t, `Update the requirements on the following module sets:
+
+### mod-set-1 v1.2.0
+
+- go.opentelemetry.io/other/test1
+- go.opentelemetry.io/other/test2
+
+### mod-set-2 v0.30.0
+
+- go.opentelemetry.io/other/test3
+
+This pull request was created by `+"`multimod sync`"+`.
+`, pr.Body)
+	assert.Equal(t, head, pr.Head)
+	assert.Equal(t, "main", pr.Base)
+}
@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+)
+
+// defaultRemoteRef is the ref versioning files are fetched at when none is
+// given, the default branch of the repository.
+const defaultRemoteRef = "HEAD"
+
+// rawContentURL is the base URL raw files of GitHub repositories are fetched
+// from. It is a variable so it can be replaced in tests.
+var rawContentURL = "https://raw.githubusercontent.com"
+
+var remoteRepoRegexp = regexp.MustCompile(`^(github\.com/)?[\w.-]+/[\w.-]+(@\S+)?$`)
+
+var errNotGitHub = errors.New("only GitHub repositories are supported")
+
+// remoteRepo is a GitHub repository at a given ref.
+type remoteRepo struct {
+	Owner string
+	Name  string
+	Ref   string
+}
+
+func (r remoteRepo) String() string {
+	return fmt.Sprintf("%s/%s@%s", r.Owner, r.Name, r.Ref)
+}
+
+// IsRemoteRepo reports whether repo names a GitHub repository, either as a
+// URL or as owner/repo[@ref], rather than a local directory.
+func IsRemoteRepo(repo string) bool {
+	if strings.Contains(repo, "://") {
+		return true
+	}
+	if _, err := os.Stat(repo); err == nil {
+		return false
+	}
+	return remoteRepoRegexp.MatchString(repo)
+}
+
+// parseRemoteRepo parses repo, in any of the forms accepted by IsRemoteRepo.
+func parseRemoteRepo(repo string) (remoteRepo, error) {
+	p := strings.TrimPrefix(repo, "github.com/")
+	if strings.Contains(repo, "://") {
+		u, err := url.Parse(repo)
+		if err != nil {
+			return remoteRepo{}, fmt.Errorf("could not parse repo URL %v: %w", repo, err)
+		}
+		if u.Host != "github.com" {
+			return remoteRepo{}, fmt.Errorf("%w: %v", errNotGitHub, repo)
+		}
+		p = u.Path
+	}
+
+	p, ref, ok := strings.Cut(strings.Trim(p, "/"), "@")
+	if !ok || ref == "" {
+		ref = defaultRemoteRef
+	}
+	parts := strings.Split(strings.TrimSuffix(p, ".git"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return remoteRepo{}, fmt.Errorf("repo %v is not of the form owner/repo[@ref]", repo)
+	}
+	return remoteRepo{Owner: parts[0], Name: parts[1], Ref: ref}, nil
+}
+
+// fetchVersioningFile downloads the versioning file at versioningFile,
+// relative to the root of r, and the versioning files it includes into dir,
+// keeping their layout. It returns the path of the downloaded versioning
+// file.
+func fetchVersioningFile(ctx context.Context, client *http.Client, r remoteRepo, versioningFile, dir string) (string, error) {
+	fetched := make(map[string]bool)
+	if err := fetchVersioningFileIncludes(ctx, client, r, path.Clean(versioningFile), dir, fetched); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, filepath.FromSlash(path.Clean(versioningFile))), nil
+}
+
+// fetchVersioningFileIncludes downloads file and, recursively, the versioning
+// files it includes. fetched holds the files already downloaded.
+func fetchVersioningFileIncludes(ctx context.Context, client *http.Client, r remoteRepo, file, dir string, fetched map[string]bool) error {
+	if fetched[file] {
+		return nil
+	}
+	fetched[file] = true
+
+	if path.IsAbs(file) || file == ".." || strings.HasPrefix(file, "../") {
+		return fmt.Errorf("versioning file %v is outside of repo %v", file, r)
+	}
+
+	data, err := fetchFile(ctx, client, r, file)
+	if err != nil {
+		return err
+	}
+
+	dest := filepath.Join(dir, filepath.FromSlash(file))
+	if err = os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return fmt.Errorf("could not create directory for %v: %w", dest, err)
+	}
+	if err = os.WriteFile(dest, data, 0o600); err != nil {
+		return fmt.Errorf("could not write %v: %w", dest, err)
+	}
+
+	var cfg struct {
+		Include []string `yaml:"include"`
+	}
+	if err = yaml.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("could not parse versioning file %v: %w", file, err)
+	}
+	for _, inc := range cfg.Include {
+		inc = path.Join(path.Dir(file), filepath.ToSlash(inc))
+		if err = fetchVersioningFileIncludes(ctx, client, r, inc, dir, fetched); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetchFile returns the content of file, relative to the root of r. The
+// token used for remote operations, if any, is sent so files of private
+// repositories can be read.
+func fetchFile(ctx context.Context, client *http.Client, r remoteRepo, file string) ([]byte, error) {
+	u := fmt.Sprintf("%s/%s/%s/%s/%s", rawContentURL, r.Owner, r.Name, r.Ref, file)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create request: %w", err)
+	}
+	if token := common.Token(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch %v from %v: %w", file, r, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not fetch %v from %v: %s", file, r, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %v from %v: %w", file, r, err)
+	}
+	return data, nil
+}
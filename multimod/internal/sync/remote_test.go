@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+)
+
+func TestParseRemoteRepo(t *testing.T) {
+	testCases := []struct {
+		repo    string
+		want    remoteRepo
+		wantErr bool
+	}{
+		{repo: "open-telemetry/opentelemetry-go", want: remoteRepo{Owner: "open-telemetry", Name: "opentelemetry-go", Ref: "HEAD"}},
+		{repo: "open-telemetry/opentelemetry-go@v1.11.0", want: remoteRepo{Owner: "open-telemetry", Name: "opentelemetry-go", Ref: "v1.11.0"}},
+		{repo: "github.com/open-telemetry/opentelemetry-go@main", want: remoteRepo{Owner: "open-telemetry", Name: "opentelemetry-go", Ref: "main"}},
+		{repo: "https://github.com/open-telemetry/opentelemetry-go.git", want: remoteRepo{Owner: "open-telemetry", Name: "opentelemetry-go", Ref: "HEAD"}},
+		{repo: "https://github.com/open-telemetry/opentelemetry-go@release/v1", want: remoteRepo{Owner: "open-telemetry", Name: "opentelemetry-go", Ref: "release/v1"}},
+		{repo: "https://gitlab.com/open-telemetry/opentelemetry-go", wantErr: true},
+		{repo: "https://github.com/open-telemetry", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.repo, func(t *testing.T) {
+			got, err := parseRemoteRepo(tc.repo)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestIsRemoteRepo(t *testing.T) {
+	assert.True(t, IsRemoteRepo("open-telemetry/opentelemetry-go@v1.11.0"))
+	assert.True(t, IsRemoteRepo("https://github.com/open-telemetry/opentelemetry-go"))
+	assert.False(t, IsRemoteRepo(testDataDir))
+	assert.False(t, IsRemoteRepo("test_data/new_sync"))
+	assert.False(t, IsRemoteRepo("../../../opentelemetry-go"))
+}
+
+func TestFetchVersioningFile(t *testing.T) {
+	files := map[string]string{
+		"/owner/repo/v1.0.0/versions.yaml": "include:\n  - experimental/versions.yaml\n" +
+			"module-sets:\n  stable:\n    version: v1.0.0\n    modules:\n      - go.opentelemetry.io/other/test1\n",
+		"/owner/repo/v1.0.0/experimental/versions.yaml": "include:\n  - ../versions.yaml\n" +
+			"module-sets:\n  experimental:\n    version: v0.1.0\n    modules:\n      - go.opentelemetry.io/other/test2\n",
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		content, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer srv.Close()
+
+	orig := rawContentURL
+	rawContentURL = srv.URL
+	t.Cleanup(func() { rawContentURL = orig })
+	t.Setenv(common.EnvGitToken, "secret")
+
+	r := remoteRepo{Owner: "owner", Name: "repo", Ref: "v1.0.0"}
+	dir := t.TempDir()
+
+	got, err := fetchVersioningFile(context.Background(), srv.Client(), r, "versions.yaml", dir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "versions.yaml"), got)
+	assert.FileExists(t, filepath.Join(dir, "experimental", "versions.yaml"))
+
+	names, err := common.GetModuleSetNames(got)
+	require.Error(t, err, "include cycle must still be detected")
+	assert.Nil(t, names)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "experimental", "versions.yaml"), []byte(
+		"module-sets:\n  experimental:\n    version: v0.1.0\n    modules:\n      - go.opentelemetry.io/other/test2\n",
+	), 0o600))
+	names, err = common.GetModuleSetNames(got)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"experimental", "stable"}, names)
+
+	_, err = fetchVersioningFile(context.Background(), srv.Client(), r, "missing.yaml", dir)
+	assert.ErrorContains(t, err, "404")
+}
@@ -15,71 +15,227 @@
 package sync
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"net/http"
+	"os"
+	"path/filepath"
 
 	"github.com/go-git/go-git/v5"
+	"golang.org/x/mod/modfile"
 
+	"go.opentelemetry.io/build-tools/internal/logging"
 	"go.opentelemetry.io/build-tools/internal/repo"
 	"go.opentelemetry.io/build-tools/multimod/internal/common"
 )
 
-func Run(myVersioningFile string, otherVersioningFile string, otherRepoRoot string, otherModuleSetNames []string, allModuleSets bool, skipModTidy bool) {
+// Options configure Run.
+type Options struct {
+	// VersioningFile is the path of the versioning file of the repo.
+	VersioningFile string
+	// OtherVersioningFile is the path of the versioning file of the other
+	// repo, relative to its root if the other repo is remote.
+	OtherVersioningFile string
+	// OtherRepoRoot is the root of the local checkout of the other repo, or
+	// its GitHub repository.
+	OtherRepoRoot string
+	// OtherModuleSetNames are the names of the module sets of the other repo
+	// to sync.
+	OtherModuleSetNames []string
+	// AllModuleSets syncs all the module sets of the other repo instead of
+	// OtherModuleSetNames.
+	AllModuleSets bool
+	// SkipModTidy skips running go mod tidy in the updated modules.
+	SkipModTidy bool
+	// SyncGoDirectives raises the go and toolchain directives of the modules
+	// depending on the module sets to those of the other repo.
+	SyncGoDirectives bool
+	// Commit is the commit of the other repo whose pseudo-versions are
+	// required instead of the released versions, if not empty.
+	Commit string
+	// DryRun prints the requirements that would be updated without
+	// modifying any go.mod file.
+	DryRun bool
+	// CreatePR commits the changes to a new branch, pushes it to Remote and
+	// opens a pull request against Base.
+	CreatePR bool
+	// Remote is the remote the branch is pushed to with CreatePR.
+	Remote string
+	// Base is the branch the pull request is opened against with CreatePR.
+	Base string
+	// SignKeyPath is the path of the key signing the commit made with
+	// CreatePR. If empty, the key in the environment is used, if any.
+	SignKeyPath string
+}
+
+// Run updates the requirements of the modules of the repo on the module sets
+// of the other repo of opts.
+func Run(ctx context.Context, opts Options) {
+	otherVersioningFile := opts.OtherVersioningFile
+	otherModuleSetNames := opts.OtherModuleSetNames
 	myRepoRoot, err := repo.FindRoot()
 	if err != nil {
-		log.Fatalf("unable to find repo root: %v", err)
+		logging.Fatalf("unable to find repo root: %v", err)
 	}
-	log.Printf("Using repo with root at %s\n\n", myRepoRoot)
+	logging.Infof("Using repo with root at %s", myRepoRoot)
 
-	if allModuleSets {
-		otherModuleSetNames, err = common.GetAllModuleSetNames(otherVersioningFile, otherRepoRoot)
+	isRemote := IsRemoteRepo(opts.OtherRepoRoot)
+	if isRemote {
+		if opts.SyncGoDirectives {
+			logging.Fatalf("syncing go directives requires a local checkout of the other repo")
+		}
+		if opts.Commit != "" {
+			logging.Fatalf("syncing to a commit requires a local checkout of the other repo")
+		}
+
+		other, err := parseRemoteRepo(opts.OtherRepoRoot)
 		if err != nil {
-			log.Fatalf("could not automatically get all module set names: %v", err)
+			logging.Fatalf("%v", err)
 		}
+
+		tmpDir, err := os.MkdirTemp("", "multimod-sync-")
+		if err != nil {
+			logging.Fatalf("could not create temporary directory: %v", err)
+		}
+		defer os.RemoveAll(tmpDir)
+
+		otherVersioningFile, err = fetchVersioningFile(ctx, http.DefaultClient, other, otherVersioningFile, tmpDir)
+		if err != nil {
+			logging.Fatalf("could not fetch versioning file: %v", err)
+		}
+		logging.Infof("Using versioning file of %v", other)
 	}
 
-	repo, err := git.PlainOpen(myRepoRoot)
+	if opts.AllModuleSets {
+		if isRemote {
+			otherModuleSetNames, err = common.GetModuleSetNames(otherVersioningFile)
+		} else {
+			otherModuleSetNames, err = common.GetAllModuleSetNames(otherVersioningFile, opts.OtherRepoRoot)
+		}
+		if err != nil {
+			logging.Fatalf("could not automatically get all module set names: %v", err)
+		}
+	}
+
+	repo, err := common.OpenRepo(myRepoRoot)
 	if err != nil {
-		log.Fatalf("could not open repo at %v: %v", myRepoRoot, err)
+		logging.Fatalf("could not open repo at %v: %v", myRepoRoot, err)
+	}
+
+	if !opts.DryRun {
+		if err = common.VerifyWorkingTreeClean(repo); err != nil {
+			logging.Fatalf("VerifyWorkingTreeClean failed: %v", err)
+		}
 	}
 
-	if err = common.VerifyWorkingTreeClean(repo); err != nil {
-		log.Fatalf("VerifyWorkingTreeClean failed: %v", err)
+	var commitVers commitVersions
+	if opts.Commit != "" {
+		commitVers, err = newCommitVersions(otherVersioningFile, opts.OtherRepoRoot, opts.Commit)
+		if err != nil {
+			logging.Fatalf("%v", err)
+		}
+		logging.Infof("Using pseudo-versions of commit %v", commitVers.commit.Hash)
 	}
 
+	var (
+		synced  []syncedModuleSet
+		planned []versionChange
+	)
 	for _, moduleSetName := range otherModuleSetNames {
-		s, err := newSync(myVersioningFile, otherVersioningFile, moduleSetName, myRepoRoot)
+		if err = ctx.Err(); err != nil {
+			logging.Fatalf("interrupted before module set %v: %v", moduleSetName, err)
+		}
+
+		s, err := newSync(opts.VersioningFile, otherVersioningFile, moduleSetName, myRepoRoot)
 		if err != nil {
-			log.Fatalf("error creating new sync struct: %v", err)
+			logging.Fatalf("error creating new sync struct: %v", err)
+		}
+
+		logging.Infof("===== Module Set: %v =====", moduleSetName)
+
+		if opts.DryRun {
+			changes, err := s.dryRunChanges(commitVers)
+			if err != nil {
+				logging.Fatalf("could not compute changes: %v", err)
+			}
+			planned = append(planned, changes...)
+			continue
 		}
 
-		log.Printf("===== Module Set: %v =====\n", moduleSetName)
+		version := s.OtherModuleSet.Version
+		if opts.Commit != "" {
+			version = commitVers.shortHash()
+			err = s.updateAllGoModFilesToCommit(commitVers)
+		} else {
+			err = s.updateAllGoModFiles()
+		}
+		if err != nil {
+			logging.Fatalf("updateAllGoModFiles failed: %v", err)
+		}
 
-		if err = s.updateAllGoModFiles(); err != nil {
-			log.Fatalf("updateAllGoModFiles failed: %v", err)
+		if opts.SyncGoDirectives {
+			if err = s.updateGoDirectives(otherVersioningFile, opts.OtherRepoRoot); err != nil {
+				logging.Fatalf("updateGoDirectives failed: %v", err)
+			}
 		}
 
 		modSetUpToDate, err := checkModuleSetUpToDate(repo)
 		if err != nil {
-			log.Fatal(err)
+			logging.Fatalf("%v", err)
 		}
 		if modSetUpToDate {
-			log.Println("Module set already up to date. Skipping...")
+			logging.Infof("Module set already up to date. Skipping...")
 			continue
 		} else {
-			log.Println("Updating versions for module set...")
+			logging.Infof("Updating versions for module set...")
 		}
 
-		if skipModTidy {
-			log.Println("Skipping go mod tidy...")
+		if opts.SkipModTidy {
+			logging.Infof("Skipping go mod tidy...")
 		} else {
-			if err := common.RunGoModTidy(s.MyModuleVersioning.ModPathMap); err != nil {
-				log.Printf("WARNING: failed to run 'go mod tidy': %v\n", err)
+			if err := common.RunGoModTidy(ctx, s.MyModuleVersioning.ModPathMap); err != nil {
+				if ctx.Err() != nil {
+					logging.Fatalf("interrupted while running 'go mod tidy': %v", err)
+				}
+				logging.Warnf("failed to run 'go mod tidy': %v", err)
 			}
 		}
+
+		synced = append(synced, syncedModuleSet{
+			Name:    moduleSetName,
+			Version: version,
+			Modules: s.OtherModuleSet.Modules,
+		})
 	}
 
-	log.Println(`=========
+	if opts.DryRun {
+		if len(planned) == 0 {
+			logging.Infof("All module sets already up to date. Nothing would change.")
+			return
+		}
+		sortChanges(planned)
+		if err = writeChanges(os.Stdout, planned); err != nil {
+			logging.Fatalf("could not write changes: %v", err)
+		}
+		return
+	}
+
+	if opts.CreatePR {
+		if len(synced) == 0 {
+			logging.Infof("All module sets already up to date. No pull request created.")
+			return
+		}
+		signer, err := common.NewCommitSigner(opts.SignKeyPath)
+		if err != nil {
+			logging.Fatalf("could not load commit signing key: %v", err)
+		}
+		if err = openPullRequest(ctx, repo, synced, opts.Remote, opts.Base, signer); err != nil {
+			logging.Fatalf("could not open pull request: %v", err)
+		}
+		return
+	}
+
+	logging.Infof(`=========
 Prerelease finished successfully. Now run the following to verify the changes:
 
 git diff main
@@ -87,6 +243,37 @@ git diff main
 Then, if necessary, commit changes and push to upstream/make a pull request.`)
 }
 
+// openPullRequest commits the changes syncing sets to a new branch, pushes it
+// to remote and opens a pull request merging it into base.
+func openPullRequest(ctx context.Context, repo *git.Repository, sets []syncedModuleSet, remote, base string, signer common.CommitSigner) error {
+	branch := branchName(sets)
+	pr, err := newPullRequest(sets, branch, base)
+	if err != nil {
+		return err
+	}
+
+	if _, err = common.CommitChangesToNewBranch(ctx, branch, pr.Title, repo, nil, signer); err != nil {
+		return fmt.Errorf("could not commit changes to branch %v: %w", branch, err)
+	}
+	logging.Infof("Committed changes to branch %v", branch)
+
+	if err = common.PushBranch(ctx, repo, remote, branch); err != nil {
+		return err
+	}
+	logging.Infof("Pushed branch %v to %v", branch, remote)
+
+	owner, name, err := common.GitHubRepo(repo, remote)
+	if err != nil {
+		return err
+	}
+	created, err := common.CreatePullRequest(ctx, http.DefaultClient, owner, name, pr)
+	if err != nil {
+		return err
+	}
+	logging.Infof("Opened pull request %v", created.HTMLURL)
+	return nil
+}
+
 // sync holds fields needed to update one module set at a time.
 type sync struct {
 	OtherModuleSetName string
@@ -112,8 +299,9 @@ func newSync(myVersioningFilename, otherVersioningFilename, modSetToUpdate, myRe
 	}, nil
 }
 
-// updateAllGoModFiles updates ALL modules' requires sections to use the newVersion number
-// for the modules given in newModPaths.
+// updateAllGoModFiles updates ALL modules' requires sections to use the
+// versions of the modules of the other module set, honoring its version
+// overrides.
 func (s sync) updateAllGoModFiles() error {
 	modFilePaths := make([]common.ModuleFilePath, 0, len(s.MyModuleVersioning.ModPathMap))
 
@@ -121,17 +309,98 @@ func (s sync) updateAllGoModFiles() error {
 		modFilePaths = append(modFilePaths, filePath)
 	}
 
-	if err := common.UpdateGoModFiles(
-		modFilePaths,
-		s.OtherModuleSet.Modules,
-		s.OtherModuleSet.Version,
-	); err != nil {
+	if err := common.UpdateGoModFilesToModuleSet(modFilePaths, s.OtherModuleSet); err != nil {
 		return fmt.Errorf("could not update all go mod files: %w", err)
 	}
 
 	return nil
 }
 
+// updateAllGoModFilesToCommit updates ALL modules' requires sections to use
+// the pseudo-versions at the commit of commitVers for the modules of the other
+// module set.
+func (s sync) updateAllGoModFilesToCommit(commitVers commitVersions) error {
+	modFilePaths := make([]common.ModuleFilePath, 0, len(s.MyModuleVersioning.ModPathMap))
+
+	for _, filePath := range s.MyModuleVersioning.ModPathMap {
+		modFilePaths = append(modFilePaths, filePath)
+	}
+
+	for _, modPath := range s.OtherModuleSet.Modules {
+		version, err := commitVers.pseudoVersion(modPath)
+		if err != nil {
+			return fmt.Errorf("could not get pseudo-version of %v: %w", modPath, err)
+		}
+		logging.Debugf("Using %v %v", modPath, version)
+
+		if err = common.UpdateGoModFiles(modFilePaths, []common.ModulePath{modPath}, version); err != nil {
+			return fmt.Errorf("could not update all go mod files: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// updateGoDirectives raises the go and toolchain directives of the modules
+// requiring a module of the other module set to the highest ones used by the
+// modules of the set in the other repo.
+func (s sync) updateGoDirectives(otherVersioningFilename, otherRepoRoot string) error {
+	otherModVersioning, err := common.NewModuleVersioning(otherVersioningFilename, otherRepoRoot)
+	if err != nil {
+		return fmt.Errorf("could not get other ModuleVersioning: %w", err)
+	}
+
+	otherModFilePaths := make([]common.ModuleFilePath, 0, len(s.OtherModuleSet.Modules))
+	for _, modPath := range s.OtherModuleSet.Modules {
+		modFilePath, ok := otherModVersioning.ModPathMap[modPath]
+		if !ok {
+			return fmt.Errorf("module %v not found in other repo %v", modPath, otherRepoRoot)
+		}
+		otherModFilePaths = append(otherModFilePaths, modFilePath)
+	}
+
+	want, err := common.MaxGoDirectives(otherModFilePaths)
+	if err != nil {
+		return err
+	}
+
+	dependents, err := s.dependentModFilePaths()
+	if err != nil {
+		return err
+	}
+
+	logging.Infof("Updating go directives to go %v, toolchain %v...", want.Go, want.Toolchain)
+	return common.RaiseGoDirectives(dependents, want)
+}
+
+// dependentModFilePaths returns the go.mod files of the modules requiring a
+// module of the other module set.
+func (s sync) dependentModFilePaths() ([]common.ModuleFilePath, error) {
+	setModules := make(map[string]struct{}, len(s.OtherModuleSet.Modules))
+	for _, modPath := range s.OtherModuleSet.Modules {
+		setModules[string(modPath)] = struct{}{}
+	}
+
+	var dependents []common.ModuleFilePath
+	for _, modFilePath := range s.MyModuleVersioning.ModPathMap {
+		data, err := os.ReadFile(filepath.Clean(string(modFilePath)))
+		if err != nil {
+			return nil, fmt.Errorf("could not read go.mod file %v: %w", modFilePath, err)
+		}
+		f, err := modfile.ParseLax(string(modFilePath), data, nil)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse go.mod file %v: %w", modFilePath, err)
+		}
+		for _, r := range f.Require {
+			if _, ok := setModules[r.Mod.Path]; ok {
+				dependents = append(dependents, modFilePath)
+				break
+			}
+		}
+	}
+	return dependents, nil
+}
+
 func checkModuleSetUpToDate(repo *git.Repository) (bool, error) {
 	worktree, err := common.GetWorktree(repo)
 	if err != nil {
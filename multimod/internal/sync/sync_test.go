@@ -351,3 +351,61 @@ func TestUpdateAllGoModFiles(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateGoDirectives(t *testing.T) {
+	testName := "update_all_go_mod_files"
+	versionsYamlDir := filepath.Join(testDataDir, testName)
+
+	myVersioningFilename := filepath.Join(versionsYamlDir, "versions_valid.yaml")
+	otherVersioningFilename := filepath.Join(versionsYamlDir, "other_versions_valid.yaml")
+
+	tmpRootDir := t.TempDir()
+	modFiles := map[string][]byte{
+		filepath.Join(tmpRootDir, "my", "test", "test1", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/sync/test/test1\n\n" +
+			"go 1.16\n\n" +
+			"require go.opentelemetry.io/other/test/test1 v1.0.0-old\n"),
+		filepath.Join(tmpRootDir, "my", "test", "test2", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/sync/test/test2\n\n" +
+			"go 1.22\n\n" +
+			"require go.opentelemetry.io/other/test/test1 v1.0.0-old\n"),
+		filepath.Join(tmpRootDir, "my", "test", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/sync/test3\n\n" +
+			"go 1.16\n\n" +
+			"require go.opentelemetry.io/other/test2 v0.1.0-old\n"),
+		filepath.Join(tmpRootDir, "my", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/sync/testroot/v2\n\n" +
+			"go 1.16\n"),
+		filepath.Join(tmpRootDir, "other", "test", "test1", "go.mod"): []byte("module go.opentelemetry.io/other/test/test1\n\n" +
+			"go 1.21.0\n\n" +
+			"toolchain go1.21.3\n"),
+		filepath.Join(tmpRootDir, "other", "test2", "go.mod"): []byte("module go.opentelemetry.io/other/test2\n\n" +
+			"go 1.19\n"),
+		filepath.Join(tmpRootDir, "other", "go.mod"): []byte("module go.opentelemetry.io/other/testroot/v2\n\n" +
+			"go 1.20\n"),
+	}
+	require.NoError(t, commontest.WriteTempFiles(modFiles), "could not create go mod file tree")
+
+	s, err := newSync(myVersioningFilename, otherVersioningFilename, "other-mod-set-1", filepath.Join(tmpRootDir, "my"))
+	require.NoError(t, err)
+	require.NoError(t, s.updateGoDirectives(otherVersioningFilename, filepath.Join(tmpRootDir, "other")))
+
+	expected := map[string]string{
+		// Raised to the directives of the module set.
+		filepath.Join("my", "test", "test1", "go.mod"): "module go.opentelemetry.io/build-tools/multimod/internal/sync/test/test1\n\n" +
+			"go 1.21.0\n\n" +
+			"toolchain go1.21.3\n\n" +
+			"require go.opentelemetry.io/other/test/test1 v1.0.0-old\n",
+		// Never lowered.
+		filepath.Join("my", "test", "test2", "go.mod"): "module go.opentelemetry.io/build-tools/multimod/internal/sync/test/test2\n\n" +
+			"go 1.22\n\n" +
+			"require go.opentelemetry.io/other/test/test1 v1.0.0-old\n",
+		// Not depending on the module set.
+		filepath.Join("my", "test", "go.mod"): "module go.opentelemetry.io/build-tools/multimod/internal/sync/test3\n\n" +
+			"go 1.16\n\n" +
+			"require go.opentelemetry.io/other/test2 v0.1.0-old\n",
+		filepath.Join("my", "go.mod"): "module go.opentelemetry.io/build-tools/multimod/internal/sync/testroot/v2\n\n" +
+			"go 1.16\n",
+	}
+	for modFilePathSuffix, want := range expected {
+		actual, err := os.ReadFile(filepath.Clean(filepath.Join(tmpRootDir, modFilePathSuffix)))
+		require.NoError(t, err)
+		assert.Equal(t, want, string(actual), modFilePathSuffix)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// verifyOnBranch returns an error if the commit to tag is not reachable from
+// the head of branch, so that releases are only tagged from protected
+// branches. branch is resolved as a git revision, so a remote-tracking
+// branch such as upstream/main may be given as well.
+func (t tagger) verifyOnBranch(branch string) error {
+	branchHash, err := t.Repo.ResolveRevision(plumbing.Revision(branch))
+	if err != nil {
+		return fmt.Errorf("could not resolve branch %v: %w", branch, err)
+	}
+
+	branchCommit, err := t.Repo.CommitObject(*branchHash)
+	if err != nil {
+		return fmt.Errorf("could not get head commit of branch %v: %w", branch, err)
+	}
+	commit, err := t.Repo.CommitObject(t.CommitHash)
+	if err != nil {
+		return fmt.Errorf("could not get commit %v: %w", t.CommitHash, err)
+	}
+
+	ok, err := commit.IsAncestor(branchCommit)
+	if err != nil {
+		return fmt.Errorf("could not check if commit %v is on branch %v: %w", t.CommitHash, branch, err)
+	}
+	if !ok {
+		return &errCommitNotOnBranch{commitHash: t.CommitHash, branch: branch}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+	"go.opentelemetry.io/build-tools/multimod/internal/common/commontest"
+	"go.opentelemetry.io/build-tools/multimod/releasetest"
+)
+
+func TestVerifyOnBranch(t *testing.T) {
+	repo, firstHash, err := releasetest.InitNewMemoryRepoWithCommit(nil)
+	require.NoError(t, err)
+	head, err := repo.Head()
+	require.NoError(t, err)
+	mainBranch := head.Name().Short()
+
+	secondHash, err := common.CommitChangesToNewBranch(context.Background(), "pr_branch", "commit used in a test", repo, commontest.TestAuthor, nil)
+	require.NoError(t, err)
+
+	t.Run("head_of_branch", func(t *testing.T) {
+		tagger := tagger{CommitHash: firstHash, Repo: repo}
+		assert.NoError(t, tagger.verifyOnBranch(mainBranch))
+	})
+
+	t.Run("ancestor_of_branch", func(t *testing.T) {
+		tagger := tagger{CommitHash: firstHash, Repo: repo}
+		assert.NoError(t, tagger.verifyOnBranch("pr_branch"))
+	})
+
+	t.Run("not_on_branch", func(t *testing.T) {
+		tagger := tagger{CommitHash: secondHash, Repo: repo}
+		err := tagger.verifyOnBranch(mainBranch)
+		assert.Equal(t, &errCommitNotOnBranch{commitHash: secondHash, branch: mainBranch}, err)
+	})
+
+	t.Run("unknown_branch", func(t *testing.T) {
+		tagger := tagger{CommitHash: firstHash, Repo: repo}
+		assert.Error(t, tagger.verifyOnBranch("does_not_exist"))
+	})
+}
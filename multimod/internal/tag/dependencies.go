@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+)
+
+// dependencyTag is the tag of a module of another module set required by a
+// module of the module set being tagged.
+type dependencyTag struct {
+	ModulePath    common.ModulePath
+	Version       string
+	ModuleSetName string
+	TagName       string
+}
+
+// dependencyTags returns the tags of the versions of the modules of other
+// module sets required by the go.mod files of the module set, as they are in
+// the commit being tagged. Pseudo-versions and modules not listed in the
+// versioning file are ignored as they have no tag.
+func (t tagger) dependencyTags() ([]dependencyTag, error) {
+	commit, err := t.Repo.CommitObject(t.CommitHash)
+	if err != nil {
+		return nil, fmt.Errorf("could not get commit %s: %w", t.CommitHash, err)
+	}
+
+	seen := make(map[string]bool)
+	var deps []dependencyTag
+	for _, modPath := range t.ModuleSetRelease.ModSetPaths() {
+		modFilePath := t.ModuleSetRelease.ModPathMap[modPath]
+		rel, err := filepath.Rel(t.repoRoot, string(modFilePath))
+		if err != nil {
+			return nil, err
+		}
+		file, err := commit.File(filepath.ToSlash(rel))
+		if err != nil {
+			return nil, fmt.Errorf("could not get %s in commit %s: %w", rel, t.CommitHash, err)
+		}
+		content, err := file.Contents()
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s in commit %s: %w", rel, t.CommitHash, err)
+		}
+		modFile, err := modfile.ParseLax(rel, []byte(content), nil)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse %s in commit %s: %w", rel, t.CommitHash, err)
+		}
+
+		for _, req := range modFile.Require {
+			reqPath := common.ModulePath(req.Mod.Path)
+			info, ok := t.ModuleSetRelease.ModInfoMap[reqPath]
+			if !ok || info.ModuleSetName == t.ModuleSetRelease.ModSetName || module.IsPseudoVersion(req.Mod.Version) {
+				continue
+			}
+			tagNames, err := common.ModulePathsToTagNames([]common.ModulePath{reqPath}, t.ModuleSetRelease.ModPathMap, t.repoRoot)
+			if err != nil {
+				return nil, err
+			}
+			tagName := common.ModuleFullTagName(tagNames[0], req.Mod.Version)
+			if seen[tagName] {
+				continue
+			}
+			seen[tagName] = true
+			deps = append(deps, dependencyTag{
+				ModulePath:    reqPath,
+				Version:       req.Mod.Version,
+				ModuleSetName: info.ModuleSetName,
+				TagName:       tagName,
+			})
+		}
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].TagName < deps[j].TagName })
+	return deps, nil
+}
+
+// verifyDependencyTags returns an error if a tag returned by dependencyTags
+// exists neither in the repository nor on remote, and is not created for a
+// module set tagged along. Tagging the module set
+// before the module sets it depends on would publish module versions whose
+// requirements cannot be resolved.
+func (t tagger) verifyDependencyTags(ctx context.Context, remote string) error {
+	deps, err := t.dependencyTags()
+	if err != nil {
+		return err
+	}
+
+	var missing []dependencyTag
+	for _, dep := range deps {
+		if t.pending[dep.TagName] {
+			logging.Debugf("Found dependency tag %v in module sets tagged along", dep.TagName)
+			continue
+		}
+		_, err := t.Repo.Tag(dep.TagName)
+		switch {
+		case errors.Is(err, git.ErrTagNotFound):
+			missing = append(missing, dep)
+		case err != nil:
+			return fmt.Errorf("unable to fetch git tag ref for %v: %w", dep.TagName, err)
+		default:
+			logging.Debugf("Found dependency tag %v", dep.TagName)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	remoteTags, err := listRemoteTags(ctx, t.Repo, remote)
+	if err != nil {
+		return err
+	}
+	var notFound []dependencyTag
+	for _, dep := range missing {
+		if remoteTags[dep.TagName] {
+			logging.Debugf("Found dependency tag %v on remote %v", dep.TagName, remote)
+			continue
+		}
+		notFound = append(notFound, dep)
+	}
+	if len(notFound) > 0 {
+		return &errDependencyTagsMissing{
+			modSetName: t.ModuleSetRelease.ModSetName,
+			deps:       notFound,
+		}
+	}
+	return nil
+}
+
+// listRemoteTags returns the names of the tags of remote. No tags are
+// returned if repo has no such remote.
+func listRemoteTags(ctx context.Context, repo *git.Repository, remote string) (map[string]bool, error) {
+	tags := make(map[string]bool)
+	rem, err := repo.Remote(remote)
+	if errors.Is(err, git.ErrRemoteNotFound) {
+		logging.Debugf("Remote %v not found, only checking local tags", remote)
+		return tags, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get remote %v: %w", remote, err)
+	}
+
+	auth, err := common.RepoRemoteAuth(repo, remote)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get credentials for remote %s: %w", remote, err)
+	}
+	refs, err := rem.ListContext(ctx, &git.ListOptions{Auth: auth})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list tags of remote %s: %w", remote, common.ClassifyRemoteError(err))
+	}
+	for _, ref := range refs {
+		if ref.Name().IsTag() {
+			tags[ref.Name().Short()] = true
+		}
+	}
+	return tags, nil
+}
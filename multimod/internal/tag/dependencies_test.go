@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+	"go.opentelemetry.io/build-tools/multimod/internal/common/commontest"
+)
+
+// newDependencyTestRepo returns a repository whose commit contains modules
+// where mod-set-1 depends on mod-set-2 and mod-set-3.
+func newDependencyTestRepo(t *testing.T) (string, *git.Repository) {
+	tmpRootDir := t.TempDir()
+	repo, _, err := commontest.InitNewRepoWithCommit(tmpRootDir)
+	require.NoError(t, err)
+
+	modFiles := map[string][]byte{
+		filepath.Join(tmpRootDir, "test", "test1", "go.mod"): []byte("module go.opentelemetry.io/test/test1\n\ngo 1.16\n\n" +
+			"require (\n" +
+			"\tgo.opentelemetry.io/test/test2 v1.2.3\n" +
+			"\tgo.opentelemetry.io/testroot/v2 v2.2.2\n" +
+			"\tgo.opentelemetry.io/other v1.0.0\n" +
+			")\n"),
+		filepath.Join(tmpRootDir, "test", "test2", "go.mod"): []byte("module go.opentelemetry.io/test/test2\n\ngo 1.16\n\n" +
+			"require (\n" +
+			"\tgo.opentelemetry.io/test3 v0.0.0-20220101000000-abcdefabcdef\n" +
+			"\tgo.opentelemetry.io/testroot/v2 v2.2.2\n" +
+			")\n"),
+		filepath.Join(tmpRootDir, "test", "go.mod"): []byte("module go.opentelemetry.io/test3\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "go.mod"):         []byte("module go.opentelemetry.io/testroot/v2\n\ngo 1.16\n"),
+	}
+	require.NoError(t, commontest.WriteTempFiles(modFiles), "could not create go mod file tree")
+	worktree, err := common.GetWorktree(repo)
+	require.NoError(t, err)
+	require.NoError(t, worktree.AddGlob("."))
+	_, err = common.CommitChanges(context.Background(), "add modules", repo, commontest.TestAuthor, nil)
+	require.NoError(t, err)
+
+	return tmpRootDir, repo
+}
+
+func TestDependencyTags(t *testing.T) {
+	versioningFilename := filepath.Join(testDataDir, "verify_dependency_tags", "versions_valid.yaml")
+	tmpRootDir, _ := newDependencyTestRepo(t)
+
+	tagger, err := newTagger(versioningFilename, "mod-set-1", tmpRootDir, "HEAD", false)
+	require.NoError(t, err)
+
+	deps, err := tagger.dependencyTags()
+	require.NoError(t, err)
+	assert.Equal(t, []dependencyTag{
+		{
+			ModulePath:    "go.opentelemetry.io/testroot/v2",
+			Version:       "v2.2.2",
+			ModuleSetName: "mod-set-3",
+			TagName:       "v2.2.2",
+		},
+	}, deps)
+
+	tagger, err = newTagger(versioningFilename, "mod-set-3", tmpRootDir, "HEAD", false)
+	require.NoError(t, err)
+
+	deps, err = tagger.dependencyTags()
+	require.NoError(t, err)
+	assert.Empty(t, deps)
+}
+
+func TestVerifyDependencyTags(t *testing.T) {
+	versioningFilename := filepath.Join(testDataDir, "verify_dependency_tags", "versions_valid.yaml")
+	createTagOptions := &git.CreateTagOptions{
+		Message: "test tag message",
+		Tagger:  commontest.TestAuthor,
+	}
+
+	t.Run("missing", func(t *testing.T) {
+		tmpRootDir, _ := newDependencyTestRepo(t)
+		tagger, err := newTagger(versioningFilename, "mod-set-1", tmpRootDir, "HEAD", false)
+		require.NoError(t, err)
+
+		err = tagger.verifyDependencyTags(context.Background(), "upstream")
+		var missingErr *errDependencyTagsMissing
+		require.ErrorAs(t, err, &missingErr)
+		assert.EqualError(t, err, "module set mod-set-1 requires module versions that are not tagged, tag module set mod-set-3 before mod-set-1:\n"+
+			"go.opentelemetry.io/testroot/v2 v2.2.2 (module set mod-set-3, tag v2.2.2)")
+	})
+
+	t.Run("local", func(t *testing.T) {
+		tmpRootDir, repo := newDependencyTestRepo(t)
+		tagger, err := newTagger(versioningFilename, "mod-set-1", tmpRootDir, "HEAD", false)
+		require.NoError(t, err)
+
+		_, err = repo.CreateTag("v2.2.2", tagger.CommitHash, createTagOptions)
+		require.NoError(t, err)
+
+		assert.NoError(t, tagger.verifyDependencyTags(context.Background(), "upstream"))
+	})
+
+	t.Run("tagged along", func(t *testing.T) {
+		tmpRootDir, _ := newDependencyTestRepo(t)
+		tagger, err := newTagger(versioningFilename, "mod-set-1", tmpRootDir, "HEAD", false)
+		require.NoError(t, err)
+		tagger.pending = map[string]bool{"v2.2.2": true}
+
+		assert.NoError(t, tagger.verifyDependencyTags(context.Background(), "upstream"))
+	})
+
+	t.Run("remote", func(t *testing.T) {
+		tmpRootDir, repo := newDependencyTestRepo(t)
+		tagger, err := newTagger(versioningFilename, "mod-set-1", tmpRootDir, "HEAD", false)
+		require.NoError(t, err)
+
+		upstreamRepoDir := t.TempDir()
+		_, err = git.PlainInit(upstreamRepoDir, true)
+		require.NoError(t, err)
+		_, err = repo.CreateRemote(&config.RemoteConfig{Name: "upstream", URLs: []string{upstreamRepoDir}})
+		require.NoError(t, err)
+
+		_, err = repo.CreateTag("v2.2.2", tagger.CommitHash, createTagOptions)
+		require.NoError(t, err)
+		require.NoError(t, pushTags(context.Background(), []string{"v2.2.2"}, repo, "upstream"))
+		require.NoError(t, repo.DeleteTag("v2.2.2"))
+
+		assert.NoError(t, tagger.verifyDependencyTags(context.Background(), "upstream"))
+	})
+}
@@ -16,6 +16,7 @@ package tag
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/go-git/go-git/v5/plumbing"
@@ -30,6 +31,15 @@ func (e *errGitTagsNotOnCommit) Error() string {
 	return fmt.Sprintf("some git tags are not on commit %s:\n%s", e.commitHash, strings.Join(e.tagNames, "\n"))
 }
 
+type errCommitNotOnBranch struct {
+	commitHash plumbing.Hash
+	branch     string
+}
+
+func (e *errCommitNotOnBranch) Error() string {
+	return fmt.Sprintf("commit %s is not reachable from branch %s", e.commitHash, e.branch)
+}
+
 type errCouldNotGetCommitHash struct {
 	err error
 }
@@ -37,3 +47,36 @@ type errCouldNotGetCommitHash struct {
 func (e *errCouldNotGetCommitHash) Error() string {
 	return fmt.Sprintf("error getting full hash: %v", e.err)
 }
+
+type errDependencyTagsMissing struct {
+	modSetName string
+	deps       []dependencyTag
+}
+
+func (e *errDependencyTagsMissing) Error() string {
+	var setNames []string
+	seen := make(map[string]bool)
+	lines := make([]string, 0, len(e.deps))
+	for _, dep := range e.deps {
+		if !seen[dep.ModuleSetName] {
+			seen[dep.ModuleSetName] = true
+			setNames = append(setNames, dep.ModuleSetName)
+		}
+		lines = append(lines, fmt.Sprintf("%s %s (module set %s, tag %s)", dep.ModulePath, dep.Version, dep.ModuleSetName, dep.TagName))
+	}
+	sort.Strings(setNames)
+	return fmt.Sprintf("module set %s requires module versions that are not tagged, tag module set %s before %s:\n%s",
+		e.modSetName, strings.Join(setNames, ", "), e.modSetName, strings.Join(lines, "\n"))
+}
+
+type errProtectedTags struct {
+	tags []protectedTag
+}
+
+func (e *errProtectedTags) Error() string {
+	lines := make([]string, 0, len(e.tags))
+	for _, tag := range e.tags {
+		lines = append(lines, fmt.Sprintf("%s: %s", tag.TagName, tag.Reason))
+	}
+	return fmt.Sprintf("refusing to delete protected tags, use --force to delete them anyway:\n%s", strings.Join(lines, "\n"))
+}
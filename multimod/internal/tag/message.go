@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+)
+
+// defaultTagMessage is the template of the message of annotated tags used if
+// none is given with the tag-message field of the module set or the
+// --tag-message flag.
+const defaultTagMessage = "Module set {{.ModuleSet}}, Version {{.Version}}"
+
+// tagMessageData is the data the tag message template is executed with.
+type tagMessageData struct {
+	// ModuleSet is the name of the module set.
+	ModuleSet string
+	// Version is the version of the module set.
+	Version string
+	// Module is the import path of the tagged module.
+	Module string
+	// ModuleVersion is the version of the tagged module, which differs from
+	// Version if it is held back at a version override.
+	ModuleVersion string
+	// Tag is the full name of the tag.
+	Tag string
+	// Commit is the full hash of the tagged commit.
+	Commit string
+}
+
+// tagMessages returns the message of each tag of t, rendered from
+// t.MessageTemplate, the tag-message field of the module set, or the default
+// message, in that order of precedence.
+func (t tagger) tagMessages() (map[string]string, error) {
+	text := t.MessageTemplate
+	if text == "" {
+		text = t.ModSet.TagMessage
+	}
+	if text == "" {
+		text = defaultTagMessage
+	}
+	tmpl, err := template.New("tag-message").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tag message template: %w", err)
+	}
+
+	modPaths := make(map[string]common.ModulePath, len(t.ModSet.Modules))
+	for i, modFullTag := range t.ModuleFullTagNames() {
+		modPaths[modFullTag] = t.ModSet.Modules[i]
+	}
+
+	messages := make(map[string]string, len(t.fullTags))
+	for _, modFullTag := range t.fullTags {
+		modPath := modPaths[modFullTag]
+		var sb strings.Builder
+		if err = tmpl.Execute(&sb, tagMessageData{
+			ModuleSet:     t.ModSetName,
+			Version:       t.ModSetVersion(),
+			Module:        string(modPath),
+			ModuleVersion: t.ModuleVersion(modPath),
+			Tag:           modFullTag,
+			Commit:        t.CommitHash.String(),
+		}); err != nil {
+			return nil, fmt.Errorf("could not render tag message of %v: %w", modFullTag, err)
+		}
+		if strings.TrimSpace(sb.String()) == "" {
+			return nil, fmt.Errorf("tag message of %v is empty", modFullTag)
+		}
+		messages[modFullTag] = sb.String()
+	}
+	return messages, nil
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+	"go.opentelemetry.io/build-tools/multimod/internal/common/commontest"
+)
+
+func TestTagMessages(t *testing.T) {
+	versioningFilename := filepath.Join(testDataDir, "tag_all_modules", "versions_valid.yaml")
+
+	tmpRootDir := t.TempDir()
+	repo, _, err := commontest.InitNewRepoWithCommit(tmpRootDir)
+	require.NoError(t, err)
+
+	fullHash, err := common.CommitChangesToNewBranch(context.Background(), "test_commit", "commit used in a test", repo, commontest.TestAuthor, nil)
+	require.NoError(t, err)
+
+	modFiles := map[string][]byte{
+		filepath.Join(tmpRootDir, "test", "test1", "go.mod"):        []byte("module go.opentelemetry.io/test/test1\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "test", "test2", "go.mod"):        []byte("module go.opentelemetry.io/test2\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "test", "go.mod"):                 []byte("module go.opentelemetry.io/test3\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "go.mod"):                         []byte("module go.opentelemetry.io/testroot/v2\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "test", "testexcluded", "go.mod"): []byte("module go.opentelemetry.io/test/testexcluded\n\ngo 1.16\n"),
+	}
+	require.NoError(t, commontest.WriteTempFiles(modFiles), "could not create go mod file tree")
+
+	tagger, err := newTagger(versioningFilename, "mod-set-2", tmpRootDir, fullHash.String(), false)
+	require.NoError(t, err)
+
+	messages, err := tagger.tagMessages()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"test/test2/v0.1.0": "Module set mod-set-2, Version v0.1.0",
+		"test/v0.1.0":       "Module set mod-set-2, Version v0.1.0",
+	}, messages)
+
+	tagger.ModSet.TagMessage = "{{.ModuleSet}} {{.Version}}: https://example.com/releases/{{.Version}}"
+	messages, err = tagger.tagMessages()
+	require.NoError(t, err)
+	assert.Equal(t, "mod-set-2 v0.1.0: https://example.com/releases/v0.1.0", messages["test/v0.1.0"])
+
+	// The flag takes precedence over the module set.
+	tagger.MessageTemplate = "{{.Module}} {{.ModuleVersion}} ({{.Tag}}) at {{.Commit}}"
+	messages, err = tagger.tagMessages()
+	require.NoError(t, err)
+	assert.Equal(t, "go.opentelemetry.io/test2 v0.1.0 (test/test2/v0.1.0) at "+fullHash.String(), messages["test/test2/v0.1.0"])
+
+	require.NoError(t, tagger.tagAllModules(context.Background(), commontest.TestAuthor))
+	ref, err := repo.Tag("test/test2/v0.1.0")
+	require.NoError(t, err)
+	tagObj, err := repo.TagObject(ref.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, "go.opentelemetry.io/test2 v0.1.0 (test/test2/v0.1.0) at "+fullHash.String()+"\n", tagObj.Message)
+
+	tagger.MessageTemplate = "{{.Unknown}}"
+	_, err = tagger.tagMessages()
+	assert.ErrorContains(t, err, "could not render tag message of test/test2/v0.1.0")
+
+	tagger.MessageTemplate = "{{.ModuleSet"
+	_, err = tagger.tagMessages()
+	assert.ErrorContains(t, err, "invalid tag message template")
+
+	tagger.MessageTemplate = "{{if false}}x{{end}}"
+	_, err = tagger.tagMessages()
+	assert.ErrorContains(t, err, "tag message of test/test2/v0.1.0 is empty")
+}
@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"golang.org/x/mod/module"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+)
+
+const defaultGoProxy = "https://proxy.golang.org"
+
+// protectedTag is a tag that must not be deleted without --force.
+type protectedTag struct {
+	TagName string
+	Reason  string
+}
+
+// protectedTags returns the tags of the module set that are already published
+// on the module proxy or that are older than maxAge at now. The age is not
+// checked if maxAge is 0. Deleting a published tag and tagging another commit
+// with it makes the checksum of the module version change, which fails the
+// builds that already use it.
+func (t tagger) protectedTags(ctx context.Context, maxAge time.Duration, now time.Time) ([]protectedTag, error) {
+	proxies := goProxies()
+	noProxy := os.Getenv("GONOPROXY")
+	if noProxy == "" {
+		noProxy = os.Getenv("GOPRIVATE")
+	}
+
+	var protected []protectedTag
+	modPaths := t.ModuleSetRelease.ModSetPaths()
+	fullTags := make(map[string]bool, len(t.fullTags))
+	for _, tagName := range t.fullTags {
+		fullTags[tagName] = true
+	}
+	for i, tagName := range t.ModuleSetRelease.ModuleFullTagNames() {
+		if !fullTags[tagName] {
+			// Tag of a held back module from an earlier release.
+			continue
+		}
+		modPath := string(modPaths[i])
+		version := t.ModuleSetRelease.ModuleVersion(modPaths[i])
+
+		if !module.MatchPrefixPatterns(noProxy, modPath) {
+			for _, proxy := range proxies {
+				published, err := onProxy(ctx, proxy, modPath, version)
+				if err != nil {
+					return nil, fmt.Errorf("could not check if %s@%s is published: %w", modPath, version, err)
+				}
+				if published {
+					protected = append(protected, protectedTag{
+						TagName: tagName,
+						Reason:  fmt.Sprintf("%s@%s is published on %s", modPath, version, proxy),
+					})
+					break
+				}
+			}
+		}
+
+		if maxAge <= 0 {
+			continue
+		}
+		created, err := t.tagTime(tagName)
+		if err != nil {
+			return nil, err
+		}
+		if age := now.Sub(created); age > maxAge {
+			protected = append(protected, protectedTag{
+				TagName: tagName,
+				Reason:  fmt.Sprintf("created %s ago, more than %s", age.Round(time.Minute), maxAge),
+			})
+		}
+	}
+	return protected, nil
+}
+
+// verifyDeletable returns an error listing the protected tags of the module
+// set, if any.
+func (t tagger) verifyDeletable(ctx context.Context, maxAge time.Duration, now time.Time) error {
+	protected, err := t.protectedTags(ctx, maxAge, now)
+	if err != nil {
+		return err
+	}
+	if len(protected) > 0 {
+		return &errProtectedTags{tags: protected}
+	}
+	return nil
+}
+
+// goProxies returns the module proxies listed in GOPROXY, or the default
+// proxy if it is not set.
+func goProxies() []string {
+	env, ok := os.LookupEnv("GOPROXY")
+	if !ok || env == "" {
+		return []string{defaultGoProxy}
+	}
+	var proxies []string
+	for _, proxy := range strings.FieldsFunc(env, func(r rune) bool { return r == ',' || r == '|' }) {
+		if proxy == "direct" || proxy == "off" {
+			continue
+		}
+		proxies = append(proxies, strings.TrimSuffix(proxy, "/"))
+	}
+	return proxies
+}
+
+// onProxy returns whether version of the module with modPath is available on
+// the module proxy with URL proxy.
+func onProxy(ctx context.Context, proxy, modPath, version string) (bool, error) {
+	escPath, err := module.EscapePath(modPath)
+	if err != nil {
+		return false, err
+	}
+	escVersion, err := module.EscapeVersion(version)
+	if err != nil {
+		return false, err
+	}
+	url := fmt.Sprintf("%s/%s/@v/%s.info", proxy, escPath, escVersion)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound, http.StatusGone:
+		logging.Debugf("%s@%s is not published on %s", modPath, version, proxy)
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected status %s from %s", resp.Status, url)
+	}
+}
+
+// tagTime returns when the tag was created, or the commit time of the commit
+// it points to for lightweight tags.
+func (t tagger) tagTime(tagName string) (time.Time, error) {
+	ref, err := t.Repo.Tag(tagName)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to fetch git tag ref for %v: %w", tagName, err)
+	}
+	tagObj, err := t.Repo.TagObject(ref.Hash())
+	if err == nil {
+		return tagObj.Tagger.When, nil
+	}
+	if !errors.Is(err, plumbing.ErrObjectNotFound) {
+		return time.Time{}, fmt.Errorf("unable to get tag object: %w", err)
+	}
+	commit, err := t.Repo.CommitObject(ref.Hash())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("could not get tag commit: %w", err)
+	}
+	return commit.Committer.When, nil
+}
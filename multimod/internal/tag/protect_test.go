@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tag
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+	"go.opentelemetry.io/build-tools/multimod/internal/common/commontest"
+)
+
+func TestProtectedTags(t *testing.T) {
+	versioningFilename := filepath.Join(testDataDir, "delete_module_set_tags", "versions_valid.yaml")
+	now := time.Date(2022, time.November, 1, 12, 0, 0, 0, time.UTC)
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/go.opentelemetry.io/testroot/v2/@v/v2.2.2.info":
+			_, _ = w.Write([]byte(`{"Version":"v2.2.2"}`))
+		case "/go.opentelemetry.io/test2/@v/v0.1.0.info":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(proxy.Close)
+
+	testCases := []struct {
+		name       string
+		modSetName string
+		tagAge     time.Duration
+		maxAge     time.Duration
+		goProxy    string
+		noProxy    string
+		want       []protectedTag
+		wantErr    string
+	}{
+		{
+			name:       "unpublished_recent",
+			modSetName: "mod-set-1",
+			tagAge:     time.Hour,
+			maxAge:     24 * time.Hour,
+			goProxy:    proxy.URL + ",direct",
+		},
+		{
+			name:       "published",
+			modSetName: "mod-set-3",
+			tagAge:     time.Hour,
+			maxAge:     24 * time.Hour,
+			goProxy:    "off|" + proxy.URL + "/",
+			want: []protectedTag{
+				{TagName: "v2.2.2", Reason: "go.opentelemetry.io/testroot/v2@v2.2.2 is published on " + proxy.URL},
+			},
+		},
+		{
+			name:       "published_private",
+			modSetName: "mod-set-3",
+			tagAge:     time.Hour,
+			maxAge:     24 * time.Hour,
+			goProxy:    proxy.URL,
+			noProxy:    "go.opentelemetry.io/testroot",
+		},
+		{
+			name:       "old",
+			modSetName: "mod-set-1",
+			tagAge:     72 * time.Hour,
+			maxAge:     24 * time.Hour,
+			goProxy:    proxy.URL,
+			want: []protectedTag{
+				{TagName: "test/test1/v1.2.3-RC1+meta", Reason: "created 72h0m0s ago, more than 24h0m0s"},
+			},
+		},
+		{
+			name:       "old_age_check_disabled",
+			modSetName: "mod-set-1",
+			tagAge:     72 * time.Hour,
+			goProxy:    proxy.URL,
+		},
+		{
+			name:       "proxy_error",
+			modSetName: "mod-set-2",
+			tagAge:     time.Hour,
+			goProxy:    proxy.URL,
+			wantErr:    "could not check if go.opentelemetry.io/test2@v0.1.0 is published: unexpected status 500 Internal Server Error from " + proxy.URL + "/go.opentelemetry.io/test2/@v/v0.1.0.info",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("GOPROXY", tc.goProxy)
+			t.Setenv("GONOPROXY", tc.noProxy)
+			t.Setenv("GOPRIVATE", "")
+
+			tmpRootDir := t.TempDir()
+			repo, _, err := commontest.InitNewRepoWithCommit(tmpRootDir)
+			require.NoError(t, err)
+			fullHash, err := common.CommitChangesToNewBranch(context.Background(), "test_commit", "commit used in a test", repo, commontest.TestAuthor, nil)
+			require.NoError(t, err)
+
+			modFiles := map[string][]byte{
+				filepath.Join(tmpRootDir, "test", "test1", "go.mod"): []byte("module go.opentelemetry.io/test/test1\n\ngo 1.16\n"),
+				filepath.Join(tmpRootDir, "test", "go.mod"):          []byte("module go.opentelemetry.io/test2\n\ngo 1.16\n"),
+				filepath.Join(tmpRootDir, "go.mod"):                  []byte("module go.opentelemetry.io/testroot/v2\n\ngo 1.16\n"),
+			}
+			require.NoError(t, commontest.WriteTempFiles(modFiles), "could not create go mod file tree")
+
+			tagger, err := newTagger(versioningFilename, tc.modSetName, tmpRootDir, fullHash.String(), false)
+			require.NoError(t, err)
+			for _, tagName := range tagger.ModuleFullTagNames() {
+				_, err = repo.CreateTag(tagName, fullHash, &git.CreateTagOptions{
+					Message: "test tag message",
+					Tagger: &object.Signature{
+						Name:  commontest.TestAuthor.Name,
+						Email: commontest.TestAuthor.Email,
+						When:  now.Add(-tc.tagAge),
+					},
+				})
+				require.NoError(t, err)
+			}
+
+			got, err := tagger.protectedTags(context.Background(), tc.maxAge, now)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+
+			err = tagger.verifyDeletable(context.Background(), tc.maxAge, now)
+			if len(tc.want) == 0 {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, "refusing to delete protected tags, use --force to delete them anyway:\n"+tc.want[0].TagName+": ")
+			}
+		})
+	}
+}
+
+func TestGoProxies(t *testing.T) {
+	t.Setenv("GOPROXY", "")
+	assert.Equal(t, []string{defaultGoProxy}, goProxies())
+
+	t.Setenv("GOPROXY", "https://proxy.example.com/,https://other.example.com|direct")
+	assert.Equal(t, []string{"https://proxy.example.com", "https://other.example.com"}, goProxies())
+
+	t.Setenv("GOPROXY", "off")
+	assert.Empty(t, goProxies())
+}
@@ -15,53 +15,202 @@
 package tag
 
 import (
+	"bufio"
+	"context"
 	"errors"
 	"fmt"
-	"log"
+	"io"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
 
-	"github.com/go-git/go-git/v5/config"
-
+	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"go.uber.org/multierr"
 
+	"go.opentelemetry.io/build-tools/internal/logging"
 	"go.opentelemetry.io/build-tools/internal/repo"
 	"go.opentelemetry.io/build-tools/multimod/internal/common"
 )
 
-func Run(versioningFile, moduleSetName, commitHash string, deleteModuleSetTags bool, shouldPushTags bool, remote string) {
+// Options configure Run.
+type Options struct {
+	// VersioningFile is the path of the versioning file.
+	VersioningFile string
+	// ModuleSetNames are the names of the module sets to tag.
+	ModuleSetNames []string
+	// AllModuleSets tags all the module sets of the versioning file instead
+	// of ModuleSetNames, skipping frozen and already tagged module sets.
+	AllModuleSets bool
+	// CommitHash is the commit to tag.
+	CommitHash string
+	// DeleteModuleSetTags deletes the tags of the module sets from the commit
+	// instead of creating them.
+	DeleteModuleSetTags bool
+	// DeleteRemote is the remote the deleted tags are also deleted from, if
+	// not empty.
+	DeleteRemote string
+	// Yes deletes tags from DeleteRemote without asking for confirmation.
+	Yes bool
+	// Force deletes tags even if they are protected because they are
+	// published on the module proxy or older than MaxTagAge.
+	Force bool
+	// MaxTagAge is the maximum age of the tags deleted without Force. 0
+	// disables the check.
+	MaxTagAge time.Duration
+	// Push pushes the created tags to Remote.
+	Push bool
+	// Remote is the remote the tags are pushed to and dependency tags are
+	// looked up on.
+	Remote string
+	// Unfreeze tags module sets even if they are frozen.
+	Unfreeze bool
+	// SignKeyPath is the path of the OpenPGP key signing the tags. If empty,
+	// tags are created and signed by the git executable.
+	SignKeyPath string
+	// DryRun prints the tags that would be created, or deleted, without
+	// changing them.
+	DryRun bool
+	// RequireBranch is the branch the commit must be reachable from, if not
+	// empty.
+	RequireBranch string
+	// TagMessage is the template of the tag messages, overriding the
+	// tag-message of the module sets, if not empty.
+	TagMessage string
+}
+
+// Run tags the commit with the tags of the module sets of opts, or deletes
+// them. The checks of all the module sets are done before any tag is created,
+// and if tagging or pushing fails the tags created for all the module sets are
+// removed. Deleted tags are also deleted from opts.DeleteRemote if it is not
+// empty, after confirmation unless opts.Yes is set.
+func Run(ctx context.Context, opts Options) {
+	moduleSetNames := opts.ModuleSetNames
 
 	repoRoot, err := repo.FindRoot()
 	if err != nil {
-		log.Fatalf("unable to change to repo root: %v", err)
+		logging.Fatalf("unable to change to repo root: %v", err)
 	}
 
-	t, err := newTagger(versioningFile, moduleSetName, repoRoot, commitHash, deleteModuleSetTags)
-	if err != nil {
-		log.Fatalf("Error creating new tagger struct: %v", err)
+	if opts.AllModuleSets {
+		moduleSetNames, err = common.GetAllModuleSetNames(opts.VersioningFile, repoRoot)
+		if err != nil {
+			logging.Fatalf("could not automatically get all module set names: %v", err)
+		}
+	}
+
+	var signKey *openpgp.Entity
+	if opts.SignKeyPath != "" && !opts.DeleteModuleSetTags {
+		signKey, err = common.LoadSignKey(opts.SignKeyPath, []byte(os.Getenv(common.SignKeyPassphraseEnv)))
+		if err != nil {
+			logging.Fatalf("%v", err)
+		}
+	}
+
+	var taggers []tagger
+	pending := make(map[string]bool)
+	for _, moduleSetName := range moduleSetNames {
+		t, err := newTagger(opts.VersioningFile, moduleSetName, repoRoot, opts.CommitHash, opts.DeleteModuleSetTags)
+		if err != nil {
+			if opts.AllModuleSets && errors.As(err, &common.ErrGitTagsAlreadyExist{}) {
+				logging.Infof("Module set %v already tagged. Skipping...", moduleSetName)
+				continue
+			}
+			logging.Fatalf("Error creating new tagger struct: %v", err)
+		}
+		t.SignKey = signKey
+		t.MessageTemplate = opts.TagMessage
+		t.pending = pending
+
+		if err := t.CheckNotFrozen(opts.Unfreeze); err != nil {
+			if opts.AllModuleSets {
+				logging.Infof("Module set %v is frozen. Skipping...", moduleSetName)
+				continue
+			}
+			logging.Fatalf("%v", err)
+		}
+		if t.ModSet.Frozen {
+			logging.Warnf("Module set %v is frozen, tagging it anyway", moduleSetName)
+		}
+
+		for _, tagName := range t.fullTags {
+			pending[tagName] = true
+		}
+		taggers = append(taggers, t)
 	}
 
 	// if delete-module-set-tags is specified, then delete all newModTagNames
-	// whose versions match the one in the versioning file. Otherwise, tag all
-	// modules in the given set.
-	if deleteModuleSetTags {
-		if err := t.deleteModuleSetTags(); err != nil {
-			log.Fatalf("Error deleting tags for the specified module set: %v", err)
+	// whose versions match the one in the versioning file, unless they are
+	// protected. Otherwise, tag all modules in the given sets.
+	if opts.DeleteModuleSetTags {
+		if !opts.Force {
+			for _, t := range taggers {
+				if err := t.verifyDeletable(ctx, opts.MaxTagAge, time.Now()); err != nil {
+					logging.Fatalf("Error deleting tags for module set %v: %v", t.ModSetName, err)
+				}
+			}
+		}
+		if opts.DeleteRemote != "" && !opts.DryRun && !opts.Yes && !confirmRemoteDeletion(os.Stdin, os.Stderr, taggers, opts.DeleteRemote) {
+			logging.Fatalf("Aborted, no tag was deleted")
+		}
+		for _, t := range taggers {
+			if opts.DryRun {
+				t.writePlan(os.Stdout, true, opts.DeleteRemote)
+				continue
+			}
+			// The remote tags are deleted first so that the local tags are
+			// left to retry if it fails.
+			if opts.DeleteRemote != "" {
+				if err := deleteRemoteTags(ctx, t.fullTags, t.Repo, opts.DeleteRemote); err != nil {
+					logging.Fatalf("Error deleting tags for module set %v: %v", t.ModSetName, err)
+				}
+				logging.Infof("Deleted module tags of module set %v from %v", t.ModSetName, opts.DeleteRemote)
+			}
+			if err := t.deleteModuleSetTags(); err != nil {
+				logging.Fatalf("Error deleting tags for module set %v: %v", t.ModSetName, err)
+			}
+			logging.Infof("Successfully deleted module tags of module set %v", t.ModSetName)
 		}
+		return
+	}
 
-		fmt.Println("Successfully deleted module tags")
-	} else {
-		if err := t.tagAllModules(nil); err != nil {
-			log.Fatalf("unable to tag modules: %v", err)
+	for _, t := range taggers {
+		if opts.RequireBranch != "" {
+			if err := t.verifyOnBranch(opts.RequireBranch); err != nil {
+				logging.Fatalf("unable to tag modules: %v", err)
+			}
+		}
+		if err := t.verifyDependencyTags(ctx, opts.Remote); err != nil {
+			logging.Fatalf("unable to tag modules: %v", err)
+		}
+		if _, err := t.tagMessages(); err != nil {
+			logging.Fatalf("unable to tag modules of module set %v: %v", t.ModSetName, err)
 		}
 	}
+	if opts.DryRun {
+		pushTo := ""
+		if opts.Push {
+			pushTo = opts.Remote
+		}
+		for _, t := range taggers {
+			t.writePlan(os.Stdout, false, pushTo)
+		}
+		return
+	}
+	if err := tagModuleSets(ctx, taggers, nil); err != nil {
+		logging.Fatalf("unable to tag modules: %v", err)
+	}
 
-	if shouldPushTags {
-		if err := pushTags(t.ModuleSetRelease.ModuleFullTagNames(), t.Repo, remote); err != nil {
-			log.Fatalf("failed to pushTags tags: %v", err)
+	if opts.Push {
+		if err := pushModuleSetsTags(ctx, taggers, opts.Remote); err != nil {
+			logging.Fatalf("unable to push tags: %v", err)
 		}
+		logging.Infof("Pushed module tags to %v", opts.Remote)
 	}
 }
 
@@ -69,15 +218,33 @@ type tagger struct {
 	common.ModuleSetRelease
 	CommitHash plumbing.Hash
 	Repo       *git.Repository
+	// SignKey is the key signing the tags. If nil, tags are created and
+	// signed by the git executable.
+	SignKey *openpgp.Entity
+	// MessageTemplate is the template of the message of the tags. If empty,
+	// the tag-message of the module set, or a default message, is used.
+	MessageTemplate string
+
+	// fullTags are the full tag names of the modules to tag or delete.
+	fullTags []string
+	// pending are the tags created for the module sets tagged along, which
+	// count as existing dependency tags.
+	pending  map[string]bool
+	repoRoot string
 }
 
 func newTagger(versioningFilename, modSetToUpdate, repoRoot, hash string, deleteModuleSetTags bool) (tagger, error) {
+	repoRoot, err := filepath.Abs(repoRoot)
+	if err != nil {
+		return tagger{}, fmt.Errorf("could not get absolute path of repo root: %w", err)
+	}
+
 	modRelease, err := common.NewModuleSetRelease(versioningFilename, modSetToUpdate, repoRoot)
 	if err != nil {
 		return tagger{}, fmt.Errorf("error creating tagger struct: %w", err)
 	}
 
-	repo, err := git.PlainOpen(repoRoot)
+	repo, err := common.OpenRepo(repoRoot)
 	if err != nil {
 		return tagger{}, fmt.Errorf("could not open repo at %v: %w", repoRoot, err)
 	}
@@ -87,7 +254,10 @@ func newTagger(versioningFilename, modSetToUpdate, repoRoot, hash string, delete
 		return tagger{}, fmt.Errorf("could not get full commit hash of given hash %v: %w", hash, err)
 	}
 
-	modFullTagNames := modRelease.ModuleFullTagNames()
+	modFullTagNames, err := moduleSetTags(modRelease, repo, fullCommitHash, deleteModuleSetTags)
+	if err != nil {
+		return tagger{}, err
+	}
 
 	if deleteModuleSetTags {
 		if err = verifyTagsOnCommit(modFullTagNames, repo, fullCommitHash); err != nil {
@@ -103,34 +273,56 @@ func newTagger(versioningFilename, modSetToUpdate, repoRoot, hash string, delete
 		ModuleSetRelease: modRelease,
 		CommitHash:       fullCommitHash,
 		Repo:             repo,
+		fullTags:         modFullTagNames,
+		repoRoot:         repoRoot,
 	}, nil
 }
 
-func verifyTagsOnCommit(modFullTagNames []string, repo *git.Repository, targetCommitHash plumbing.Hash) error {
-	var tagsNotOnCommit []string
-
-	for _, tagName := range modFullTagNames {
-		tagRef, tagRefErr := repo.Tag(tagName)
+// moduleSetTags returns the full tag names of the modules in modRelease to
+// create, or to delete from the commit at hash if deleting is set. The
+// existing tags of modules held back at a version override are left out, as
+// they belong to an earlier release, unless deleting and they are on the
+// commit.
+func moduleSetTags(modRelease common.ModuleSetRelease, repo *git.Repository, hash plumbing.Hash, deleting bool) ([]string, error) {
+	tagIndex, err := common.NewTagIndex(repo)
+	if err != nil {
+		return nil, err
+	}
+	if !deleting {
+		return modRelease.NewModuleFullTagNames(tagIndex), nil
+	}
 
-		if tagRefErr != nil {
-			if errors.Is(tagRefErr, git.ErrTagNotFound) {
-				tagsNotOnCommit = append(tagsNotOnCommit, tagName)
+	var modFullTags []string
+	for i, modFullTag := range modRelease.ModuleFullTagNames() {
+		if modRelease.ModSet.IsHeldBack(modRelease.ModSet.Modules[i]) {
+			tagHash, exists, err := tagIndex.CommitHash(repo, modFullTag)
+			if err != nil {
+				return nil, err
+			}
+			if !exists || tagHash != hash {
 				continue
 			}
-			return fmt.Errorf("unable to fetch git tag ref for %v: %w", tagName, tagRefErr)
 		}
+		modFullTags = append(modFullTags, modFullTag)
+	}
+	return modFullTags, nil
+}
 
-		tagObj, tagObjErr := repo.TagObject(tagRef.Hash())
-		if tagObjErr != nil {
-			return fmt.Errorf("unable to get tag object: %w", tagObjErr)
-		}
+func verifyTagsOnCommit(modFullTagNames []string, repo *git.Repository, targetCommitHash plumbing.Hash) error {
+	tagIndex, err := common.NewTagIndex(repo)
+	if err != nil {
+		return err
+	}
+
+	var tagsNotOnCommit []string
 
-		tagCommit, tagCommitErr := tagObj.Commit()
-		if tagCommitErr != nil {
-			return fmt.Errorf("could not get tag object commit: %w", tagCommitErr)
+	for _, tagName := range modFullTagNames {
+		tagCommitHash, exists, err := tagIndex.CommitHash(repo, tagName)
+		if err != nil {
+			return err
 		}
 
-		if targetCommitHash != tagCommit.Hash {
+		if !exists || targetCommitHash != tagCommitHash {
 			tagsNotOnCommit = append(tagsNotOnCommit, tagName)
 		}
 	}
@@ -155,7 +347,7 @@ func getFullCommitHash(hash string, repo *git.Repository) (plumbing.Hash, error)
 }
 
 func (t tagger) deleteModuleSetTags() error {
-	modFullTagsToDelete := t.ModuleSetRelease.ModuleFullTagNames()
+	modFullTagsToDelete := t.fullTags
 
 	if err := deleteTags(modFullTagsToDelete, t.Repo); err != nil {
 		return fmt.Errorf("unable to delete module tags: %w", err)
@@ -168,7 +360,7 @@ func (t tagger) deleteModuleSetTags() error {
 // created tags if the new module tagging fails.
 func deleteTags(modFullTags []string, repo *git.Repository) error {
 	for _, modFullTag := range modFullTags {
-		log.Printf("Deleting tag %v\n", modFullTag)
+		logging.Debugf("Deleting tag %v", modFullTag)
 
 		if err := repo.DeleteTag(modFullTag); err != nil {
 			return err
@@ -177,46 +369,61 @@ func deleteTags(modFullTags []string, repo *git.Repository) error {
 	return nil
 }
 
-func (t tagger) tagAllModules(customTagger *object.Signature) error {
-	modFullTags := t.ModuleSetRelease.ModuleFullTagNames()
+// tagAllModules tags the commit with the full tag name of every module in the
+// module set. Tags are created with go-git if customTagger or t.SignKey is
+// set, otherwise with the git executable. If tagging fails, including because
+// ctx is done, the tags already created are removed.
+func (t tagger) tagAllModules(ctx context.Context, customTagger *object.Signature) error {
+	modFullTags := t.fullTags
 
-	tagMessage := fmt.Sprintf("Module set %v, Version %v",
-		t.ModuleSetRelease.ModSetName, t.ModuleSetRelease.ModSetVersion())
+	tagMessages, err := t.tagMessages()
+	if err != nil {
+		return err
+	}
 
 	var addedFullTags []string
 
-	log.Printf("Tagging commit %s:\n", t.CommitHash)
+	// Run git in the working tree root so tags are created in the right
+	// repository, also when it is a linked worktree.
+	useGit := customTagger == nil && t.SignKey == nil
+	var tagDir string
+	if useGit {
+		worktree, err := common.GetWorktree(t.Repo)
+		if err != nil {
+			return err
+		}
+		tagDir = worktree.Filesystem.Root()
+	}
+
+	logging.Infof("Tagging commit %s:", t.CommitHash)
 
 	for _, newFullTag := range modFullTags {
-		log.Printf("%v\n", newFullTag)
+		logging.Debugf("%v", newFullTag)
 
 		var err error
-		if customTagger == nil {
-			cfg, err2 := t.Repo.Config()
-			if err2 != nil {
-				err = fmt.Errorf("unable to load repo config: %w", err2)
-				if cfg == nil || cfg.Core.Worktree == "" {
-					// This is not recoverable, do not panic below.
-					return err
-				}
-			}
+		switch {
+		case ctx.Err() != nil:
+			// Interrupted, remove the tags added so far below.
+			err = ctx.Err()
+		case useGit:
 			// TODO: figure out how to use go-git and gpg-agent without needing to have decrypted private key material
 			// #nosec G204
-			cmd := exec.Command("git", "tag", "-a", "-s", "-m", tagMessage, newFullTag, t.CommitHash.String())
-			cmd.Dir = cfg.Core.Worktree
+			cmd := exec.CommandContext(ctx, "git", "tag", "-a", "-s", "-m", tagMessages[newFullTag], newFullTag, t.CommitHash.String())
+			cmd.Dir = tagDir
 			output, err2 := cmd.CombinedOutput()
 			if err2 != nil {
 				err = fmt.Errorf("unable to create tag: %q: %w", string(output), err2)
 			}
-		} else {
+		default:
 			_, err = t.Repo.CreateTag(newFullTag, t.CommitHash, &git.CreateTagOptions{
-				Message: tagMessage,
+				Message: tagMessages[newFullTag],
 				Tagger:  customTagger,
+				SignKey: t.SignKey,
 			})
 		}
 
 		if err != nil {
-			log.Println("error creating a tag, removing all newly created tags...")
+			logging.Warnf("error creating a tag, removing all newly created tags...")
 			err = fmt.Errorf("git tag failed for %v: %w", newFullTag, err)
 			// remove newly created tags to prevent inconsistencies
 			if delTagsErr := deleteTags(addedFullTags, t.Repo); delTagsErr != nil {
@@ -229,33 +436,170 @@ func (t tagger) tagAllModules(customTagger *object.Signature) error {
 		addedFullTags = append(addedFullTags, newFullTag)
 	}
 
+	logging.Infof("Tagged %d modules", len(addedFullTags))
 	return nil
 }
 
-func pushTags(tagsToPush []string, repo *git.Repository, remote string) error {
+// writePlan writes to w the tags that would be created, or deleted if
+// deleting is set, and the remote they would be pushed to, or also deleted
+// from, if remote is not empty.
+func (t tagger) writePlan(w io.Writer, deleting bool, remote string) {
+	action := "create"
+	if deleting {
+		action = "delete"
+	}
+	fmt.Fprintf(w, "Module set %v, version %v, commit %v\n",
+		t.ModuleSetRelease.ModSetName, t.ModuleSetRelease.ModSetVersion(), t.CommitHash)
+	fmt.Fprintf(w, "Tags to %v:\n", action)
+	for _, tagName := range t.fullTags {
+		fmt.Fprintf(w, "  %v\n", tagName)
+	}
+	switch {
+	case remote != "" && deleting:
+		fmt.Fprintf(w, "Tags would also be deleted from %v\n", remote)
+	case remote != "":
+		fmt.Fprintf(w, "Tags would be pushed to %v\n", remote)
+	}
+}
 
-	for _, fullTageName := range tagsToPush {
-		tagref, err := repo.Tag(fullTageName)
-		if err != nil {
-			return fmt.Errorf("unable to fetch git tag ref for %v: %w", fullTageName, err)
+// tagModuleSets tags the commits of taggers with the tags of their module
+// sets, in order. If tagging a module set fails, the tags created for the
+// module sets tagged before are removed too, so either all the module sets
+// are tagged or none.
+func tagModuleSets(ctx context.Context, taggers []tagger, customTagger *object.Signature) error {
+	for i, t := range taggers {
+		if len(taggers) > 1 {
+			logging.Infof("===== Module Set: %v =====", t.ModSetName)
 		}
-		refName := fmt.Sprintf("%s:%s", tagref.Name(), tagref.Name())
-		rs := config.RefSpec(refName)
-		err = rs.Validate()
-		if err != nil {
-			return fmt.Errorf("failed validation for refspec %s:%w", rs.String(), err)
+		err := t.tagAllModules(ctx, customTagger)
+		if err == nil {
+			continue
 		}
-		err = repo.Push(&git.PushOptions{
-			RefSpecs:   []config.RefSpec{rs},
-			RemoteName: remote,
-		})
-		if err != nil {
-			if errors.Is(err, git.NoErrAlreadyUpToDate) {
-				log.Printf("tag %s is is already present on remote %s", tagref.Name(), remote)
-			} else {
-				return fmt.Errorf("error pushing tag %s:%w", tagref.Name(), err)
+
+		if i > 0 {
+			logging.Warnf("removing the tags of the module sets tagged before...")
+		}
+		for _, tagged := range taggers[:i] {
+			if delTagsErr := deleteTags(tagged.fullTags, tagged.Repo); delTagsErr != nil {
+				return multierr.Combine(err, fmt.Errorf("during handling of the above error, failed to not remove all tags: %w", delTagsErr))
 			}
 		}
+		return err
+	}
+	return nil
+}
+
+// pushModuleSetsTags pushes the tags of the module sets of taggers, which
+// share their repository, to remote in a single push. If the push fails, the
+// local tags are removed so tagging can be retried.
+func pushModuleSetsTags(ctx context.Context, taggers []tagger, remote string) error {
+	if len(taggers) == 0 {
+		return nil
+	}
+	var modFullTags []string
+	for _, t := range taggers {
+		modFullTags = append(modFullTags, t.fullTags...)
+	}
+	repo := taggers[0].Repo
+
+	err := pushTags(ctx, modFullTags, repo, remote)
+	if err == nil {
+		return nil
+	}
+
+	logging.Warnf("error pushing tags, removing all newly created tags...")
+	if delTagsErr := deleteTags(modFullTags, repo); delTagsErr != nil {
+		return multierr.Combine(err, fmt.Errorf("during handling of the above error, failed to not remove all tags: %w", delTagsErr))
+	}
+	return err
+}
+
+// pushTags pushes the tags in tagsToPush to remote in a single push.
+func pushTags(ctx context.Context, tagsToPush []string, repo *git.Repository, remote string) error {
+	auth, err := common.RepoRemoteAuth(repo, remote)
+	if err != nil {
+		return fmt.Errorf("unable to get credentials for remote %s: %w", remote, err)
+	}
+
+	refSpecs := make([]config.RefSpec, 0, len(tagsToPush))
+	for _, fullTagName := range tagsToPush {
+		tagref, err := repo.Tag(fullTagName)
+		if err != nil {
+			return fmt.Errorf("unable to fetch git tag ref for %v: %w", fullTagName, err)
+		}
+		rs := config.RefSpec(fmt.Sprintf("%s:%s", tagref.Name(), tagref.Name()))
+		if err := rs.Validate(); err != nil {
+			return fmt.Errorf("failed validation for refspec %s: %w", rs.String(), err)
+		}
+		refSpecs = append(refSpecs, rs)
+	}
+
+	err = repo.PushContext(ctx, &git.PushOptions{
+		RefSpecs:   refSpecs,
+		RemoteName: remote,
+		Auth:       auth,
+	})
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		logging.Infof("tags are already present on remote %s", remote)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error pushing tags to %s: %w", remote, common.ClassifyRemoteError(err))
+	}
+	return nil
+}
+
+// deleteRemoteTags deletes the tags in tagsToDelete from remote in a single
+// push. Tags missing from the remote are ignored.
+func deleteRemoteTags(ctx context.Context, tagsToDelete []string, repo *git.Repository, remote string) error {
+	auth, err := common.RepoRemoteAuth(repo, remote)
+	if err != nil {
+		return fmt.Errorf("unable to get credentials for remote %s: %w", remote, err)
+	}
+
+	refSpecs := make([]config.RefSpec, 0, len(tagsToDelete))
+	for _, fullTagName := range tagsToDelete {
+		rs := config.RefSpec(":" + plumbing.NewTagReferenceName(fullTagName).String())
+		if err := rs.Validate(); err != nil {
+			return fmt.Errorf("failed validation for refspec %s: %w", rs.String(), err)
+		}
+		refSpecs = append(refSpecs, rs)
+	}
+
+	err = repo.PushContext(ctx, &git.PushOptions{
+		RefSpecs:   refSpecs,
+		RemoteName: remote,
+		Auth:       auth,
+	})
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		logging.Infof("tags are already absent from remote %s", remote)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error deleting tags from %s: %w", remote, common.ClassifyRemoteError(err))
 	}
 	return nil
 }
+
+// confirmRemoteDeletion asks on out whether to delete the tags of taggers
+// from remote and returns whether the answer read from in is yes.
+func confirmRemoteDeletion(in io.Reader, out io.Writer, taggers []tagger, remote string) bool {
+	var n int
+	names := make([]string, 0, len(taggers))
+	for _, t := range taggers {
+		n += len(t.fullTags)
+		names = append(names, t.ModSetName)
+	}
+	fmt.Fprintf(out, "Delete %d tags of module sets %v from remote %v? [y/N]: ", n, strings.Join(names, ", "), remote)
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Fprintln(out)
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
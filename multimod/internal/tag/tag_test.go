@@ -15,11 +15,13 @@
 package tag
 
 import (
+	"context"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/go-git/go-git/v5/config"
@@ -31,6 +33,7 @@ import (
 
 	"go.opentelemetry.io/build-tools/multimod/internal/common"
 	"go.opentelemetry.io/build-tools/multimod/internal/common/commontest"
+	"go.opentelemetry.io/build-tools/multimod/releasetest"
 )
 
 var (
@@ -51,7 +54,7 @@ func TestNewTagger(t *testing.T) {
 	repo, _, err := commontest.InitNewRepoWithCommit(tmpRootDir)
 	require.NoError(t, err)
 
-	fullHash, err := common.CommitChangesToNewBranch("test_commit", "commit used in a test", repo, commontest.TestAuthor)
+	fullHash, err := common.CommitChangesToNewBranch(context.Background(), "test_commit", "commit used in a test", repo, commontest.TestAuthor, nil)
 	require.NoError(t, err)
 	hashPrefix := fullHash.String()[:8]
 
@@ -154,35 +157,22 @@ func TestNewTagger(t *testing.T) {
 }
 
 func TestVerifyTagsOnCommit(t *testing.T) {
-	tmpRootDir := t.TempDir()
-	repo, firstHash, err := commontest.InitNewRepoWithCommit(tmpRootDir)
+	repo, firstHash, err := releasetest.InitNewMemoryRepoWithCommit(nil)
 	require.NoError(t, err)
 
-	secondHash, err := common.CommitChangesToNewBranch("test_commit", "commit used in a test", repo, commontest.TestAuthor)
+	secondHash, err := common.CommitChangesToNewBranch(context.Background(), "test_commit", "commit used in a test", repo, commontest.TestAuthor, nil)
 	require.NoError(t, err)
 
-	createTagOptions := &git.CreateTagOptions{
-		Message: "test tag message",
-		Tagger:  commontest.TestAuthor,
-	}
-
-	for _, tagName := range []string{
+	require.NoError(t, releasetest.CreateTags(repo, firstHash,
 		"test_tag_first_hash_1/v1.0.0",
 		"test_tag_first_hash_2/v1.0.0",
 		"test_tag_first_hash_3/v1.0.0",
-	} {
-		_, err = repo.CreateTag(tagName, firstHash, createTagOptions)
-		require.NoError(t, err)
-	}
-
-	for _, tagName := range []string{
+	))
+	require.NoError(t, releasetest.CreateTags(repo, secondHash,
 		"test_tag_second_hash_1/v1.0.0",
 		"test_tag_second_hash_2/v1.0.0",
 		"test_tag_second_hash_3/v1.0.0",
-	} {
-		_, err = repo.CreateTag(tagName, secondHash, createTagOptions)
-		require.NoError(t, err)
-	}
+	))
 
 	testCases := []struct {
 		name           string
@@ -265,7 +255,7 @@ func TestGetFullCommitHash(t *testing.T) {
 	repo, _, err := commontest.InitNewRepoWithCommit(tmpRootDir)
 	require.NoError(t, err)
 
-	fullHash, err := common.CommitChangesToNewBranch("test_commit", "commit used in a test", repo, commontest.TestAuthor)
+	fullHash, err := common.CommitChangesToNewBranch(context.Background(), "test_commit", "commit used in a test", repo, commontest.TestAuthor, nil)
 	require.NoError(t, err)
 	hashPrefix := fullHash.String()[:8]
 
@@ -319,7 +309,7 @@ func TestDeleteModuleSetTags(t *testing.T) {
 	repo, _, err := commontest.InitNewRepoWithCommit(tmpRootDir)
 	require.NoError(t, err)
 
-	fullHash, err := common.CommitChangesToNewBranch("test_commit", "commit used in a test", repo, commontest.TestAuthor)
+	fullHash, err := common.CommitChangesToNewBranch(context.Background(), "test_commit", "commit used in a test", repo, commontest.TestAuthor, nil)
 	require.NoError(t, err)
 	hashPrefix := fullHash.String()[:8]
 
@@ -385,6 +375,47 @@ func TestDeleteModuleSetTags(t *testing.T) {
 	}
 }
 
+func TestModuleSetTagsHeldBack(t *testing.T) {
+	versioningFilename := filepath.Join(testDataDir, "module_set_tags", "versions_valid.yaml")
+
+	tmpRootDir := t.TempDir()
+	repo, firstHash, err := commontest.InitNewRepoWithCommit(tmpRootDir)
+	require.NoError(t, err)
+	secondHash, err := common.CommitChangesToNewBranch(context.Background(), "test_commit", "commit used in a test", repo, commontest.TestAuthor, nil)
+	require.NoError(t, err)
+
+	modFiles := map[string][]byte{
+		filepath.Join(tmpRootDir, "test", "test1", "go.mod"): []byte("module go.opentelemetry.io/test/test1\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "test", "go.mod"):          []byte("module go.opentelemetry.io/test2\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "go.mod"):                  []byte("module go.opentelemetry.io/testroot/v2\n\ngo 1.16\n"),
+	}
+	require.NoError(t, commontest.WriteTempFiles(modFiles), "could not create go mod file tree")
+
+	createTagOptions := &git.CreateTagOptions{
+		Message: "test tag message",
+		Tagger:  commontest.TestAuthor,
+	}
+
+	// The held back module is tagged if its version was not released yet.
+	tagger, err := newTagger(versioningFilename, "mod-set-1", tmpRootDir, secondHash.String(), false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test/test1/v1.1.0", "test/v1.0.1"}, tagger.fullTags)
+
+	// It is not tagged again once released on an earlier commit.
+	_, err = repo.CreateTag("test/v1.0.1", firstHash, createTagOptions)
+	require.NoError(t, err)
+	tagger, err = newTagger(versioningFilename, "mod-set-1", tmpRootDir, secondHash.String(), false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test/test1/v1.1.0"}, tagger.fullTags)
+
+	// Nor is its tag deleted with the tags of the module set.
+	_, err = repo.CreateTag("test/test1/v1.1.0", secondHash, createTagOptions)
+	require.NoError(t, err)
+	tagger, err = newTagger(versioningFilename, "mod-set-1", tmpRootDir, secondHash.String(), true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test/test1/v1.1.0"}, tagger.fullTags)
+}
+
 func TestDeleteTags(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -554,7 +585,7 @@ func TestTagAllModules(t *testing.T) {
 			repo, _, err := commontest.InitNewRepoWithCommit(tmpRootDir)
 			require.NoError(t, err)
 
-			fullHash, err := common.CommitChangesToNewBranch("test_commit", "commit used in a test", repo, commontest.TestAuthor)
+			fullHash, err := common.CommitChangesToNewBranch(context.Background(), "test_commit", "commit used in a test", repo, commontest.TestAuthor, nil)
 			require.NoError(t, err)
 			hashPrefix := fullHash.String()[:8]
 
@@ -580,7 +611,7 @@ func TestTagAllModules(t *testing.T) {
 				return
 			}
 			require.NoError(t, err)
-			require.NoError(t, tagger.tagAllModules(commontest.TestAuthor))
+			require.NoError(t, tagger.tagAllModules(context.Background(), commontest.TestAuthor))
 			for _, tagName := range tc.shouldExistTags {
 				tagRef, tagRefErr := repo.Tag(tagName)
 
@@ -604,7 +635,7 @@ func TestTagPush(t *testing.T) {
 	originRepo, firstHash, err := commontest.InitNewRepoWithCommit(originRepoDir)
 	require.NoError(t, err)
 
-	secondHash, err := common.CommitChangesToNewBranch("test_commit", "commit used in a test", originRepo, commontest.TestAuthor)
+	secondHash, err := common.CommitChangesToNewBranch(context.Background(), "test_commit", "commit used in a test", originRepo, commontest.TestAuthor, nil)
 	require.NoError(t, err)
 
 	createTagOptions := &git.CreateTagOptions{
@@ -669,7 +700,7 @@ func TestTagPush(t *testing.T) {
 				refCommitMap[tagRef.Name().String()] = tagRef.Hash().String()
 			}
 
-			err = pushTags(tc.moduleFullTags, originRepo, "upstream")
+			err = pushTags(context.Background(), tc.moduleFullTags, originRepo, "upstream")
 			require.NoError(t, err)
 
 			for name, target := range refCommitMap {
@@ -709,6 +740,260 @@ func TestPushTags_BadRemote(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	err = pushTags(tagsToPush, originRepo, "upstream")
+	err = pushTags(context.Background(), tagsToPush, originRepo, "upstream")
 	assert.Error(t, err)
 }
+
+func TestPushModuleSetsTagsRollback(t *testing.T) {
+	versioningFilename := filepath.Join(testDataDir, "tag_all_modules", "versions_valid.yaml")
+
+	tmpRootDir := t.TempDir()
+	repo, _, err := commontest.InitNewRepoWithCommit(tmpRootDir)
+	require.NoError(t, err)
+
+	fullHash, err := common.CommitChangesToNewBranch(context.Background(), "test_commit", "commit used in a test", repo, commontest.TestAuthor, nil)
+	require.NoError(t, err)
+
+	modFiles := map[string][]byte{
+		filepath.Join(tmpRootDir, "test", "test1", "go.mod"):        []byte("module go.opentelemetry.io/test/test1\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "test", "test2", "go.mod"):        []byte("module go.opentelemetry.io/test2\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "test", "go.mod"):                 []byte("module go.opentelemetry.io/test3\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "go.mod"):                         []byte("module go.opentelemetry.io/testroot/v2\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "test", "testexcluded", "go.mod"): []byte("module go.opentelemetry.io/test/testexcluded\n\ngo 1.16\n"),
+	}
+	require.NoError(t, commontest.WriteTempFiles(modFiles), "could not create go mod file tree")
+
+	modSetTagger, err := newTagger(versioningFilename, "mod-set-2", tmpRootDir, fullHash.String(), false)
+	require.NoError(t, err)
+	require.NoError(t, modSetTagger.tagAllModules(context.Background(), commontest.TestAuthor))
+
+	upstreamRepoDir := t.TempDir()
+	upstreamRepo, err := git.PlainInit(upstreamRepoDir, true)
+	require.NoError(t, err)
+	_, err = repo.CreateRemote(&config.RemoteConfig{Name: "upstream", URLs: []string{upstreamRepoDir}})
+	require.NoError(t, err)
+
+	// Pushing to a missing remote fails and removes the local tags.
+	assert.Error(t, pushModuleSetsTags(context.Background(), []tagger{modSetTagger}, "missing"))
+	for _, tagName := range []string{"test/test2/v0.1.0", "test/v0.1.0"} {
+		_, err = repo.Tag(tagName)
+		assert.ErrorIsf(t, err, git.ErrTagNotFound, "tag %v should have been removed", tagName)
+	}
+
+	require.NoError(t, modSetTagger.tagAllModules(context.Background(), commontest.TestAuthor))
+	require.NoError(t, pushModuleSetsTags(context.Background(), []tagger{modSetTagger}, "upstream"))
+	for _, tagName := range []string{"test/test2/v0.1.0", "test/v0.1.0"} {
+		_, err = repo.Tag(tagName)
+		assert.NoError(t, err, tagName)
+		_, err = upstreamRepo.Tag(tagName)
+		assert.NoError(t, err, tagName)
+	}
+}
+
+func TestDeleteRemoteTags(t *testing.T) {
+	repo, hash, err := commontest.InitNewRepoWithCommit(t.TempDir())
+	require.NoError(t, err)
+
+	tagNames := []string{"test/v0.1.0", "test/test2/v0.1.0"}
+	for _, tagName := range append(tagNames, "other/v0.1.0") {
+		_, err = repo.CreateTag(tagName, hash, &git.CreateTagOptions{Message: tagName, Tagger: commontest.TestAuthor})
+		require.NoError(t, err)
+	}
+
+	upstreamRepoDir := t.TempDir()
+	upstreamRepo, err := git.PlainInit(upstreamRepoDir, true)
+	require.NoError(t, err)
+	_, err = repo.CreateRemote(&config.RemoteConfig{Name: "upstream", URLs: []string{upstreamRepoDir}})
+	require.NoError(t, err)
+	require.NoError(t, pushTags(context.Background(), append(tagNames, "other/v0.1.0"), repo, "upstream"))
+
+	require.NoError(t, deleteRemoteTags(context.Background(), tagNames, repo, "upstream"))
+	for _, tagName := range tagNames {
+		_, err = upstreamRepo.Tag(tagName)
+		assert.ErrorIs(t, err, git.ErrTagNotFound, tagName)
+		_, err = repo.Tag(tagName)
+		assert.NoError(t, err, "local tag %v should be kept", tagName)
+	}
+	_, err = upstreamRepo.Tag("other/v0.1.0")
+	assert.NoError(t, err, "other tags should be kept")
+
+	// Tags already deleted are ignored.
+	assert.NoError(t, deleteRemoteTags(context.Background(), tagNames, repo, "upstream"))
+
+	assert.Error(t, deleteRemoteTags(context.Background(), tagNames, repo, "missing"))
+}
+
+func TestConfirmRemoteDeletion(t *testing.T) {
+	taggers := []tagger{
+		{ModuleSetRelease: common.ModuleSetRelease{ModSetName: "mod-set-1"}, fullTags: []string{"a/v1.0.0", "b/v1.0.0"}},
+		{ModuleSetRelease: common.ModuleSetRelease{ModSetName: "mod-set-2"}, fullTags: []string{"c/v0.1.0"}},
+	}
+	for _, tc := range []struct {
+		answer string
+		want   bool
+	}{
+		{answer: "y\n", want: true},
+		{answer: " Yes\n", want: true},
+		{answer: "yes", want: true},
+		{answer: "n\n", want: false},
+		{answer: "\n", want: false},
+		{answer: "", want: false},
+	} {
+		var out strings.Builder
+		assert.Equal(t, tc.want, confirmRemoteDeletion(strings.NewReader(tc.answer), &out, taggers, "upstream"), "%q", tc.answer)
+		assert.Contains(t, out.String(), "Delete 3 tags of module sets mod-set-1, mod-set-2 from remote upstream? [y/N]: ")
+	}
+}
+
+// errAfterContext is a context whose Err method starts returning
+// context.Canceled after it has been called n times.
+type errAfterContext struct {
+	context.Context
+	n int
+}
+
+func (c *errAfterContext) Err() error {
+	if c.n <= 0 {
+		return context.Canceled
+	}
+	c.n--
+	return nil
+}
+
+func TestTagAllModulesInterrupted(t *testing.T) {
+	versioningFilename := filepath.Join(testDataDir, "tag_all_modules", "versions_valid.yaml")
+
+	tmpRootDir := t.TempDir()
+	repo, _, err := commontest.InitNewRepoWithCommit(tmpRootDir)
+	require.NoError(t, err)
+
+	fullHash, err := common.CommitChangesToNewBranch(context.Background(), "test_commit", "commit used in a test", repo, commontest.TestAuthor, nil)
+	require.NoError(t, err)
+
+	modFiles := map[string][]byte{
+		filepath.Join(tmpRootDir, "test", "test1", "go.mod"):        []byte("module go.opentelemetry.io/test/test1\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "test", "test2", "go.mod"):        []byte("module go.opentelemetry.io/test2\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "test", "go.mod"):                 []byte("module go.opentelemetry.io/test3\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "go.mod"):                         []byte("module go.opentelemetry.io/testroot/v2\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "test", "testexcluded", "go.mod"): []byte("module go.opentelemetry.io/test/testexcluded\n\ngo 1.16\n"),
+	}
+	require.NoError(t, commontest.WriteTempFiles(modFiles), "could not create go mod file tree")
+
+	tagger, err := newTagger(versioningFilename, "mod-set-2", tmpRootDir, fullHash.String(), false)
+	require.NoError(t, err)
+
+	// Interrupted after the first of the two tags was created.
+	ctx := &errAfterContext{Context: context.Background(), n: 1}
+	err = tagger.tagAllModules(ctx, commontest.TestAuthor)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	for _, tagName := range []string{"test/test2/v0.1.0", "test/v0.1.0"} {
+		_, err = repo.Tag(tagName)
+		assert.ErrorIsf(t, err, git.ErrTagNotFound, "tag %v should have been removed", tagName)
+	}
+}
+
+func TestTagModuleSets(t *testing.T) {
+	versioningFilename := filepath.Join(testDataDir, "tag_all_modules", "versions_valid.yaml")
+	allTags := []string{"test/test1/v1.2.3-RC1+meta", "v2.2.2", "test/test2/v0.1.0", "test/v0.1.0"}
+
+	for _, tc := range []struct {
+		name string
+		// n is the number of tags created before the interruption.
+		n       int
+		wantErr error
+	}{
+		{name: "all tagged", n: len(allTags)},
+		{name: "interrupted in last module set", n: 3, wantErr: context.Canceled},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpRootDir := t.TempDir()
+			repo, _, err := commontest.InitNewRepoWithCommit(tmpRootDir)
+			require.NoError(t, err)
+
+			fullHash, err := common.CommitChangesToNewBranch(context.Background(), "test_commit", "commit used in a test", repo, commontest.TestAuthor, nil)
+			require.NoError(t, err)
+
+			modFiles := map[string][]byte{
+				filepath.Join(tmpRootDir, "test", "test1", "go.mod"):        []byte("module go.opentelemetry.io/test/test1\n\ngo 1.16\n"),
+				filepath.Join(tmpRootDir, "test", "test2", "go.mod"):        []byte("module go.opentelemetry.io/test2\n\ngo 1.16\n"),
+				filepath.Join(tmpRootDir, "test", "go.mod"):                 []byte("module go.opentelemetry.io/test3\n\ngo 1.16\n"),
+				filepath.Join(tmpRootDir, "go.mod"):                         []byte("module go.opentelemetry.io/testroot/v2\n\ngo 1.16\n"),
+				filepath.Join(tmpRootDir, "test", "testexcluded", "go.mod"): []byte("module go.opentelemetry.io/test/testexcluded\n\ngo 1.16\n"),
+			}
+			require.NoError(t, commontest.WriteTempFiles(modFiles), "could not create go mod file tree")
+
+			var taggers []tagger
+			for _, modSetName := range []string{"mod-set-1", "mod-set-3", "mod-set-2"} {
+				tagger, err := newTagger(versioningFilename, modSetName, tmpRootDir, fullHash.String(), false)
+				require.NoError(t, err)
+				taggers = append(taggers, tagger)
+			}
+
+			ctx := &errAfterContext{Context: context.Background(), n: tc.n}
+			err = tagModuleSets(ctx, taggers, commontest.TestAuthor)
+			assert.ErrorIs(t, err, tc.wantErr)
+
+			for _, tagName := range allTags {
+				_, err = repo.Tag(tagName)
+				if tc.wantErr == nil {
+					assert.NoError(t, err, tagName)
+				} else {
+					assert.ErrorIsf(t, err, git.ErrTagNotFound, "tag %v should have been removed", tagName)
+				}
+			}
+		})
+	}
+}
+
+func TestWritePlan(t *testing.T) {
+	versioningFilename := filepath.Join(testDataDir, "tag_all_modules", "versions_valid.yaml")
+
+	tmpRootDir := t.TempDir()
+	repo, _, err := commontest.InitNewRepoWithCommit(tmpRootDir)
+	require.NoError(t, err)
+
+	fullHash, err := common.CommitChangesToNewBranch(context.Background(), "test_commit", "commit used in a test", repo, commontest.TestAuthor, nil)
+	require.NoError(t, err)
+
+	modFiles := map[string][]byte{
+		filepath.Join(tmpRootDir, "test", "test1", "go.mod"):        []byte("module go.opentelemetry.io/test/test1\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "test", "test2", "go.mod"):        []byte("module go.opentelemetry.io/test2\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "test", "go.mod"):                 []byte("module go.opentelemetry.io/test3\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "go.mod"):                         []byte("module go.opentelemetry.io/testroot/v2\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "test", "testexcluded", "go.mod"): []byte("module go.opentelemetry.io/test/testexcluded\n\ngo 1.16\n"),
+	}
+	require.NoError(t, commontest.WriteTempFiles(modFiles), "could not create go mod file tree")
+
+	tagger, err := newTagger(versioningFilename, "mod-set-2", tmpRootDir, fullHash.String(), false)
+	require.NoError(t, err)
+
+	var b strings.Builder
+	tagger.writePlan(&b, false, "upstream")
+	assert.Equal(t, "Module set mod-set-2, version v0.1.0, commit "+fullHash.String()+"\n"+
+		"Tags to create:\n"+
+		"  test/test2/v0.1.0\n"+
+		"  test/v0.1.0\n"+
+		"Tags would be pushed to upstream\n", b.String())
+
+	b.Reset()
+	tagger.writePlan(&b, true, "")
+	assert.Equal(t, "Module set mod-set-2, version v0.1.0, commit "+fullHash.String()+"\n"+
+		"Tags to delete:\n"+
+		"  test/test2/v0.1.0\n"+
+		"  test/v0.1.0\n", b.String())
+
+	b.Reset()
+	tagger.writePlan(&b, true, "upstream")
+	assert.Equal(t, "Module set mod-set-2, version v0.1.0, commit "+fullHash.String()+"\n"+
+		"Tags to delete:\n"+
+		"  test/test2/v0.1.0\n"+
+		"  test/v0.1.0\n"+
+		"Tags would also be deleted from upstream\n", b.String())
+
+	// Nothing is tagged.
+	for _, tagName := range []string{"test/test2/v0.1.0", "test/v0.1.0"} {
+		_, err = repo.Tag(tagName)
+		assert.ErrorIs(t, err, git.ErrTagNotFound, tagName)
+	}
+}
@@ -18,6 +18,8 @@ import (
 	"fmt"
 	"strings"
 
+	"golang.org/x/mod/semver"
+
 	"go.opentelemetry.io/build-tools/multimod/internal/common"
 )
 
@@ -27,7 +29,7 @@ type errModuleNotInSet struct {
 }
 
 func (e *errModuleNotInSet) Error() string {
-	return fmt.Sprintf("Module %v (defined in %v) is not listed in any module set.", e.modPath, e.modFilePath)
+	return fmt.Sprintf("Module %v (defined in %v) is not listed in any module set or in excluded-modules.", e.modPath, e.modFilePath)
 }
 
 type errModuleNotInRepo struct {
@@ -39,6 +41,38 @@ func (e *errModuleNotInRepo) Error() string {
 	return fmt.Sprintf("Module %v in module set %v does not exist in the current repo.", e.modPath, e.modSetName)
 }
 
+type errModuleSetSlice struct {
+	errs []error
+}
+
+func (e *errModuleSetSlice) Error() string {
+	var errorStringSlice []string
+	for _, err := range e.errs {
+		errorStringSlice = append(errorStringSlice, err.Error())
+	}
+
+	return strings.Join(errorStringSlice, "\n")
+}
+
+type errStaleEntries struct {
+	entries []staleEntry
+}
+
+func (e *errStaleEntries) Error() string {
+	var errorStringSlice []string
+	for _, entry := range e.entries {
+		if entry.modSetName == "" {
+			errorStringSlice = append(errorStringSlice,
+				fmt.Sprintf("Excluded module %v does not exist in the current repo.", entry.modPath))
+		} else {
+			errorStringSlice = append(errorStringSlice,
+				fmt.Sprintf("Module %v in module set %v does not exist in the current repo.", entry.modPath, entry.modSetName))
+		}
+	}
+
+	return strings.Join(errorStringSlice, "\n")
+}
+
 type errInvalidVersion struct {
 	modSetName    string
 	modSetVersion string
@@ -48,6 +82,21 @@ func (e *errInvalidVersion) Error() string {
 	return fmt.Sprintf("Module set %v has invalid version string: %v", e.modSetName, e.modSetVersion)
 }
 
+type errInvalidVersionOverride struct {
+	modSetName    string
+	modSetVersion string
+	modPath       common.ModulePath
+	version       string
+}
+
+func (e *errInvalidVersionOverride) Error() string {
+	if !semver.IsValid(e.version) {
+		return fmt.Sprintf("Module %v in set %v has invalid version override: %v", e.modPath, e.modSetName, e.version)
+	}
+	return fmt.Sprintf("Module %v in set %v has version override %v not lower than the set version %v",
+		e.modPath, e.modSetName, e.version, e.modSetVersion)
+}
+
 type errMultipleSetSameVersionSlice struct {
 	errs []*errMultipleSetSameVersion
 }
@@ -71,16 +120,60 @@ func (e *errMultipleSetSameVersion) Error() string {
 		e.modSetVersion, e.modSetNames)
 }
 
-// errDependency is logged upon discovery that a stable module depends on an unstable module.
+type errDependencySlice struct {
+	errs []*errDependency
+}
+
+func (e *errDependencySlice) Error() string {
+	var errorStringSlice []string
+	for _, err := range e.errs {
+		errorStringSlice = append(errorStringSlice, err.Error())
+	}
+
+	return strings.Join(errorStringSlice, "\n")
+}
+
+// errDependency is returned upon discovery that a stable module depends on an unstable module.
 type errDependency struct {
-	modPath    common.ModulePath
-	modVersion string
-	depPath    common.ModulePath
-	depVersion string
+	modPath       common.ModulePath
+	modSetName    string
+	modVersion    string
+	depPath       common.ModulePath
+	depModSetName string
+	depVersion    string
 }
 
 func (e *errDependency) Error() string {
-	return fmt.Sprintf("WARNING: Stable module %v (%v) depends on unstable module %v (%v).\n",
-		e.modPath, e.modVersion,
-		e.depPath, e.depVersion)
+	return fmt.Sprintf("Stable module %v (module set %v, %v) depends on unstable module %v (module set %v, %v).",
+		e.modPath, e.modSetName, e.modVersion,
+		e.depPath, e.depModSetName, e.depVersion)
+}
+
+type errRequiredVersionSlice struct {
+	errs []*errRequiredVersion
+}
+
+func (e *errRequiredVersionSlice) Error() string {
+	var errorStringSlice []string
+	for _, err := range e.errs {
+		errorStringSlice = append(errorStringSlice, err.Error())
+	}
+
+	return strings.Join(errorStringSlice, "\n")
+}
+
+// errRequiredVersion is returned upon discovery that a module requires a
+// module of the repo at a version other than the version of its module set.
+type errRequiredVersion struct {
+	modPath         common.ModulePath
+	modSetName      string
+	depPath         common.ModulePath
+	depModSetName   string
+	version         string
+	expectedVersion string
+}
+
+func (e *errRequiredVersion) Error() string {
+	return fmt.Sprintf("Module %v (module set %v) requires %v at %v instead of %v (module set %v).",
+		e.modPath, e.modSetName, e.depPath, e.version, e.expectedVersion, e.depModSetName)
 }
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+)
+
+// Identifiers of the rules checked by verify.
+const (
+	RuleNoStaleEntries     = "no-stale-entries"
+	RuleAllModulesInSet    = "all-modules-in-set"
+	RuleValidVersions      = "valid-versions"
+	RuleStableDependencies = "stable-dependencies"
+	RuleRequiredVersions   = "required-versions"
+)
+
+// Severities of results.
+const (
+	SeverityError = "error"
+	SeverityInfo  = "info"
+)
+
+// Result is the outcome of a rule for a module set. A rule failing for
+// modules that are not listed in any module set has no module set.
+type Result struct {
+	Rule      string `json:"rule"`
+	Severity  string `json:"severity"`
+	ModuleSet string `json:"module_set,omitempty"`
+	Module    string `json:"module,omitempty"`
+	Message   string `json:"message"`
+}
+
+// results runs every rule and returns one result per violation, and one
+// result with info severity for every module set a rule passed for.
+func (v verification) results() []Result {
+	single := func(check func() error) func() []error {
+		return func() []error { return []error{check()} }
+	}
+	rules := []struct {
+		id    string
+		check func() []error
+	}{
+		{id: RuleNoStaleEntries, check: single(v.verifyNoStaleEntries)},
+		{id: RuleAllModulesInSet, check: v.moduleSetErrors},
+		{id: RuleValidVersions, check: single(v.verifyVersions)},
+		{id: RuleStableDependencies, check: single(v.verifyDependencies)},
+		{id: RuleRequiredVersions, check: single(v.verifyRequiredVersions)},
+	}
+
+	var setNames []string
+	for name := range v.ModuleVersioning.ModSetMap {
+		setNames = append(setNames, name)
+	}
+	sort.Strings(setNames)
+
+	var results []Result
+	for _, rule := range rules {
+		failed := make(map[string]bool)
+		for _, err := range rule.check() {
+			for _, r := range ruleViolations(rule.id, err) {
+				failed[r.ModuleSet] = true
+				results = append(results, r)
+			}
+		}
+		for _, name := range setNames {
+			if !failed[name] {
+				results = append(results, Result{Rule: rule.id, Severity: SeverityInfo, ModuleSet: name, Message: "passed"})
+			}
+		}
+	}
+	return results
+}
+
+// ruleViolations splits the error returned by the check of rule into one
+// result per violation.
+func ruleViolations(rule string, err error) []Result {
+	if err == nil {
+		return nil
+	}
+	newResult := func(modSetName, modPath, msg string) Result {
+		return Result{Rule: rule, Severity: SeverityError, ModuleSet: modSetName, Module: modPath, Message: msg}
+	}
+
+	var results []Result
+	switch e := err.(type) {
+	case *errStaleEntries:
+		for _, entry := range e.entries {
+			results = append(results, newResult(entry.modSetName, string(entry.modPath), (&errStaleEntries{entries: []staleEntry{entry}}).Error()))
+		}
+	case *errModuleNotInSet:
+		results = append(results, newResult("", string(e.modPath), e.Error()))
+	case *errModuleNotInRepo:
+		results = append(results, newResult(e.modSetName, string(e.modPath), e.Error()))
+	case *errInvalidVersion:
+		results = append(results, newResult(e.modSetName, "", e.Error()))
+	case *errInvalidVersionOverride:
+		results = append(results, newResult(e.modSetName, string(e.modPath), e.Error()))
+	case *errMultipleSetSameVersionSlice:
+		for _, err := range e.errs {
+			names := append([]string(nil), err.modSetNames...)
+			sort.Strings(names)
+			for _, name := range names {
+				results = append(results, newResult(name, "", err.Error()))
+			}
+		}
+	case *errDependencySlice:
+		for _, err := range e.errs {
+			results = append(results, newResult(err.modSetName, string(err.modPath), err.Error()))
+		}
+	case *errRequiredVersionSlice:
+		for _, err := range e.errs {
+			results = append(results, newResult(err.modSetName, string(err.modPath), err.Error()))
+		}
+	default:
+		results = append(results, newResult("", "", err.Error()))
+	}
+	return results
+}
+
+// countErrors returns the number of results with error severity.
+func countErrors(results []Result) int {
+	n := 0
+	for _, r := range results {
+		if r.Severity == SeverityError {
+			n++
+		}
+	}
+	return n
+}
+
+// writeResults writes results as an indented JSON array to w.
+func writeResults(w io.Writer, results []Result) error {
+	if results == nil {
+		results = []Result{}
+	}
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common/commontest"
+)
+
+func TestResults(t *testing.T) {
+	versioningFilename := filepath.Join(testDataDir, "verify_all_modules_in_set", "module_not_in_repo.yaml")
+
+	tmpRootDir := t.TempDir()
+	unlistedModFile := filepath.Join(tmpRootDir, "unlisted", "go.mod")
+	modFiles := map[string][]byte{
+		filepath.Join(tmpRootDir, "test", "test1", "go.mod"): []byte("module go.opentelemetry.io/test/test1\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "test", "go.mod"):          []byte("module go.opentelemetry.io/test2\n\ngo 1.16\n"),
+		unlistedModFile: []byte("module go.opentelemetry.io/unlisted\n\ngo 1.16\n"),
+	}
+	require.NoError(t, commontest.WriteTempFiles(modFiles), "could not create go mod file tree")
+
+	v, err := newVerification(versioningFilename, tmpRootDir)
+	require.NoError(t, err)
+
+	info := func(rule, modSetName string) Result {
+		return Result{Rule: rule, Severity: SeverityInfo, ModuleSet: modSetName, Message: "passed"}
+	}
+	assert.Equal(t, []Result{
+		{
+			Rule:     RuleNoStaleEntries,
+			Severity: SeverityError,
+			Module:   "go.opentelemetry.io/test/testexcluded",
+			Message:  "Excluded module go.opentelemetry.io/test/testexcluded does not exist in the current repo.",
+		},
+		{
+			Rule:      RuleNoStaleEntries,
+			Severity:  SeverityError,
+			ModuleSet: "mod-set-3",
+			Module:    "go.opentelemetry.io/testroot/v2",
+			Message:   "Module go.opentelemetry.io/testroot/v2 in module set mod-set-3 does not exist in the current repo.",
+		},
+		info(RuleNoStaleEntries, "mod-set-1"),
+		info(RuleNoStaleEntries, "mod-set-2"),
+		{
+			Rule:     RuleAllModulesInSet,
+			Severity: SeverityError,
+			Module:   "go.opentelemetry.io/unlisted",
+			Message:  "Module go.opentelemetry.io/unlisted (defined in " + unlistedModFile + ") is not listed in any module set or in excluded-modules.",
+		},
+		{
+			Rule:      RuleAllModulesInSet,
+			Severity:  SeverityError,
+			ModuleSet: "mod-set-3",
+			Module:    "go.opentelemetry.io/testroot/v2",
+			Message:   "Module go.opentelemetry.io/testroot/v2 in module set mod-set-3 does not exist in the current repo.",
+		},
+		info(RuleAllModulesInSet, "mod-set-1"),
+		info(RuleAllModulesInSet, "mod-set-2"),
+		info(RuleValidVersions, "mod-set-1"),
+		info(RuleValidVersions, "mod-set-2"),
+		info(RuleValidVersions, "mod-set-3"),
+		info(RuleStableDependencies, "mod-set-1"),
+		info(RuleStableDependencies, "mod-set-2"),
+		info(RuleStableDependencies, "mod-set-3"),
+		info(RuleRequiredVersions, "mod-set-1"),
+		info(RuleRequiredVersions, "mod-set-2"),
+		info(RuleRequiredVersions, "mod-set-3"),
+	}, v.results())
+}
+
+func TestRuleViolations(t *testing.T) {
+	assert.Nil(t, ruleViolations(RuleValidVersions, nil))
+
+	assert.Equal(t, []Result{
+		{Rule: RuleValidVersions, Severity: SeverityError, ModuleSet: "a", Message: "Multiple module sets have the same major version (v1): [b a]"},
+		{Rule: RuleValidVersions, Severity: SeverityError, ModuleSet: "b", Message: "Multiple module sets have the same major version (v1): [b a]"},
+	}, ruleViolations(RuleValidVersions, &errMultipleSetSameVersionSlice{
+		errs: []*errMultipleSetSameVersion{{modSetNames: []string{"b", "a"}, modSetVersion: "v1"}},
+	}))
+
+	assert.Equal(t, []Result{
+		{Rule: RuleValidVersions, Severity: SeverityError, ModuleSet: "a", Message: "Module set a has invalid version string: 1.0"},
+	}, ruleViolations(RuleValidVersions, &errInvalidVersion{modSetName: "a", modSetVersion: "1.0"}))
+}
+
+func TestWriteResults(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, writeResults(&b, nil))
+	assert.Equal(t, "[]\n", b.String())
+
+	b.Reset()
+	require.NoError(t, writeResults(&b, []Result{{Rule: RuleValidVersions, Severity: SeverityInfo, ModuleSet: "a", Message: "passed"}}))
+	assert.JSONEq(t, `[{"rule":"valid-versions","severity":"info","module_set":"a","message":"passed"}]`, b.String())
+}
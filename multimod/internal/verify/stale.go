@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+)
+
+// staleEntry is a module listed in a versioning file for which no go.mod file
+// exists in the repo, typically left behind when a module is deleted.
+type staleEntry struct {
+	modPath common.ModulePath
+	// modSetName is the module set listing the module, or empty if the
+	// module is listed in the excluded modules section.
+	modSetName string
+}
+
+// staleEntries returns the stale entries of the versioning file, sorted by
+// module path.
+func (v verification) staleEntries() []staleEntry {
+	var entries []staleEntry
+	for modPath, modInfo := range v.ModuleVersioning.ModInfoMap {
+		if _, exists := v.repoModules[modPath]; !exists {
+			entries = append(entries, staleEntry{modPath: modPath, modSetName: modInfo.ModuleSetName})
+		}
+	}
+	for _, modPath := range v.excludedModules {
+		if _, exists := v.repoModules[modPath]; !exists {
+			entries = append(entries, staleEntry{modPath: modPath})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].modPath < entries[j].modPath })
+	return entries
+}
+
+// verifyNoStaleEntries checks that every module listed in a module set or in
+// the excluded modules section exists in the repo.
+func (v verification) verifyNoStaleEntries() error {
+	if entries := v.staleEntries(); len(entries) > 0 {
+		return &errStaleEntries{entries: entries}
+	}
+
+	logging.Infof("PASS: All modules listed in the versioning file exist.")
+
+	return nil
+}
+
+// removeStaleEntries removes the modules of entries from the module sets and
+// excluded modules sections of the versioning file and the versioning files it
+// includes. Files are only rewritten if they list a stale module.
+func removeStaleEntries(versioningFilename string, entries []staleEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	stale := make(map[string]struct{}, len(entries))
+	for _, entry := range entries {
+		stale[string(entry.modPath)] = struct{}{}
+	}
+	return removeModules(versioningFilename, stale, nil)
+}
+
+// removeModules removes the modules in stale from the versioning file and,
+// recursively, the versioning files it includes. including holds the files
+// currently being edited to detect include cycles.
+func removeModules(versioningFilename string, stale map[string]struct{}, including []string) error {
+	absFilename, err := filepath.Abs(versioningFilename)
+	if err != nil {
+		return fmt.Errorf("could not get absolute path of versioning file: %w", err)
+	}
+	for _, f := range including {
+		if f == absFilename {
+			return fmt.Errorf("versioning file %v includes itself", versioningFilename)
+		}
+	}
+	including = append(including, absFilename)
+
+	info, err := os.Stat(versioningFilename)
+	if err != nil {
+		return fmt.Errorf("could not read versioning file: %w", err)
+	}
+	data, err := os.ReadFile(filepath.Clean(versioningFilename))
+	if err != nil {
+		return fmt.Errorf("could not read versioning file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err = yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("could not parse versioning file %v: %w", versioningFilename, err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil
+	}
+	root := doc.Content[0]
+
+	removed := removeFromSequence(common.YAMLMappingValue(root, "excluded-modules"), versioningFilename, stale)
+	if sets := common.YAMLMappingValue(root, "module-sets"); sets != nil && sets.Kind == yaml.MappingNode {
+		for i := 1; i < len(sets.Content); i += 2 {
+			removed += removeFromSequence(common.YAMLMappingValue(sets.Content[i], "modules"), versioningFilename, stale)
+		}
+	}
+
+	if removed > 0 {
+		var b bytes.Buffer
+		encoder := yaml.NewEncoder(&b)
+		encoder.SetIndent(2)
+		if err = encoder.Encode(&doc); err != nil {
+			return err
+		}
+		if err = encoder.Close(); err != nil {
+			return err
+		}
+		if err = os.WriteFile(versioningFilename, b.Bytes(), info.Mode().Perm()); err != nil {
+			return fmt.Errorf("could not write versioning file: %w", err)
+		}
+	}
+
+	if includes := common.YAMLMappingValue(root, "include"); includes != nil && includes.Kind == yaml.SequenceNode {
+		for _, n := range includes.Content {
+			inc := n.Value
+			if !filepath.IsAbs(inc) {
+				inc = filepath.Join(filepath.Dir(versioningFilename), inc)
+			}
+			if err = removeModules(inc, stale, including); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// removeFromSequence removes the scalar values in stale from the sequence
+// node seq and returns the number of values removed.
+func removeFromSequence(seq *yaml.Node, versioningFilename string, stale map[string]struct{}) int {
+	if seq == nil || seq.Kind != yaml.SequenceNode {
+		return 0
+	}
+
+	kept := seq.Content[:0]
+	for _, n := range seq.Content {
+		if _, ok := stale[n.Value]; ok {
+			logging.Infof("Removing stale module %v from %v", n.Value, versioningFilename)
+			continue
+		}
+		kept = append(kept, n)
+	}
+	removed := len(seq.Content) - len(kept)
+	seq.Content = kept
+	return removed
+}
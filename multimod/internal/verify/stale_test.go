@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common/commontest"
+)
+
+func TestStaleEntries(t *testing.T) {
+	testName := "verify_stale_entries"
+	versionYamlDir := filepath.Join(testDataDir, testName)
+
+	tmpRootDir := t.TempDir()
+	files := map[string][]byte{
+		filepath.Join(tmpRootDir, "test", "test1", "go.mod"):    []byte("module \"go.opentelemetry.io/test/test1\"\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "test", "go.mod"):             []byte("module go.opentelemetry.io/test2\n\ngo 1.16\n"),
+		filepath.Join(tmpRootDir, "test", "excluded", "go.mod"): []byte("module \"go.opentelemetry.io/test/testexcluded\"\n\ngo 1.16\n"),
+	}
+	for _, name := range []string{"versions.yaml", filepath.Join("experimental", "versions.yaml")} {
+		data, err := os.ReadFile(filepath.Join(versionYamlDir, name))
+		require.NoError(t, err)
+		files[filepath.Join(tmpRootDir, name)] = data
+	}
+	require.NoError(t, commontest.WriteTempFiles(files), "could not create file tree")
+
+	versioningFilename := filepath.Join(tmpRootDir, "versions.yaml")
+	v, err := newVerification(versioningFilename, tmpRootDir)
+	require.NoError(t, err)
+
+	expected := []staleEntry{
+		{modPath: "go.opentelemetry.io/test/deleted", modSetName: "mod-set-1"},
+		{modPath: "go.opentelemetry.io/test/deletedexcluded"},
+		{modPath: "go.opentelemetry.io/test/deletedexperimental", modSetName: "mod-set-experimental"},
+	}
+	assert.Equal(t, expected, v.staleEntries())
+	assert.Equal(t, &errStaleEntries{entries: expected}, v.verifyNoStaleEntries())
+	assert.EqualError(t, v.verifyNoStaleEntries(),
+		"Module go.opentelemetry.io/test/deleted in module set mod-set-1 does not exist in the current repo.\n"+
+			"Excluded module go.opentelemetry.io/test/deletedexcluded does not exist in the current repo.\n"+
+			"Module go.opentelemetry.io/test/deletedexperimental in module set mod-set-experimental does not exist in the current repo.",
+	)
+
+	require.NoError(t, removeStaleEntries(versioningFilename, v.staleEntries()))
+
+	v, err = newVerification(versioningFilename, tmpRootDir)
+	require.NoError(t, err)
+	assert.NoError(t, v.verifyNoStaleEntries())
+	assert.NoError(t, v.verifyAllModulesInSet())
+
+	data, err := os.ReadFile(versioningFilename)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# limitations under the License.")
+	assert.Contains(t, string(data), "# The main module.")
+	assert.Contains(t, string(data), "go.opentelemetry.io/test/testexcluded")
+	assert.NotContains(t, string(data), "deleted")
+
+	data, err = os.ReadFile(filepath.Join(tmpRootDir, "experimental", "versions.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "go.opentelemetry.io/test2")
+	assert.NotContains(t, string(data), "deleted")
+}
+
+func TestRemoveStaleEntriesNone(t *testing.T) {
+	versioningFilename := filepath.Join(testDataDir, "verify_stale_entries", "versions.yaml")
+	info, err := os.Stat(versioningFilename)
+	require.NoError(t, err)
+
+	require.NoError(t, removeStaleEntries(versioningFilename, nil))
+
+	after, err := os.Stat(versioningFilename)
+	require.NoError(t, err)
+	assert.Equal(t, info.ModTime(), after.ModTime())
+}
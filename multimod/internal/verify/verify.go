@@ -16,46 +16,87 @@ package verify
 
 import (
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"golang.org/x/mod/modfile"
 	"golang.org/x/mod/semver"
 
+	"go.opentelemetry.io/build-tools/internal/logging"
 	"go.opentelemetry.io/build-tools/internal/repo"
 	"go.opentelemetry.io/build-tools/multimod/internal/common"
 )
 
-func Run(versioningFile string) {
+// Output formats of Run.
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+func Run(versioningFile string, fix bool, output string) {
+	if output != OutputText && output != OutputJSON {
+		logging.Fatalf("invalid output %q, must be %v or %v", output, OutputText, OutputJSON)
+	}
 
 	repoRoot, err := repo.FindRoot()
 	if err != nil {
-		log.Fatalf("unable to find repo root: %v", err)
+		logging.Fatalf("unable to find repo root: %v", err)
 	}
 
 	v, err := newVerification(versioningFile, repoRoot)
 	if err != nil {
-		log.Fatalf("Error creating new verification struct: %v", err)
+		logging.Fatalf("Error creating new verification struct: %v", err)
+	}
+
+	if fix {
+		if err = removeStaleEntries(versioningFile, v.staleEntries()); err != nil {
+			logging.Fatalf("removeStaleEntries failed: %v", err)
+		}
+		if v, err = newVerification(versioningFile, repoRoot); err != nil {
+			logging.Fatalf("Error creating new verification struct: %v", err)
+		}
+	}
+
+	if output == OutputJSON {
+		results := v.results()
+		if err = writeResults(os.Stdout, results); err != nil {
+			logging.Fatalf("could not write results: %v", err)
+		}
+		if n := countErrors(results); n > 0 {
+			logging.Fatalf("verify found %d errors", n)
+		}
+		return
+	}
+
+	if err = v.verifyNoStaleEntries(); err != nil {
+		logging.Fatalf("verifyNoStaleEntries failed, run with --fix to remove them: %v", err)
 	}
 
 	if err = v.verifyAllModulesInSet(); err != nil {
-		log.Fatalf("verifyAllModulesInSet failed: %v", err)
+		logging.Fatalf("verifyAllModulesInSet failed: %v", err)
 	}
 
 	if err = v.verifyVersions(); err != nil {
-		log.Fatalf("verifyVersions failed: %v", err)
+		logging.Fatalf("verifyVersions failed: %v", err)
 	}
 
 	if err = v.verifyDependencies(); err != nil {
-		log.Fatalf("verifyDependencies failed: %v", err)
+		logging.Fatalf("verifyDependencies failed: %v", err)
+	}
+
+	if err = v.verifyRequiredVersions(); err != nil {
+		logging.Fatalf("verifyRequiredVersions failed, run prerelease to update the requirements: %v", err)
 	}
 
-	log.Println("PASS: Module sets successfully verified.")
+	logging.Infof("PASS: Module sets successfully verified.")
 }
 
 type verification struct {
 	common.ModuleVersioning
+	// repoModules holds all modules in the repo, including excluded ones.
+	repoModules     common.ModulePathMap
+	excludedModules []common.ModulePath
 }
 
 // dependencyMap keeps track of all modules' dependencies.
@@ -67,19 +108,53 @@ func newVerification(versioningFilename, repoRoot string) (verification, error)
 		return verification{}, fmt.Errorf("call to NewModuleVersioning failed: %w", err)
 	}
 
+	excludedModules, err := common.ReadExcludedModules(versioningFilename)
+	if err != nil {
+		return verification{}, fmt.Errorf("could not read excluded modules: %w", err)
+	}
+
+	repoModules, err := common.FindModules(repoRoot)
+	if err != nil {
+		return verification{}, fmt.Errorf("could not find modules in repo: %w", err)
+	}
+
 	return verification{
 		ModuleVersioning: modVersioning,
+		repoModules:      repoModules,
+		excludedModules:  excludedModules,
 	}, nil
 }
 
 // getDependencies returns a map of each module's dependencies on other modules within the same repo.
 func (v verification) getDependencies() (dependencyMap, error) {
-	modVersioning := v.ModuleVersioning
+	requires, err := v.getRequires()
+	if err != nil {
+		return nil, err
+	}
+
 	dependencies := make(dependencyMap)
+	for modPath, reqs := range requires {
+		for _, req := range reqs {
+			dependencies[modPath] = append(dependencies[modPath], common.ModulePath(req.Mod.Path))
+		}
+	}
+
+	return dependencies, nil
+}
+
+// getRequires returns a map of each module's requirements on other modules
+// within the same repo, in the order of its go.mod file.
+func (v verification) getRequires() (map[common.ModulePath][]*modfile.Require, error) {
+	modVersioning := v.ModuleVersioning
+	requires := make(map[common.ModulePath][]*modfile.Require)
 
 	// Dependencies are defined by the require section of go.mod files.
 	for modPath := range modVersioning.ModInfoMap {
-		modFilePath := modVersioning.ModPathMap[modPath]
+		modFilePath, exists := modVersioning.ModPathMap[modPath]
+		if !exists {
+			// modules missing from the repo are reported by verifyAllModulesInSet.
+			continue
+		}
 		modData, err := os.ReadFile(filepath.Clean(string(modFilePath)))
 		if err != nil {
 			return nil, fmt.Errorf("could not read mod file: %w", err)
@@ -94,38 +169,61 @@ func (v verification) getDependencies() (dependencyMap, error) {
 		for _, dep := range modFile.Require {
 			// check if dependency is in the same repo (i.e. if it exists in the module versioning file)
 			if _, exists := modVersioning.ModInfoMap[common.ModulePath(dep.Mod.Path)]; exists {
-				dependencies[modPath] = append(dependencies[modPath], common.ModulePath(dep.Mod.Path))
+				requires[modPath] = append(requires[modPath], dep)
 			}
 		}
 	}
 
-	return dependencies, nil
+	return requires, nil
 }
 
 // verifyAllModulesInSet checks that every module (as defined by a go.mod file) is contained in exactly
-// one module set, unless it is excluded.
+// one module set, unless it is excluded, and that every module of a module set exists. All the
+// modules failing the check are listed.
 func (v verification) verifyAllModulesInSet() error {
+	if errs := v.moduleSetErrors(); len(errs) > 0 {
+		return &errModuleSetSlice{errs: errs}
+	}
+
+	logging.Infof("PASS: All modules exist in exactly one set.")
+
+	return nil
+}
+
+// moduleSetErrors returns an error for every module that is not listed in any
+// module set and for every module of a module set that does not exist in the
+// repo, sorted by module path.
+func (v verification) moduleSetErrors() []error {
+	var notInSet []*errModuleNotInSet
 	for modPath, modFilePath := range v.ModuleVersioning.ModPathMap {
 		if _, exists := v.ModuleVersioning.ModInfoMap[modPath]; !exists {
-			return &errModuleNotInSet{
+			notInSet = append(notInSet, &errModuleNotInSet{
 				modPath:     modPath,
 				modFilePath: modFilePath,
-			}
+			})
 		}
 	}
+	sort.Slice(notInSet, func(i, j int) bool { return notInSet[i].modPath < notInSet[j].modPath })
 
+	var notInRepo []*errModuleNotInRepo
 	for modPath, modInfo := range v.ModuleVersioning.ModInfoMap {
 		if _, exists := v.ModuleVersioning.ModPathMap[modPath]; !exists {
-			return &errModuleNotInRepo{
+			notInRepo = append(notInRepo, &errModuleNotInRepo{
 				modPath:    modPath,
 				modSetName: modInfo.ModuleSetName,
-			}
+			})
 		}
 	}
+	sort.Slice(notInRepo, func(i, j int) bool { return notInRepo[i].modPath < notInRepo[j].modPath })
 
-	log.Println("PASS: All modules exist in exactly one set.")
-
-	return nil
+	var errs []error
+	for _, err := range notInSet {
+		errs = append(errs, err)
+	}
+	for _, err := range notInRepo {
+		errs = append(errs, err)
+	}
+	return errs
 }
 
 // verifyVersions checks that module set versions conform to versioning semantics.
@@ -143,6 +241,18 @@ func (v verification) verifyVersions() error {
 			}
 		}
 
+		// Check that version overrides hold modules back at valid versions
+		for _, override := range modSet.VersionOverrides {
+			if !semver.IsValid(override.Version) || semver.Compare(override.Version, modSet.Version) >= 0 {
+				return &errInvalidVersionOverride{
+					modSetName:    modSetName,
+					modSetVersion: modSet.Version,
+					modPath:       override.Module,
+					version:       override.Version,
+				}
+			}
+		}
+
 		if common.IsStableVersion(modSet.Version) {
 			// Add all sets to major version map
 			modSetMajorVersion := semver.Major(modSet.Version)
@@ -167,40 +277,95 @@ func (v verification) verifyVersions() error {
 		}
 	}
 
-	log.Println("PASS: All module versions are valid, and no module sets have same non-zero major version.")
+	logging.Infof("PASS: All module versions are valid, and no module sets have same non-zero major version.")
 
 	return nil
 }
 
-// verifyDependencies checks that dependencies between modules conform to versioning semantics.
+// verifyDependencies checks that no module of a stable module set depends on
+// a module of an unstable module set.
 func (v verification) verifyDependencies() error {
 	dependencies, err := v.getDependencies()
 	if err != nil {
 		return fmt.Errorf("could not get dependencies of module versioning: %w", err)
 	}
 
+	var depErrors []*errDependency
 	for modPath, modDeps := range dependencies {
 		// check if module is stable
-		modVersion := v.ModuleVersioning.ModInfoMap[modPath].Version
-		if common.IsStableVersion(modVersion) {
-
-			for _, depPath := range modDeps {
-				// check if dependency is on an unstable module
-				depVersion := v.ModuleVersioning.ModInfoMap[depPath].Version
-				if !common.IsStableVersion(depVersion) {
-					log.Println(
-						&errDependency{
-							modPath:    modPath,
-							modVersion: modVersion,
-							depPath:    depPath,
-							depVersion: depVersion,
-						},
-					)
-				}
+		modInfo := v.ModuleVersioning.ModInfoMap[modPath]
+		if !common.IsStableVersion(modInfo.Version) {
+			continue
+		}
+
+		for _, depPath := range modDeps {
+			// check if dependency is on an unstable module
+			depInfo := v.ModuleVersioning.ModInfoMap[depPath]
+			if !common.IsStableVersion(depInfo.Version) {
+				depErrors = append(depErrors, &errDependency{
+					modPath:       modPath,
+					modSetName:    modInfo.ModuleSetName,
+					modVersion:    modInfo.Version,
+					depPath:       depPath,
+					depModSetName: depInfo.ModuleSetName,
+					depVersion:    depInfo.Version,
+				})
 			}
 		}
 	}
 
-	log.Println("Finished checking all stable modules' dependencies.")
+	if len(depErrors) > 0 {
+		sort.Slice(depErrors, func(i, j int) bool {
+			if depErrors[i].modPath != depErrors[j].modPath {
+				return depErrors[i].modPath < depErrors[j].modPath
+			}
+			return depErrors[i].depPath < depErrors[j].depPath
+		})
+		return &errDependencySlice{errs: depErrors}
+	}
+
+	logging.Infof("PASS: No stable modules depend on unstable modules.")
+	return nil
+}
+
+// verifyRequiredVersions checks that every module requires the modules of the
+// repo at the version of their module set, honoring version overrides.
+func (v verification) verifyRequiredVersions() error {
+	requires, err := v.getRequires()
+	if err != nil {
+		return fmt.Errorf("could not get dependencies of module versioning: %w", err)
+	}
+
+	var versionErrors []*errRequiredVersion
+	for modPath, reqs := range requires {
+		modInfo := v.ModuleVersioning.ModInfoMap[modPath]
+		for _, req := range reqs {
+			depPath := common.ModulePath(req.Mod.Path)
+			depInfo := v.ModuleVersioning.ModInfoMap[depPath]
+			// go.mod files drop build metadata, which is ignored by the comparison.
+			if semver.Compare(req.Mod.Version, depInfo.Version) != 0 {
+				versionErrors = append(versionErrors, &errRequiredVersion{
+					modPath:         modPath,
+					modSetName:      modInfo.ModuleSetName,
+					depPath:         depPath,
+					depModSetName:   depInfo.ModuleSetName,
+					version:         req.Mod.Version,
+					expectedVersion: depInfo.Version,
+				})
+			}
+		}
+	}
+
+	if len(versionErrors) > 0 {
+		sort.Slice(versionErrors, func(i, j int) bool {
+			if versionErrors[i].modPath != versionErrors[j].modPath {
+				return versionErrors[i].modPath < versionErrors[j].modPath
+			}
+			return versionErrors[i].depPath < versionErrors[j].depPath
+		})
+		return &errRequiredVersionSlice{errs: versionErrors}
+	}
+
+	logging.Infof("PASS: All modules require modules of the repo at the versions of their module sets.")
 	return nil
 }
@@ -15,7 +15,6 @@
 package verify
 
 import (
-	"bytes"
 	"errors"
 	"io"
 	"log"
@@ -40,17 +39,6 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
-func captureOutput(f func()) string {
-	var buf bytes.Buffer
-	log.SetOutput(&buf)
-	defer func() {
-		log.SetOutput(io.Discard)
-	}()
-
-	f()
-	return buf.String()
-}
-
 func TestNewVerification(t *testing.T) {
 	testName := "new_verification"
 	versionYamlDir := filepath.Join(testDataDir, testName)
@@ -257,10 +245,37 @@ func TestVerifyAllModulesInSet(t *testing.T) {
 				filepath.Join(tmpRootDir, "not_listed", "go.mod"):                     []byte("module go.opentelemetry.io/testroot/v2\n\ngo 1.16\n"),
 				filepath.Join(tmpRootDir, "not_listed", "test", "excluded", "go.mod"): []byte("module \"go.opentelemetry.io/test/testexcluded\"\n\ngo 1.16\n"),
 			},
-			expectedError: &errModuleNotInSet{
-				modPath:     "go.opentelemetry.io/testroot/v2",
-				modFilePath: common.ModuleFilePath(filepath.Join(tmpRootDir, "not_listed", "go.mod")),
+			expectedError: &errModuleSetSlice{errs: []error{
+				&errModuleNotInSet{
+					modPath:     "go.opentelemetry.io/testroot/v2",
+					modFilePath: common.ModuleFilePath(filepath.Join(tmpRootDir, "not_listed", "go.mod")),
+				},
+			}},
+		},
+		{
+			name:               "modules not listed",
+			versioningFilename: filepath.Join(versionYamlDir, "modules_not_listed.yaml"),
+			repoRoot:           filepath.Join(tmpRootDir, "several_not_listed"),
+			modFiles: map[string][]byte{
+				filepath.Join(tmpRootDir, "several_not_listed", "test", "test1", "go.mod"):    []byte("module \"go.opentelemetry.io/test/test1\"\n\ngo 1.16\n"),
+				filepath.Join(tmpRootDir, "several_not_listed", "test", "go.mod"):             []byte("module go.opentelemetry.io/test2\n\ngo 1.16\n"),
+				filepath.Join(tmpRootDir, "several_not_listed", "go.mod"):                     []byte("module go.opentelemetry.io/testroot/v2\n\ngo 1.16\n"),
+				filepath.Join(tmpRootDir, "several_not_listed", "test", "excluded", "go.mod"): []byte("module \"go.opentelemetry.io/test/testexcluded\"\n\ngo 1.16\n"),
 			},
+			expectedError: &errModuleSetSlice{errs: []error{
+				&errModuleNotInSet{
+					modPath:     "go.opentelemetry.io/test/testexcluded",
+					modFilePath: common.ModuleFilePath(filepath.Join(tmpRootDir, "several_not_listed", "test", "excluded", "go.mod")),
+				},
+				&errModuleNotInSet{
+					modPath:     "go.opentelemetry.io/test2",
+					modFilePath: common.ModuleFilePath(filepath.Join(tmpRootDir, "several_not_listed", "test", "go.mod")),
+				},
+				&errModuleNotInSet{
+					modPath:     "go.opentelemetry.io/testroot/v2",
+					modFilePath: common.ModuleFilePath(filepath.Join(tmpRootDir, "several_not_listed", "go.mod")),
+				},
+			}},
 		},
 		{
 			name:               "module not in repo",
@@ -271,10 +286,12 @@ func TestVerifyAllModulesInSet(t *testing.T) {
 				filepath.Join(tmpRootDir, "not_in_repo", "test", "go.mod"):             []byte("module go.opentelemetry.io/test2\n\ngo 1.16\n"),
 				filepath.Join(tmpRootDir, "not_in_repo", "test", "excluded", "go.mod"): []byte("module \"go.opentelemetry.io/test/testexcluded\"\n\ngo 1.16\n"),
 			},
-			expectedError: &errModuleNotInRepo{
-				modPath:    "go.opentelemetry.io/testroot/v2",
-				modSetName: "mod-set-3",
-			},
+			expectedError: &errModuleSetSlice{errs: []error{
+				&errModuleNotInRepo{
+					modPath:    "go.opentelemetry.io/testroot/v2",
+					modSetName: "mod-set-3",
+				},
+			}},
 		},
 	}
 
@@ -331,6 +348,23 @@ func TestVerifyVersions(t *testing.T) {
 				modSetVersion: "invalid-version-v.02.0.",
 			},
 		},
+		{
+			name:               "invalid version override",
+			versioningFilename: filepath.Join(versionYamlDir, "invalid_version_override.yaml"),
+			repoRoot:           filepath.Join(tmpRootDir, "invalid_version_override"),
+			modFiles: map[string][]byte{
+				filepath.Join(tmpRootDir, "invalid_version_override", "test", "test1", "go.mod"):    []byte("module \"go.opentelemetry.io/test/test1\"\n\ngo 1.16\n"),
+				filepath.Join(tmpRootDir, "invalid_version_override", "test", "go.mod"):             []byte("module go.opentelemetry.io/test2\n\ngo 1.16\n"),
+				filepath.Join(tmpRootDir, "invalid_version_override", "go.mod"):                     []byte("module go.opentelemetry.io/testroot/v2\n\ngo 1.16\n"),
+				filepath.Join(tmpRootDir, "invalid_version_override", "test", "excluded", "go.mod"): []byte("module \"go.opentelemetry.io/test/testexcluded\"\n\ngo 1.16\n"),
+			},
+			expectedError: &errInvalidVersionOverride{
+				modSetName:    "mod-set-2",
+				modSetVersion: "v0.1.0",
+				modPath:       "go.opentelemetry.io/test2",
+				version:       "v0.2.0",
+			},
+		},
 		{
 			name:               "multiple sets with same major version",
 			versioningFilename: filepath.Join(versionYamlDir, "multiple_sets_same_major.yaml"),
@@ -374,6 +408,10 @@ func TestVerifyVersions(t *testing.T) {
 					// compare that modSetNames elements match (order should not matter)
 					assert.ElementsMatch(t, expectedErr.modSetNames, actualErr.modSetNames)
 				}
+				overrideErr := &errInvalidVersionOverride{}
+				if errors.As(tc.expectedError, &overrideErr) {
+					assert.Equal(t, tc.expectedError, actual)
+				}
 			} else {
 				assert.Equal(t, tc.expectedError, actual)
 			}
@@ -391,8 +429,7 @@ func TestVerifyDependencies(t *testing.T) {
 		versioningFilename string
 		repoRoot           string
 		modFiles           map[string][]byte
-		expectWarnings     bool
-		expectedLogs       []string
+		expectedErr        error
 	}{
 		{
 			name:               "valid",
@@ -423,10 +460,7 @@ func TestVerifyDependencies(t *testing.T) {
 					"go.opentelemetry.io/build-tools/multimod/internal/verify/test3 v0.1.0\n" +
 					")"),
 			},
-			expectWarnings: false,
-			expectedLogs: []string{
-				"Finished checking all stable modules' dependencies.\n",
-			},
+			expectedErr: nil,
 		},
 		{
 			name:               "stable depends on unstable",
@@ -460,26 +494,33 @@ func TestVerifyDependencies(t *testing.T) {
 					"go.opentelemetry.io/build-tools/multimod/internal/verify/testroot v0.2.0\n" +
 					")"),
 			},
-			expectWarnings: true,
-			expectedLogs: []string{
-				(&errDependency{
-					modPath:    "go.opentelemetry.io/build-tools/multimod/internal/verify/test/test1",
-					modVersion: "v1.2.3-RC1+meta",
-					depPath:    "go.opentelemetry.io/build-tools/multimod/internal/verify/test3",
-					depVersion: "v0.1.0",
-				}).Error(),
-				(&errDependency{
-					modPath:    "go.opentelemetry.io/build-tools/multimod/internal/verify/test/test2",
-					modVersion: "v1.2.3-RC1+meta",
-					depPath:    "go.opentelemetry.io/build-tools/multimod/internal/verify/testroot",
-					depVersion: "v0.2.0",
-				}).Error(),
-				(&errDependency{
-					modPath:    "go.opentelemetry.io/build-tools/multimod/internal/verify/test/test2",
-					modVersion: "v1.2.3-RC1+meta",
-					depPath:    "go.opentelemetry.io/build-tools/multimod/internal/verify/test3",
-					depVersion: "v0.1.0",
-				}).Error(),
+			expectedErr: &errDependencySlice{
+				errs: []*errDependency{
+					{
+						modPath:       "go.opentelemetry.io/build-tools/multimod/internal/verify/test/test1",
+						modSetName:    "mod-set-1",
+						modVersion:    "v1.2.3-RC1+meta",
+						depPath:       "go.opentelemetry.io/build-tools/multimod/internal/verify/test3",
+						depModSetName: "mod-set-2",
+						depVersion:    "v0.1.0",
+					},
+					{
+						modPath:       "go.opentelemetry.io/build-tools/multimod/internal/verify/test/test2",
+						modSetName:    "mod-set-1",
+						modVersion:    "v1.2.3-RC1+meta",
+						depPath:       "go.opentelemetry.io/build-tools/multimod/internal/verify/test3",
+						depModSetName: "mod-set-2",
+						depVersion:    "v0.1.0",
+					},
+					{
+						modPath:       "go.opentelemetry.io/build-tools/multimod/internal/verify/test/test2",
+						modSetName:    "mod-set-1",
+						modVersion:    "v1.2.3-RC1+meta",
+						depPath:       "go.opentelemetry.io/build-tools/multimod/internal/verify/testroot",
+						depModSetName: "mod-set-3",
+						depVersion:    "v0.2.0",
+					},
+				},
 			},
 		},
 	}
@@ -491,18 +532,95 @@ func TestVerifyDependencies(t *testing.T) {
 			v, err := newVerification(tc.versioningFilename, tc.repoRoot)
 			require.NoError(t, err)
 
-			actual := captureOutput(func() {
-				err = v.verifyDependencies()
-				require.NoError(t, err)
-			})
+			err = v.verifyDependencies()
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
 
-			if tc.expectWarnings {
-				for _, expectedLog := range tc.expectedLogs {
-					assert.Contains(t, actual, expectedLog)
-				}
-			} else {
-				assert.NotContains(t, actual, "WARNING")
-			}
+func TestVerifyRequiredVersions(t *testing.T) {
+	versioningFilename := filepath.Join(testDataDir, "verify_dependencies", "versions_valid.yaml")
+
+	tmpRootDir := t.TempDir()
+	testCases := []struct {
+		name        string
+		repoRoot    string
+		modFiles    map[string][]byte
+		expectedErr error
+	}{
+		{
+			name:     "valid",
+			repoRoot: filepath.Join(tmpRootDir, "valid"),
+			modFiles: map[string][]byte{
+				filepath.Join(tmpRootDir, "valid", "test", "test1", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/verify/test/test1\n\n" +
+					"go 1.16\n\n" +
+					"require (\n\t" +
+					"go.opentelemetry.io/build-tools/multimod/internal/verify/test/test2 v1.2.3-RC1+meta\n\t" +
+					"go.opentelemetry.io/other v1.0.0\n" +
+					")"),
+				filepath.Join(tmpRootDir, "valid", "test", "test2", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/verify/test/test2\n\n" +
+					"go 1.16\n"),
+				filepath.Join(tmpRootDir, "valid", "test", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/verify/test3\n\n" +
+					"go 1.16\n\n" +
+					"require go.opentelemetry.io/build-tools/multimod/internal/verify/testroot v0.2.0\n"),
+				filepath.Join(tmpRootDir, "valid", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/verify/testroot\n\n" +
+					"go 1.16\n\n" +
+					"require go.opentelemetry.io/build-tools/multimod/internal/verify/test3 v0.1.0\n"),
+			},
+		},
+		{
+			name:     "outdated requires",
+			repoRoot: filepath.Join(tmpRootDir, "outdated"),
+			modFiles: map[string][]byte{
+				filepath.Join(tmpRootDir, "outdated", "test", "test1", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/verify/test/test1\n\n" +
+					"go 1.16\n\n" +
+					"require (\n\t" +
+					"go.opentelemetry.io/build-tools/multimod/internal/verify/test/test2 v1.2.2\n\t" +
+					"go.opentelemetry.io/build-tools/multimod/internal/verify/test3 v0.1.0\n" +
+					")"),
+				filepath.Join(tmpRootDir, "outdated", "test", "test2", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/verify/test/test2\n\n" +
+					"go 1.16\n"),
+				filepath.Join(tmpRootDir, "outdated", "test", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/verify/test3\n\n" +
+					"go 1.16\n"),
+				filepath.Join(tmpRootDir, "outdated", "go.mod"): []byte("module go.opentelemetry.io/build-tools/multimod/internal/verify/testroot\n\n" +
+					"go 1.16\n\n" +
+					"require (\n\t" +
+					"go.opentelemetry.io/build-tools/multimod/internal/verify/test/test1 v1.2.3-RC1+meta\n\t" +
+					"go.opentelemetry.io/build-tools/multimod/internal/verify/test3 v0.0.9\n" +
+					")"),
+			},
+			expectedErr: &errRequiredVersionSlice{
+				errs: []*errRequiredVersion{
+					{
+						modPath:         "go.opentelemetry.io/build-tools/multimod/internal/verify/test/test1",
+						modSetName:      "mod-set-1",
+						depPath:         "go.opentelemetry.io/build-tools/multimod/internal/verify/test/test2",
+						depModSetName:   "mod-set-1",
+						version:         "v1.2.2",
+						expectedVersion: "v1.2.3-RC1+meta",
+					},
+					{
+						modPath:         "go.opentelemetry.io/build-tools/multimod/internal/verify/testroot",
+						modSetName:      "mod-set-3",
+						depPath:         "go.opentelemetry.io/build-tools/multimod/internal/verify/test3",
+						depModSetName:   "mod-set-2",
+						version:         "v0.0.9",
+						expectedVersion: "v0.1.0",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, commontest.WriteTempFiles(tc.modFiles), "could not create go mod file tree")
+
+			v, err := newVerification(versioningFilename, tc.repoRoot)
+			require.NoError(t, err)
+
+			err = v.verifyRequiredVersions()
+			assert.Equal(t, tc.expectedErr, err)
 		})
 	}
 }
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releasetest
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"go.opentelemetry.io/build-tools/multimod/versioning"
+)
+
+// Module is a module of a module set.
+type Module struct {
+	// Path is the import path of the module.
+	Path versioning.ModulePath
+	// Dir is the slash separated directory of the go.mod file of the module,
+	// relative to the repository root.
+	Dir string
+}
+
+// Builder builds the module versioning of a repository.
+type Builder struct {
+	root     string
+	modSets  versioning.ModuleSetMap
+	modPaths versioning.ModulePathMap
+}
+
+// NewBuilder returns a Builder for the repository rooted at root.
+func NewBuilder(root string) *Builder {
+	return &Builder{
+		root:     root,
+		modSets:  make(versioning.ModuleSetMap),
+		modPaths: make(versioning.ModulePathMap),
+	}
+}
+
+// ModuleSet adds a module set named name with version and modules.
+func (b *Builder) ModuleSet(name, version string, modules ...Module) *Builder {
+	modSet := versioning.ModuleSet{Version: version}
+	for _, mod := range modules {
+		modSet.Modules = append(modSet.Modules, mod.Path)
+		b.modPaths[mod.Path] = versioning.ModuleFilePath(filepath.Join(b.root, filepath.FromSlash(mod.Dir), "go.mod"))
+	}
+	b.modSets[name] = modSet
+	return b
+}
+
+// Freeze marks the module set named name, which must have been added, as
+// frozen.
+func (b *Builder) Freeze(name string) *Builder {
+	modSet := b.modSets[name]
+	modSet.Frozen = true
+	b.modSets[name] = modSet
+	return b
+}
+
+// ModuleVersioning returns the module versioning of all added module sets.
+func (b *Builder) ModuleVersioning() versioning.ModuleVersioning {
+	modVersioning := versioning.ModuleVersioning{
+		ModSetMap:  make(versioning.ModuleSetMap, len(b.modSets)),
+		ModPathMap: make(versioning.ModulePathMap, len(b.modPaths)),
+		ModInfoMap: make(versioning.ModuleInfoMap, len(b.modPaths)),
+	}
+	for name, modSet := range b.modSets {
+		modVersioning.ModSetMap[name] = modSet
+		for _, modPath := range modSet.Modules {
+			modVersioning.ModInfoMap[modPath] = versioning.ModuleInfo{
+				ModuleSetName: name,
+				Version:       modSet.ModuleVersion(modPath),
+			}
+		}
+	}
+	for modPath, modFilePath := range b.modPaths {
+		modVersioning.ModPathMap[modPath] = modFilePath
+	}
+	return modVersioning
+}
+
+// ModuleSetRelease returns the release of the module set named name.
+func (b *Builder) ModuleSetRelease(name string) (versioning.ModuleSetRelease, error) {
+	modVersioning := b.ModuleVersioning()
+
+	modSet, exists := modVersioning.ModSetMap[name]
+	if !exists {
+		return versioning.ModuleSetRelease{}, fmt.Errorf("could not find module set %v", name)
+	}
+
+	tagNames, err := versioning.ModuleTagNames(modSet.Modules, modVersioning.ModPathMap, b.root)
+	if err != nil {
+		return versioning.ModuleSetRelease{}, fmt.Errorf("could not retrieve tag names from module paths: %w", err)
+	}
+
+	return versioning.ModuleSetRelease{
+		ModuleVersioning: modVersioning,
+		ModSetName:       name,
+		ModSet:           modSet,
+		TagNames:         tagNames,
+	}, nil
+}
+
+// GoModFiles returns minimal go.mod files of all added modules, keyed by their
+// file path, to be written to disk by tests that need them.
+func (b *Builder) GoModFiles() map[string][]byte {
+	files := make(map[string][]byte, len(b.modPaths))
+	for modPath, modFilePath := range b.modPaths {
+		files[string(modFilePath)] = []byte(fmt.Sprintf("module %s\n\ngo 1.16\n", modPath))
+	}
+	return files
+}
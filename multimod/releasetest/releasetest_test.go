@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releasetest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common/commontest"
+	"go.opentelemetry.io/build-tools/multimod/versioning"
+)
+
+func newTestBuilder(root string) *Builder {
+	return NewBuilder(root).
+		ModuleSet("mod-set-1", "v1.2.3",
+			Module{Path: "go.opentelemetry.io/test/test1", Dir: "test/test1"},
+			Module{Path: "go.opentelemetry.io/testroot/v2", Dir: "."},
+		).
+		ModuleSet("mod-set-2", "v0.1.0",
+			Module{Path: "go.opentelemetry.io/test2", Dir: "test"},
+		).
+		Freeze("mod-set-2")
+}
+
+func TestModuleSetRelease(t *testing.T) {
+	root := t.TempDir()
+
+	modRelease, err := newTestBuilder(root).ModuleSetRelease("mod-set-1")
+	require.NoError(t, err)
+	assert.Equal(t, "mod-set-1", modRelease.ModSetName)
+	assert.Equal(t, "v1.2.3", modRelease.ModSetVersion())
+	assert.Equal(t, []string{"test/test1/v1.2.3", "v1.2.3"}, modRelease.ModuleFullTagNames())
+	assert.NoError(t, modRelease.CheckNotFrozen(false))
+
+	modRelease, err = newTestBuilder(root).ModuleSetRelease("mod-set-2")
+	require.NoError(t, err)
+	assert.Error(t, modRelease.CheckNotFrozen(false))
+
+	_, err = newTestBuilder(root).ModuleSetRelease("mod-set-3")
+	assert.Error(t, err)
+}
+
+// TestModuleVersioning checks the builder matches a versioning file.
+func TestModuleVersioning(t *testing.T) {
+	root := t.TempDir()
+	b := newTestBuilder(root)
+	require.NoError(t, commontest.WriteTempFiles(b.GoModFiles()))
+
+	versioningFilename := filepath.Join(root, "versions.yaml")
+	require.NoError(t, os.WriteFile(versioningFilename, []byte(`module-sets:
+  mod-set-1:
+    version: v1.2.3
+    modules:
+      - go.opentelemetry.io/test/test1
+      - go.opentelemetry.io/testroot/v2
+  mod-set-2:
+    version: v0.1.0
+    frozen: true
+    modules:
+      - go.opentelemetry.io/test2
+`), 0600))
+
+	expected, err := versioning.NewModuleVersioning(versioningFilename, root)
+	require.NoError(t, err)
+	assert.Equal(t, expected, b.ModuleVersioning())
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releasetest
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common/commontest"
+)
+
+// InitNewMemoryRepoWithCommit initializes a repository whose storage and
+// working tree are kept in memory, and commits files, keyed by their slash
+// separated path, to it. It can replace a repository on disk in tests that only use
+// git objects and references and do not read the working tree from disk.
+func InitNewMemoryRepoWithCommit(files map[string][]byte) (*git.Repository, plumbing.Hash, error) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		return nil, plumbing.ZeroHash, fmt.Errorf("could not initialize in-memory git repo: %w", err)
+	}
+
+	commitHash, err := CommitFiles(repo, files)
+	if err != nil {
+		return nil, plumbing.ZeroHash, err
+	}
+	return repo, commitHash, nil
+}
+
+// CommitFiles writes files, keyed by their slash separated path relative to
+// the root of the working tree of repo, and commits them. The working tree can
+// be on disk or in memory.
+func CommitFiles(repo *git.Repository, files map[string][]byte) (plumbing.Hash, error) {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	for name, data := range files {
+		if err = util.WriteFile(worktree.Filesystem, name, data, 0600); err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("could not write file %v: %w", name, err)
+		}
+	}
+	if len(files) > 0 {
+		if err = worktree.AddGlob("."); err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("could not add files to git repo: %w", err)
+		}
+	}
+
+	commitHash, err := worktree.Commit("test commit", &git.CommitOptions{
+		All:    true,
+		Author: commontest.TestAuthor,
+	})
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("could not commit changes to git: %w", err)
+	}
+	return commitHash, nil
+}
+
+// CreateTags creates annotated tags with tagNames on the commit with hash.
+func CreateTags(repo *git.Repository, hash plumbing.Hash, tagNames ...string) error {
+	for _, tagName := range tagNames {
+		_, err := repo.CreateTag(tagName, hash, &git.CreateTagOptions{
+			Message: "test tag message",
+			Tagger:  commontest.TestAuthor,
+		})
+		if err != nil {
+			return fmt.Errorf("could not create tag %v: %w", tagName, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package releasetest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common/commontest"
+)
+
+func TestInitNewMemoryRepoWithCommit(t *testing.T) {
+	repo, hash, err := InitNewMemoryRepoWithCommit(map[string][]byte{
+		"go.mod":            []byte("module go.opentelemetry.io/testroot\n\ngo 1.16\n"),
+		"test/test1/go.mod": []byte("module go.opentelemetry.io/test/test1\n\ngo 1.16\n"),
+	})
+	require.NoError(t, err)
+
+	head, err := repo.Head()
+	require.NoError(t, err)
+	assert.Equal(t, hash, head.Hash())
+
+	commit, err := repo.CommitObject(hash)
+	require.NoError(t, err)
+	file, err := commit.File("test/test1/go.mod")
+	require.NoError(t, err)
+	content, err := file.Contents()
+	require.NoError(t, err)
+	assert.Equal(t, "module go.opentelemetry.io/test/test1\n\ngo 1.16\n", content)
+
+	require.NoError(t, CreateTags(repo, hash, "v1.0.0", "test/test1/v1.0.0"))
+	tag, err := repo.Tag("test/test1/v1.0.0")
+	require.NoError(t, err)
+	tagObj, err := repo.TagObject(tag.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, hash, tagObj.Target)
+	assert.Error(t, CreateTags(repo, hash, "v1.0.0"), "tag already exists")
+
+	_, _, err = InitNewMemoryRepoWithCommit(nil)
+	assert.NoError(t, err, "empty commit")
+}
+
+func TestCommitFiles(t *testing.T) {
+	root := t.TempDir()
+	diskRepo, _, err := commontest.InitNewRepoWithCommit(root)
+	require.NoError(t, err)
+	memoryRepo, _, err := InitNewMemoryRepoWithCommit(nil)
+	require.NoError(t, err)
+
+	for name, repo := range map[string]*git.Repository{"disk": diskRepo, "memory": memoryRepo} {
+		t.Run(name, func(t *testing.T) {
+			hash, err := CommitFiles(repo, map[string][]byte{"a/b/go.mod": []byte("module go.opentelemetry.io/test/a/b\n")})
+			require.NoError(t, err)
+
+			head, err := repo.Head()
+			require.NoError(t, err)
+			assert.Equal(t, hash, head.Hash())
+
+			commit, err := repo.CommitObject(hash)
+			require.NoError(t, err)
+			file, err := commit.File("a/b/go.mod")
+			require.NoError(t, err)
+			content, err := file.Contents()
+			require.NoError(t, err)
+			assert.Equal(t, "module go.opentelemetry.io/test/a/b\n", content)
+		})
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, "a", "b", "go.mod"))
+	require.NoError(t, err)
+	assert.Equal(t, "module go.opentelemetry.io/test/a/b\n", string(data), "written to the working tree on disk")
+}
@@ -0,0 +1,22 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package versioning reads multimod versioning files, typically named
+// versions.yaml, and provides the release operations of multimod, so other
+// build tooling, such as release bots or dashboards, can use them without
+// running the multimod command.
+//
+// The types are aliases of the ones used by multimod itself, so they always
+// match the behavior of the command.
+package versioning
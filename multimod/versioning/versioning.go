@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package versioning
+
+import (
+	"go.opentelemetry.io/build-tools/multimod/internal/common"
+)
+
+// ModulePath is the import path of a Go module, e.g.
+// go.opentelemetry.io/otel/sdk.
+type ModulePath = common.ModulePath
+
+// ModuleFilePath is the file path of the go.mod file of a module.
+type ModuleFilePath = common.ModuleFilePath
+
+// ModuleTagName is the path of the directory of a module relative to the
+// repository root, prefixing the Git tags of the module, e.g. sdk/metric.
+type ModuleTagName = common.ModuleTagName
+
+// ModuleSet is a set of modules released together at the same version.
+type ModuleSet = common.ModuleSet
+
+// ModuleSetMap maps the name of a module set to the module set.
+type ModuleSetMap = common.ModuleSetMap
+
+// VersionOverride holds a module of a module set back at its own version.
+type VersionOverride = common.VersionOverride
+
+// VersionFile is a file of a module holding the version of its module set.
+type VersionFile = common.VersionFile
+
+// ModuleInfo is the module set and version of a module.
+type ModuleInfo = common.ModuleInfo
+
+// ModuleInfoMap maps the import path of a module to its ModuleInfo.
+type ModuleInfoMap = common.ModuleInfoMap
+
+// ModulePathMap maps the import path of a module to the path of its go.mod
+// file.
+type ModulePathMap = common.ModulePathMap
+
+// ModuleVersioning holds the module sets of a versioning file and the
+// modules of the repository.
+type ModuleVersioning = common.ModuleVersioning
+
+// ModuleSetRelease holds the modules of a module set to release and their Git
+// tag names.
+type ModuleSetRelease = common.ModuleSetRelease
+
+// NewModuleVersioning returns the ModuleVersioning of the versioning file,
+// and the versioning files it includes, for the repository at repoRoot.
+func NewModuleVersioning(versioningFilename, repoRoot string) (ModuleVersioning, error) {
+	return common.NewModuleVersioning(versioningFilename, repoRoot)
+}
+
+// NewModuleSetRelease returns the ModuleSetRelease of the module set named
+// modSetName in the versioning file, for the repository at repoRoot.
+func NewModuleSetRelease(versioningFilename, modSetName, repoRoot string) (ModuleSetRelease, error) {
+	return common.NewModuleSetRelease(versioningFilename, modSetName, repoRoot)
+}
+
+// ModuleSetNames returns the sorted names of the module sets of the
+// versioning file, without looking for the modules in the repository.
+func ModuleSetNames(versioningFilename string) ([]string, error) {
+	return common.GetModuleSetNames(versioningFilename)
+}
+
+// ReadExcludedModules returns the modules excluded from versioning in the
+// versioning file.
+func ReadExcludedModules(versioningFilename string) ([]ModulePath, error) {
+	return common.ReadExcludedModules(versioningFilename)
+}
+
+// FindModules returns the go.mod files of all the modules of the repository
+// at root.
+func FindModules(root string) (ModulePathMap, error) {
+	return common.FindModules(root)
+}
+
+// IsStableVersion reports whether the version v is stable, i.e. v1 or above.
+func IsStableVersion(v string) bool {
+	return common.IsStableVersion(v)
+}
+
+// ModuleFullTagName returns the full Git tag name of the module with tag
+// name modTagName at version, e.g. sdk/metric/v0.45.0.
+func ModuleFullTagName(modTagName ModuleTagName, version string) string {
+	return common.ModuleFullTagName(modTagName, version)
+}
+
+// ModuleTagNames returns the tag names of the modules with import paths
+// modPaths, whose go.mod files are given by modPathMap, in the repository at
+// repoRoot.
+func ModuleTagNames(modPaths []ModulePath, modPathMap ModulePathMap, repoRoot string) ([]ModuleTagName, error) {
+	return common.ModulePathsToTagNames(modPaths, modPathMap, repoRoot)
+}
+
+// UpdateGoModFiles sets the version of the requirements on the modules
+// newModPaths to newVersion in the go.mod files modFilePaths. Only the
+// version tokens are rewritten, comments and formatting are preserved.
+func UpdateGoModFiles(modFilePaths []ModuleFilePath, newModPaths []ModulePath, newVersion string) error {
+	return common.UpdateGoModFiles(modFilePaths, newModPaths, newVersion)
+}
+
+// UpdateGoModFilesToModuleSet sets the version of the requirements on the
+// modules of modSet to their version in the set, honoring its version
+// overrides, in the go.mod files modFilePaths.
+func UpdateGoModFilesToModuleSet(modFilePaths []ModuleFilePath, modSet ModuleSet) error {
+	return common.UpdateGoModFilesToModuleSet(modFilePaths, modSet)
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package versioning_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/multimod/internal/common/commontest"
+	"go.opentelemetry.io/build-tools/multimod/versioning"
+)
+
+func TestVersioning(t *testing.T) {
+	root := t.TempDir()
+	versioningFilename := filepath.Join(root, "versions.yaml")
+	files := map[string][]byte{
+		versioningFilename: []byte("module-sets:\n" +
+			"  stable-v1:\n" +
+			"    version: v1.2.0\n" +
+			"    modules:\n" +
+			"      - example.com/repo\n" +
+			"      - example.com/repo/sdk\n" +
+			"  experimental:\n" +
+			"    version: v0.3.0\n" +
+			"    modules:\n" +
+			"      - example.com/repo/exporter\n" +
+			"excluded-modules:\n" +
+			"  - example.com/repo/tools\n"),
+		filepath.Join(root, "go.mod"):             []byte("module example.com/repo\n\ngo 1.18\n"),
+		filepath.Join(root, "sdk", "go.mod"):      []byte("module example.com/repo/sdk\n\ngo 1.18\n\nrequire example.com/repo v1.1.0\n"),
+		filepath.Join(root, "exporter", "go.mod"): []byte("module example.com/repo/exporter\n\ngo 1.18\n\nrequire example.com/repo/sdk v1.1.0 // indirect\n"),
+		filepath.Join(root, "tools", "go.mod"):    []byte("module example.com/repo/tools\n\ngo 1.18\n"),
+	}
+	require.NoError(t, commontest.WriteTempFiles(files))
+
+	names, err := versioning.ModuleSetNames(versioningFilename)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"experimental", "stable-v1"}, names)
+
+	excluded, err := versioning.ReadExcludedModules(versioningFilename)
+	require.NoError(t, err)
+	assert.Equal(t, []versioning.ModulePath{"example.com/repo/tools"}, excluded)
+
+	v, err := versioning.NewModuleVersioning(versioningFilename, root)
+	require.NoError(t, err)
+	assert.Equal(t, versioning.ModuleInfo{ModuleSetName: "experimental", Version: "v0.3.0"}, v.ModInfoMap["example.com/repo/exporter"])
+	assert.True(t, versioning.IsStableVersion(v.ModSetMap["stable-v1"].Version))
+	assert.False(t, versioning.IsStableVersion(v.ModSetMap["experimental"].Version))
+
+	release, err := versioning.NewModuleSetRelease(versioningFilename, "stable-v1", root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.2.0", "sdk/v1.2.0"}, release.ModuleFullTagNames())
+	assert.Equal(t, "sdk/v1.2.0", versioning.ModuleFullTagName("sdk", "v1.2.0"))
+
+	tagNames, err := versioning.ModuleTagNames([]versioning.ModulePath{"example.com/repo/exporter"}, v.ModPathMap, root)
+	require.NoError(t, err)
+	assert.Equal(t, []versioning.ModuleTagName{"exporter"}, tagNames)
+
+	modFilePaths := []versioning.ModuleFilePath{
+		v.ModPathMap["example.com/repo/sdk"],
+		v.ModPathMap["example.com/repo/exporter"],
+	}
+	require.NoError(t, versioning.UpdateGoModFilesToModuleSet(modFilePaths, release.ModSet))
+
+	data, err := os.ReadFile(filepath.Join(root, "sdk", "go.mod"))
+	require.NoError(t, err)
+	assert.Equal(t, "module example.com/repo/sdk\n\ngo 1.18\n\nrequire example.com/repo v1.2.0\n", string(data))
+	data, err = os.ReadFile(filepath.Join(root, "exporter", "go.mod"))
+	require.NoError(t, err)
+	assert.Equal(t, "module example.com/repo/exporter\n\ngo 1.18\n\nrequire example.com/repo/sdk v1.2.0 // indirect\n", string(data))
+}
@@ -0,0 +1,251 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+)
+
+const defaultSpecRepo = "https://github.com/open-telemetry/opentelemetry-specification"
+
+var (
+	errChecksumMismatch = errors.New("checksum mismatch")
+	errNoChecksum       = errors.New("no checksum to verify the archive against")
+)
+
+var httpClient = &http.Client{Timeout: 5 * time.Minute}
+
+// specArchiveURL returns the URL of the source archive for the release of
+// the specification repository tagged version.
+func specArchiveURL(repoURL, version string) string {
+	return fmt.Sprintf("%s/archive/refs/tags/%s.tar.gz", strings.TrimSuffix(repoURL, "/"), version)
+}
+
+// specArchiveCacheDir returns the directory archives are cached in.
+func specArchiveCacheDir(cfg config) (string, error) {
+	if cfg.cacheDir != "" {
+		return cfg.cacheDir, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to determine cache directory: %w", err)
+	}
+	return filepath.Join(dir, "semconvgen"), nil
+}
+
+// fetchSpecArchive returns the path to a local copy of the specification
+// release archive for cfg.specArchiveVersion, downloading it into the cache
+// if it is not already there. The archive is verified against
+// cfg.specChecksum if one is provided, otherwise against the checksum
+// recorded when it was downloaded. Cached archives without a recorded
+// checksum are not used, and archives are only downloaded if
+// cfg.specChecksum is provided.
+func fetchSpecArchive(cfg config) (string, error) {
+	cacheDir, err := specArchiveCacheDir(cfg)
+	if err != nil {
+		return "", err
+	}
+	if err = os.MkdirAll(cacheDir, 0700); err != nil {
+		return "", fmt.Errorf("unable to create cache directory %s: %w", cacheDir, err)
+	}
+
+	url := specArchiveURL(cfg.specRepo, cfg.specArchiveVersion)
+	urlSum := sha256.Sum256([]byte(url))
+	archive := filepath.Join(cacheDir, fmt.Sprintf("%x-%s.tar.gz", urlSum[:6], cfg.specArchiveVersion))
+	sumFile := archive + ".sha256"
+
+	if _, err = os.Stat(archive); err == nil {
+		want := cfg.specChecksum
+		if want == "" {
+			// #nosec G304
+			recorded, readErr := os.ReadFile(sumFile)
+			if readErr == nil {
+				want = strings.TrimSpace(string(recorded))
+			}
+		}
+		if want == "" {
+			logging.Warnf("Cached archive %s has no recorded checksum, ignoring it", archive)
+		} else if _, err = verifyChecksum(archive, want); err == nil {
+			return archive, nil
+		} else {
+			logging.Warnf("Cached archive %s is invalid (%v), downloading again", archive, err)
+		}
+	}
+
+	if cfg.specChecksum == "" {
+		return "", fmt.Errorf("%w: --spec-sha256 is required to download %s", errNoChecksum, url)
+	}
+
+	tmp := archive + ".tmp"
+	if err = download(url, tmp); err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp)
+
+	sum, err := verifyChecksum(tmp, cfg.specChecksum)
+	if err != nil {
+		return "", fmt.Errorf("unable to verify %s: %w", url, err)
+	}
+
+	if err = os.Rename(tmp, archive); err != nil {
+		return "", fmt.Errorf("unable to cache archive: %w", err)
+	}
+	if err = os.WriteFile(sumFile, []byte(sum+"\n"), 0600); err != nil {
+		return "", fmt.Errorf("unable to record archive checksum: %w", err)
+	}
+	return archive, nil
+}
+
+// download writes the content found at url to the file dst.
+func download(url, dst string) error {
+	resp, err := httpClient.Get(url) // #nosec G107
+	if err != nil {
+		return fmt.Errorf("unable to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to download %s: %s", url, resp.Status)
+	}
+
+	f, err := os.OpenFile(filepath.Clean(dst), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to create %s: %w", dst, err)
+	}
+	if _, err = io.Copy(f, resp.Body); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("unable to download %s: %w", url, err)
+	}
+	return f.Close()
+}
+
+// verifyChecksum computes the sha256 checksum of the file at path and
+// compares it to the hex encoded want, if want is not empty. The computed
+// checksum is returned.
+func verifyChecksum(path, want string) (string, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	got := hex.EncodeToString(h.Sum(nil))
+	if want != "" && !strings.EqualFold(got, want) {
+		return got, fmt.Errorf("%w: got %s, want %s", errChecksumMismatch, got, want)
+	}
+	return got, nil
+}
+
+// extractSpecArchive extracts the gzipped tarball archive into toDir. The
+// top-level directory GitHub wraps source archives in is stripped.
+func extractSpecArchive(archive, toDir string) error {
+	f, err := os.Open(filepath.Clean(archive))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("unable to read archive %s: %w", archive, err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		var hdr *tar.Header
+		hdr, err = tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("unable to read archive %s: %w", archive, err)
+		}
+
+		name := filepath.FromSlash(hdr.Name)
+		// Strip the top-level directory.
+		if i := strings.IndexRune(name, filepath.Separator); i >= 0 {
+			name = name[i+1:]
+		} else {
+			continue
+		}
+		if name == "" {
+			continue
+		}
+
+		target := filepath.Join(toDir, name)
+		if !strings.HasPrefix(target, filepath.Clean(toDir)+string(filepath.Separator)) {
+			return fmt.Errorf("invalid path in archive %s: %s", archive, hdr.Name)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(target, 0700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err = os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+				return err
+			}
+			if err = extractFile(tr, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func extractFile(r io.Reader, target string) error {
+	out, err := os.OpenFile(filepath.Clean(target), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	// #nosec G110
+	if _, err = io.Copy(out, r); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// unpackSpecToDir fetches the specification release archive and extracts it
+// into toDir. It has the same signature as checkoutSpecToDir.
+func unpackSpecToDir(cfg config, toDir string) (doneFunc func(), err error) {
+	archive, err := fetchSpecArchive(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err = extractSpecArchive(archive, toDir); err != nil {
+		return nil, err
+	}
+	// The extracted files live in the render temporary directory which is
+	// removed by the caller.
+	return func() {}, nil
+}
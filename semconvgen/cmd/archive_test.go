@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testArchive(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	files := []struct {
+		name, body string
+		typ        byte
+	}{
+		{"opentelemetry-specification-1.0.0/", "", tar.TypeDir},
+		{"opentelemetry-specification-1.0.0/semantic_conventions/", "", tar.TypeDir},
+		{"opentelemetry-specification-1.0.0/semantic_conventions/trace/http.yaml", "groups: []\n", tar.TypeReg},
+	}
+	for _, f := range files {
+		hdr := &tar.Header{Name: f.name, Typeflag: f.typ, Mode: 0600, Size: int64(len(f.body))}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(f.body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestUnpackSpecToDir(t *testing.T) {
+	archive := testArchive(t)
+	sum := sha256.Sum256(archive)
+
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/archive/refs/tags/v1.0.0.tar.gz" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(archive)
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := config{
+		specArchiveVersion: "v1.0.0",
+		specChecksum:       hex.EncodeToString(sum[:]),
+		specRepo:           srv.URL,
+		cacheDir:           t.TempDir(),
+	}
+
+	for i := 0; i < 2; i++ {
+		dir := t.TempDir()
+		done, err := unpackSpecToDir(cfg, dir)
+		if err != nil {
+			t.Fatalf("unpackSpecToDir() error = %v", err)
+		}
+		done()
+
+		got, err := os.ReadFile(filepath.Join(dir, "semantic_conventions", "trace", "http.yaml"))
+		if err != nil {
+			t.Fatalf("extracted file missing: %v", err)
+		}
+		if string(got) != "groups: []\n" {
+			t.Errorf("extracted file = %q", got)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("archive downloaded %d times, want 1", requests)
+	}
+}
+
+func TestFetchSpecArchiveChecksumMismatch(t *testing.T) {
+	archive := testArchive(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(archive)
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := config{
+		specArchiveVersion: "v1.0.0",
+		specChecksum:       "0000",
+		specRepo:           srv.URL,
+		cacheDir:           t.TempDir(),
+	}
+	if _, err := fetchSpecArchive(cfg); !errors.Is(err, errChecksumMismatch) {
+		t.Errorf("fetchSpecArchive() error = %v, want %v", err, errChecksumMismatch)
+	}
+}
+
+func TestFetchSpecArchiveNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(srv.Close)
+
+	cfg := config{
+		specArchiveVersion: "v1.0.0",
+		specChecksum:       "0000",
+		specRepo:           srv.URL,
+		cacheDir:           t.TempDir(),
+	}
+	if _, err := fetchSpecArchive(cfg); err == nil || errors.Is(err, errChecksumMismatch) {
+		t.Errorf("fetchSpecArchive() error = %v, want error for missing release", err)
+	}
+}
+
+func TestFetchSpecArchiveChecksumRequired(t *testing.T) {
+	archive := testArchive(t)
+	sum := sha256.Sum256(archive)
+
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write(archive)
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := config{
+		specArchiveVersion: "v1.0.0",
+		specRepo:           srv.URL,
+		cacheDir:           t.TempDir(),
+	}
+	if _, err := fetchSpecArchive(cfg); !errors.Is(err, errNoChecksum) {
+		t.Errorf("fetchSpecArchive() error = %v, want %v", err, errNoChecksum)
+	}
+	if requests != 0 {
+		t.Errorf("archive downloaded %d times without a checksum, want 0", requests)
+	}
+
+	// The checksum recorded for a verified download is enough to use the
+	// cached archive.
+	verified := cfg
+	verified.specChecksum = hex.EncodeToString(sum[:])
+	cached, err := fetchSpecArchive(verified)
+	if err != nil {
+		t.Fatalf("fetchSpecArchive() error = %v", err)
+	}
+	if _, err = fetchSpecArchive(cfg); err != nil {
+		t.Errorf("fetchSpecArchive() error = %v for a cached archive with a recorded checksum", err)
+	}
+	if requests != 1 {
+		t.Errorf("archive downloaded %d times, want 1", requests)
+	}
+
+	// Cached archives without a recorded checksum are not trusted.
+	if err = os.Remove(cached + ".sha256"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = fetchSpecArchive(cfg); !errors.Is(err, errNoChecksum) {
+		t.Errorf("fetchSpecArchive() error = %v, want %v", err, errNoChecksum)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	// Import embed to load the default capitalization rules.
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed capitalizations.yaml
+var defaultCapitalizations []byte
+
+// capitalizationRules define how generated identifiers are rewritten to
+// conform to Go's naming idiom.
+type capitalizationRules struct {
+	// Initialisms are written as listed wherever their title-cased form is
+	// followed by an upper-case letter, whitespace, a digit, or the end of a
+	// word.
+	Initialisms []string `yaml:"initialisms"`
+	// Replacements map strings to what they are replaced with after the
+	// initialisms have been applied.
+	Replacements map[string]string `yaml:"replacements"`
+}
+
+// loadCapitalizationRules returns the default capitalization rules extended by
+// those in the YAML file at path, if it is not empty. Replacements in the file
+// take precedence over the default ones.
+func loadCapitalizationRules(path string) (capitalizationRules, error) {
+	var rules capitalizationRules
+	if err := yaml.Unmarshal(defaultCapitalizations, &rules); err != nil {
+		return capitalizationRules{}, fmt.Errorf("invalid default capitalization rules: %w", err)
+	}
+	if path == "" {
+		return rules, nil
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return capitalizationRules{}, fmt.Errorf("unable to read capitalization rules: %w", err)
+	}
+	var custom capitalizationRules
+	if err = yaml.Unmarshal(data, &custom); err != nil {
+		return capitalizationRules{}, fmt.Errorf("invalid capitalization rules in %s: %w", path, err)
+	}
+
+	known := make(map[string]struct{}, len(rules.Initialisms))
+	for _, init := range rules.Initialisms {
+		known[init] = struct{}{}
+	}
+	for _, init := range custom.Initialisms {
+		if _, ok := known[init]; !ok {
+			rules.Initialisms = append(rules.Initialisms, init)
+		}
+	}
+	if rules.Replacements == nil {
+		rules.Replacements = make(map[string]string, len(custom.Replacements))
+	}
+	for cur, repl := range custom.Replacements {
+		rules.Replacements[cur] = repl
+	}
+	return rules, nil
+}
+
+// apply returns data with all identifiers rewritten according to the rules.
+func (r capitalizationRules) apply(data []byte) []byte {
+	caser := cases.Title(language.Und)
+	for _, init := range r.Initialisms {
+		// Match the title-cased capitalization target, asserting that its followed by
+		// either a capital letter, whitespace, a digit, or the end of text.
+		// This is to avoid, e.g., turning "Identifier" into "IDentifier".
+		re := regexp.MustCompile(regexp.QuoteMeta(caser.String(strings.ToLower(init))) + `([A-Z\s\d]|\b|$)`)
+		// RE2 does not support zero-width lookahead assertions, so we have to replace
+		// the last character that may have matched the first capture group in the
+		// expression constructed above.
+		data = re.ReplaceAll(data, []byte(init+`$1`))
+	}
+
+	// Apply replacements in a stable order.
+	keys := make([]string, 0, len(r.Replacements))
+	for cur := range r.Replacements {
+		keys = append(keys, cur)
+	}
+	sort.Strings(keys)
+	for _, cur := range keys {
+		data = bytes.ReplaceAll(data, []byte(cur), []byte(r.Replacements[cur]))
+	}
+	return data
+}
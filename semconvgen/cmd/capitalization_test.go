@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultCapitalizationRules(t *testing.T) {
+	rules, err := loadCapitalizationRules("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		in, want string
+	}{
+		{"HttpMethodKey", "HTTPMethodKey"},
+		{"NetHostIpKey", "NetHostIPKey"},
+		{"DbSqlTable", "DBSQLTable"},
+		{"IdentifierKey", "IdentifierKey"},
+		{"Mysql", "MySQL"},
+		{"DBRedisDatabaseIndex", "DBRedisDBIndex"},
+		{"NetTransportIpTcp", "NetTransportTCP"},
+		{"CodeLineno", "CodeLineNumber"},
+	}
+	for _, test := range tests {
+		if got := string(rules.apply([]byte(test.in))); got != test.want {
+			t.Errorf("apply(%q) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestLoadCapitalizationRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	custom := "initialisms:\n  - OTLP\n  - HTTP\nreplacements:\n  Lineno: Line\n  Foo: Bar\n"
+	if err := os.WriteFile(path, []byte(custom), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	defaults, err := loadCapitalizationRules("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules, err := loadCapitalizationRules(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := len(rules.Initialisms), len(defaults.Initialisms)+1; got != want {
+		t.Errorf("got %d initialisms, want %d: duplicates should be ignored", got, want)
+	}
+	if got, want := string(rules.apply([]byte("OtlpHttpFooLineno"))), "OTLPHTTPBarLine"; got != want {
+		t.Errorf("apply() = %q, want %q", got, want)
+	}
+}
+
+func TestLoadCapitalizationRulesErrors(t *testing.T) {
+	if _, err := loadCapitalizationRules(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected error for missing file")
+	}
+
+	path := filepath.Join(t.TempDir(), "invalid.yaml")
+	if err := os.WriteFile(path, []byte("initialisms: {"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadCapitalizationRules(path); err == nil {
+		t.Error("expected error for invalid file")
+	}
+}
@@ -12,34 +12,32 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package main
+package cmd
 
 import (
 	"bytes"
 	"errors"
 	"fmt"
-	"log"
 	"os"
 	"os/exec"
 	"path"
-	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	flag "github.com/spf13/pflag"
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
 
 	"golang.org/x/mod/semver"
 
+	"go.opentelemetry.io/build-tools/internal/logging"
 	"go.opentelemetry.io/build-tools/internal/repo"
 )
 
-func main() {
-	// Plain log output, no timestamps.
-	log.SetFlags(0)
-
+// Execute generates the semantic convention package configured by the command
+// line flags.
+func Execute() {
 	cfg := config{}
+	var quiet, verbose bool
 	flag.StringVarP(&cfg.inputPath, "input", "i", "", "Path to semantic convention definition YAML. Should be a directory in the specification git repository.")
 	flag.StringVarP(&cfg.specVersion, "specver", "s", "", "Version of semantic convention to generate. Must be an existing version tag in the specification git repository.")
 	flag.StringVarP(&cfg.outputPath, "output", "o", "", "Path to output target. Must be either an absolute path or relative to the repository root. If unspecified will output to a sub-directory with the name matching the version number specified via --specver flag.")
@@ -47,21 +45,40 @@ func main() {
 	flag.StringVarP(&cfg.outputFilename, "filename", "f", "", "Filename for templated output. If not specified 'basename(inputPath).go' will be used.")
 	flag.StringVarP(&cfg.templateFilename, "template", "t", "template.j2", "Template filename")
 	flag.StringVarP(&cfg.templateParameters, "parameters", "p", "", "List of key=value pairs separated by comma. These values are fed into the template as-is.")
+	flag.StringVar(&cfg.specArchiveVersion, "spec-version", "", "Release of the specification to download and generate from, instead of using a local clone. The --input path is resolved inside the release.")
+	flag.StringVar(&cfg.specChecksum, "spec-sha256", "", "Expected sha256 checksum of the release archive downloaded with --spec-version. Required to download the archive, cached archives are verified against the checksum recorded when they were downloaded.")
+	flag.StringVar(&cfg.specRepo, "spec-repo", defaultSpecRepo, "Repository the --spec-version release archive is downloaded from.")
+	flag.StringVar(&cfg.specPath, "spec-path", "", "Path to a local clone of the specification repository, e.g. a fork, to generate from as is, including uncommitted changes. The --input path is resolved inside the clone.")
+	flag.StringVar(&cfg.specRef, "spec-ref", "", "Git ref, e.g. a branch of a fork, checked out from the --spec-path clone to generate from instead of its working tree.")
+	flag.StringVar(&cfg.cacheDir, "cache-dir", "", "Directory downloaded release archives are cached in. Defaults to a semconvgen directory in the user cache directory.")
+	flag.StringVar(&cfg.capitalizationsPath, "capitalizations", "", "Path to a YAML file of capitalization rules (initialisms and replacements) applied to generated identifiers in addition to the defaults.")
+	flag.StringVar(&cfg.inventoryPath, "inventory", "", "Path to a JSON inventory of the generated attribute keys (key, Go identifier, package, and deprecation status) to write. Entries of other generated files in an existing inventory are kept.")
+	flag.BoolVar(&cfg.native, "native", false, "Render the template natively as a Go text/template instead of running the generator container. Docker is not required.")
+	flag.BoolVarP(&quiet, "quiet", "q", false, logging.QuietUsage)
+	flag.BoolVar(&verbose, "verbose", false, logging.VerboseUsage)
 	flag.Parse()
 
+	logging.Configure(quiet, verbose)
+
 	cfg, err := validateConfig(cfg)
 	if err != nil {
-		fmt.Println(err)
+		logging.Errorf("%v", err)
 		flag.Usage()
 		os.Exit(-1)
 	}
 
+	rules, err := loadCapitalizationRules(cfg.capitalizationsPath)
+	if err != nil {
+		logging.Errorf("%v", err)
+		os.Exit(-1)
+	}
+
 	err = render(cfg)
 	if err != nil {
 		panic(err)
 	}
 
-	err = fixIdentifiers(cfg)
+	err = fixIdentifiers(cfg, rules)
 	if err != nil {
 		panic(err)
 	}
@@ -70,16 +87,32 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+
+	if cfg.inventoryPath != "" {
+		err = writeInventory(cfg.inventoryPath, cfg.outputFilename, packageImportPath(cfg))
+		if err != nil {
+			panic(err)
+		}
+	}
 }
 
 type config struct {
-	inputPath          string
-	outputPath         string
-	outputFilename     string
-	templateFilename   string
-	templateParameters string
-	containerImage     string
-	specVersion        string
+	inputPath           string
+	outputPath          string
+	outputFilename      string
+	templateFilename    string
+	templateParameters  string
+	containerImage      string
+	specVersion         string
+	specArchiveVersion  string
+	specChecksum        string
+	specRepo            string
+	specPath            string
+	specRef             string
+	cacheDir            string
+	capitalizationsPath string
+	inventoryPath       string
+	native              bool
 }
 
 func validateConfig(cfg config) (config, error) {
@@ -91,6 +124,24 @@ func validateConfig(cfg config) (config, error) {
 		cfg.outputFilename = fmt.Sprintf("%s.go", path.Base(cfg.inputPath))
 	}
 
+	if cfg.specPath != "" {
+		if cfg.specArchiveVersion != "" {
+			return config{}, errors.New("--spec-path and --spec-version cannot be used together")
+		}
+	} else if cfg.specRef != "" {
+		return config{}, errors.New("--spec-ref requires --spec-path")
+	}
+
+	if cfg.specArchiveVersion != "" {
+		if cfg.specVersion != "" && cfg.specVersion != cfg.specArchiveVersion {
+			return config{}, errors.New("--specver and --spec-version must match if both are provided")
+		}
+		cfg.specVersion = cfg.specArchiveVersion
+		if cfg.specRepo == "" {
+			cfg.specRepo = defaultSpecRepo
+		}
+	}
+
 	if cfg.specVersion == "" {
 		// Find the latest version of the specification and use it for generation.
 		var err error
@@ -140,20 +191,31 @@ func render(cfg config) error {
 		return fmt.Errorf("unable to create input directory: %w", err)
 	}
 
-	outputPath := path.Join(tmpDir, "output")
-	err = os.Mkdir(outputPath, 0700)
-	if err != nil {
-		return fmt.Errorf("unable to create output directory: %w", err)
-	}
-
 	// Checkout the specification repo to a temp dir. This will be the input
 	// for the generator.
-	doneFunc, err := checkoutSpecToDir(cfg, specCheckoutPath)
+	prepareSpec := checkoutSpecToDir
+	switch {
+	case cfg.specArchiveVersion != "":
+		prepareSpec = unpackSpecToDir
+	case cfg.specPath != "" && cfg.specRef == "":
+		prepareSpec = copySpecToDir
+	}
+	doneFunc, err := prepareSpec(cfg, specCheckoutPath)
 	if err != nil {
 		return err
 	}
 	defer doneFunc()
 
+	if cfg.native {
+		return renderNative(cfg, path.Join(specCheckoutPath, "semantic_conventions", path.Base(cfg.inputPath)))
+	}
+
+	outputPath := path.Join(tmpDir, "output")
+	err = os.Mkdir(outputPath, 0700)
+	if err != nil {
+		return fmt.Errorf("unable to create output directory: %w", err)
+	}
+
 	// #nosec G204
 	err = exec.Command("cp", cfg.templateFilename, tmpDir).Run()
 	if err != nil {
@@ -215,8 +277,7 @@ func findLatestSpecVersion(cfg config) (string, error) {
 	// in the repo.
 	// #nosec G204
 	cmd := exec.Command("git", "tag")
-	// The specification repo is in cfg.inputPath.
-	cmd.Dir = cfg.inputPath
+	cmd.Dir = specRepoDir(cfg)
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("unable to exec %s: %w", cmd.String(), err)
@@ -238,7 +299,7 @@ func findLatestSpecVersion(cfg config) (string, error) {
 	sort.Sort(versions)
 
 	if len(versions) == 0 {
-		return "", fmt.Errorf("no version tags found in the specification repo at %s", cfg.inputPath)
+		return "", fmt.Errorf("no version tags found in the specification repo at %s", specRepoDir(cfg))
 	}
 
 	// Use the latest version number.
@@ -246,16 +307,30 @@ func findLatestSpecVersion(cfg config) (string, error) {
 	return lastVer, nil
 }
 
+// specRepoDir returns the directory of the specification git repository.
+func specRepoDir(cfg config) string {
+	if cfg.specPath != "" {
+		return cfg.specPath
+	}
+	// Without --spec-path the specification repo is in cfg.inputPath.
+	return cfg.inputPath
+}
+
 // checkoutSpecToDir checks out the specification repository to the toDir.
 // Returned doneFunc should be called when the directory is no longer needed and can be
 // cleaned up.
 func checkoutSpecToDir(cfg config, toDir string) (doneFunc func(), err error) {
-	// Checkout the selected tag to make sure we use the correct version of semantic
-	// convention yaml files as the input. We will checkout the worktree to a temporary toDir.
+	// Checkout the selected tag, or ref if provided, to make sure we use the correct
+	// version of semantic convention yaml files as the input. We will checkout the
+	// worktree to a temporary toDir.
+	ref := cfg.specVersion
+	if cfg.specRef != "" {
+		ref = cfg.specRef
+	}
+	repoDir := specRepoDir(cfg)
 	// #nosec G204
-	cmd := exec.Command("git", "worktree", "add", toDir, cfg.specVersion)
-	// The specification repo is in cfg.inputPath.
-	cmd.Dir = cfg.inputPath
+	cmd := exec.Command("git", "worktree", "add", "--detach", toDir, ref)
+	cmd.Dir = repoDir
 	err = cmd.Run()
 	if err != nil {
 		return nil, fmt.Errorf("unable to exec %s: %w", cmd.String(), err)
@@ -264,146 +339,26 @@ func checkoutSpecToDir(cfg config, toDir string) (doneFunc func(), err error) {
 	doneFunc = func() {
 		// Remove the worktree when it is no longer needed.
 		cmd := exec.Command("git", "worktree", "remove", "-f", toDir)
-		cmd.Dir = cfg.inputPath
+		cmd.Dir = repoDir
 		err := cmd.Run()
 		if err != nil {
-			log.Printf("Could not cleanup spec repo worktree, unable to exec %s: %s\n", cmd.String(), err.Error())
+			logging.Warnf("Could not cleanup spec repo worktree, unable to exec %s: %s", cmd.String(), err.Error())
 		}
 	}
 
 	return doneFunc, nil
 }
 
-var capitalizations = []string{
-	"ACL",
-	"AIX",
-	"AKS",
-	"AMD64",
-	"API",
-	"ARM32",
-	"ARM64",
-	"ARN",
-	"ARNs",
-	"ASCII",
-	"AWS",
-	"CPP",
-	"CPU",
-	"CSS",
-	"DB",
-	"DC",
-	"DNS",
-	"EC2",
-	"ECS",
-	"EDB",
-	"EKS",
-	"EOF",
-	"GCP",
-	"GRPC",
-	"GUID",
-	"HPUX",
-	"HSQLDB",
-	"HTML",
-	"HTTP",
-	"HTTPS",
-	"IA64",
-	"ID",
-	"IP",
-	"JDBC",
-	"JSON",
-	"K8S",
-	"LHS",
-	"MSSQL",
-	"OS",
-	"PHP",
-	"PID",
-	"PPC32",
-	"PPC64",
-	"QPS",
-	"QUIC",
-	"RAM",
-	"RHS",
-	"RPC",
-	"SDK",
-	"SLA",
-	"SMTP",
-	"SPDY",
-	"SQL",
-	"SSH",
-	"TCP",
-	"TLS",
-	"TTL",
-	"UDP",
-	"UID",
-	"UI",
-	"UUID",
-	"URI",
-	"URL",
-	"UTF8",
-	"VM",
-	"XML",
-	"XMPP",
-	"XSRF",
-	"XSS",
-	"ZOS",
-	"CronJob",
-	"DaemonSet",
-	"StatefulSet",
-	"ReplicaSet",
-	"WebEngine",
-	"MySQL",
-	"PostgreSQL",
-	"MariaDB",
-	"MaxDB",
-	"FirstSQL",
-	"InstantDB",
-	"HBase",
-	"MongoDB",
-	"CouchDB",
-	"CosmosDB",
-	"DynamoDB",
-	"HanaDB",
-	"FreeBSD",
-	"NetBSD",
-	"OpenBSD",
-	"DragonflyBSD",
-	"InProc",
-	"FaaS",
-}
-
-// These are not simple capitalization fixes, but require string replacement.
-// All occurrences of the key will be replaced with the corresponding value.
-var replacements = map[string]string{
-	"RedisDatabase": "RedisDB",
-	"IPTCP":         "TCP",
-	"IPUDP":         "UDP",
-	"Lineno":        "LineNumber",
-}
-
-func fixIdentifiers(cfg config) error {
+func fixIdentifiers(cfg config, rules capitalizationRules) error {
 	data, err := os.ReadFile(cfg.outputFilename)
 	if err != nil {
 		return fmt.Errorf("unable to read file: %w", err)
 	}
 
-	caser := cases.Title(language.Und)
-	for _, init := range capitalizations {
-		// Match the title-cased capitalization target, asserting that its followed by
-		// either a capital letter, whitespace, a digit, or the end of text.
-		// This is to avoid, e.g., turning "Identifier" into "IDentifier".
-		re := regexp.MustCompile(caser.String(strings.ToLower(init)) + `([A-Z\s\d]|\b|$)`)
-		// RE2 does not support zero-width lookahead assertions, so we have to replace
-		// the last character that may have matched the first capture group in the
-		// expression constructed above.
-		data = re.ReplaceAll(data, []byte(init+`$1`))
-	}
-
-	for cur, repl := range replacements {
-		data = bytes.ReplaceAll(data, []byte(cur), []byte(repl))
-	}
+	data = rules.apply(data)
 
 	// Inject the correct import path.
-	packageDir := path.Base(path.Dir(cfg.outputFilename))
-	importPath := fmt.Sprintf(`"go.opentelemetry.io/otel/semconv/%s"`, packageDir)
+	importPath := strconv.Quote(packageImportPath(cfg))
 	data = bytes.ReplaceAll(data, []byte(`[[IMPORTPATH]]`), []byte(importPath))
 
 	err = os.WriteFile(cfg.outputFilename, data, 0600)
@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// inventoryEntry describes a generated attribute key constant.
+type inventoryEntry struct {
+	// Key is the attribute key, e.g. "http.method".
+	Key string `json:"key"`
+	// Name is the name of the Go identifier of the key, e.g. "HTTPMethodKey".
+	Name string `json:"name"`
+	// Package is the import path of the package the identifier is in.
+	Package string `json:"package"`
+	// File is the base name of the generated file declaring the identifier.
+	File string `json:"file"`
+	// Deprecated is whether the identifier is documented as deprecated.
+	Deprecated bool `json:"deprecated"`
+}
+
+// inventory lists the attribute keys generated in a package. It is consumed
+// by documentation generators instead of parsing the generated source.
+type inventory struct {
+	Attributes []inventoryEntry `json:"attributes"`
+}
+
+// packageImportPath returns the import path of the generated package.
+func packageImportPath(cfg config) string {
+	return fmt.Sprintf("go.opentelemetry.io/otel/semconv/%s", path.Base(path.Dir(cfg.outputFilename)))
+}
+
+// attributeKeys returns the attribute key identifiers declared in the Go
+// source file fn. Those are the constants and variables initialized with a
+// call to a Key function, such as attribute.Key("http.method").
+func attributeKeys(fn, pkg string) ([]inventoryEntry, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), fn, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse generated file: %w", err)
+	}
+
+	var entries []inventoryEntry
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || (gen.Tok != token.CONST && gen.Tok != token.VAR) {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			doc := vs.Doc
+			if doc == nil && len(gen.Specs) == 1 {
+				doc = gen.Doc
+			}
+			for i, name := range vs.Names {
+				if i >= len(vs.Values) {
+					break
+				}
+				key, ok := attributeKey(vs.Values[i])
+				if !ok {
+					continue
+				}
+				entries = append(entries, inventoryEntry{
+					Key:        key,
+					Name:       name.Name,
+					Package:    pkg,
+					File:       filepath.Base(fn),
+					Deprecated: isDeprecated(doc),
+				})
+			}
+		}
+	}
+	return entries, nil
+}
+
+// attributeKey returns the key of the expression if it is a call to a Key
+// function with a string literal.
+func attributeKey(expr ast.Expr) (string, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return "", false
+	}
+	var fun string
+	switch f := call.Fun.(type) {
+	case *ast.Ident:
+		fun = f.Name
+	case *ast.SelectorExpr:
+		fun = f.Sel.Name
+	}
+	lit, ok := call.Args[0].(*ast.BasicLit)
+	if fun != "Key" || !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	key, err := strconv.Unquote(lit.Value)
+	return key, err == nil
+}
+
+// isDeprecated returns whether the doc comment has a paragraph starting with
+// "Deprecated:", following the Go convention.
+func isDeprecated(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, paragraph := range strings.Split(doc.Text(), "\n\n") {
+		if strings.HasPrefix(strings.TrimSpace(paragraph), "Deprecated:") {
+			return true
+		}
+	}
+	return false
+}
+
+// writeInventory writes the attribute keys generated in the Go source file fn
+// to the JSON inventory at inventoryPath. Entries of other files in an
+// existing inventory are kept, so a single inventory can describe a package
+// generated in several runs.
+func writeInventory(inventoryPath, fn, pkg string) error {
+	entries, err := attributeKeys(fn, pkg)
+	if err != nil {
+		return err
+	}
+
+	var inv inventory
+	data, err := os.ReadFile(filepath.Clean(inventoryPath))
+	switch {
+	case err == nil:
+		if err = json.Unmarshal(data, &inv); err != nil {
+			return fmt.Errorf("unable to parse existing inventory %s: %w", inventoryPath, err)
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return fmt.Errorf("unable to read existing inventory: %w", err)
+	}
+
+	kept := inv.Attributes[:0]
+	for _, e := range inv.Attributes {
+		if e.Package != pkg || e.File != filepath.Base(fn) {
+			kept = append(kept, e)
+		}
+	}
+	inv.Attributes = append(kept, entries...)
+	sort.SliceStable(inv.Attributes, func(i, j int) bool {
+		a, b := inv.Attributes[i], inv.Attributes[j]
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		return a.Name < b.Name
+	})
+	if inv.Attributes == nil {
+		inv.Attributes = []inventoryEntry{}
+	}
+
+	data, err = json.MarshalIndent(inv, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode inventory: %w", err)
+	}
+	if err = os.WriteFile(inventoryPath, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("unable to write inventory: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testGenerated = `package semconv
+
+import "go.opentelemetry.io/otel/attribute"
+
+// HTTP attributes.
+const (
+	// HTTP request method.
+	HTTPMethodKey = attribute.Key("http.method")
+	// Deprecated: use HTTPMethodKey instead.
+	HTTPVerbKey = attribute.Key("http.verb")
+)
+
+// Deprecated: use NetPeerNameKey instead.
+const NetPeerHostKey = attribute.Key("net.peer.host")
+
+var (
+	// HTTP request method GET.
+	HTTPMethodGet = HTTPMethodKey.String("GET")
+	DBSystemKey   = attribute.Key("db.system")
+)
+
+const SchemaURL = "https://opentelemetry.io/schemas/1.12.0"
+`
+
+func TestAttributeKeys(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "trace.go")
+	if err := os.WriteFile(fn, []byte(testGenerated), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := attributeKeys(fn, "go.opentelemetry.io/otel/semconv/v1.12.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := func(key, name string, deprecated bool) inventoryEntry {
+		return inventoryEntry{
+			Key:        key,
+			Name:       name,
+			Package:    "go.opentelemetry.io/otel/semconv/v1.12.0",
+			File:       "trace.go",
+			Deprecated: deprecated,
+		}
+	}
+	want := []inventoryEntry{
+		entry("http.method", "HTTPMethodKey", false),
+		entry("http.verb", "HTTPVerbKey", true),
+		entry("net.peer.host", "NetPeerHostKey", true),
+		entry("db.system", "DBSystemKey", false),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("attributeKeys() = %+v, want %+v", got, want)
+	}
+}
+
+func TestWriteInventory(t *testing.T) {
+	dir := t.TempDir()
+	fn := filepath.Join(dir, "trace.go")
+	if err := os.WriteFile(fn, []byte(testGenerated), 0600); err != nil {
+		t.Fatal(err)
+	}
+	const pkg = "go.opentelemetry.io/otel/semconv/v1.12.0"
+
+	// Entries of other files are kept, those of the generated file replaced.
+	existing := inventory{Attributes: []inventoryEntry{
+		{Key: "service.name", Name: "ServiceNameKey", Package: pkg, File: "resource.go"},
+		{Key: "http.removed", Name: "HTTPRemovedKey", Package: pkg, File: "trace.go"},
+	}}
+	data, err := json.Marshal(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inventoryPath := filepath.Join(dir, "inventory.json")
+	if err = os.WriteFile(inventoryPath, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = writeInventory(inventoryPath, fn, pkg); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err = os.ReadFile(inventoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got inventory
+	if err = json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []string
+	for _, e := range got.Attributes {
+		keys = append(keys, e.Key)
+	}
+	want := []string{"db.system", "http.method", "http.verb", "net.peer.host", "service.name"}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("inventory keys = %v, want %v", keys, want)
+	}
+}
+
+func TestWriteInventoryInvalid(t *testing.T) {
+	dir := t.TempDir()
+	fn := filepath.Join(dir, "trace.go")
+	if err := os.WriteFile(fn, []byte(testGenerated), 0600); err != nil {
+		t.Fatal(err)
+	}
+	inventoryPath := filepath.Join(dir, "inventory.json")
+	if err := os.WriteFile(inventoryPath, []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeInventory(inventoryPath, fn, "semconv"); err == nil {
+		t.Error("writeInventory() with invalid existing inventory succeeded")
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// copySpecToDir copies the working tree of the local specification clone at
+// cfg.specPath into toDir, so uncommitted changes are generated from too.
+// The git directory is not copied. It has the same signature as
+// checkoutSpecToDir.
+func copySpecToDir(cfg config, toDir string) (doneFunc func(), err error) {
+	src := filepath.Clean(cfg.specPath)
+	err = filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(toDir, rel)
+
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, 0700)
+		case d.Type().IsRegular():
+			// #nosec G304
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			return extractFile(f, target)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to copy specification from %s: %w", cfg.specPath, err)
+	}
+	// The copied files live in the render temporary directory which is
+	// removed by the caller.
+	return func() {}, nil
+}
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func writeSpecFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readSpecFile(t *testing.T, root, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	args = append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func TestCopySpecToDir(t *testing.T) {
+	spec := t.TempDir()
+	writeSpecFile(t, spec, "semantic_conventions/trace/http.yaml", "groups: []\n")
+	writeSpecFile(t, spec, ".git/HEAD", "ref: refs/heads/main\n")
+
+	dir := t.TempDir()
+	done, err := copySpecToDir(config{specPath: spec}, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done()
+
+	if got := readSpecFile(t, dir, "semantic_conventions/trace/http.yaml"); got != "groups: []\n" {
+		t.Errorf("unexpected content %q", got)
+	}
+	if _, err = os.Stat(filepath.Join(dir, ".git")); !os.IsNotExist(err) {
+		t.Errorf("git directory copied: %v", err)
+	}
+}
+
+func TestCheckoutSpecRefToDir(t *testing.T) {
+	spec := t.TempDir()
+	git(t, spec, "init", "-q")
+	writeSpecFile(t, spec, "semantic_conventions/trace/http.yaml", "groups: []\n")
+	git(t, spec, "add", "-A")
+	git(t, spec, "commit", "-q", "-m", "initial")
+	git(t, spec, "tag", "v1.0.0")
+	git(t, spec, "checkout", "-q", "-b", "fork-branch")
+	writeSpecFile(t, spec, "semantic_conventions/trace/http.yaml", "groups: [fork]\n")
+	git(t, spec, "commit", "-q", "-a", "-m", "fork change")
+
+	cfg := config{specPath: spec, specVersion: "v1.0.0", specRef: "fork-branch"}
+	dir := filepath.Join(t.TempDir(), "input")
+	done, err := checkoutSpecToDir(cfg, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := readSpecFile(t, dir, "semantic_conventions/trace/http.yaml"); got != "groups: [fork]\n" {
+		t.Errorf("unexpected content %q", got)
+	}
+	done()
+	if _, err = os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("worktree not removed: %v", err)
+	}
+
+	version, err := findLatestSpecVersion(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != "v1.0.0" {
+		t.Errorf("findLatestSpecVersion() = %q, want v1.0.0", version)
+	}
+}
+
+func TestValidateConfigSpecPath(t *testing.T) {
+	base := config{
+		inputPath:        "trace",
+		outputPath:       "/out",
+		templateFilename: "/template.j2",
+		specVersion:      "v1.0.0",
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*config)
+		wantErr bool
+	}{
+		{
+			name:   "spec path",
+			modify: func(c *config) { c.specPath = "/spec" },
+		},
+		{
+			name:   "spec path and ref",
+			modify: func(c *config) { c.specPath, c.specRef = "/spec", "fork/main" },
+		},
+		{
+			name:    "spec ref without path",
+			modify:  func(c *config) { c.specRef = "fork/main" },
+			wantErr: true,
+		},
+		{
+			name:    "spec path and version",
+			modify:  func(c *config) { c.specPath, c.specArchiveVersion = "/spec", "v1.0.0" },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base
+			tt.modify(&cfg)
+			_, err := validateConfig(cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,312 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// semconvModel is the semantic convention model a native template is
+// executed with.
+type semconvModel struct {
+	// Groups are the groups of all definition files, in the lexical order
+	// of the files then in the order of their definition.
+	Groups []*semconvGroup
+	// Attributes are the attributes defined by Groups, excluding
+	// references, ordered by key.
+	Attributes []*semconvAttribute
+	// Params are the --parameters key=value pairs.
+	Params map[string]string
+}
+
+// semconvGroup is a group of a semantic convention definition file.
+type semconvGroup struct {
+	ID         string              `yaml:"id"`
+	Type       string              `yaml:"type"`
+	Prefix     string              `yaml:"prefix"`
+	Brief      string              `yaml:"brief"`
+	Note       string              `yaml:"note"`
+	Extends    string              `yaml:"extends"`
+	Attributes []*semconvAttribute `yaml:"attributes"`
+}
+
+// semconvAttribute is an attribute of a group. Attributes referencing one
+// defined by another group are resolved to a copy of that attribute.
+type semconvAttribute struct {
+	ID         string        `yaml:"id"`
+	Ref        string        `yaml:"ref"`
+	Type       attributeType `yaml:"type"`
+	Brief      string        `yaml:"brief"`
+	Note       string        `yaml:"note"`
+	Examples   interface{}   `yaml:"examples"`
+	Stability  string        `yaml:"stability"`
+	Deprecated string        `yaml:"deprecated"`
+	// Key is the fully qualified name of the attribute, its ID prefixed by
+	// the prefix of the group defining it.
+	Key string `yaml:"-"`
+}
+
+// attributeType is the type of an attribute, either a primitive or array
+// type name, e.g. "string" or "int[]", or an enum.
+type attributeType struct {
+	Name              string
+	AllowCustomValues bool
+	Members           []enumMember
+}
+
+// enumMember is a value of an enum attribute type.
+type enumMember struct {
+	ID    string      `yaml:"id"`
+	Value interface{} `yaml:"value"`
+	Brief string      `yaml:"brief"`
+	Note  string      `yaml:"note"`
+}
+
+const enumTypeName = "enum"
+
+func (t *attributeType) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		t.Name = value.Value
+		return nil
+	}
+	var enum struct {
+		AllowCustomValues bool         `yaml:"allow_custom_values"`
+		Members           []enumMember `yaml:"members"`
+	}
+	if err := value.Decode(&enum); err != nil {
+		return err
+	}
+	t.Name = enumTypeName
+	t.AllowCustomValues = enum.AllowCustomValues
+	t.Members = enum.Members
+	return nil
+}
+
+// goTypes maps attribute type names to the suffix of the attribute package
+// functions and methods handling them, e.g. attribute.Key.String.
+var goTypes = map[string]string{
+	"string":    "String",
+	"int":       "Int",
+	"double":    "Float64",
+	"boolean":   "Bool",
+	"string[]":  "StringSlice",
+	"int[]":     "IntSlice",
+	"double[]":  "Float64Slice",
+	"boolean[]": "BoolSlice",
+}
+
+// IsEnum returns whether the type of a is an enum.
+func (a *semconvAttribute) IsEnum() bool {
+	return a.Type.Name == enumTypeName
+}
+
+// GoType returns the suffix of the attribute package functions handling the
+// values of a, e.g. "String" or "IntSlice". Enums are handled by the type
+// of their member values.
+func (a *semconvAttribute) GoType() string {
+	if !a.IsEnum() {
+		return goTypes[a.Type.Name]
+	}
+	for _, m := range a.Type.Members {
+		switch m.Value.(type) {
+		case int:
+			return goTypes["int"]
+		case float64:
+			return goTypes["double"]
+		}
+	}
+	return goTypes["string"]
+}
+
+// IsDeprecated returns whether a is deprecated.
+func (a *semconvAttribute) IsDeprecated() bool {
+	return a.Deprecated != ""
+}
+
+// loadSemconvModel reads the semantic convention definition files in
+// yamlRoot and its subdirectories.
+func loadSemconvModel(yamlRoot string) (*semconvModel, error) {
+	var files []string
+	err := filepath.WalkDir(yamlRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ext := filepath.Ext(path); !d.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read semantic conventions in %s: %w", yamlRoot, err)
+	}
+
+	model := &semconvModel{}
+	for _, file := range files {
+		// #nosec G304
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read semantic conventions: %w", err)
+		}
+		var content struct {
+			Groups []*semconvGroup `yaml:"groups"`
+		}
+		if err = yaml.Unmarshal(data, &content); err != nil {
+			return nil, fmt.Errorf("invalid semantic conventions %s: %w", file, err)
+		}
+		model.Groups = append(model.Groups, content.Groups...)
+	}
+
+	defined := make(map[string]*semconvAttribute)
+	for _, g := range model.Groups {
+		for _, a := range g.Attributes {
+			if a.Ref != "" {
+				continue
+			}
+			a.Key = a.ID
+			if g.Prefix != "" {
+				a.Key = g.Prefix + "." + a.ID
+			}
+			if _, ok := defined[a.Key]; ok {
+				return nil, fmt.Errorf("attribute %s is defined more than once", a.Key)
+			}
+			defined[a.Key] = a
+			model.Attributes = append(model.Attributes, a)
+		}
+	}
+	sort.Slice(model.Attributes, func(i, j int) bool {
+		return model.Attributes[i].Key < model.Attributes[j].Key
+	})
+
+	for _, g := range model.Groups {
+		for i, a := range g.Attributes {
+			if a.Ref == "" {
+				continue
+			}
+			target, ok := defined[a.Ref]
+			if !ok {
+				return nil, fmt.Errorf("attribute %s referenced by group %s is not defined", a.Ref, g.ID)
+			}
+			// A reference can refine the brief and note of the attribute.
+			resolved := *target
+			resolved.Ref = a.Ref
+			if a.Brief != "" {
+				resolved.Brief = a.Brief
+			}
+			if a.Note != "" {
+				resolved.Note = a.Note
+			}
+			g.Attributes[i] = &resolved
+		}
+	}
+	return model, nil
+}
+
+// templateFuncs are the functions available to native templates.
+var templateFuncs = template.FuncMap{
+	"camel":   camelCase,
+	"comment": comment,
+	"quote":   func(v interface{}) string { return strconv.Quote(fmt.Sprint(v)) },
+	"lower":   strings.ToLower,
+	"upper":   strings.ToUpper,
+	"trim":    strings.TrimSpace,
+}
+
+// camelCase returns s, e.g. "http.status_code", with each word capitalized
+// and the separators removed, e.g. "HttpStatusCode". Initialisms are fixed
+// afterwards with the capitalization rules.
+func camelCase(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '.' || r == '_' || r == '-' || unicode.IsSpace(r)
+	})
+	var sb strings.Builder
+	for _, w := range words {
+		runes := []rune(w)
+		runes[0] = unicode.ToUpper(runes[0])
+		sb.WriteString(string(runes))
+	}
+	return sb.String()
+}
+
+// comment returns the lines of s as Go line comments.
+func comment(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("// "+strings.TrimSpace(line), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseTemplateParameters parses the comma separated key=value pairs of
+// --parameters.
+func parseTemplateParameters(params string) (map[string]string, error) {
+	parsed := make(map[string]string)
+	if params == "" {
+		return parsed, nil
+	}
+	for _, pair := range strings.Split(params, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid template parameter %q, expected key=value", pair)
+		}
+		parsed[key] = value
+	}
+	return parsed, nil
+}
+
+// renderNative executes the template of cfg as a Go text/template with the
+// semantic convention model read from yamlRoot, and writes the result to
+// the output file.
+func renderNative(cfg config, yamlRoot string) error {
+	model, err := loadSemconvModel(yamlRoot)
+	if err != nil {
+		return err
+	}
+	model.Params, err = parseTemplateParameters(cfg.templateParameters)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.New(filepath.Base(cfg.templateFilename)).
+		Funcs(templateFuncs).
+		ParseFiles(cfg.templateFilename)
+	if err != nil {
+		return fmt.Errorf("unable to parse template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, model); err != nil {
+		return fmt.Errorf("unable to render template: %w", err)
+	}
+
+	err = os.MkdirAll(cfg.outputPath, 0700)
+	if err != nil {
+		return fmt.Errorf("unable to create output directory %s: %w", cfg.outputPath, err)
+	}
+	err = os.WriteFile(cfg.outputFilename, buf.Bytes(), 0600)
+	if err != nil {
+		return fmt.Errorf("unable to write output file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const nativeSpecHTTP = `groups:
+  - id: http
+    prefix: http
+    type: span
+    brief: HTTP spans.
+    attributes:
+      - id: method
+        type: string
+        brief: HTTP request method.
+        examples: ["GET", "POST"]
+      - id: status_code
+        type: int
+        brief: HTTP response status code.
+      - id: flavor
+        type:
+          allow_custom_values: true
+          members:
+            - id: http_1_1
+              value: "1.1"
+            - id: http_2_0
+              value: "2.0"
+        brief: Kind of HTTP protocol used.
+        deprecated: Use network.protocol.version instead.
+`
+
+const nativeSpecNet = `groups:
+  - id: network
+    prefix: net
+    type: attribute_group
+    brief: Network attributes.
+    attributes:
+      - id: peer.port
+        type: int
+        brief: Remote port number.
+  - id: http.client
+    prefix: http
+    type: span
+    brief: HTTP client spans.
+    attributes:
+      - ref: http.method
+        brief: HTTP client request method.
+      - ref: net.peer.port
+`
+
+const nativeTemplate = `package {{ .Params.package }}
+
+const (
+{{- range .Attributes }}
+	{{ comment .Brief }}
+{{- if .IsDeprecated }}
+	//
+	// Deprecated: {{ .Deprecated }}
+{{- end }}
+	{{ camel .Key }}Key = attribute.Key({{ quote .Key }}) // {{ .GoType }}
+{{- if .IsEnum }}
+{{- $attr := . }}
+{{- range .Type.Members }}
+	{{ camel $attr.Key }}{{ camel .ID }} = {{ quote .Value }}
+{{- end }}
+{{- end }}
+{{- end }}
+)
+{{ range .Groups }}
+// {{ .ID }}:{{ range .Attributes }} {{ .Key }}{{ end }}
+{{- end }}
+`
+
+func TestLoadSemconvModel(t *testing.T) {
+	root := t.TempDir()
+	writeSpecFile(t, root, "http.yaml", nativeSpecHTTP)
+	writeSpecFile(t, root, "sub/net.yml", nativeSpecNet)
+	writeSpecFile(t, root, "README.md", "not a definition")
+
+	model, err := loadSemconvModel(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []string
+	for _, a := range model.Attributes {
+		keys = append(keys, a.Key)
+	}
+	if want := []string{"http.flavor", "http.method", "http.status_code", "net.peer.port"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("attributes = %v, want %v", keys, want)
+	}
+
+	if len(model.Groups) != 3 {
+		t.Fatalf("expected 3 groups, got %d", len(model.Groups))
+	}
+	client := model.Groups[2]
+	method, port := client.Attributes[0], client.Attributes[1]
+	if method.Key != "http.method" || method.Brief != "HTTP client request method." || method.GoType() != "String" {
+		t.Errorf("unexpected resolved reference %+v", method)
+	}
+	if port.Key != "net.peer.port" || port.Brief != "Remote port number." || port.GoType() != "Int" {
+		t.Errorf("unexpected resolved reference %+v", port)
+	}
+	if model.Groups[0].Attributes[0].Brief != "HTTP request method." {
+		t.Error("resolving a reference modified the referenced attribute")
+	}
+
+	flavor := model.Attributes[0]
+	if !flavor.IsEnum() || !flavor.Type.AllowCustomValues || len(flavor.Type.Members) != 2 || flavor.GoType() != "String" {
+		t.Errorf("unexpected enum attribute %+v", flavor)
+	}
+	if !flavor.IsDeprecated() {
+		t.Error("expected deprecated attribute")
+	}
+}
+
+func TestLoadSemconvModelErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{
+			name: "undefined reference",
+			spec: "groups:\n  - id: a\n    attributes:\n      - ref: b.c\n",
+		},
+		{
+			name: "duplicate attribute",
+			spec: "groups:\n  - id: a\n    prefix: a\n    attributes:\n      - id: b\n      - id: b\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeSpecFile(t, root, "spec.yaml", test.spec)
+			if _, err := loadSemconvModel(root); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestCamelCase(t *testing.T) {
+	for in, want := range map[string]string{
+		"http.status_code":   "HttpStatusCode",
+		"net.peer.port":      "NetPeerPort",
+		"http_1_1":           "Http11",
+		"messaging.rocketmq": "MessagingRocketmq",
+	} {
+		if got := camelCase(in); got != want {
+			t.Errorf("camelCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestParseTemplateParameters(t *testing.T) {
+	params, err := parseTemplateParameters("package=semconv,version=v1.12.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"package": "semconv", "version": "v1.12.0"}; !reflect.DeepEqual(params, want) {
+		t.Errorf("params = %v, want %v", params, want)
+	}
+	if _, err = parseTemplateParameters("package"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestRenderNative(t *testing.T) {
+	spec := t.TempDir()
+	writeSpecFile(t, spec, "semantic_conventions/trace/http.yaml", nativeSpecHTTP)
+	writeSpecFile(t, spec, "semantic_conventions/trace/net.yaml", nativeSpecNet)
+	tmpl := filepath.Join(t.TempDir(), "template.tmpl")
+	if err := os.WriteFile(tmpl, []byte(nativeTemplate), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(t.TempDir(), "v1.0.0")
+	cfg := config{
+		inputPath:          "trace",
+		specPath:           spec,
+		native:             true,
+		templateFilename:   tmpl,
+		templateParameters: "package=semconv",
+		outputPath:         out,
+		outputFilename:     filepath.Join(out, "trace.go"),
+	}
+	if err := render(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `package semconv
+
+const (
+	// Kind of HTTP protocol used.
+	//
+	// Deprecated: Use network.protocol.version instead.
+	HttpFlavorKey = attribute.Key("http.flavor") // String
+	HttpFlavorHttp11 = "1.1"
+	HttpFlavorHttp20 = "2.0"
+	// HTTP request method.
+	HttpMethodKey = attribute.Key("http.method") // String
+	// HTTP response status code.
+	HttpStatusCodeKey = attribute.Key("http.status_code") // Int
+	// Remote port number.
+	NetPeerPortKey = attribute.Key("net.peer.port") // Int
+)
+
+// http: http.method http.status_code http.flavor
+// network: net.peer.port
+// http.client: http.method net.peer.port
+`
+	if got := readSpecFile(t, out, "trace.go"); got != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -7,6 +7,7 @@ require (
 	go.opentelemetry.io/build-tools v0.2.0
 	golang.org/x/mod v0.6.0
 	golang.org/x/text v0.4.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 replace go.opentelemetry.io/build-tools => ../
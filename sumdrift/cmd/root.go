@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"go.opentelemetry.io/build-tools/internal/logging"
+	"go.opentelemetry.io/build-tools/internal/repo"
+	sd "go.opentelemetry.io/build-tools/sumdrift/internal"
+)
+
+var errUnexplained = errors.New("unexplained go.sum changes found")
+
+type commandConfig struct {
+	rootPath        string
+	ref             string
+	output          string
+	snapshotPath    string
+	modCachePath    string
+	format          string
+	quiet           bool
+	verbose         bool
+	rootCommand     cobra.Command
+	snapshotCommand cobra.Command
+	verifyCommand   cobra.Command
+}
+
+func newCommandConfig() *commandConfig {
+	c := &commandConfig{}
+
+	c.rootCommand = cobra.Command{
+		Use:   "sumdrift",
+		Short: "Attest that go.sum changes between releases are explained by go.mod changes",
+		Long: `Sumdrift snapshots the go.sum files of all modules of a repository at a release and
+		verifies, at the next release, that every go.sum change is explained by a change of the
+		requirements of the go.mod file of the same module and that no checksum of a module version
+		changed.`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			logging.Configure(c.quiet, c.verbose)
+			if c.rootPath == "" {
+				rp, err := repo.FindRoot()
+				if err != nil {
+					return fmt.Errorf("could not find a valid repository: %w", err)
+				}
+				c.rootPath = rp
+			}
+			return nil
+		},
+	}
+
+	c.snapshotCommand = cobra.Command{
+		Use:   "snapshot",
+		Short: "Snapshot the go.mod and go.sum files of all modules",
+		Long: `Snapshot records the go.sum lines and the go.mod digest of every module of the
+		repository at --ref, or the working tree if it is not set, as JSON.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := sd.Take(cmd.Context(), c.rootPath, c.ref)
+			if err != nil {
+				return err
+			}
+			data, err := s.Marshal()
+			if err != nil {
+				return err
+			}
+			if c.output == "" {
+				_, err = cmd.OutOrStdout().Write(data)
+				return err
+			}
+			logging.Infof("writing snapshot of %d modules to %s", len(s.Modules), c.output)
+			return os.WriteFile(filepath.Clean(c.output), data, 0o600)
+		},
+	}
+
+	c.verifyCommand = cobra.Command{
+		Use:   "verify",
+		Short: "Verify go.sum changes since a snapshot",
+		Long: `Verify compares the repository at --ref, or the working tree if it is not set, to a
+		snapshot and writes an attestation report. It fails if a go.sum line was added or removed for
+		a module version that is not a changed requirement of the go.mod file of the same module, nor
+		required by one according to the go.mod files in the module cache, or if the checksum of a
+		module version changed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var write func(sd.Report, io.Writer) error
+			switch c.format {
+			case "markdown":
+				write = sd.Report.WriteMarkdown
+			case "json":
+				write = sd.Report.WriteJSON
+			default:
+				return fmt.Errorf("invalid format %q, must be markdown or json", c.format)
+			}
+
+			base, err := sd.ReadSnapshot(c.snapshotPath)
+			if err != nil {
+				return err
+			}
+			head, err := sd.Take(cmd.Context(), c.rootPath, c.ref)
+			if err != nil {
+				return err
+			}
+			modCache := c.modCachePath
+			if modCache == "" {
+				if modCache, err = sd.GoModCache(cmd.Context()); err != nil {
+					return fmt.Errorf("could not find the module cache, set --modcache: %w", err)
+				}
+			}
+			r := sd.Verify(base, head, sd.ModCache(modCache))
+
+			w := cmd.OutOrStdout()
+			if c.output != "" {
+				f, err := os.Create(filepath.Clean(c.output))
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				w = f
+			}
+			if err := write(r, w); err != nil {
+				return err
+			}
+
+			if !r.OK() {
+				for _, f := range r.Findings {
+					logging.Errorf("%s", f)
+				}
+				logging.Errorf("found %d unexplained go.sum changes", len(r.Findings))
+				return errUnexplained
+			}
+			return nil
+		},
+	}
+
+	c.rootCommand.AddCommand(&c.snapshotCommand, &c.verifyCommand)
+	return c
+}
+
+var (
+	comCfg = newCommandConfig()
+)
+
+// Execute runs the sumdrift command line, exiting with a non-zero status if it
+// fails or unexplained go.sum changes are found.
+func Execute() {
+	if err := comCfg.rootCommand.Execute(); err != nil {
+		if !errors.Is(err, errUnexplained) {
+			logging.Errorf("failed to execute: %v", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func init() {
+	flags := comCfg.rootCommand.PersistentFlags()
+	flags.StringVar(&comCfg.rootPath, "root", "", `path to the root directory of the repository. If --root flag is not provided sumdrift will attempt to find a
+	git repository in the current or a parent directory.`)
+	flags.StringVar(&comCfg.ref, "ref", "", "git revision, e.g. a release tag, to read the modules at instead of the working tree")
+	flags.StringVarP(&comCfg.output, "output", "o", "", "path of the file to write to instead of the standard output")
+	flags.BoolVarP(&comCfg.verbose, "verbose", "v", false, "verbose output")
+	flags.BoolVarP(&comCfg.quiet, "quiet", "q", false, "only log errors, takes precedence over --verbose")
+
+	verifyFlags := comCfg.verifyCommand.Flags()
+	verifyFlags.StringVar(&comCfg.snapshotPath, "snapshot", "", "path to the snapshot of the previous release")
+	verifyFlags.StringVar(&comCfg.format, "format", "markdown", "format of the report, markdown or json")
+	verifyFlags.StringVar(&comCfg.modCachePath, "modcache", "", "path of the module cache holding the go.mod files of the dependencies, defaults to the GOMODCACHE of the go command")
+	if err := comCfg.verifyCommand.MarkFlagRequired("snapshot"); err != nil {
+		logging.Fatalf("could not mark snapshot flag as required: %v", err)
+	}
+}
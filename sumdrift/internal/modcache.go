@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumdrift
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// Requirements returns the module versions required by the go.mod file of the
+// module version mod.
+type Requirements func(mod module.Version) ([]module.Version, error)
+
+// ModCache returns the Requirements read from the go.mod files downloaded to
+// the module cache at dir. The go.mod files of the dependencies of a module
+// are downloaded by running `go mod download` in it.
+func ModCache(dir string) Requirements {
+	return func(mod module.Version) ([]module.Version, error) {
+		escPath, err := module.EscapePath(mod.Path)
+		if err != nil {
+			return nil, err
+		}
+		escVersion, err := module.EscapeVersion(mod.Version)
+		if err != nil {
+			return nil, err
+		}
+		name := filepath.Join(dir, "cache", "download", filepath.FromSlash(escPath), "@v", escVersion+".mod")
+		data, err := os.ReadFile(filepath.Clean(name))
+		if err != nil {
+			return nil, err
+		}
+		// The replace directives of dependencies are ignored by the go
+		// command, so the file can be parsed leniently.
+		f, err := modfile.ParseLax(name, data, nil)
+		if err != nil {
+			return nil, err
+		}
+		reqs := make([]module.Version, 0, len(f.Require))
+		for _, r := range f.Require {
+			reqs = append(reqs, r.Mod)
+		}
+		return reqs, nil
+	}
+}
+
+// GoModCache returns the module cache directory of the go command.
+func GoModCache(ctx context.Context) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "go", "env", "GOMODCACHE")
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("go env GOMODCACHE failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	dir := strings.TrimSpace(string(out))
+	if dir == "" {
+		return "", errors.New("the go command has no module cache")
+	}
+	return dir, nil
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumdrift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/module"
+
+	"go.opentelemetry.io/build-tools/testfiles"
+)
+
+func TestModCache(t *testing.T) {
+	dir := t.TempDir()
+	testfiles.Write(t, dir, map[string]string{
+		// Upper case letters of module paths are escaped in the module cache.
+		"cache/download/example.com/!upper/@v/v1.0.0.mod": `module example.com/Upper
+
+go 1.18
+
+require (
+	example.com/a v1.1.0
+	example.com/b v0.2.0 // indirect
+)
+
+replace example.com/a => ../a
+`,
+		"cache/download/example.com/invalid/@v/v1.0.0.mod": "module example.com/invalid\n\nrequire (\n",
+	})
+	reqs := ModCache(dir)
+
+	deps, err := reqs(module.Version{Path: "example.com/Upper", Version: "v1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, []module.Version{
+		{Path: "example.com/a", Version: "v1.1.0"},
+		{Path: "example.com/b", Version: "v0.2.0"},
+	}, deps)
+
+	_, err = reqs(module.Version{Path: "example.com/Upper", Version: "v2.0.0"})
+	assert.Error(t, err, "not in the module cache")
+
+	_, err = reqs(module.Version{Path: "example.com/invalid", Version: "v1.0.0"})
+	assert.Error(t, err, "invalid go.mod file")
+}
@@ -0,0 +1,278 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumdrift
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+
+	"go.opentelemetry.io/build-tools/internal/repo"
+)
+
+const (
+	goModFileName = "go.mod"
+	goSumFileName = "go.sum"
+)
+
+// Module is the state of the dependencies of a Go module in a snapshot.
+type Module struct {
+	// GoModSHA256 is the hex encoded SHA-256 digest of the go.mod file.
+	GoModSHA256 string `json:"go_mod_sha256"`
+	// GoSum are the sorted, non-empty lines of the go.sum file.
+	GoSum []string `json:"go_sum"`
+	// Require are the sorted module versions required by the go.mod file, as
+	// "path version", after applying its replace directives. Requirements
+	// replaced by a directory are omitted as they have no go.sum lines.
+	Require []string `json:"require"`
+}
+
+// Snapshot records the go.mod and go.sum files of all Go modules of a
+// repository at a git revision.
+type Snapshot struct {
+	// Ref is the git revision the snapshot was taken at, empty for the
+	// working tree.
+	Ref string `json:"ref,omitempty"`
+	// Commit is the commit hash Ref resolved to.
+	Commit string `json:"commit,omitempty"`
+	// Modules maps the slash separated directory of every module, relative to
+	// the repository root, to its state.
+	Modules map[string]Module `json:"modules"`
+}
+
+// source reads the files of a repository.
+type source interface {
+	// goModFiles returns the slash separated paths of all go.mod files.
+	goModFiles() ([]string, error)
+	// readFile returns the content of the file at the slash separated path,
+	// or an error wrapping fs.ErrNotExist if it does not exist.
+	readFile(name string) ([]byte, error)
+}
+
+// Take takes a snapshot of the repository at root. If ref is empty the working
+// tree is used, otherwise the files are read from the git revision ref.
+func Take(ctx context.Context, root, ref string) (Snapshot, error) {
+	s := Snapshot{Ref: ref, Modules: make(map[string]Module)}
+
+	var src source = dirSource(root)
+	if ref != "" {
+		commit, err := git(ctx, root, "rev-parse", "--verify", ref+"^{commit}")
+		if err != nil {
+			return Snapshot{}, err
+		}
+		s.Commit = strings.TrimSpace(string(commit))
+		src = revSource{ctx: ctx, root: root, commit: s.Commit}
+	}
+
+	goMods, err := src.goModFiles()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	for _, goMod := range goMods {
+		modData, err := src.readFile(goMod)
+		if err != nil {
+			return Snapshot{}, err
+		}
+		dir := path.Dir(goMod)
+		sumData, err := src.readFile(path.Join(dir, goSumFileName))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return Snapshot{}, err
+		}
+
+		modFile, err := repo.ParseModFile(goMod, modData)
+		if err != nil {
+			return Snapshot{}, fmt.Errorf("invalid %s: %w", goMod, err)
+		}
+
+		digest := sha256.Sum256(modData)
+		s.Modules[dir] = Module{
+			GoModSHA256: hex.EncodeToString(digest[:]),
+			GoSum:       sumLines(sumData),
+			Require:     requirements(modFile),
+		}
+	}
+	return s, nil
+}
+
+// sumLines returns the sorted, non-empty lines of a go.sum file.
+func sumLines(data []byte) []string {
+	lines := []string{}
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		if line := strings.Join(strings.Fields(s.Text()), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+// requirements returns the sorted module versions required by the go.mod file
+// f, as "path version", after applying its replace directives.
+func requirements(f *modfile.File) []string {
+	replaced := make(map[module.Version]module.Version, len(f.Replace))
+	for _, r := range f.Replace {
+		replaced[r.Old] = r.New
+	}
+
+	reqs := []string{}
+	for _, r := range f.Require {
+		mod, ok := replaced[r.Mod]
+		if !ok {
+			mod, ok = replaced[module.Version{Path: r.Mod.Path}]
+		}
+		if !ok {
+			mod = r.Mod
+		}
+		if mod.Version == "" {
+			// Replaced by a directory.
+			continue
+		}
+		reqs = append(reqs, mod.Path+" "+mod.Version)
+	}
+	sort.Strings(reqs)
+	return reqs
+}
+
+// skipDir returns whether the directory with the given base name does not
+// contain modules of the repository.
+func skipDir(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "vendor" || name == "testdata"
+}
+
+// dirSource reads the working tree of the repository at its root.
+type dirSource string
+
+func (d dirSource) goModFiles() ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(string(d), func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if p != string(d) && skipDir(entry.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Name() == goModFileName {
+			rel, err := filepath.Rel(string(d), p)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	return files, err
+}
+
+func (d dirSource) readFile(name string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(string(d), filepath.FromSlash(name)))
+}
+
+// revSource reads the files of a commit of the repository at root.
+type revSource struct {
+	ctx    context.Context
+	root   string
+	commit string
+}
+
+func (r revSource) goModFiles() ([]string, error) {
+	out, err := git(r.ctx, r.root, "ls-tree", "-r", "-z", "--name-only", r.commit)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, name := range strings.Split(string(out), "\x00") {
+		if path.Base(name) != goModFileName {
+			continue
+		}
+		skip := false
+		for _, elem := range strings.Split(path.Dir(name), "/") {
+			if elem != "." && skipDir(elem) {
+				skip = true
+				break
+			}
+		}
+		if !skip {
+			files = append(files, name)
+		}
+	}
+	return files, nil
+}
+
+func (r revSource) readFile(name string) ([]byte, error) {
+	// Check the file exists first, as git show fails the same way for
+	// missing files and other errors.
+	out, err := git(r.ctx, r.root, "ls-tree", "--name-only", r.commit, "--", name)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(string(out)) == "" {
+		return nil, fmt.Errorf("%s at %s: %w", name, r.commit, fs.ErrNotExist)
+	}
+	return git(r.ctx, r.root, "show", r.commit+":"+name)
+}
+
+// git runs git in the repository at root and returns its standard output.
+func git(ctx context.Context, root string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", root}, args...)...) // #nosec G204
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// ReadSnapshot reads a snapshot encoded by Snapshot.Marshal from a file.
+func ReadSnapshot(name string) (Snapshot, error) {
+	data, err := os.ReadFile(filepath.Clean(name))
+	if err != nil {
+		return Snapshot{}, err
+	}
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return Snapshot{}, fmt.Errorf("invalid snapshot %s: %w", name, err)
+	}
+	return s, nil
+}
+
+// Marshal returns the JSON encoding of the snapshot.
+func (s Snapshot) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumdrift
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/build-tools/testfiles"
+)
+
+func runGit(t *testing.T, root string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", root}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+const (
+	testGoMod = "module example.com/a\n\ngo 1.18\n\nrequire example.com/dep v1.0.0\n"
+	testSum   = "example.com/dep v1.0.0 h1:AAA=\nexample.com/dep v1.0.0/go.mod h1:BBB=\n"
+
+	testReplacedGoMod = `module example.com/a/replaced
+
+go 1.18
+
+require (
+	example.com/dep v1.0.0
+	example.com/fork v1.2.0
+	example.com/local v0.0.0
+	example.com/pinned v1.0.0
+)
+
+replace (
+	example.com/fork => example.com/forked v1.3.0
+	example.com/local => ../local
+	example.com/pinned v1.0.0 => example.com/pinned v1.0.1
+	example.com/pinned v1.1.0 => example.com/other v1.1.0
+)
+`
+)
+
+func TestTakeWorkingTree(t *testing.T) {
+	root := t.TempDir()
+	testfiles.Write(t, root, map[string]string{
+		"go.mod":                 testGoMod,
+		"go.sum":                 testSum + "\n",
+		"sub/go.mod":             "module example.com/a/sub\n",
+		"replaced/go.mod":        testReplacedGoMod,
+		"testdata/x/go.mod":      "module example.com/ignored\n",
+		".tools/go.mod":          "module example.com/tools\n",
+		"internal/_skip/go.mod":  "module example.com/skip\n",
+		"internal/notmod/doc.go": "package notmod\n",
+	})
+
+	s, err := Take(context.Background(), root, "")
+	require.NoError(t, err)
+	assert.Empty(t, s.Ref)
+	assert.Empty(t, s.Commit)
+	require.Len(t, s.Modules, 3)
+	assert.Equal(t, []string{
+		"example.com/dep v1.0.0 h1:AAA=",
+		"example.com/dep v1.0.0/go.mod h1:BBB=",
+	}, s.Modules["."].GoSum)
+	assert.Equal(t, []string{}, s.Modules["sub"].GoSum, "missing go.sum")
+	assert.Len(t, s.Modules["."].GoModSHA256, 64)
+	assert.Equal(t, []string{"example.com/dep v1.0.0"}, s.Modules["."].Require)
+	assert.Equal(t, []string{}, s.Modules["sub"].Require)
+	assert.Equal(t, []string{
+		"example.com/dep v1.0.0",
+		"example.com/forked v1.3.0",
+		"example.com/pinned v1.0.1",
+	}, s.Modules["replaced"].Require, "replaced requirements")
+}
+
+func TestTakeInvalidGoMod(t *testing.T) {
+	root := t.TempDir()
+	testfiles.Write(t, root, map[string]string{"go.mod": "module example.com/a\n\nrequire (\n"})
+
+	_, err := Take(context.Background(), root, "")
+	assert.ErrorContains(t, err, "invalid go.mod")
+}
+
+func TestTakeRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+	root := t.TempDir()
+	runGit(t, root, "init", "-q")
+	testfiles.Write(t, root, map[string]string{
+		"go.mod":     testGoMod,
+		"go.sum":     testSum,
+		"sub/go.mod": "module example.com/a/sub\n",
+	})
+	runGit(t, root, "add", "-A")
+	runGit(t, root, "commit", "-q", "-m", "initial")
+	runGit(t, root, "tag", "v1.0.0")
+
+	// Changes to the working tree are not part of the tagged snapshot.
+	testfiles.Write(t, root, map[string]string{"go.sum": ""})
+
+	s, err := Take(context.Background(), root, "v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", s.Ref)
+	assert.Len(t, s.Commit, 40)
+
+	tree, err := Take(context.Background(), root, "")
+	require.NoError(t, err)
+	assert.Equal(t, tree.Modules["."].GoModSHA256, s.Modules["."].GoModSHA256)
+	assert.Equal(t, tree.Modules["sub"], s.Modules["sub"])
+	assert.Len(t, s.Modules["."].GoSum, 2)
+	assert.Empty(t, tree.Modules["."].GoSum)
+
+	_, err = Take(context.Background(), root, "v9.9.9")
+	assert.Error(t, err)
+}
+
+func TestReadSnapshot(t *testing.T) {
+	s := Snapshot{
+		Ref:    "v1.0.0",
+		Commit: "0123",
+		Modules: map[string]Module{
+			".": {GoModSHA256: "abc", GoSum: []string{"example.com/dep v1.0.0 h1:AAA="}},
+		},
+	}
+	data, err := s.Marshal()
+	require.NoError(t, err)
+
+	name := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, os.WriteFile(name, data, 0o600))
+	got, err := ReadSnapshot(name)
+	require.NoError(t, err)
+	assert.Equal(t, s, got)
+
+	require.NoError(t, os.WriteFile(name, []byte("{"), 0o600))
+	_, err = ReadSnapshot(name)
+	assert.ErrorContains(t, err, "invalid snapshot")
+}
@@ -0,0 +1,335 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumdrift
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/module"
+)
+
+// Module statuses of a report.
+const (
+	StatusUnchanged = "unchanged"
+	StatusExplained = "explained"
+	StatusAdded     = "added"
+	StatusRemoved   = "removed"
+	StatusFailed    = "unexplained"
+)
+
+// Kinds of findings.
+const (
+	// KindChecksumChanged is a go.sum entry whose checksum changed for the
+	// same module version. A published module version must never change.
+	KindChecksumChanged = "checksum-changed"
+	// KindUnexplained is a go.sum entry added or removed for a module version
+	// that is neither a changed requirement of the go.mod file of the module
+	// nor required, directly or not, by one.
+	KindUnexplained = "unexplained"
+)
+
+// Finding is a go.sum change that is not explained by go.mod changes.
+type Finding struct {
+	Module string `json:"module"`
+	Kind   string `json:"kind"`
+	Entry  string `json:"entry"`
+	Detail string `json:"detail"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s: %s (%s)", f.Module, f.Kind, f.Entry, f.Detail)
+}
+
+// ModuleReport summarizes the go.sum changes of a module.
+type ModuleReport struct {
+	Module       string   `json:"module"`
+	Status       string   `json:"status"`
+	GoModChanged bool     `json:"go_mod_changed"`
+	Added        []string `json:"added,omitempty"`
+	Removed      []string `json:"removed,omitempty"`
+}
+
+// Report attests the go.sum changes between two snapshots.
+type Report struct {
+	BaseRef    string         `json:"base_ref,omitempty"`
+	BaseCommit string         `json:"base_commit,omitempty"`
+	HeadRef    string         `json:"head_ref,omitempty"`
+	HeadCommit string         `json:"head_commit,omitempty"`
+	Modules    []ModuleReport `json:"modules"`
+	Findings   []Finding      `json:"findings"`
+}
+
+// OK returns whether all go.sum changes are explained.
+func (r Report) OK() bool {
+	return len(r.Findings) == 0
+}
+
+// sumKey returns the module version, and /go.mod suffix, of a go.sum line,
+// and its checksum.
+func sumKey(line string) (string, string) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 {
+		return line, ""
+	}
+	return fields[0] + " " + fields[1], fields[2]
+}
+
+// sumModule returns the module version of a go.sum line as "path version".
+func sumModule(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return line
+	}
+	return fields[0] + " " + strings.TrimSuffix(fields[1], "/go.mod")
+}
+
+// reachable returns the module versions, as "path version", of roots and of
+// the module versions they require, directly or not, according to reqs.
+// Module versions whose requirements are not known are kept as leaves.
+func reachable(roots []string, reqs Requirements) map[string]struct{} {
+	seen := make(map[string]struct{}, len(roots))
+	queue := append([]string(nil), roots...)
+	for len(queue) > 0 {
+		mod := queue[0]
+		queue = queue[1:]
+		if _, ok := seen[mod]; ok {
+			continue
+		}
+		seen[mod] = struct{}{}
+
+		path, version, ok := strings.Cut(mod, " ")
+		if !ok || reqs == nil {
+			continue
+		}
+		deps, err := reqs(module.Version{Path: path, Version: version})
+		if err != nil {
+			continue
+		}
+		for _, dep := range deps {
+			queue = append(queue, dep.Path+" "+dep.Version)
+		}
+	}
+	return seen
+}
+
+// cached returns reqs remembering the requirements of every module version.
+func cached(reqs Requirements) Requirements {
+	if reqs == nil {
+		return nil
+	}
+	type result struct {
+		deps []module.Version
+		err  error
+	}
+	results := make(map[module.Version]result)
+	return func(mod module.Version) ([]module.Version, error) {
+		r, ok := results[mod]
+		if !ok {
+			r.deps, r.err = reqs(mod)
+			results[mod] = r
+		}
+		return r.deps, r.err
+	}
+}
+
+// Verify compares the head snapshot to the base one. A go.sum line may only
+// be added for a module version required by the head go.mod file of the same
+// module but not the base one, or reachable from such a requirement, and
+// removed for a module version only required, or reachable, from the base
+// go.mod file. The requirements of the dependencies are read with reqs, if not
+// nil. The checksum of a module version must never change.
+func Verify(base, head Snapshot, reqs Requirements) Report {
+	reqs = cached(reqs)
+
+	r := Report{
+		BaseRef:    base.Ref,
+		BaseCommit: base.Commit,
+		HeadRef:    head.Ref,
+		HeadCommit: head.Commit,
+		Modules:    []ModuleReport{},
+		Findings:   []Finding{},
+	}
+
+	dirs := make(map[string]struct{}, len(base.Modules)+len(head.Modules))
+	for dir := range base.Modules {
+		dirs[dir] = struct{}{}
+	}
+	for dir := range head.Modules {
+		dirs[dir] = struct{}{}
+	}
+	sorted := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		sorted = append(sorted, dir)
+	}
+	sort.Strings(sorted)
+
+	for _, dir := range sorted {
+		b, inBase := base.Modules[dir]
+		h, inHead := head.Modules[dir]
+		switch {
+		case !inBase:
+			r.Modules = append(r.Modules, ModuleReport{Module: dir, Status: StatusAdded, GoModChanged: true, Added: h.GoSum})
+			continue
+		case !inHead:
+			r.Modules = append(r.Modules, ModuleReport{Module: dir, Status: StatusRemoved, GoModChanged: true, Removed: b.GoSum})
+			continue
+		}
+
+		mr := ModuleReport{
+			Module:       dir,
+			GoModChanged: b.GoModSHA256 != h.GoModSHA256,
+			Added:        difference(h.GoSum, b.GoSum),
+			Removed:      difference(b.GoSum, h.GoSum),
+		}
+
+		var findings []Finding
+		baseSums := make(map[string]string, len(b.GoSum))
+		for _, line := range b.GoSum {
+			key, sum := sumKey(line)
+			baseSums[key] = sum
+		}
+		// Lines whose checksum changed are only reported as such.
+		changedSums := make(map[string]struct{})
+		for _, line := range mr.Added {
+			key, sum := sumKey(line)
+			if old, ok := baseSums[key]; ok && old != sum {
+				changedSums[key] = struct{}{}
+				findings = append(findings, Finding{
+					Module: dir,
+					Kind:   KindChecksumChanged,
+					Entry:  line,
+					Detail: fmt.Sprintf("checksum was %s", old),
+				})
+			}
+		}
+		required := reachable(difference(h.Require, b.Require), reqs)
+		unrequired := reachable(difference(b.Require, h.Require), reqs)
+		for _, line := range mr.Added {
+			key, _ := sumKey(line)
+			if _, ok := changedSums[key]; ok {
+				continue
+			}
+			if _, ok := required[sumModule(line)]; !ok {
+				findings = append(findings, Finding{Module: dir, Kind: KindUnexplained, Entry: line, Detail: unexplainedDetail("added", mr.GoModChanged)})
+			}
+		}
+		for _, line := range mr.Removed {
+			key, _ := sumKey(line)
+			if _, ok := changedSums[key]; ok {
+				continue
+			}
+			if _, ok := unrequired[sumModule(line)]; !ok {
+				findings = append(findings, Finding{Module: dir, Kind: KindUnexplained, Entry: line, Detail: unexplainedDetail("removed", mr.GoModChanged)})
+			}
+		}
+
+		switch {
+		case len(findings) > 0:
+			mr.Status = StatusFailed
+		case len(mr.Added) == 0 && len(mr.Removed) == 0:
+			mr.Status = StatusUnchanged
+		default:
+			mr.Status = StatusExplained
+		}
+		r.Modules = append(r.Modules, mr)
+		r.Findings = append(r.Findings, findings...)
+	}
+	return r
+}
+
+// unexplainedDetail describes a go.sum line added or removed, as given by
+// change, without a requirement change explaining it.
+func unexplainedDetail(change string, goModChanged bool) string {
+	if !goModChanged {
+		return change + " without go.mod change"
+	}
+	return change + " without a go.mod requirement change requiring it"
+}
+
+// difference returns the lines of the sorted a that are not in the sorted b.
+func difference(a, b []string) []string {
+	var out []string
+	i, j := 0, 0
+	for i < len(a) {
+		switch {
+		case j >= len(b) || a[i] < b[j]:
+			out = append(out, a[i])
+			i++
+		case a[i] == b[j]:
+			i++
+			j++
+		default:
+			j++
+		}
+	}
+	return out
+}
+
+// WriteJSON writes the report as JSON.
+func (r Report) WriteJSON(w io.Writer) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// WriteMarkdown writes the report as a Markdown attestation for review.
+func (r Report) WriteMarkdown(w io.Writer) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# go.sum attestation\n\n")
+	fmt.Fprintf(&sb, "- Base: %s\n", revision(r.BaseRef, r.BaseCommit))
+	fmt.Fprintf(&sb, "- Head: %s\n\n", revision(r.HeadRef, r.HeadCommit))
+
+	sb.WriteString("| Module | go.mod changed | Added | Removed | Status |\n")
+	sb.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, m := range r.Modules {
+		changed := "no"
+		if m.GoModChanged {
+			changed = "yes"
+		}
+		fmt.Fprintf(&sb, "| `%s` | %s | %d | %d | %s |\n", m.Module, changed, len(m.Added), len(m.Removed), m.Status)
+	}
+	sb.WriteString("\n")
+
+	if r.OK() {
+		sb.WriteString("All go.sum changes are explained by go.mod changes.\n")
+	} else {
+		fmt.Fprintf(&sb, "## Unexplained changes\n\n")
+		for _, f := range r.Findings {
+			fmt.Fprintf(&sb, "- `%s` %s: `%s` (%s)\n", f.Module, f.Kind, f.Entry, f.Detail)
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// revision describes a snapshot revision.
+func revision(ref, commit string) string {
+	switch {
+	case ref == "":
+		return "working tree"
+	case commit == "" || ref == commit:
+		return fmt.Sprintf("`%s`", ref)
+	default:
+		return fmt.Sprintf("`%s` (`%s`)", ref, commit)
+	}
+}
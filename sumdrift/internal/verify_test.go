@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sumdrift
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/module"
+)
+
+func TestVerify(t *testing.T) {
+	base := Snapshot{
+		Ref:    "v1.0.0",
+		Commit: "1111",
+		Modules: map[string]Module{
+			".": {GoModSHA256: "a1", GoSum: []string{
+				"example.com/dep v1.0.0 h1:AAA=",
+				"example.com/dep v1.0.0/go.mod h1:BBB=",
+			}, Require: []string{"example.com/dep v1.0.0"}},
+			"same":    {GoModSHA256: "s1", GoSum: []string{"example.com/x v1.0.0 h1:XXX="}},
+			"drift":   {GoModSHA256: "d1", GoSum: []string{"example.com/y v1.0.0 h1:YYY="}},
+			"tamper":  {GoModSHA256: "t1", GoSum: []string{"example.com/z v1.0.0 h1:ZZZ="}},
+			"removed": {GoModSHA256: "r1", GoSum: []string{}},
+		},
+	}
+	head := Snapshot{
+		Modules: map[string]Module{
+			".": {GoModSHA256: "a2", GoSum: []string{
+				"example.com/dep v1.1.0 h1:CCC=",
+				"example.com/dep v1.1.0/go.mod h1:DDD=",
+			}, Require: []string{"example.com/dep v1.1.0"}},
+			"same":  {GoModSHA256: "s1", GoSum: []string{"example.com/x v1.0.0 h1:XXX="}},
+			"drift": {GoModSHA256: "d1", GoSum: []string{"example.com/w v1.0.0 h1:WWW=", "example.com/y v1.0.0 h1:YYY="}},
+			// A checksum change is never explained, even with a go.mod change.
+			"tamper": {GoModSHA256: "t2", GoSum: []string{"example.com/z v1.0.0 h1:BAD="}},
+			"added":  {GoModSHA256: "n1", GoSum: []string{"example.com/n v1.0.0 h1:NNN="}},
+		},
+	}
+
+	r := Verify(base, head, nil)
+	assert.False(t, r.OK())
+
+	statuses := make(map[string]string)
+	for _, m := range r.Modules {
+		statuses[m.Module] = m.Status
+	}
+	assert.Equal(t, map[string]string{
+		".":       StatusExplained,
+		"added":   StatusAdded,
+		"drift":   StatusFailed,
+		"removed": StatusRemoved,
+		"same":    StatusUnchanged,
+		"tamper":  StatusFailed,
+	}, statuses)
+	assert.Equal(t, []Finding{
+		{Module: "drift", Kind: KindUnexplained, Entry: "example.com/w v1.0.0 h1:WWW=", Detail: "added without go.mod change"},
+		{Module: "tamper", Kind: KindChecksumChanged, Entry: "example.com/z v1.0.0 h1:BAD=", Detail: "checksum was h1:ZZZ="},
+	}, r.Findings)
+
+	var buf bytes.Buffer
+	require.NoError(t, r.WriteMarkdown(&buf))
+	assert.Contains(t, buf.String(), "- Base: `v1.0.0` (`1111`)\n- Head: working tree\n")
+	assert.Contains(t, buf.String(), "| `drift` | no | 1 | 0 | unexplained |\n")
+	assert.Contains(t, buf.String(), "- `tamper` checksum-changed: `example.com/z v1.0.0 h1:BAD=` (checksum was h1:ZZZ=)\n")
+
+	buf.Reset()
+	require.NoError(t, r.WriteJSON(&buf))
+	assert.Contains(t, buf.String(), `"kind": "checksum-changed"`)
+}
+
+func TestVerifyExplained(t *testing.T) {
+	base := Snapshot{Modules: map[string]Module{
+		".": {GoModSHA256: "a1", GoSum: []string{"example.com/dep v1.0.0 h1:AAA="}, Require: []string{"example.com/dep v1.0.0"}},
+	}}
+	head := Snapshot{Modules: map[string]Module{
+		".": {GoModSHA256: "a2", GoSum: []string{"example.com/dep v1.1.0 h1:BBB="}, Require: []string{"example.com/dep v1.1.0"}},
+	}}
+
+	r := Verify(base, head, nil)
+	assert.True(t, r.OK())
+	assert.Equal(t, []Finding{}, r.Findings)
+
+	var buf bytes.Buffer
+	require.NoError(t, r.WriteMarkdown(&buf))
+	assert.Contains(t, buf.String(), "All go.sum changes are explained by go.mod changes.\n")
+}
+
+// graph returns Requirements from the module graph given as the requirements
+// of module versions, as "path version". The requirements of other module
+// versions are not known.
+func graph(edges map[string][]string) Requirements {
+	return func(mod module.Version) ([]module.Version, error) {
+		deps, ok := edges[mod.Path+" "+mod.Version]
+		if !ok {
+			return nil, errors.New("unknown module version")
+		}
+		var reqs []module.Version
+		for _, dep := range deps {
+			path, version, _ := strings.Cut(dep, " ")
+			reqs = append(reqs, module.Version{Path: path, Version: version})
+		}
+		return reqs, nil
+	}
+}
+
+func TestVerifyRequirements(t *testing.T) {
+	reqs := graph(map[string][]string{
+		"example.com/a v1.0.0": {"example.com/old v0.1.0"},
+		"example.com/a v1.1.0": {"example.com/b v1.2.0"},
+		"example.com/b v1.2.0": {"example.com/c v1.0.0", "example.com/a v1.1.0"},
+		"example.com/c v1.0.0": nil,
+	})
+	base := Snapshot{Modules: map[string]Module{
+		".": {
+			GoModSHA256: "m1",
+			GoSum: sumLines([]byte(`example.com/a v1.0.0 h1:A10=
+example.com/a v1.0.0/go.mod h1:A10mod=
+example.com/kept v1.0.0 h1:KEPT=
+example.com/old v0.1.0/go.mod h1:OLD=
+example.com/stale v1.0.0/go.mod h1:STALE=
+`)),
+			Require: []string{"example.com/a v1.0.0", "example.com/kept v1.0.0"},
+		},
+	}}
+	head := Snapshot{Modules: map[string]Module{
+		".": {
+			GoModSHA256: "m2",
+			GoSum: sumLines([]byte(`example.com/a v1.1.0 h1:A11=
+example.com/a v1.1.0/go.mod h1:A11mod=
+example.com/b v1.2.0/go.mod h1:B12mod=
+example.com/c v1.0.0/go.mod h1:C10mod=
+example.com/kept v1.0.0 h1:KEPT=
+example.com/kept v1.1.0/go.mod h1:KEPT11mod=
+`)),
+			Require: []string{"example.com/a v1.1.0", "example.com/kept v1.0.0"},
+		},
+	}}
+
+	tests := []struct {
+		name     string
+		reqs     Requirements
+		expected []Finding
+	}{
+		{
+			name: "module graph",
+			reqs: reqs,
+			expected: []Finding{
+				{Module: ".", Kind: KindUnexplained, Entry: "example.com/kept v1.1.0/go.mod h1:KEPT11mod=", Detail: "added without a go.mod requirement change requiring it"},
+				{Module: ".", Kind: KindUnexplained, Entry: "example.com/stale v1.0.0/go.mod h1:STALE=", Detail: "removed without a go.mod requirement change requiring it"},
+			},
+		},
+		{
+			name: "changed requirements only",
+			expected: []Finding{
+				{Module: ".", Kind: KindUnexplained, Entry: "example.com/b v1.2.0/go.mod h1:B12mod=", Detail: "added without a go.mod requirement change requiring it"},
+				{Module: ".", Kind: KindUnexplained, Entry: "example.com/c v1.0.0/go.mod h1:C10mod=", Detail: "added without a go.mod requirement change requiring it"},
+				{Module: ".", Kind: KindUnexplained, Entry: "example.com/kept v1.1.0/go.mod h1:KEPT11mod=", Detail: "added without a go.mod requirement change requiring it"},
+				{Module: ".", Kind: KindUnexplained, Entry: "example.com/old v0.1.0/go.mod h1:OLD=", Detail: "removed without a go.mod requirement change requiring it"},
+				{Module: ".", Kind: KindUnexplained, Entry: "example.com/stale v1.0.0/go.mod h1:STALE=", Detail: "removed without a go.mod requirement change requiring it"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := Verify(base, head, tc.reqs)
+			assert.Equal(t, tc.expected, r.Findings)
+			require.Len(t, r.Modules, 1)
+			assert.Equal(t, StatusFailed, r.Modules[0].Status)
+		})
+	}
+}
+
+func TestReachable(t *testing.T) {
+	reqs := graph(map[string][]string{
+		"example.com/a v1.0.0": {"example.com/b v1.0.0", "example.com/c v1.0.0"},
+		"example.com/b v1.0.0": {"example.com/a v1.0.0"},
+	})
+
+	assert.Equal(t, map[string]struct{}{
+		"example.com/a v1.0.0": {},
+		"example.com/b v1.0.0": {},
+		"example.com/c v1.0.0": {},
+	}, reachable([]string{"example.com/a v1.0.0"}, reqs), "cycles and unknown module versions")
+	assert.Equal(t, map[string]struct{}{
+		"example.com/a v1.0.0": {},
+	}, reachable([]string{"example.com/a v1.0.0"}, nil), "no requirements")
+	assert.Empty(t, reachable(nil, reqs))
+}